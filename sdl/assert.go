@@ -173,9 +173,14 @@ func SDL_assert_always(condition bool) {
  */
 type SDL_AssertionHandler func(data *SDL_AssertData, userdata any) SDL_AssertState
 
-var assertionHandler SDL_AssertionHandler = SDL_AssertionHandler(SDL_PromptAssertion)
+var assertionHandler SDL_AssertionHandler
 var assertionData any
 
+func init() {
+	/* Set here, the prompt reaches back into the asserting code through the video subsystem */
+	assertionHandler = SDL_PromptAssertion
+}
+
 /*
  * Set an application-defined assertion handler.
  *
@@ -336,6 +341,9 @@ func SDL_AbortAssertion() {
 	SDL_ExitProcess(42)
 }
 
+/* A prompt for platforms where stdio has no user on the other end */
+var assertPromptBackend func(message string) SDL_AssertState
+
 func SDL_PromptAssertion(data *SDL_AssertData, userdata any) SDL_AssertState {
 	var state SDL_AssertState = SDL_ASSERTION_ABORT
 	/*
@@ -386,7 +394,7 @@ func SDL_PromptAssertion(data *SDL_AssertData, userdata any) SDL_AssertState {
 	*/
 
 	// let env. variable override, so unit tests won't block in a GUI.
-	envr := os.Getenv("SDL_ASSERT")
+	envr := SDL_GetHint(SDL_HINT_ASSERT)
 	if envr != "" {
 		if envr == "abort" {
 			return SDL_ASSERTION_ABORT
@@ -403,70 +411,45 @@ func SDL_PromptAssertion(data *SDL_AssertData, userdata any) SDL_AssertState {
 		}
 	}
 
+	// Leave fullscreen mode, if possible (scary!)
+	// Only the focused window is in the way of the prompt, popups count as
+	// their toplevel window.
+	for _, window := range SDL_GetWindows() {
+		if window.flags&SDL_WINDOW_INPUT_FOCUS == 0 {
+			continue
+		}
+		for sdlIsPopup(window) && window.parent != nil {
+			window = window.parent
+		}
+		if window.fullscreen_exclusive {
+			sdlMinimizeWindow(window)
+		}
+		//* !!! FIXME: ungrab the input if we're not fullscreen?
+		break
+	}
+
 	/*
-		    // Leave fullscreen mode, if possible (scary!)
-		    window = SDL_GetToplevelForKeyboardFocus();
-		    if (window) {
-		        if (window.fullscreen_exclusive) {
-		            SDL_MinimizeWindow(window);
-		        } else {
-		            //* !!! FIXME: ungrab the input if we're not fullscreen?
-		            // No need to mess with the window
-		            window = NULL;
-		        }
-		    }
-
-		    // Show a messagebox if we can, otherwise fall back to stdio
-		    SDL_zero(messagebox);
-		    messagebox.flags = SDL_MESSAGEBOX_WARNING;
-		    messagebox.window = window;
-		    messagebox.title = "Assertion Failed";
-		    messagebox.message = message;
-		    messagebox.numbuttons = SDL_arraysize(buttons);
-		    messagebox.buttons = buttons;
-
-		    if (SDL_ShowMessageBox(&messagebox, &selected) == 0) {
-		        if (selected == -1) {
-		            state = SDL_ASSERTION_IGNORE;
-		        } else {
-		            state = (SDL_AssertState)selected;
-		        }
-		    } else {
-		#ifdef SDL_PLATFORM_EMSCRIPTEN
-		        // This is nasty, but we can't block on a custom UI.
-		        for (;;) {
-		            SDL_bool okay = SDL_TRUE;
-		            char *buf = (char *) MAIN_THREAD_EM_ASM_PTR({
-		                var str =
-		                    UTF8ToString($0) + '\n\n' +
-		                    'Abort/Retry/Ignore/AlwaysIgnore? [ariA] :';
-		                var reply = window.prompt(str, "i");
-		                if (reply === null) {
-		                    reply = "i";
-		                }
-		                return allocate(intArrayFromString(reply), 'i8', ALLOC_NORMAL);
-		            }, message);
-
-		            if (SDL_strcmp(buf, "a") == 0) {
-		                state = SDL_ASSERTION_ABORT;
-		            } else if (SDL_strcmp(buf, "b") == 0) {
-		                state = SDL_ASSERTION_BREAK;
-		            } else if (SDL_strcmp(buf, "r") == 0) {
-		                state = SDL_ASSERTION_RETRY;
-		            } else if (SDL_strcmp(buf, "i") == 0) {
-		                state = SDL_ASSERTION_IGNORE;
-		            } else if (SDL_strcmp(buf, "A") == 0) {
-		                state = SDL_ASSERTION_ALWAYS_IGNORE;
-		            } else {
-		                okay = SDL_FALSE;
-		            }
-		            free(buf);  // This should NOT be SDL_free()
-
-		            if (okay) {
-		                break;
-		            }
-		        }
+	   // Show a messagebox if we can, otherwise fall back to stdio
+	   SDL_zero(messagebox);
+	   messagebox.flags = SDL_MESSAGEBOX_WARNING;
+	   messagebox.window = window;
+	   messagebox.title = "Assertion Failed";
+	   messagebox.message = message;
+	   messagebox.numbuttons = SDL_arraysize(buttons);
+	   messagebox.buttons = buttons;
+
+	   if (SDL_ShowMessageBox(&messagebox, &selected) == 0) {
+	       if (selected == -1) {
+	           state = SDL_ASSERTION_IGNORE;
+	       } else {
+	           state = (SDL_AssertState)selected;
+	       }
+	   } else {
 	*/
+	if assertPromptBackend != nil {
+		return assertPromptBackend(SDL_RenderAssertMessage(*data))
+	}
+
 	for {
 		var buf string
 		fmt.Fprintf(os.Stderr, "Abort/Break/Retry/Ignore/AlwaysIgnore? [abriA] : ")
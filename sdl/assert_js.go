@@ -0,0 +1,35 @@
+//go:build js && wasm
+
+package sdl
+
+import "syscall/js"
+
+func init() {
+	assertPromptBackend = sdlJSPromptAssertion
+}
+
+/* Ask with window.prompt(), which blocks the page like the assertion blocks the program */
+func sdlJSPromptAssertion(message string) SDL_AssertState {
+	prompt := js.Global().Get("prompt")
+	if prompt.Type() != js.TypeFunction {
+		return SDL_ASSERTION_ABORT
+	}
+	for {
+		reply := prompt.Invoke(message+"\n\nAbort/Break/Retry/Ignore/AlwaysIgnore? [abriA] :", "i")
+		if reply.IsNull() {
+			return SDL_ASSERTION_IGNORE /* the prompt was cancelled */
+		}
+		switch reply.String() {
+		case "a":
+			return SDL_ASSERTION_ABORT
+		case "b":
+			return SDL_ASSERTION_BREAK
+		case "r":
+			return SDL_ASSERTION_RETRY
+		case "i":
+			return SDL_ASSERTION_IGNORE
+		case "A":
+			return SDL_ASSERTION_ALWAYS_IGNORE
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package sdl
+
+/*
+ * Fast path for blits from 8-bit indexed surfaces.
+ *
+ * Instead of looking up and mapping the color of every pixel, the palette is
+ * mapped to destination pixel values once and the blit is a table lookup
+ * per pixel. The table is kept in the source surface and only rebuilt when
+ * the destination format, either palette or the color modulation changed,
+ * so animating a palette with SDL_SetPaletteColors() costs 256 mappings per
+ * frame, not a conversion of the whole surface.
+ *
+ * The fast path produces the same pixels as the generic blit, it's used
+ * when the result doesn't depend on the destination: no blending, or alpha
+ * blending of colors that are all opaque.
+ */
+
+/* The mapping of the palette of an indexed surface to the pixels of a destination */
+type sdlBlitMap struct {
+	valid               bool
+	dst_details         *SDL_PixelFormatDetails
+	dst_palette         *SDL_Palette
+	dst_palette_version uint32
+	src_palette         *SDL_Palette
+	src_palette_version uint32
+	color_mod           SDL_Color
+	table               [256]uint32 /* destination pixel values, by source index */
+	opaque              bool        /* every mapped color has full alpha */
+}
+
+/* Whether the map was built for these surfaces in their current state */
+func (blit *sdlBlitMap) matches(src, dst *SDL_Surface) bool {
+	return blit.valid &&
+		blit.dst_details == dst.details &&
+		blit.dst_palette == dst.palette &&
+		(dst.palette == nil || blit.dst_palette_version == dst.palette.Version) &&
+		blit.src_palette == src.palette &&
+		blit.src_palette_version == src.palette.Version &&
+		blit.color_mod == src.color_mod
+}
+
+/* Map every index of the source palette, with the color modulation applied, to the destination format */
+func (blit *sdlBlitMap) build(src, dst *SDL_Surface) {
+	mod := src.color_mod
+	blit.opaque = true
+	for i := range blit.table {
+		r, g, b, a := SDL_GetRGBA(uint32(i), src.details, src.palette)
+		r = uint8(sdlMul8(uint32(r), uint32(mod.R)))
+		g = uint8(sdlMul8(uint32(g), uint32(mod.G)))
+		b = uint8(sdlMul8(uint32(b), uint32(mod.B)))
+		a = uint8(sdlMul8(uint32(a), uint32(mod.A)))
+		if a != 0xFF {
+			blit.opaque = false
+		}
+		blit.table[i] = SDL_MapRGBA(dst.details, dst.palette, r, g, b, a)
+	}
+
+	blit.valid = true
+	blit.dst_details = dst.details
+	blit.dst_palette = dst.palette
+	if dst.palette != nil {
+		blit.dst_palette_version = dst.palette.Version
+	}
+	blit.src_palette = src.palette
+	blit.src_palette_version = src.palette.Version
+	blit.color_mod = mod
+}
+
+/*
+ * Blit an indexed surface through its palette map, returns false without
+ * touching dst if the blit needs the generic path.
+ */
+func sdlBlitIndexed(src *SDL_Surface, sx0, sy0 int, dst *SDL_Surface, clipped *SDL_Rect) bool {
+	if src.Format != SDL_PIXELFORMAT_INDEX8 || src.palette == nil {
+		return false
+	}
+	if src.blend_mode != SDL_BLENDMODE_NONE && src.blend_mode != SDL_BLENDMODE_BLEND {
+		return false
+	}
+
+	blit := &src.blit_map
+	if !blit.matches(src, dst) {
+		blit.build(src, dst)
+	}
+	if src.blend_mode == SDL_BLENDMODE_BLEND && !blit.opaque {
+		return false
+	}
+
+	for y := 0; y < clipped.H; y++ {
+		row := src.Pixels[(sy0+y)*src.Pitch+sx0:]
+		for x := 0; x < clipped.W; x++ {
+			index := row[x]
+			if src.has_key && uint32(index) == src.color_key {
+				continue
+			}
+			dst.putPixel(clipped.X+x, clipped.Y+y, blit.table[index])
+		}
+	}
+	return true
+}
@@ -0,0 +1,360 @@
+package sdl
+
+import "encoding/binary"
+import "io"
+import "math"
+import "math/bits"
+import "os"
+
+/*
+ * Windows BMP reading and writing.
+ *
+ * This is a lossless format every image viewer understands, which makes it
+ * the format of choice for dumping surfaces while debugging. Only the
+ * uncompressed variants are supported: 8-bit paletted, 24-bit and 32-bit
+ * images, the latter with optional bitfield masks and alpha.
+ */
+
+/* Compression encodings for BMP files */
+const (
+	bmpBI_RGB       = 0
+	bmpBI_BITFIELDS = 3
+)
+
+const (
+	bmpFileHeaderSize = 14
+	bmpInfoHeaderSize = 40
+	bmpV4HeaderSize   = 108
+	bmpLCS_sRGB       = 0x73524742
+)
+
+/* Bit position and width of a BMP color mask */
+func bmpMaskInfo(mask uint32) (shift uint8, nbits uint8) {
+	if mask == 0 {
+		return 0, 0
+	}
+	return uint8(bits.TrailingZeros32(mask)), uint8(bits.OnesCount32(mask))
+}
+
+/**
+ * Load a BMP image from a data stream.
+ *
+ * The new surface should be freed with SDL_DestroySurface(). Not doing so
+ * will result in a memory leak.
+ *
+ * - src the data stream for the surface.
+ * Returns a pointer to a new SDL_Surface structure or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DestroySurface
+ * See also SDL_LoadBMP
+ * See also SDL_SaveBMP_IO
+ */
+func SDL_LoadBMP_IO(src io.Reader) *SDL_Surface {
+	if src == nil {
+		SDL_InvalidParamError("src")
+		return nil
+	}
+	data, err := io.ReadAll(src)
+	if err != nil {
+		SDL_SetError(err.Error())
+		return nil
+	}
+
+	/* Read in the BMP file header */
+	if len(data) < bmpFileHeaderSize+bmpInfoHeaderSize || string(data[0:2]) != "BM" {
+		SDL_SetError("File is not a Windows BMP file")
+		return nil
+	}
+	bfOffBits := int(binary.LittleEndian.Uint32(data[10:]))
+
+	/* Read the Win32 BITMAPINFOHEADER */
+	header := data[bmpFileHeaderSize:]
+	biSize := int(binary.LittleEndian.Uint32(header[0:]))
+	if biSize < bmpInfoHeaderSize {
+		SDL_SetError("Unsupported BMP header size")
+		return nil
+	}
+	biWidth := int(int32(binary.LittleEndian.Uint32(header[4:])))
+	biHeight := int(int32(binary.LittleEndian.Uint32(header[8:])))
+	biBitCount := int(binary.LittleEndian.Uint16(header[14:]))
+	biCompression := binary.LittleEndian.Uint32(header[16:])
+	biClrUsed := int(binary.LittleEndian.Uint32(header[32:]))
+
+	topDown := false
+	if biHeight < 0 {
+		topDown = true
+		biHeight = -biHeight
+	}
+	if biWidth <= 0 || biHeight == 0 {
+		SDL_SetError("BMP file has an invalid width or height")
+		return nil
+	}
+	if biCompression != bmpBI_RGB && biCompression != bmpBI_BITFIELDS {
+		SDL_SetError("Compressed BMP files not supported")
+		return nil
+	}
+
+	/* Get the color masks */
+	var Rmask, Gmask, Bmask, Amask uint32
+	switch {
+	case biCompression == bmpBI_BITFIELDS:
+		/* The masks follow a plain info header, or are part of a larger one */
+		masks := header[bmpInfoHeaderSize:]
+		if len(masks) < 12 {
+			SDL_SetError("BMP file is truncated")
+			return nil
+		}
+		Rmask = binary.LittleEndian.Uint32(masks[0:])
+		Gmask = binary.LittleEndian.Uint32(masks[4:])
+		Bmask = binary.LittleEndian.Uint32(masks[8:])
+		if biSize >= bmpInfoHeaderSize+16 && len(masks) >= 16 {
+			Amask = binary.LittleEndian.Uint32(masks[12:])
+		}
+	case biBitCount == 24 || biBitCount == 32:
+		Rmask, Gmask, Bmask = 0x00FF0000, 0x0000FF00, 0x000000FF
+	}
+
+	var format SDL_PixelFormat
+	switch biBitCount {
+	case 8:
+		format = SDL_PIXELFORMAT_INDEX8
+	case 24:
+		format = SDL_PIXELFORMAT_XRGB8888
+	case 32:
+		format = tern(Amask != 0, SDL_PIXELFORMAT_ARGB8888, SDL_PIXELFORMAT_XRGB8888)
+	default:
+		SDL_SetErrorf("Unsupported BMP bit depth %d", biBitCount)
+		return nil
+	}
+
+	/*
+	 * Check that the file holds all the pixels before allocating the surface,
+	 * rows are padded to 4 bytes. The sizes come from the file, so they are
+	 * compared by division to stay clear of overflows.
+	 */
+	bpp := biBitCount / 8
+	if biWidth > (math.MaxInt32-3)/bpp {
+		SDL_SetError("BMP file has an invalid width or height")
+		return nil
+	}
+	rowSize := biWidth * bpp
+	pitch := (rowSize + 3) &^ 3
+	available := len(data) - bfOffBits
+	if bfOffBits < bmpFileHeaderSize+bmpInfoHeaderSize || available < rowSize || biHeight-1 > (available-rowSize)/pitch {
+		SDL_SetError("BMP file is truncated")
+		return nil
+	}
+	if biBitCount == 8 {
+		if biClrUsed == 0 || biClrUsed > 256 {
+			biClrUsed = 256
+		}
+		if biSize > len(data)-bmpFileHeaderSize-biClrUsed*4 {
+			SDL_SetError("BMP file is truncated")
+			return nil
+		}
+	}
+
+	surface := SDL_CreateSurface(biWidth, biHeight, format)
+	if surface == nil {
+		return nil
+	}
+
+	/* Load the palette, if any */
+	if biBitCount == 8 {
+		start := bmpFileHeaderSize + biSize
+		colors := make([]SDL_Color, biClrUsed)
+		for i := range colors {
+			entry := data[start+i*4:]
+			colors[i] = SDL_Color{entry[2], entry[1], entry[0], SDL_ALPHA_OPAQUE}
+		}
+		SDL_SetPaletteColors(surface.palette, colors, 0)
+	}
+
+	/* Read the surface pixels */
+	Rshift, Rbits := bmpMaskInfo(Rmask)
+	Gshift, Gbits := bmpMaskInfo(Gmask)
+	Bshift, Bbits := bmpMaskInfo(Bmask)
+	Ashift, Abits := bmpMaskInfo(Amask)
+
+	for y := 0; y < biHeight; y++ {
+		row := data[bfOffBits+y*pitch:]
+		dst_y := tern(topDown, y, biHeight-1-y)
+		for x := 0; x < biWidth; x++ {
+			var pixel uint32
+			switch bpp {
+			case 1:
+				surface.putPixel(x, dst_y, uint32(row[x]))
+				continue
+			case 3:
+				pixel = uint32(row[x*3]) | uint32(row[x*3+1])<<8 | uint32(row[x*3+2])<<16
+			default:
+				pixel = binary.LittleEndian.Uint32(row[x*4:])
+			}
+			c := SDL_Color{
+				sdlExpandComponent((pixel&Rmask)>>Rshift, Rbits),
+				sdlExpandComponent((pixel&Gmask)>>Gshift, Gbits),
+				sdlExpandComponent((pixel&Bmask)>>Bshift, Bbits),
+				tern(Amask != 0, sdlExpandComponent((pixel&Amask)>>Ashift, Abits), SDL_ALPHA_OPAQUE),
+			}
+			surface.putColor(x, dst_y, c)
+		}
+	}
+	return surface
+}
+
+// LoadBMP_IO is SDL_LoadBMP_IO() returning a Go error instead of nil.
+func LoadBMP_IO(src io.Reader) (*SDL_Surface, error) {
+	return errorFromObject(SDL_LoadBMP_IO(src))
+}
+
+/**
+ * Load a BMP image from a file.
+ *
+ * The new surface should be freed with SDL_DestroySurface(). Not doing so
+ * will result in a memory leak.
+ *
+ * - file the BMP file to load.
+ * Returns a pointer to a new SDL_Surface structure or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DestroySurface
+ * See also SDL_LoadBMP_IO
+ * See also SDL_SaveBMP
+ */
+func SDL_LoadBMP(file string) *SDL_Surface {
+	f, err := os.Open(file)
+	if err != nil {
+		SDL_SetErrorf("Couldn't open %s: %s", file, err)
+		return nil
+	}
+	defer f.Close()
+
+	return SDL_LoadBMP_IO(f)
+}
+
+// LoadBMP is SDL_LoadBMP() returning a Go error instead of nil.
+func LoadBMP(file string) (*SDL_Surface, error) {
+	return errorFromObject(SDL_LoadBMP(file))
+}
+
+/**
+ * Save a surface to a data stream in BMP format.
+ *
+ * Surfaces with a 8-bit palettized format are saved as 8-bit paletted
+ * images, everything else is saved losslessly as 32-bit ARGB with an alpha
+ * mask.
+ *
+ * - surface the SDL_Surface structure containing the image to be saved.
+ * - dst a data stream to save to.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_LoadBMP_IO
+ * See also SDL_SaveBMP
+ */
+func SDL_SaveBMP_IO(surface *SDL_Surface, dst io.Writer) bool {
+	if surface == nil || surface.Pixels == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if dst == nil {
+		return SDL_InvalidParamError("dst")
+	}
+
+	paletted := surface.Format == SDL_PIXELFORMAT_INDEX8 && surface.palette != nil
+	bpp := tern(paletted, 1, 4)
+	biSize := tern(paletted, bmpInfoHeaderSize, bmpV4HeaderSize)
+	ncolors := 0
+	if paletted {
+		ncolors = surface.palette.Ncolors
+	}
+	pitch := (surface.W*bpp + 3) &^ 3
+	bfOffBits := bmpFileHeaderSize + biSize + ncolors*4
+	buf := make([]byte, bfOffBits+pitch*surface.H)
+
+	/* Set the BMP file header values */
+	copy(buf[0:], "BM")
+	binary.LittleEndian.PutUint32(buf[2:], uint32(len(buf)))
+	binary.LittleEndian.PutUint32(buf[10:], uint32(bfOffBits))
+
+	/* Set the BMP info values */
+	header := buf[bmpFileHeaderSize:]
+	binary.LittleEndian.PutUint32(header[0:], uint32(biSize))
+	binary.LittleEndian.PutUint32(header[4:], uint32(surface.W))
+	binary.LittleEndian.PutUint32(header[8:], uint32(surface.H))
+	binary.LittleEndian.PutUint16(header[12:], 1)
+	binary.LittleEndian.PutUint16(header[14:], uint16(bpp*8))
+	binary.LittleEndian.PutUint32(header[20:], uint32(pitch*surface.H))
+	binary.LittleEndian.PutUint32(header[32:], uint32(ncolors))
+	if paletted {
+		binary.LittleEndian.PutUint32(header[16:], bmpBI_RGB)
+		for i, c := range surface.palette.Colors[:ncolors] {
+			entry := header[biSize+i*4:]
+			entry[0], entry[1], entry[2] = c.B, c.G, c.R
+		}
+	} else {
+		binary.LittleEndian.PutUint32(header[16:], bmpBI_BITFIELDS)
+		binary.LittleEndian.PutUint32(header[40:], 0x00FF0000)
+		binary.LittleEndian.PutUint32(header[44:], 0x0000FF00)
+		binary.LittleEndian.PutUint32(header[48:], 0x000000FF)
+		binary.LittleEndian.PutUint32(header[52:], 0xFF000000)
+		binary.LittleEndian.PutUint32(header[56:], bmpLCS_sRGB)
+	}
+
+	/* Write the bitmap image upside down */
+	for y := 0; y < surface.H; y++ {
+		row := buf[bfOffBits+(surface.H-1-y)*pitch:]
+		for x := 0; x < surface.W; x++ {
+			if paletted {
+				row[x] = uint8(surface.getPixel(x, y))
+				continue
+			}
+			c := surface.getColor(x, y)
+			row[x*4], row[x*4+1], row[x*4+2], row[x*4+3] = c.B, c.G, c.R, c.A
+		}
+	}
+
+	if _, err := dst.Write(buf); err != nil {
+		return SDL_SetError(err.Error())
+	}
+	return true
+}
+
+/**
+ * Save a surface to a file.
+ *
+ * See SDL_SaveBMP_IO() for the formats that are written.
+ *
+ * - surface the SDL_Surface structure containing the image to be saved.
+ * - file a file to save to.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_LoadBMP
+ * See also SDL_SaveBMP_IO
+ */
+func SDL_SaveBMP(surface *SDL_Surface, file string) bool {
+	f, err := os.Create(file)
+	if err != nil {
+		return SDL_SetErrorf("Couldn't open %s: %s", file, err)
+	}
+
+	ok := SDL_SaveBMP_IO(surface, f)
+	if err := f.Close(); err != nil && ok {
+		return SDL_SetError(err.Error())
+	}
+	return ok
+}
+
+// SaveBMP is SDL_SaveBMP() returning a Go error instead of a boolean.
+func SaveBMP(surface *SDL_Surface, file string) error {
+	return errorFromResult(SDL_SaveBMP(surface, file))
+}
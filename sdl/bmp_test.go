@@ -0,0 +1,139 @@
+package sdl
+
+import "bytes"
+import "encoding/binary"
+import "testing"
+
+/* Save a surface as BMP and load it back */
+func testBMPRoundTrip(t *testing.T, surface *SDL_Surface) *SDL_Surface {
+	t.Helper()
+	var buf bytes.Buffer
+	if !SDL_SaveBMP_IO(surface, &buf) {
+		t.Fatalf("SDL_SaveBMP_IO failed: %s", SDL_GetError())
+	}
+	loaded := SDL_LoadBMP_IO(&buf)
+	if loaded == nil {
+		t.Fatalf("SDL_LoadBMP_IO failed: %s", SDL_GetError())
+	}
+	t.Cleanup(func() { SDL_DestroySurface(loaded) })
+	return loaded
+}
+
+/* Save a surface as BMP, for tests that damage the file */
+func testBMPData(t *testing.T, surface *SDL_Surface) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if !SDL_SaveBMP_IO(surface, &buf) {
+		t.Fatalf("SDL_SaveBMP_IO failed: %s", SDL_GetError())
+	}
+	return buf.Bytes()
+}
+
+func TestBMPRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		surface *SDL_Surface
+	}{
+		{"color bars", SDLTest_CreateColorBars(37, 5)},
+		{"blend ramp", SDLTest_CreateBlendRamp(64, 17)},
+		{"face", SDLTest_CreateFace()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.surface == nil {
+				t.Fatalf("creating the image failed: %s", SDL_GetError())
+			}
+			defer SDL_DestroySurface(test.surface)
+
+			loaded := testBMPRoundTrip(t, test.surface)
+			if loaded.W != test.surface.W || loaded.H != test.surface.H {
+				t.Fatalf("loaded a %dx%d surface, want %dx%d", loaded.W, loaded.H, test.surface.W, test.surface.H)
+			}
+			if failed := SDLTest_CompareSurfaces(loaded, test.surface, 0); failed != 0 {
+				t.Errorf("%d pixels differ after the round trip:\n%s", failed, SDLTest_DiffSurfaces(loaded, test.surface, 0, 10))
+			}
+		})
+	}
+}
+
+func TestBMPRoundTripPaletted(t *testing.T) {
+	surface := SDL_CreateSurface(7, 3, SDL_PIXELFORMAT_INDEX8)
+	if surface == nil {
+		t.Fatalf("SDL_CreateSurface failed: %s", SDL_GetError())
+	}
+	defer SDL_DestroySurface(surface)
+	colors := []SDL_Color{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}, {10, 20, 30, 255}}
+	SDL_SetPaletteColors(surface.palette, colors, 0)
+	for y := 0; y < surface.H; y++ {
+		for x := 0; x < surface.W; x++ {
+			surface.Pixels[y*surface.Pitch+x] = byte((x + y) % len(colors))
+		}
+	}
+
+	loaded := testBMPRoundTrip(t, surface)
+	if loaded.Format != SDL_PIXELFORMAT_INDEX8 {
+		t.Errorf("loaded a %s surface, want SDL_PIXELFORMAT_INDEX8", SDL_GetPixelFormatName(loaded.Format))
+	}
+	if failed := SDLTest_CompareSurfaces(loaded, surface, 0); failed != 0 {
+		t.Errorf("%d pixels differ after the round trip:\n%s", failed, SDLTest_DiffSurfaces(loaded, surface, 0, 10))
+	}
+}
+
+func TestBMPLoadRejectsTruncatedPixels(t *testing.T) {
+	surface := SDLTest_CreateColorBars(16, 16)
+	defer SDL_DestroySurface(surface)
+	data := testBMPData(t, surface)
+
+	if loaded := SDL_LoadBMP_IO(bytes.NewReader(data[:len(data)-1])); loaded != nil {
+		SDL_DestroySurface(loaded)
+		t.Fatal("SDL_LoadBMP_IO accepted a truncated file")
+	}
+}
+
+func TestBMPLoadRejectsHugeDimensions(t *testing.T) {
+	surface := SDLTest_CreateColorBars(16, 16)
+	defer SDL_DestroySurface(surface)
+
+	tests := []struct {
+		name          string
+		width, height int32
+	}{
+		{"huge width", 0x7FFFFFFF, 16},
+		{"huge height", 16, 0x7FFFFFFF},
+		{"huge top-down height", 16, -0x80000000},
+		{"huge both", 0x40000000, 0x40000000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			/* The header claims far more pixels than the file holds */
+			data := testBMPData(t, surface)
+			binary.LittleEndian.PutUint32(data[bmpFileHeaderSize+4:], uint32(test.width))
+			binary.LittleEndian.PutUint32(data[bmpFileHeaderSize+8:], uint32(test.height))
+			if loaded := SDL_LoadBMP_IO(bytes.NewReader(data)); loaded != nil {
+				SDL_DestroySurface(loaded)
+				t.Fatal("SDL_LoadBMP_IO accepted a header larger than the file")
+			}
+		})
+	}
+}
+
+func TestCompareSurfacesReportsDifferences(t *testing.T) {
+	/* A failed comparison saves both surfaces in the working directory */
+	t.Chdir(t.TempDir())
+
+	surface := SDLTest_CreateColorBars(8, 4)
+	defer SDL_DestroySurface(surface)
+	reference := SDLTest_CreateColorBars(8, 4)
+	defer SDL_DestroySurface(reference)
+
+	if failed := SDLTest_CompareSurfaces(surface, reference, 0); failed != 0 {
+		t.Fatalf("identical surfaces differ in %d pixels", failed)
+	}
+	SDL_WriteSurfacePixel(surface, 3, 2, 1, 2, 3, 255)
+	if failed := SDLTest_CompareSurfaces(surface, reference, 0); failed != 1 {
+		t.Errorf("got %d differing pixels, want 1", failed)
+	}
+	if failed := SDLTest_CompareSurfaces(surface, reference, 255*255*3); failed != 0 {
+		t.Errorf("got %d differing pixels within the allowable error, want 0", failed)
+	}
+}
@@ -0,0 +1,422 @@
+package sdl
+
+import "runtime"
+import "slices"
+
+/*
+ * The clipboard.
+ *
+ * The application offers data with SDL_SetClipboardData(), listing the
+ * MIME types it can provide; the data itself is only produced by the
+ * callback when somebody asks for it. SDL_SetClipboardText() is a shortcut
+ * offering text.
+ *
+ * None of the video drivers has a clipboard backend yet, so the clipboard is
+ * kept in process: other applications don't see what is set here, and
+ * copying in another application doesn't change it. The desktop portal
+ * isn't a way around this on Linux, its Clipboard interface only works
+ * within a RemoteDesktop session; the clipboard there belongs to the X11
+ * selections and the Wayland data devices.
+ *
+ * Every change is announced with SDL_EVENT_CLIPBOARD_UPDATE, carrying the
+ * MIME types now on offer, whether the change came from this application or
+ * from a platform notification.
+ */
+
+/**
+ * Callback function that will be called when data for the specified mime-type
+ * is requested by the OS.
+ *
+ * The clipboard is automatically cleared in SDL_Quit().
+ *
+ * - userdata a pointer to provided user data.
+ * - mime_type the requested mime-type.
+ * Returns the data for the provided mime-type, or nil if no data is
+ *          available.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_SetClipboardData
+ */
+type SDL_ClipboardDataCallback func(userdata any, mime_type string) []byte
+
+/**
+ * Callback function that will be called when the clipboard is cleared, or
+ * new data is set.
+ *
+ * - userdata a pointer to provided user data.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_SetClipboardData
+ */
+type SDL_ClipboardCleanupCallback func(userdata any)
+
+/**
+ * An event triggered when the clipboard contents have changed
+ * (event.Clipboard.*)
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_ClipboardEvent struct {
+	Owner     bool     /**< are we owning the clipboard (internal update) */
+	MimeTypes []string /**< current mime types */
+}
+
+/* The MIME types offered for text, most specific first */
+var clipboardTextMimeTypes = []string{
+	"text/plain;charset=utf-8",
+	"text/plain",
+	"TEXT",
+	"UTF8_STRING",
+	"STRING",
+}
+
+/**
+ * Flags for clipboard contents.
+ *
+ * This is an extension to the SDL API.
+ *
+ * See also SDL_SetClipboardDataWithFlags
+ * See also SDL_SetClipboardTextWithFlags
+ */
+type SDL_ClipboardFlags uint32
+
+const (
+	SDL_CLIPBOARD_SENSITIVE SDL_ClipboardFlags = 0x01 /**< The contents are sensitive, e.g. a password, and shouldn't be kept by clipboard managers or clipboard history */
+)
+
+/*
+ * The MIME types marking sensitive contents for the clipboard managers of
+ * each platform, and the data they are offered with. Windows clipboard
+ * history and cloud sync check the registered formats, KDE's Klipper the
+ * password manager hint, and macOS clipboard managers follow the
+ * nspasteboard.org concealed type.
+ */
+func sdlClipboardSensitiveData() map[string][]byte {
+	switch runtime.GOOS {
+	case "windows":
+		return map[string][]byte{
+			"ExcludeClipboardContentFromMonitorProcessing": {0, 0, 0, 0},
+			"CanIncludeInClipboardHistory":                 {0, 0, 0, 0},
+			"CanUploadToCloudClipboard":                    {0, 0, 0, 0},
+		}
+	case "darwin", "ios":
+		return map[string][]byte{
+			"org.nspasteboard.ConcealedType": {},
+		}
+	case "js", "android":
+		return nil
+	}
+	return map[string][]byte{
+		"x-kde-passwordManagerHint": []byte("secret"),
+	}
+}
+
+/* The clipboard contents, guarded by clipboardLock */
+var clipboardLock = sdlMutex{name: "video.clipboard"}
+var clipboard struct {
+	callback   SDL_ClipboardDataCallback
+	cleanup    SDL_ClipboardCleanupCallback
+	userdata   any
+	mime_types []string /* including the markers of sensitive contents */
+	markers    map[string][]byte
+	text       string /* the text set with SDL_SetClipboardText() */
+}
+
+/*
+ * Queue an SDL_EVENT_CLIPBOARD_UPDATE event. Platform backends call this
+ * when another application changes the clipboard, with owner false.
+ */
+func sdlSendClipboardUpdate(owner bool, mime_types []string) bool {
+	if !SDL_EventEnabled(SDL_EVENT_CLIPBOARD_UPDATE) {
+		return false
+	}
+	event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: SDL_EVENT_CLIPBOARD_UPDATE}}
+	event.Clipboard = SDL_ClipboardEvent{Owner: owner, MimeTypes: slices.Clone(mime_types)}
+	return SDL_PushEvent(&event)
+}
+
+/*
+ * Replace the clipboard contents and return the MIME types now offered, the
+ * previous cleanup callback is run outside the lock
+ */
+func sdlSetClipboard(callback SDL_ClipboardDataCallback, cleanup SDL_ClipboardCleanupCallback, userdata any, mime_types []string, text string, flags SDL_ClipboardFlags) []string {
+	var markers map[string][]byte
+	mime_types = slices.Clone(mime_types)
+	if flags&SDL_CLIPBOARD_SENSITIVE != 0 && len(mime_types) > 0 {
+		markers = sdlClipboardSensitiveData()
+		offered := len(mime_types)
+		for mime_type := range markers {
+			if !slices.Contains(mime_types[:offered], mime_type) {
+				mime_types = append(mime_types, mime_type)
+			}
+		}
+		slices.Sort(mime_types[offered:])
+	}
+
+	clipboardLock.Lock()
+	oldCleanup, oldUserdata := clipboard.cleanup, clipboard.userdata
+	clipboard.callback = callback
+	clipboard.cleanup = cleanup
+	clipboard.userdata = userdata
+	clipboard.mime_types = mime_types
+	clipboard.markers = markers
+	clipboard.text = text
+	clipboardLock.Unlock()
+
+	if oldCleanup != nil {
+		oldCleanup(oldUserdata)
+	}
+	return mime_types
+}
+
+/**
+ * Offer clipboard data to the OS.
+ *
+ * Tell the operating system that the application is offering clipboard data
+ * for each of the provided mime-types. Once another application requests the
+ * data the callback function will be called, allowing it to generate and
+ * respond with the data for the requested mime-type.
+ *
+ * Passing a nil callback or no mime types clears the clipboard.
+ *
+ * - callback a function pointer to the function that provides the
+ *                 clipboard data.
+ * - cleanup a function pointer to the function that cleans up the
+ *                clipboard data.
+ * - userdata an opaque pointer that will be forwarded to the callbacks.
+ * - mime_types a list of mime-types that are being offered.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_ClearClipboardData
+ * See also SDL_GetClipboardData
+ * See also SDL_HasClipboardData
+ */
+func SDL_SetClipboardData(callback SDL_ClipboardDataCallback, cleanup SDL_ClipboardCleanupCallback, userdata any, mime_types []string) bool {
+	return SDL_SetClipboardDataWithFlags(callback, cleanup, userdata, mime_types, 0)
+}
+
+/**
+ * Offer clipboard data to the OS, with flags.
+ *
+ * This is SDL_SetClipboardData() with flags for the contents. With
+ * SDL_CLIPBOARD_SENSITIVE, the MIME types clipboard managers of the platform
+ * check to leave contents alone are offered as well:
+ * ExcludeClipboardContentFromMonitorProcessing, CanIncludeInClipboardHistory
+ * and CanUploadToCloudClipboard on Windows, x-kde-passwordManagerHint on
+ * Linux and other Unix systems, and org.nspasteboard.ConcealedType on macOS.
+ * SDL provides their data, the callback isn't asked for them. Like the rest
+ * of the clipboard, the markers stay in process until a clipboard backend
+ * hands them to the platform.
+ *
+ * This is an extension to the SDL API.
+ *
+ * - callback a function pointer to the function that provides the
+ *                 clipboard data.
+ * - cleanup a function pointer to the function that cleans up the
+ *                clipboard data.
+ * - userdata an opaque pointer that will be forwarded to the callbacks.
+ * - mime_types a list of mime-types that are being offered.
+ * - flags SDL_ClipboardFlags for the contents.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_SetClipboardData
+ * See also SDL_SetClipboardTextWithFlags
+ */
+func SDL_SetClipboardDataWithFlags(callback SDL_ClipboardDataCallback, cleanup SDL_ClipboardCleanupCallback, userdata any, mime_types []string, flags SDL_ClipboardFlags) bool {
+	if callback == nil || len(mime_types) == 0 {
+		/* Setting nothing is the same as clearing */
+		sdlSetClipboard(nil, nil, nil, nil, "", 0)
+		sdlSendClipboardUpdate(true, nil)
+		return true
+	}
+
+	mime_types = sdlSetClipboard(callback, cleanup, userdata, mime_types, "", flags)
+	sdlSendClipboardUpdate(true, mime_types)
+	return true
+}
+
+/**
+ * Clear the clipboard data.
+ *
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetClipboardData
+ */
+func SDL_ClearClipboardData() bool {
+	return SDL_SetClipboardData(nil, nil, nil, nil)
+}
+
+/**
+ * Get the data from clipboard for a given mime type.
+ *
+ * - mime_type the mime type to read from the clipboard.
+ * Returns the retrieved data, or nil on failure or if there is no data for
+ *          that mime type; call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_HasClipboardData
+ * See also SDL_SetClipboardData
+ */
+func SDL_GetClipboardData(mime_type string) []byte {
+	if mime_type == "" {
+		SDL_InvalidParamError("mime_type")
+		return nil
+	}
+
+	clipboardLock.Lock()
+	callback, userdata := clipboard.callback, clipboard.userdata
+	offered := slices.Contains(clipboard.mime_types, mime_type)
+	marker, isMarker := clipboard.markers[mime_type]
+	clipboardLock.Unlock()
+
+	if isMarker {
+		return slices.Clone(marker)
+	}
+	if callback == nil || !offered {
+		return nil
+	}
+	return callback(userdata, mime_type)
+}
+
+/**
+ * Query whether there is data in the clipboard for the provided mime type.
+ *
+ * - mime_type the mime type to check for data for.
+ * Returns true if there exists data in clipboard for the provided mime type,
+ *          false if it does not.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetClipboardData
+ * See also SDL_GetClipboardData
+ */
+func SDL_HasClipboardData(mime_type string) bool {
+	clipboardLock.Lock()
+	defer clipboardLock.Unlock()
+
+	return slices.Contains(clipboard.mime_types, mime_type)
+}
+
+/**
+ * Retrieve the list of mime types available in the clipboard.
+ *
+ * Returns the mime types, nil if the clipboard is empty.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetClipboardData
+ */
+func SDL_GetClipboardMimeTypes() []string {
+	clipboardLock.Lock()
+	defer clipboardLock.Unlock()
+
+	return slices.Clone(clipboard.mime_types)
+}
+
+/* Provides the text set with SDL_SetClipboardText() */
+func sdlClipboardTextCallback(userdata any, mime_type string) []byte {
+	clipboardLock.Lock()
+	defer clipboardLock.Unlock()
+
+	return []byte(clipboard.text)
+}
+
+/**
+ * Put UTF-8 text into the clipboard.
+ *
+ * The clipboard is only shared within this process for now: the text can be
+ * read back with SDL_GetClipboardText(), but it isn't offered to other
+ * applications, and text they copy isn't seen here.
+ *
+ * - text the text to store in the clipboard.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetClipboardText
+ * See also SDL_HasClipboardText
+ */
+func SDL_SetClipboardText(text string) bool {
+	return SDL_SetClipboardTextWithFlags(text, 0)
+}
+
+/**
+ * Put UTF-8 text into the clipboard, with flags.
+ *
+ * Password managers pass SDL_CLIPBOARD_SENSITIVE, so clipboard managers
+ * don't keep the text. See SDL_SetClipboardDataWithFlags() for how it's
+ * marked on each platform.
+ *
+ * This is an extension to the SDL API.
+ *
+ * - text the text to store in the clipboard.
+ * - flags SDL_ClipboardFlags for the text.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_SetClipboardText
+ */
+func SDL_SetClipboardTextWithFlags(text string, flags SDL_ClipboardFlags) bool {
+	if text == "" {
+		return SDL_ClearClipboardData()
+	}
+
+	mime_types := sdlSetClipboard(sdlClipboardTextCallback, nil, nil, clipboardTextMimeTypes, text, flags)
+	sdlSendClipboardUpdate(true, mime_types)
+	return true
+}
+
+/**
+ * Get UTF-8 text from the clipboard.
+ *
+ * Returns the clipboard text on success or "" on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_HasClipboardText
+ * See also SDL_SetClipboardText
+ */
+func SDL_GetClipboardText() string {
+	for _, mime_type := range clipboardTextMimeTypes {
+		if data := SDL_GetClipboardData(mime_type); data != nil {
+			return string(data)
+		}
+	}
+	return ""
+}
+
+/**
+ * Query whether the clipboard exists and contains a non-empty text string.
+ *
+ * Returns true if the clipboard has text, or false if it does not.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetClipboardText
+ * See also SDL_SetClipboardText
+ */
+func SDL_HasClipboardText() bool {
+	for _, mime_type := range clipboardTextMimeTypes {
+		if SDL_HasClipboardData(mime_type) {
+			return true
+		}
+	}
+	return false
+}
+
+/* Clear the clipboard when shutting down, running the cleanup callback */
+func sdlQuitClipboard() {
+	sdlSetClipboard(nil, nil, nil, nil, "", 0)
+}
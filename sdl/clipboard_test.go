@@ -0,0 +1,59 @@
+package sdl
+
+import "slices"
+import "testing"
+
+func TestClipboardSensitiveText(t *testing.T) {
+	defer SDL_ClearClipboardData()
+
+	if !SDL_SetClipboardTextWithFlags("hunter2", SDL_CLIPBOARD_SENSITIVE) {
+		t.Fatalf("SDL_SetClipboardTextWithFlags failed: %s", SDL_GetError())
+	}
+	if text := SDL_GetClipboardText(); text != "hunter2" {
+		t.Errorf("Clipboard text is %q, expected %q", text, "hunter2")
+	}
+	mime_types := SDL_GetClipboardMimeTypes()
+	for mime_type, data := range sdlClipboardSensitiveData() {
+		if !slices.Contains(mime_types, mime_type) {
+			t.Errorf("%s isn't offered: %v", mime_type, mime_types)
+		}
+		if got := SDL_GetClipboardData(mime_type); got == nil || string(got) != string(data) {
+			t.Errorf("%s data is %q, expected %q", mime_type, got, data)
+		}
+	}
+
+	/* Plain text replaces the markers */
+	if !SDL_SetClipboardText("public") {
+		t.Fatalf("SDL_SetClipboardText failed: %s", SDL_GetError())
+	}
+	for mime_type := range sdlClipboardSensitiveData() {
+		if SDL_HasClipboardData(mime_type) {
+			t.Errorf("%s is still offered", mime_type)
+		}
+	}
+}
+
+func TestClipboardSensitiveDataKeepsOwnTypes(t *testing.T) {
+	defer SDL_ClearClipboardData()
+
+	/* A marker the application offers itself isn't offered twice */
+	markers := sdlClipboardSensitiveData()
+	offered := []string{"application/x-secret"}
+	for mime_type := range markers {
+		offered = append(offered, mime_type)
+		break
+	}
+	callback := func(userdata any, mime_type string) []byte {
+		return []byte("from the application")
+	}
+	if !SDL_SetClipboardDataWithFlags(callback, nil, nil, offered, SDL_CLIPBOARD_SENSITIVE) {
+		t.Fatalf("SDL_SetClipboardDataWithFlags failed: %s", SDL_GetError())
+	}
+	mime_types := SDL_GetClipboardMimeTypes()
+	if len(mime_types) != 1+len(markers) {
+		t.Errorf("Offered %v, expected application/x-secret and %d markers", mime_types, len(markers))
+	}
+	if data := SDL_GetClipboardData("application/x-secret"); string(data) != "from the application" {
+		t.Errorf("application/x-secret data is %q", data)
+	}
+}
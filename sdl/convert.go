@@ -0,0 +1,241 @@
+package sdl
+
+/*
+ * Surface format conversion.
+ *
+ * Converting to a format with fewer bits per color component, like RGB565
+ * or RGB332, bands smooth gradients. SDL_HINT_SURFACE_DITHER trades the
+ * bands for noise: ordered dithering adds a fixed 4x4 threshold pattern
+ * before quantizing, error diffusion (Floyd-Steinberg) carries the
+ * quantization error of each pixel over to its unvisited neighbours.
+ */
+
+/**
+ * A variable controlling the dithering SDL_ConvertSurface() applies when
+ * converting to a format with fewer bits per color component.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "none": Colors are truncated to the target depth. (default)
+ * - "ordered": A 4x4 Bayer matrix pattern is used, it's stable from frame
+ *   to frame and cheap.
+ * - "error_diffusion": Floyd-Steinberg error diffusion is used, it gives
+ *   smoother gradients but the pattern changes with the image.
+ *
+ * Conversions to indexed formats and between formats of the same depth are
+ * never dithered.
+ *
+ * This hint can be set anytime.
+ */
+const SDL_HINT_SURFACE_DITHER = "SDL_SURFACE_DITHER"
+
+type sdlDitherMode int
+
+const (
+	sdlDitherNone sdlDitherMode = iota
+	sdlDitherOrdered
+	sdlDitherErrorDiffusion
+)
+
+func sdlGetDitherMode() sdlDitherMode {
+	switch SDL_GetHint(SDL_HINT_SURFACE_DITHER) {
+	case "ordered":
+		return sdlDitherOrdered
+	case "error_diffusion":
+		return sdlDitherErrorDiffusion
+	}
+	return sdlDitherNone
+}
+
+/* 4x4 Bayer threshold matrix, the thresholds are (n + 0.5) / 16 */
+var sdlBayer4x4 = [4][4]uint8{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+/* The bits of each color component in dst, or 0 for the components that don't lose precision from src */
+func sdlDitherBits(src, dst *SDL_PixelFormatDetails) (r, g, b uint8) {
+	srcBits := func(bits uint8) uint8 {
+		if SDL_ISPIXELFORMAT_INDEXED(src.Format) {
+			return 8 /* palette colors have 8 bits per component */
+		}
+		return bits
+	}
+	dithered := func(from, to uint8) uint8 {
+		if to == 0 || to >= from {
+			return 0
+		}
+		return to
+	}
+	return dithered(srcBits(src.Rbits), dst.Rbits), dithered(srcBits(src.Gbits), dst.Gbits), dithered(srcBits(src.Bbits), dst.Bbits)
+}
+
+/*
+ * Quantize an 8-bit component to `bits` bits with an ordered dither
+ * threshold in [0, 16), returning it as an 8-bit value holding the level in
+ * its high bits. bits 0 leaves the value alone.
+ */
+func sdlDitherOrderedComponent(value uint8, bits uint8, threshold uint8) uint8 {
+	if bits == 0 {
+		return value
+	}
+	levels := uint32(1)<<bits - 1
+	/* floor(value * levels / 255 + (threshold + 0.5) / 16) */
+	level := (uint32(value)*levels*32 + 255*(2*uint32(threshold)+1)) / (255 * 32)
+	return uint8(min(level, levels) << (8 - bits))
+}
+
+/* Quantize a component with the carried error to the closest level, returning the 8-bit value and the new error */
+func sdlDitherDiffuseComponent(value int32, bits uint8) (uint8, int32) {
+	value = max(min(value, 255), 0)
+	if bits == 0 {
+		return uint8(value), 0
+	}
+	levels := int32(1)<<bits - 1
+	level := (value*levels + 127) / 255
+	return uint8(level << (8 - bits)), value - int32(sdlExpandComponent(uint32(level), bits))
+}
+
+/* Copy the pixels of src to dst, which has the same size, dithering as requested */
+func sdlConvertPixels(src, dst *SDL_Surface, mode sdlDitherMode) {
+	rbits, gbits, bbits := sdlDitherBits(src.details, dst.details)
+	if SDL_ISPIXELFORMAT_INDEXED(dst.Format) || (rbits == 0 && gbits == 0 && bbits == 0) {
+		mode = sdlDitherNone
+	}
+
+	/* Color keyed pixels keep their exact color, so they still match the key once converted */
+	keyed := func(x, y int) bool {
+		return src.has_key && src.getPixel(x, y) == src.color_key
+	}
+
+	switch mode {
+	case sdlDitherOrdered:
+		for y := 0; y < src.H; y++ {
+			for x := 0; x < src.W; x++ {
+				c := src.getColor(x, y)
+				if keyed(x, y) {
+					dst.putColor(x, y, c)
+					continue
+				}
+				t := sdlBayer4x4[y&3][x&3]
+				c.R = sdlDitherOrderedComponent(c.R, rbits, t)
+				c.G = sdlDitherOrderedComponent(c.G, gbits, t)
+				c.B = sdlDitherOrderedComponent(c.B, bbits, t)
+				dst.putColor(x, y, c)
+			}
+		}
+
+	case sdlDitherErrorDiffusion:
+		/* Errors carried to the current and the next row, with a pixel of margin on each side */
+		cur := make([][3]int32, src.W+2)
+		next := make([][3]int32, src.W+2)
+		bits := [3]uint8{rbits, gbits, bbits}
+		for y := 0; y < src.H; y++ {
+			for x := 0; x < src.W; x++ {
+				c := src.getColor(x, y)
+				if keyed(x, y) {
+					dst.putColor(x, y, c)
+					continue
+				}
+				in := [3]int32{int32(c.R), int32(c.G), int32(c.B)}
+				var out [3]uint8
+				for i := range in {
+					var e int32
+					out[i], e = sdlDitherDiffuseComponent(in[i]+cur[x+1][i]/16, bits[i])
+					cur[x+2][i] += e * 7
+					next[x][i] += e * 3
+					next[x+1][i] += e * 5
+					next[x+2][i] += e * 1
+				}
+				c.R, c.G, c.B = out[0], out[1], out[2]
+				dst.putColor(x, y, c)
+			}
+			cur, next = next, cur
+			clear(next)
+		}
+
+	default:
+		for y := 0; y < src.H; y++ {
+			for x := 0; x < src.W; x++ {
+				dst.putColor(x, y, src.getColor(x, y))
+			}
+		}
+	}
+}
+
+/* Fill a 256 color palette with an even 3-3-2 spread of RGB colors */
+func sdlDitherPalette(palette *SDL_Palette) {
+	colors := make([]SDL_Color, min(palette.Ncolors, 256))
+	for i := range colors {
+		r := uint32(i>>5) & 0x07
+		g := uint32(i>>2) & 0x07
+		b := uint32(i) & 0x03
+		colors[i] = SDL_Color{sdlExpandComponent(r, 3), sdlExpandComponent(g, 3), sdlExpandComponent(b, 2), SDL_ALPHA_OPAQUE}
+	}
+	SDL_SetPaletteColors(palette, colors, 0)
+}
+
+/**
+ * Copy an existing surface to a new surface of the specified format.
+ *
+ * This function is used to optimize images for faster *repeat* blitting. This
+ * is accomplished by converting the original and storing the result as a new
+ * surface. The new, optimized surface can then be used as the source for
+ * future blits, making them faster.
+ *
+ * The color key, color and alpha modulation and blend mode of the surface
+ * are carried over to the new one. When converting to an indexed format,
+ * the palette of an indexed surface is copied, other surfaces are mapped to
+ * an even spread of 256 colors.
+ *
+ * Converting to a format with fewer bits per color component is dithered as
+ * selected by SDL_HINT_SURFACE_DITHER.
+ *
+ * - surface the existing SDL_Surface structure to convert.
+ * - format the new pixel format.
+ * Returns the new SDL_Surface structure that is created or nil on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateSurface
+ * See also SDL_DestroySurface
+ */
+func SDL_ConvertSurface(surface *SDL_Surface, format SDL_PixelFormat) *SDL_Surface {
+	if surface == nil || (surface.Pixels == nil && surface.W > 0 && surface.H > 0) {
+		SDL_InvalidParamError("surface")
+		return nil
+	}
+	if SDL_GetPixelFormatDetails(format) == nil {
+		return nil
+	}
+
+	convert := SDL_CreateSurface(surface.W, surface.H, format)
+	if convert == nil {
+		return nil
+	}
+	if convert.palette != nil {
+		if surface.palette != nil {
+			SDL_SetPaletteColors(convert.palette, surface.palette.Colors[:min(surface.palette.Ncolors, convert.palette.Ncolors)], 0)
+		} else {
+			sdlDitherPalette(convert.palette)
+		}
+	}
+
+	sdlConvertPixels(surface, convert, sdlGetDitherMode())
+
+	convert.color_mod = surface.color_mod
+	convert.blend_mode = surface.blend_mode
+	if surface.has_key {
+		r, g, b, a := SDL_GetRGBA(surface.color_key, surface.details, surface.palette)
+		SDL_SetSurfaceColorKey(convert, true, SDL_MapRGBA(convert.details, convert.palette, r, g, b, a))
+	}
+	return convert
+}
+
+// ConvertSurface is SDL_ConvertSurface() returning a Go error instead of nil.
+func ConvertSurface(surface *SDL_Surface, format SDL_PixelFormat) (*SDL_Surface, error) {
+	return errorFromObject(SDL_ConvertSurface(surface, format))
+}
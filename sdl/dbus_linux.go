@@ -0,0 +1,918 @@
+package sdl
+
+import "bufio"
+import "encoding/binary"
+import "fmt"
+import "io"
+import "math"
+import "net"
+import "net/url"
+import "os"
+import "strconv"
+import "strings"
+import "time"
+
+/*
+ * A minimal D-Bus client for the session bus, enough for the desktop
+ * portals: method calls, replies and signals with the basic types, arrays,
+ * structs, dictionaries and variants. Unix fd passing isn't supported.
+ *
+ * One connection is shared by the package, opened on first use. A goroutine
+ * reads the incoming messages, hands method replies to their callers and
+ * signals to the handlers matching them. Signal handlers run on that
+ * goroutine, they must not block or call D-Bus methods themselves.
+ *
+ * Values are marshaled from and to Go values by signature:
+ *
+ * - y byte, b bool, n int16, q uint16, i int32, u uint32, x int64,
+ *   t uint64, d float64
+ * - s, o and g string
+ * - v sdlDBusVariant
+ * - ay []byte, as []string or []any, a{sv} map[string]sdlDBusVariant,
+ *   other dictionaries map[any]any, other arrays []any
+ * - structs []any
+ */
+
+/* Message types */
+const (
+	sdlDBusMethodCall   = 1
+	sdlDBusMethodReturn = 2
+	sdlDBusError        = 3
+	sdlDBusSignal       = 4
+)
+
+/* Header field codes */
+const (
+	sdlDBusFieldPath        = 1
+	sdlDBusFieldInterface   = 2
+	sdlDBusFieldMember      = 3
+	sdlDBusFieldErrorName   = 4
+	sdlDBusFieldReplySerial = 5
+	sdlDBusFieldDestination = 6
+	sdlDBusFieldSender      = 7
+	sdlDBusFieldSignature   = 8
+)
+
+/* How long to wait for the reply to a method call, the libdbus default */
+const sdlDBusTimeout = 25 * time.Second
+
+/* Messages are limited to 128 MiB by the specification */
+const sdlDBusMaxMessageSize = 128 << 20
+
+/* A value with its type, for the variant type */
+type sdlDBusVariant struct {
+	sig   string
+	value any
+}
+
+type sdlDBusMessage struct {
+	kind         byte
+	serial       uint32
+	reply_serial uint32
+	path         string
+	iface        string
+	member       string
+	error_name   string
+	destination  string
+	sender       string
+	sig          string
+	body         []any
+}
+
+/* The types that can be dict entry keys */
+const sdlDBusBasicTypes = "ybnqiuxtdsogh"
+
+/* Split the first complete type off a signature, false if it isn't valid */
+func sdlDBusNextType(sig string) (string, string, bool) {
+	return sdlDBusSplitType(sig, false)
+}
+
+/* Split the first complete type off a signature, dict entries are only valid as the element of an array */
+func sdlDBusSplitType(sig string, inArray bool) (string, string, bool) {
+	if sig == "" {
+		return "", "", false
+	}
+	switch sig[0] {
+	case 'a':
+		elem, rest, ok := sdlDBusSplitType(sig[1:], true)
+		if !ok {
+			return "", "", false
+		}
+		return "a" + elem, rest, true
+	case '(':
+		/* Structs have at least one field */
+		rest := sig[1:]
+		for fields := 0; rest == "" || rest[0] != ')' || fields == 0; fields++ {
+			var ok bool
+			if _, rest, ok = sdlDBusSplitType(rest, false); !ok {
+				return "", "", false
+			}
+		}
+		n := len(sig) - len(rest) + 1
+		return sig[:n], sig[n:], true
+	case '{':
+		/* A basic key and a value */
+		if !inArray || len(sig) < 2 || strings.IndexByte(sdlDBusBasicTypes, sig[1]) < 0 {
+			return "", "", false
+		}
+		_, rest, ok := sdlDBusSplitType(sig[2:], false)
+		if !ok || rest == "" || rest[0] != '}' {
+			return "", "", false
+		}
+		n := len(sig) - len(rest) + 1
+		return sig[:n], sig[n:], true
+	}
+	if strings.IndexByte(sdlDBusBasicTypes+"v", sig[0]) < 0 {
+		return "", "", false
+	}
+	return sig[:1], sig[1:], true
+}
+
+/* The alignment of a type in the wire format */
+func sdlDBusAlignment(c byte) int {
+	switch c {
+	case 'n', 'q':
+		return 2
+	case 'b', 'i', 'u', 's', 'o', 'a', 'h':
+		return 4
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	}
+	return 1
+}
+
+type sdlDBusEncoder struct {
+	buf []byte
+}
+
+func (e *sdlDBusEncoder) align(n int) {
+	for len(e.buf)%n != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *sdlDBusEncoder) uint32(v uint32) {
+	e.align(4)
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+}
+
+/* Append a value of a single complete type, setting an error if it doesn't match the type */
+func (e *sdlDBusEncoder) encode(sig string, value any) bool {
+	ok := true
+	switch sig[0] {
+	case 'y':
+		var v byte
+		v, ok = value.(byte)
+		e.buf = append(e.buf, v)
+	case 'b':
+		var v bool
+		v, ok = value.(bool)
+		e.uint32(uint32(tern(v, 1, 0)))
+	case 'n':
+		var v int16
+		v, ok = value.(int16)
+		e.align(2)
+		e.buf = binary.LittleEndian.AppendUint16(e.buf, uint16(v))
+	case 'q':
+		var v uint16
+		v, ok = value.(uint16)
+		e.align(2)
+		e.buf = binary.LittleEndian.AppendUint16(e.buf, v)
+	case 'i':
+		var v int32
+		v, ok = value.(int32)
+		e.uint32(uint32(v))
+	case 'u':
+		var v uint32
+		v, ok = value.(uint32)
+		e.uint32(v)
+	case 'x', 't', 'd':
+		var v uint64
+		switch value := value.(type) {
+		case int64:
+			v, ok = uint64(value), sig[0] == 'x'
+		case uint64:
+			v, ok = value, sig[0] == 't'
+		case float64:
+			v, ok = math.Float64bits(value), sig[0] == 'd'
+		default:
+			ok = false
+		}
+		e.align(8)
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, v)
+	case 's', 'o':
+		var v string
+		v, ok = value.(string)
+		e.uint32(uint32(len(v)))
+		e.buf = append(append(e.buf, v...), 0)
+	case 'g':
+		var v string
+		v, ok = value.(string)
+		e.buf = append(append(append(e.buf, byte(len(v))), v...), 0)
+	case 'v':
+		var v sdlDBusVariant
+		if v, ok = value.(sdlDBusVariant); ok {
+			if _, rest, valid := sdlDBusNextType(v.sig); !valid || rest != "" {
+				return SDL_SetErrorf("Invalid D-Bus variant signature '%s'", v.sig)
+			}
+			e.encode("g", v.sig)
+			return e.encode(v.sig, v.value)
+		}
+	case '(':
+		var fields []any
+		if fields, ok = value.([]any); ok {
+			e.align(8)
+			rest := sig[1 : len(sig)-1]
+			for _, field := range fields {
+				var elem string
+				if elem, rest, ok = sdlDBusNextType(rest); !ok || !e.encode(elem, field) {
+					return SDL_SetErrorf("Can't encode D-Bus struct %s", sig)
+				}
+			}
+			ok = rest == ""
+		}
+	case 'a':
+		return e.encodeArray(sig, value)
+	default:
+		ok = false
+	}
+	if !ok {
+		return SDL_SetErrorf("Can't encode %T as D-Bus type %s", value, sig)
+	}
+	return true
+}
+
+func (e *sdlDBusEncoder) encodeArray(sig string, value any) bool {
+	elem := sig[1:]
+	if _, rest, ok := sdlDBusSplitType(elem, true); !ok || rest != "" {
+		return SDL_SetErrorf("Invalid D-Bus array signature '%s'", sig)
+	}
+	e.uint32(0)
+	length := len(e.buf) - 4
+	e.align(sdlDBusAlignment(elem[0]))
+	start := len(e.buf)
+
+	ok := true
+	switch values := value.(type) {
+	case []byte:
+		ok = elem == "y"
+		e.buf = append(e.buf, values...)
+	case []string:
+		for _, v := range values {
+			ok = ok && e.encode(elem, v)
+		}
+	case []any:
+		for _, v := range values {
+			ok = ok && e.encode(elem, v)
+		}
+	case map[string]sdlDBusVariant:
+		ok = elem == "{sv}"
+		for k, v := range values {
+			e.align(8)
+			ok = ok && e.encode("s", k) && e.encode("v", v)
+		}
+	case map[any]any:
+		if elem[0] != '{' {
+			ok = false
+			break
+		}
+		key, rest, _ := sdlDBusNextType(elem[1 : len(elem)-1])
+		for k, v := range values {
+			e.align(8)
+			ok = ok && e.encode(key, k) && e.encode(rest, v)
+		}
+	default:
+		ok = false
+	}
+	if !ok {
+		return SDL_SetErrorf("Can't encode %T as D-Bus type %s", value, sig)
+	}
+	binary.LittleEndian.PutUint32(e.buf[length:], uint32(len(e.buf)-start))
+	return true
+}
+
+/* Variants may nest 64 deep by the specification */
+const sdlDBusMaxVariantDepth = 64
+
+type sdlDBusDecoder struct {
+	buf      []byte
+	pos      int
+	order    binary.ByteOrder
+	variants int /* The nesting depth of the variant being decoded */
+}
+
+func (d *sdlDBusDecoder) read(align, n int) ([]byte, bool) {
+	pos := (d.pos + align - 1) &^ (align - 1)
+	if n < 0 || pos+n > len(d.buf) {
+		return nil, false
+	}
+	d.pos = pos + n
+	return d.buf[pos:d.pos], true
+}
+
+func (d *sdlDBusDecoder) uint32() (uint32, bool) {
+	b, ok := d.read(4, 4)
+	if !ok {
+		return 0, false
+	}
+	return d.order.Uint32(b), true
+}
+
+/* Read a value of a single complete type */
+func (d *sdlDBusDecoder) decode(sig string) (any, bool) {
+	if sig == "" {
+		return nil, false
+	}
+	switch sig[0] {
+	case 'y':
+		b, ok := d.read(1, 1)
+		if !ok {
+			return nil, false
+		}
+		return b[0], true
+	case 'b':
+		v, ok := d.uint32()
+		return v != 0, ok
+	case 'n', 'q':
+		b, ok := d.read(2, 2)
+		if !ok {
+			return nil, false
+		}
+		if sig[0] == 'n' {
+			return int16(d.order.Uint16(b)), true
+		}
+		return d.order.Uint16(b), true
+	case 'i':
+		v, ok := d.uint32()
+		return int32(v), ok
+	case 'u', 'h':
+		return d.uint32()
+	case 'x', 't', 'd':
+		b, ok := d.read(8, 8)
+		if !ok {
+			return nil, false
+		}
+		v := d.order.Uint64(b)
+		switch sig[0] {
+		case 'x':
+			return int64(v), true
+		case 'd':
+			return math.Float64frombits(v), true
+		}
+		return v, true
+	case 's', 'o':
+		n, ok := d.uint32()
+		if !ok {
+			return nil, false
+		}
+		b, ok := d.read(1, int(n)+1)
+		if !ok {
+			return nil, false
+		}
+		return string(b[:n]), true
+	case 'g':
+		n, ok := d.read(1, 1)
+		if !ok {
+			return nil, false
+		}
+		b, ok := d.read(1, int(n[0])+1)
+		if !ok {
+			return nil, false
+		}
+		return string(b[:n[0]]), true
+	case 'v':
+		inner, ok := d.decode("g")
+		if !ok {
+			return nil, false
+		}
+		s := inner.(string)
+		if _, rest, valid := sdlDBusNextType(s); !valid || rest != "" {
+			return nil, false
+		}
+		if d.variants == sdlDBusMaxVariantDepth {
+			return nil, false
+		}
+		d.variants++
+		value, ok := d.decode(s)
+		d.variants--
+		return sdlDBusVariant{sig: s, value: value}, ok
+	case '(':
+		if _, ok := d.read(8, 0); !ok {
+			return nil, false
+		}
+		var fields []any
+		rest := sig[1 : len(sig)-1]
+		for rest != "" {
+			elem, r, ok := sdlDBusNextType(rest)
+			if !ok {
+				return nil, false
+			}
+			field, ok := d.decode(elem)
+			if !ok {
+				return nil, false
+			}
+			fields = append(fields, field)
+			rest = r
+		}
+		return fields, true
+	case 'a':
+		return d.decodeArray(sig)
+	}
+	return nil, false
+}
+
+func (d *sdlDBusDecoder) decodeArray(sig string) (any, bool) {
+	elem := sig[1:]
+	if elem == "" {
+		return nil, false
+	}
+	n, ok := d.uint32()
+	if !ok || n > sdlDBusMaxMessageSize {
+		return nil, false
+	}
+	if _, ok := d.read(sdlDBusAlignment(elem[0]), 0); !ok {
+		return nil, false
+	}
+	end := d.pos + int(n)
+	if end > len(d.buf) {
+		return nil, false
+	}
+
+	switch {
+	case elem == "y":
+		b, _ := d.read(1, int(n))
+		return append([]byte(nil), b...), true
+	case elem[0] == '{':
+		key, value, ok := sdlDBusNextType(elem[1 : len(elem)-1])
+		if !ok || value == "" {
+			return nil, false
+		}
+		entries := make(map[any]any)
+		variants := make(map[string]sdlDBusVariant)
+		for d.pos < end {
+			if _, ok := d.read(8, 0); !ok {
+				return nil, false
+			}
+			k, ok := d.decode(key)
+			if !ok {
+				return nil, false
+			}
+			v, ok := d.decode(value)
+			if !ok {
+				return nil, false
+			}
+			if elem == "{sv}" {
+				variants[k.(string)] = v.(sdlDBusVariant)
+			} else {
+				entries[k] = v
+			}
+		}
+		if elem == "{sv}" {
+			return variants, d.pos == end
+		}
+		return entries, d.pos == end
+	}
+
+	values := []any{}
+	for d.pos < end {
+		v, ok := d.decode(elem)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, v)
+	}
+	return values, d.pos == end
+}
+
+/* Encode a message, with the serial already assigned */
+func (m *sdlDBusMessage) marshal() ([]byte, bool) {
+	var body sdlDBusEncoder
+	rest := m.sig
+	for _, value := range m.body {
+		elem, r, ok := sdlDBusNextType(rest)
+		if !ok || !body.encode(elem, value) {
+			return nil, SDL_SetErrorf("Can't encode the arguments of %s.%s as (%s)", m.iface, m.member, m.sig)
+		}
+		rest = r
+	}
+	if rest != "" {
+		return nil, SDL_SetErrorf("Missing arguments for %s.%s (%s)", m.iface, m.member, m.sig)
+	}
+
+	fields := []any{}
+	field := func(code byte, sig string, value any) {
+		if value != "" && value != uint32(0) {
+			fields = append(fields, []any{code, sdlDBusVariant{sig: sig, value: value}})
+		}
+	}
+	field(sdlDBusFieldPath, "o", m.path)
+	field(sdlDBusFieldInterface, "s", m.iface)
+	field(sdlDBusFieldMember, "s", m.member)
+	field(sdlDBusFieldErrorName, "s", m.error_name)
+	field(sdlDBusFieldReplySerial, "u", m.reply_serial)
+	field(sdlDBusFieldDestination, "s", m.destination)
+	field(sdlDBusFieldSignature, "g", m.sig)
+
+	var e sdlDBusEncoder
+	e.buf = append(e.buf, 'l', m.kind, 0, 1)
+	e.uint32(uint32(len(body.buf)))
+	e.uint32(m.serial)
+	if !e.encode("a(yv)", fields) {
+		return nil, false
+	}
+	e.align(8)
+	return append(e.buf, body.buf...), true
+}
+
+/* Read the next message from the bus */
+func sdlDBusReadMessage(r io.Reader) (*sdlDBusMessage, bool) {
+	fixed := make([]byte, 16)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, false
+	}
+	var order binary.ByteOrder
+	switch fixed[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return nil, false
+	}
+	body_length, fields_length := order.Uint32(fixed[4:]), order.Uint32(fixed[12:])
+	if body_length > sdlDBusMaxMessageSize || fields_length > sdlDBusMaxMessageSize {
+		return nil, false
+	}
+	header_length := (16 + int(fields_length) + 7) &^ 7
+	data := make([]byte, header_length+int(body_length))
+	copy(data, fixed)
+	if _, err := io.ReadFull(r, data[16:]); err != nil {
+		return nil, false
+	}
+
+	m := &sdlDBusMessage{kind: fixed[1], serial: order.Uint32(fixed[8:])}
+	header := sdlDBusDecoder{buf: data[:header_length], pos: 12, order: order}
+	fields, ok := header.decode("a(yv)")
+	if !ok {
+		return nil, false
+	}
+	for _, f := range fields.([]any) {
+		field := f.([]any)
+		code, value := field[0].(byte), field[1].(sdlDBusVariant).value
+		switch code {
+		case sdlDBusFieldPath:
+			m.path, _ = value.(string)
+		case sdlDBusFieldInterface:
+			m.iface, _ = value.(string)
+		case sdlDBusFieldMember:
+			m.member, _ = value.(string)
+		case sdlDBusFieldErrorName:
+			m.error_name, _ = value.(string)
+		case sdlDBusFieldReplySerial:
+			m.reply_serial, _ = value.(uint32)
+		case sdlDBusFieldDestination:
+			m.destination, _ = value.(string)
+		case sdlDBusFieldSender:
+			m.sender, _ = value.(string)
+		case sdlDBusFieldSignature:
+			m.sig, _ = value.(string)
+		}
+	}
+
+	body := sdlDBusDecoder{buf: data[header_length:], order: order}
+	for rest := m.sig; rest != ""; {
+		elem, r, ok := sdlDBusNextType(rest)
+		if !ok {
+			return nil, false
+		}
+		value, ok := body.decode(elem)
+		if !ok {
+			return nil, false
+		}
+		m.body = append(m.body, value)
+		rest = r
+	}
+	return m, true
+}
+
+/* A signal subscription, empty fields match anything */
+type sdlDBusSignalHandler struct {
+	rule     string /* the match rule registered with the bus */
+	path     string
+	iface    string
+	member   string
+	callback func(msg *sdlDBusMessage) /* called with nil when the connection is lost */
+}
+
+type sdlDBusConnection struct {
+	conn net.Conn
+	name string /* the unique name the bus assigned to the connection */
+
+	lock         sdlMutex /* guards the fields below and writes to conn */
+	serial       uint32
+	replies      map[uint32]chan *sdlDBusMessage
+	handlers     map[int]*sdlDBusSignalHandler
+	next_handler int
+	closed       bool
+}
+
+/* The shared session bus connection, guarded by dbusLock */
+var dbusLock = sdlMutex{name: "dbus.session"}
+var dbusSession *sdlDBusConnection
+
+/* The session bus address, from the environment or the systemd default */
+func sdlDBusSessionAddress() string {
+	if address := os.Getenv("DBUS_SESSION_BUS_ADDRESS"); address != "" {
+		return address
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return "unix:path=" + dir + "/bus"
+	}
+	return ""
+}
+
+/* Connect to the first reachable unix socket of a bus address */
+func sdlDBusDial(address string) net.Conn {
+	for _, entry := range strings.Split(address, ";") {
+		transport, params, _ := strings.Cut(entry, ":")
+		if transport != "unix" {
+			continue
+		}
+		var name string
+		for _, param := range strings.Split(params, ",") {
+			key, value, _ := strings.Cut(param, "=")
+			value, err := url.PathUnescape(value)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "path":
+				name = value
+			case "abstract":
+				name = "@" + value
+			}
+		}
+		if name == "" {
+			continue
+		}
+		if conn, err := net.Dial("unix", name); err == nil {
+			return conn
+		}
+	}
+	return nil
+}
+
+/* The session bus connection if it is still open, call with dbusLock held */
+func sdlDBusOpenSessionLocked() *sdlDBusConnection {
+	if dbusSession != nil {
+		dbusSession.lock.Lock()
+		closed := dbusSession.closed
+		dbusSession.lock.Unlock()
+		if !closed {
+			return dbusSession
+		}
+		dbusSession = nil
+	}
+	return nil
+}
+
+/*
+ * Get the session bus connection, connecting on first use, or nil with an
+ * error set. Connecting waits for the bus, so it's done without dbusLock
+ * held, and the connection of the goroutine that finishes first is kept.
+ */
+func sdlDBusSession() *sdlDBusConnection {
+	dbusLock.Lock()
+	bus := sdlDBusOpenSessionLocked()
+	dbusLock.Unlock()
+	if bus != nil {
+		return bus
+	}
+
+	bus = sdlDBusConnectSession()
+	if bus == nil {
+		return nil
+	}
+
+	dbusLock.Lock()
+	defer dbusLock.Unlock()
+
+	if session := sdlDBusOpenSessionLocked(); session != nil {
+		bus.conn.Close()
+		return session
+	}
+	dbusSession = bus
+	return bus
+}
+
+/* Connect and authenticate to the session bus, or return nil with an error set */
+func sdlDBusConnectSession() *sdlDBusConnection {
+	conn := sdlDBusDial(sdlDBusSessionAddress())
+	if conn == nil {
+		SDL_SetError("Couldn't connect to the D-Bus session bus")
+		return nil
+	}
+
+	/* SASL EXTERNAL authentication with our uid, then switch to the message protocol */
+	conn.SetDeadline(time.Now().Add(sdlDBusTimeout))
+	reader := bufio.NewReader(conn)
+	fmt.Fprintf(conn, "\x00AUTH EXTERNAL %x\r\n", strconv.Itoa(os.Getuid()))
+	if line, err := reader.ReadString('\n'); err != nil || !strings.HasPrefix(line, "OK ") {
+		conn.Close()
+		SDL_SetError("D-Bus authentication failed")
+		return nil
+	}
+	if _, err := io.WriteString(conn, "BEGIN\r\n"); err != nil {
+		conn.Close()
+		SDL_SetError("D-Bus authentication failed")
+		return nil
+	}
+	conn.SetDeadline(time.Time{})
+
+	bus := &sdlDBusConnection{
+		conn:     conn,
+		lock:     sdlMutex{name: "dbus.connection"},
+		replies:  make(map[uint32]chan *sdlDBusMessage),
+		handlers: make(map[int]*sdlDBusSignalHandler),
+	}
+	go bus.readMessages(reader)
+
+	reply, ok := bus.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello", "")
+	if !ok || len(reply) != 1 {
+		bus.conn.Close()
+		return nil
+	}
+	bus.name, _ = reply[0].(string)
+	return bus
+}
+
+/* Dispatch the incoming messages until the connection is lost */
+func (bus *sdlDBusConnection) readMessages(r io.Reader) {
+	for {
+		msg, ok := sdlDBusReadMessage(r)
+		if !ok {
+			break
+		}
+
+		switch msg.kind {
+		case sdlDBusMethodReturn, sdlDBusError:
+			bus.lock.Lock()
+			reply := bus.replies[msg.reply_serial]
+			delete(bus.replies, msg.reply_serial)
+			bus.lock.Unlock()
+			if reply != nil {
+				reply <- msg
+			}
+		case sdlDBusSignal:
+			var callbacks []func(*sdlDBusMessage)
+			bus.lock.Lock()
+			for _, handler := range bus.handlers {
+				if (handler.path == "" || handler.path == msg.path) &&
+					(handler.iface == "" || handler.iface == msg.iface) &&
+					(handler.member == "" || handler.member == msg.member) {
+					callbacks = append(callbacks, handler.callback)
+				}
+			}
+			bus.lock.Unlock()
+			for _, callback := range callbacks {
+				callback(msg)
+			}
+		}
+	}
+
+	bus.lock.Lock()
+	bus.closed = true
+	for serial, reply := range bus.replies {
+		close(reply)
+		delete(bus.replies, serial)
+	}
+	var callbacks []func(*sdlDBusMessage)
+	for _, handler := range bus.handlers {
+		callbacks = append(callbacks, handler.callback)
+	}
+	bus.lock.Unlock()
+	bus.conn.Close()
+
+	for _, callback := range callbacks {
+		callback(nil)
+	}
+}
+
+/* Call a method and wait for its reply, returns the reply arguments or false with an error set */
+func (bus *sdlDBusConnection) Call(destination, path, iface, member, sig string, args ...any) ([]any, bool) {
+	msg := &sdlDBusMessage{
+		kind:        sdlDBusMethodCall,
+		path:        path,
+		iface:       iface,
+		member:      member,
+		destination: destination,
+		sig:         sig,
+		body:        args,
+	}
+	reply := make(chan *sdlDBusMessage, 1)
+
+	bus.lock.Lock()
+	if bus.closed {
+		bus.lock.Unlock()
+		return nil, SDL_SetError("The D-Bus connection was lost")
+	}
+	bus.serial++
+	msg.serial = bus.serial
+	data, ok := msg.marshal()
+	if !ok {
+		bus.lock.Unlock()
+		return nil, false
+	}
+	bus.replies[msg.serial] = reply
+	_, err := bus.conn.Write(data)
+	bus.lock.Unlock()
+	if err != nil {
+		bus.conn.Close() /* the reader fails the pending calls */
+		return nil, SDL_SetErrorf("Couldn't send %s.%s: %v", iface, member, err)
+	}
+
+	var response *sdlDBusMessage
+	select {
+	case response = <-reply:
+	case <-time.After(sdlDBusTimeout):
+		bus.lock.Lock()
+		delete(bus.replies, msg.serial)
+		bus.lock.Unlock()
+		return nil, SDL_SetErrorf("%s.%s timed out", iface, member)
+	}
+	if response == nil {
+		return nil, SDL_SetError("The D-Bus connection was lost")
+	}
+	if response.kind == sdlDBusError {
+		text := ""
+		if len(response.body) > 0 {
+			text, _ = response.body[0].(string)
+		}
+		return nil, SDL_SetErrorf("%s: %s", response.error_name, text)
+	}
+	return response.body, true
+}
+
+/*
+ * Subscribe to the signals matching a path, interface and member, any of
+ * which can be empty to match anything. Returns an id for
+ * RemoveSignalHandler(), or false with an error set.
+ */
+func (bus *sdlDBusConnection) AddSignalHandler(path, iface, member string, callback func(msg *sdlDBusMessage)) (int, bool) {
+	rule := "type='signal'"
+	if path != "" {
+		rule += ",path='" + path + "'"
+	}
+	if iface != "" {
+		rule += ",interface='" + iface + "'"
+	}
+	if member != "" {
+		rule += ",member='" + member + "'"
+	}
+
+	/* Registered first, so no signal is missed between the match and the registration */
+	bus.lock.Lock()
+	bus.next_handler++
+	id := bus.next_handler
+	bus.handlers[id] = &sdlDBusSignalHandler{rule: rule, path: path, iface: iface, member: member, callback: callback}
+	bus.lock.Unlock()
+
+	if _, ok := bus.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "AddMatch", "s", rule); !ok {
+		bus.lock.Lock()
+		delete(bus.handlers, id)
+		bus.lock.Unlock()
+		return 0, false
+	}
+	return id, true
+}
+
+/* Unsubscribe a signal handler */
+func (bus *sdlDBusConnection) RemoveSignalHandler(id int) {
+	bus.lock.Lock()
+	handler := bus.handlers[id]
+	delete(bus.handlers, id)
+	closed := bus.closed
+	bus.lock.Unlock()
+
+	if handler != nil && !closed {
+		bus.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "RemoveMatch", "s", handler.rule)
+	}
+}
+
+/* Whether a bus name has an owner or can be started on demand */
+func (bus *sdlDBusConnection) NameAvailable(name string) bool {
+	if reply, ok := bus.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "NameHasOwner", "s", name); ok && len(reply) == 1 && reply[0] == true {
+		return true
+	}
+	reply, ok := bus.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "ListActivatableNames", "")
+	if !ok || len(reply) != 1 {
+		return false
+	}
+	names, _ := reply[0].([]any)
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package sdl
+
+import "bytes"
+import "encoding/binary"
+import "reflect"
+import "testing"
+
+/* Nest a value in depth variants */
+func testDBusNestedVariant(depth int, value int32) any {
+	var v any = value
+	sig := "i"
+	for i := 0; i < depth; i++ {
+		v = sdlDBusVariant{sig: sig, value: v}
+		sig = "v"
+	}
+	return v
+}
+
+func TestDBusMessageRoundTrip(t *testing.T) {
+	sent := &sdlDBusMessage{
+		kind:        sdlDBusMethodCall,
+		serial:      42,
+		path:        "/org/freedesktop/portal/desktop",
+		iface:       "org.freedesktop.portal.FileChooser",
+		member:      "OpenFile",
+		destination: "org.freedesktop.portal.Desktop",
+		sig:         "ybnqiuxtdsogvayasa{sv}a{us}(is)aai",
+		body: []any{
+			byte(7), true, int16(-2), uint16(3), int32(-4), uint32(5),
+			int64(-6), uint64(7), 0.5, "text", "/a/path", "a{sv}",
+			sdlDBusVariant{sig: "(ss)", value: []any{"one", "two"}},
+			[]byte{1, 2, 3},
+			[]string{"x", "y"},
+			map[string]sdlDBusVariant{
+				"modal":    {sig: "b", value: true},
+				"multiple": {sig: "u", value: uint32(2)},
+			},
+			map[any]any{uint32(1): "one", uint32(2): "two"},
+			[]any{int32(9), "nine"},
+			[]any{[]any{int32(1), int32(2)}, []any{}},
+		},
+	}
+	data, ok := sent.marshal()
+	if !ok {
+		t.Fatalf("marshal failed: %s", SDL_GetError())
+	}
+	received, ok := sdlDBusReadMessage(bytes.NewReader(data))
+	if !ok {
+		t.Fatalf("sdlDBusReadMessage failed")
+	}
+
+	if received.kind != sent.kind || received.serial != sent.serial ||
+		received.path != sent.path || received.iface != sent.iface ||
+		received.member != sent.member || received.destination != sent.destination ||
+		received.sig != sent.sig {
+		t.Fatalf("Header mismatch: got %+v, expected %+v", *received, *sent)
+	}
+
+	/* Arrays other than bytes and dictionaries decode as []any */
+	expected := append([]any{}, sent.body...)
+	expected[14] = []any{"x", "y"}
+	for i := range expected {
+		if !reflect.DeepEqual(received.body[i], expected[i]) {
+			t.Errorf("Argument %d (%T): got %#v, expected %#v", i, expected[i], received.body[i], expected[i])
+		}
+	}
+}
+
+func TestDBusEncodeRejectsMismatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		sig   string
+		value any
+	}{
+		{"wrong basic type", "i", "text"},
+		{"wrong integer width", "x", int32(1)},
+		{"map as plain array", "ai", map[any]any{int32(1): int32(2)}},
+		{"map as array of structs", "a(ii)", map[any]any{int32(1): int32(2)}},
+		{"bytes as strings", "as", []byte{1}},
+		{"array without element", "a", []any{}},
+		{"unterminated dict entry", "a{ii", map[any]any{}},
+		{"short struct", "(ii)", []any{int32(1)}},
+		{"long struct", "(i)", []any{int32(1), int32(2)}},
+		{"invalid variant", "v", sdlDBusVariant{sig: "ii", value: int32(1)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var e sdlDBusEncoder
+			if e.encode(test.sig, test.value) {
+				t.Errorf("Encoding %#v as %s succeeded", test.value, test.sig)
+			}
+		})
+	}
+}
+
+func TestDBusDecodeVariantDepth(t *testing.T) {
+	for _, depth := range []int{1, sdlDBusMaxVariantDepth, sdlDBusMaxVariantDepth + 1} {
+		var e sdlDBusEncoder
+		if !e.encode("v", testDBusNestedVariant(depth, 17)) {
+			t.Fatalf("Encoding %d variants failed: %s", depth, SDL_GetError())
+		}
+		d := sdlDBusDecoder{buf: e.buf, order: binary.LittleEndian}
+		value, ok := d.decode("v")
+		if depth > sdlDBusMaxVariantDepth {
+			if ok {
+				t.Errorf("Decoding %d nested variants succeeded", depth)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("Decoding %d nested variants failed", depth)
+		} else if !reflect.DeepEqual(value, testDBusNestedVariant(depth, 17)) {
+			t.Errorf("Decoding %d nested variants: got %#v", depth, value)
+		}
+	}
+}
+
+func TestDBusDecodeRejectsTruncated(t *testing.T) {
+	var e sdlDBusEncoder
+	if !e.encode("a{sv}", map[string]sdlDBusVariant{"key": {sig: "s", value: "value"}}) {
+		t.Fatalf("encode failed: %s", SDL_GetError())
+	}
+	for n := 0; n < len(e.buf); n++ {
+		d := sdlDBusDecoder{buf: e.buf[:n], order: binary.LittleEndian}
+		if _, ok := d.decode("a{sv}"); ok {
+			t.Errorf("Decoding %d of %d bytes succeeded", n, len(e.buf))
+		}
+	}
+}
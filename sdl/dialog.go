@@ -0,0 +1,474 @@
+package sdl
+
+import "strings"
+
+/*
+ * File dialogs.
+ *
+ * The dialogs are asynchronous: the SDL_Show*Dialog() functions return right
+ * away and the callback is invoked once the user is done with the dialog,
+ * possibly from another goroutine. A platform backend registers its
+ * implementation from an init() function; on platforms without one, the
+ * callback gets an SDL_Unsupported() error.
+ *
+ * The filter the user picked last is remembered for each set of filters,
+ * and selected the next time a dialog shows the same filters, where the
+ * backend can preselect a filter. The filters keep the application's order.
+ */
+
+/**
+ * An entry for filters for file dialogs.
+ *
+ * `Name` is a user-readable label for the filter (for example, "Office
+ * document").
+ *
+ * `Pattern` is a semicolon-separated list of file extensions (for example,
+ * "doc;docx"). File extensions may only contain alphanumeric characters,
+ * hyphens, underscores and periods. Alternatively, the whole string can be a
+ * single asterisk ("*"), which serves as an "All files" filter.
+ *
+ * This struct is available since SDL 3.0.0.
+ *
+ * See also SDL_DialogFileCallback
+ * See also SDL_ShowOpenFileDialog
+ * See also SDL_ShowSaveFileDialog
+ * See also SDL_ShowOpenFolderDialog
+ * See also SDL_ShowFileDialogWithProperties
+ */
+type SDL_DialogFileFilter struct {
+	Name    string
+	Pattern string
+}
+
+/**
+ * Callback used by file dialog functions.
+ *
+ * The specific usage is described in each function.
+ *
+ * If `filelist` is:
+ *
+ * - nil, an error occurred. Details can be obtained with SDL_GetError().
+ * - An empty, non-nil slice, the user either didn't choose any file or
+ *   canceled the dialog.
+ * - Otherwise, the paths of the files the user chose.
+ *
+ * The filelist slice belongs to the callback, it is not used by SDL
+ * afterwards.
+ *
+ * The filter argument is the index of the filter that was selected, or -1 if
+ * no filter was selected or if the platform or method doesn't support
+ * fetching the selected filter.
+ *
+ * In Android, the `filelist` are `content://` URIs. They should be opened
+ * using SDL_IOFromFile() with appropriate modes. This applies both to open
+ * and save file dialog.
+ *
+ * - userdata an app-provided pointer, for the callback's use.
+ * - filelist the file(s) chosen by the user.
+ * - filter index of the selected filter.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_DialogFileFilter
+ * See also SDL_ShowOpenFileDialog
+ * See also SDL_ShowSaveFileDialog
+ * See also SDL_ShowOpenFolderDialog
+ * See also SDL_ShowFileDialogWithProperties
+ */
+type SDL_DialogFileCallback func(userdata any, filelist []string, filter int)
+
+/**
+ * Various types of file dialogs.
+ *
+ * This is used by SDL_ShowFileDialogWithProperties() to decide what kind of
+ * dialog to present to the user.
+ *
+ * This enum is available since SDL 3.0.0.
+ *
+ * See also SDL_ShowFileDialogWithProperties
+ */
+type SDL_FileDialogType int
+
+const (
+	SDL_FILEDIALOG_OPENFILE SDL_FileDialogType = iota
+	SDL_FILEDIALOG_SAVEFILE
+	SDL_FILEDIALOG_OPENFOLDER
+)
+
+/**
+ * A variable that specifies the dialog backend to use.
+ *
+ * By default, SDL will try all available dialog backends in a reasonable
+ * order. Most users won't need to set this hint.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "portal": Use XDG Portals through DBus (Unix only)
+ * - "zenity": Use the Zenity program (Unix only)
+ *
+ * More options may be added in the future.
+ *
+ * This hint can be set anytime.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_FILE_DIALOG_DRIVER = "SDL_FILE_DIALOG_DRIVER"
+
+const SDL_PROP_FILE_DIALOG_FILTERS_POINTER = "SDL.filedialog.filters"
+const SDL_PROP_FILE_DIALOG_NFILTERS_NUMBER = "SDL.filedialog.nfilters"
+const SDL_PROP_FILE_DIALOG_WINDOW_POINTER = "SDL.filedialog.window"
+const SDL_PROP_FILE_DIALOG_LOCATION_STRING = "SDL.filedialog.location"
+const SDL_PROP_FILE_DIALOG_MANY_BOOLEAN = "SDL.filedialog.many"
+const SDL_PROP_FILE_DIALOG_TITLE_STRING = "SDL.filedialog.title"
+const SDL_PROP_FILE_DIALOG_ACCEPT_STRING = "SDL.filedialog.accept"
+const SDL_PROP_FILE_DIALOG_CANCEL_STRING = "SDL.filedialog.cancel"
+
+/* A dialog to show, as handed to the backend */
+type sdlFileDialog struct {
+	kind     SDL_FileDialogType
+	filters  []SDL_DialogFileFilter /* in the application's order */
+	current  int                    /* the filter to preselect, the one picked last time or 0 */
+	window   *SDL_Window            /* the parent the dialog is modal for, or nil */
+	location string                 /* the folder or file to start from, or "" */
+	many     bool
+	title    string
+	accept   string
+	cancel   string
+}
+
+/* Entry points of the platform dialog backend */
+type sdlFileDialogBackend struct {
+	/*
+	 * Show a dialog, calling done from any goroutine once it is closed, with
+	 * the same arguments as SDL_DialogFileCallback. The filter index is into
+	 * dialog.filters. When filelist is nil, err is the error message for the
+	 * callback.
+	 */
+	ShowFileDialog func(dialog *sdlFileDialog, done func(filelist []string, filter int, err string))
+}
+
+var fileDialogBackend *sdlFileDialogBackend
+
+/* The filter picked last for each set of filters, see sdlDialogFiltersKey() */
+var dialogFiltersLock = sdlMutex{name: "dialog.filters"}
+var dialogLastFilter = make(map[string]int)
+
+/* A key for a set of filters, the same for the same filters in the same order */
+func sdlDialogFiltersKey(filters []SDL_DialogFileFilter) string {
+	var key strings.Builder
+	for _, filter := range filters {
+		key.WriteString(filter.Name)
+		key.WriteByte(0)
+		key.WriteString(filter.Pattern)
+		key.WriteByte(0)
+	}
+	return key.String()
+}
+
+/* Check that filter patterns are extension lists or "*", setting an error if not */
+func sdlValidateDialogFilters(filters []SDL_DialogFileFilter) bool {
+	for _, filter := range filters {
+		if filter.Pattern == "*" {
+			continue
+		}
+		for _, c := range filter.Pattern {
+			if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') &&
+				c != '-' && c != '_' && c != '.' && c != ';' {
+				return SDL_SetErrorf("Invalid character '%c' in file filter pattern '%s'", c, filter.Pattern)
+			}
+		}
+	}
+	return true
+}
+
+/*
+ * The index of the first filter a path matches, or -1, for backends that
+ * can't tell which filter the user selected.
+ */
+func sdlMatchDialogFilter(filters []SDL_DialogFileFilter, path string) int {
+	name := strings.ToLower(path)
+	for i, filter := range filters {
+		if filter.Pattern == "*" {
+			return i
+		}
+		for _, ext := range strings.Split(filter.Pattern, ";") {
+			if ext != "" && strings.HasSuffix(name, "."+strings.ToLower(ext)) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+/**
+ * Create and launch a file dialog with the specified properties.
+ *
+ * These are the supported properties:
+ *
+ * - `SDL_PROP_FILE_DIALOG_FILTERS_POINTER`: a []SDL_DialogFileFilter of
+ *   filters that the user can use to narrow down the files to select. Not
+ *   applicable for folder dialogs.
+ * - `SDL_PROP_FILE_DIALOG_NFILTERS_NUMBER`: the number of filters of the
+ *   slice to use, defaults to all of them.
+ * - `SDL_PROP_FILE_DIALOG_WINDOW_POINTER`: the window that the dialog should
+ *   be modal for.
+ * - `SDL_PROP_FILE_DIALOG_LOCATION_STRING`: the default folder or file to
+ *   start the dialog at.
+ * - `SDL_PROP_FILE_DIALOG_MANY_BOOLEAN`: true to allow the user to select
+ *   more than one entry. Not applicable for save dialogs.
+ * - `SDL_PROP_FILE_DIALOG_TITLE_STRING`: the title for the dialog.
+ * - `SDL_PROP_FILE_DIALOG_ACCEPT_STRING`: the label that the accept button
+ *   should have.
+ * - `SDL_PROP_FILE_DIALOG_CANCEL_STRING`: the label that the cancel button
+ *   should have.
+ *
+ * Note that each platform may or may not support any of the properties.
+ *
+ * The properties are read before this function returns, they can be
+ * destroyed right away.
+ *
+ * - type the type of file dialog.
+ * - callback a function pointer to be invoked when the user selects a
+ *                 file and accepts, or cancels the dialog, or an error
+ *                 occurs.
+ * - userdata an optional pointer to pass extra data to the callback when
+ *                 it will be invoked.
+ * - props the properties to use.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_FileDialogType
+ * See also SDL_DialogFileCallback
+ * See also SDL_DialogFileFilter
+ * See also SDL_ShowOpenFileDialog
+ * See also SDL_ShowSaveFileDialog
+ * See also SDL_ShowOpenFolderDialog
+ */
+func SDL_ShowFileDialogWithProperties(kind SDL_FileDialogType, callback SDL_DialogFileCallback, userdata any, props SDL_PropertiesID) {
+	if callback == nil {
+		return
+	}
+
+	dialog := &sdlFileDialog{
+		kind:     kind,
+		location: SDL_GetStringProperty(props, SDL_PROP_FILE_DIALOG_LOCATION_STRING, ""),
+		many:     SDL_GetBooleanProperty(props, SDL_PROP_FILE_DIALOG_MANY_BOOLEAN, false),
+		title:    SDL_GetStringProperty(props, SDL_PROP_FILE_DIALOG_TITLE_STRING, ""),
+		accept:   SDL_GetStringProperty(props, SDL_PROP_FILE_DIALOG_ACCEPT_STRING, ""),
+		cancel:   SDL_GetStringProperty(props, SDL_PROP_FILE_DIALOG_CANCEL_STRING, ""),
+	}
+	dialog.window, _ = SDL_GetPointerProperty(props, SDL_PROP_FILE_DIALOG_WINDOW_POINTER, nil).(*SDL_Window)
+
+	switch kind {
+	case SDL_FILEDIALOG_OPENFILE, SDL_FILEDIALOG_SAVEFILE:
+		filters, _ := SDL_GetPointerProperty(props, SDL_PROP_FILE_DIALOG_FILTERS_POINTER, nil).([]SDL_DialogFileFilter)
+		n := SDL_GetNumberProperty(props, SDL_PROP_FILE_DIALOG_NFILTERS_NUMBER, int64(len(filters)))
+		dialog.filters = filters[:max(min(int(n), len(filters)), 0)]
+		if kind == SDL_FILEDIALOG_SAVEFILE {
+			dialog.many = false
+		}
+	case SDL_FILEDIALOG_OPENFOLDER:
+	default:
+		SDL_InvalidParamError("type")
+		callback(userdata, nil, -1)
+		return
+	}
+
+	if dialog.window != nil && !sdlCheckWindow(dialog.window) {
+		callback(userdata, nil, -1)
+		return
+	}
+	if !sdlValidateDialogFilters(dialog.filters) {
+		callback(userdata, nil, -1)
+		return
+	}
+	if fileDialogBackend == nil || fileDialogBackend.ShowFileDialog == nil {
+		SDL_Unsupported()
+		callback(userdata, nil, -1)
+		return
+	}
+
+	/* Preselect the filter picked last time */
+	key := sdlDialogFiltersKey(dialog.filters)
+	dialogFiltersLock.Lock()
+	last, remembered := dialogLastFilter[key]
+	dialogFiltersLock.Unlock()
+	if remembered && last >= 0 && last < len(dialog.filters) {
+		dialog.current = last
+	}
+
+	fileDialogBackend.ShowFileDialog(dialog, func(filelist []string, filter int, err string) {
+		if filelist == nil {
+			SDL_SetError(err)
+		}
+		if filter >= 0 && filter < len(dialog.filters) {
+			if len(filelist) > 0 {
+				dialogFiltersLock.Lock()
+				dialogLastFilter[key] = filter
+				dialogFiltersLock.Unlock()
+			}
+		} else {
+			filter = -1
+		}
+		callback(userdata, filelist, filter)
+	})
+}
+
+/* Show a dialog with properties built from the arguments of the convenience functions */
+func sdlShowFileDialog(kind SDL_FileDialogType, callback SDL_DialogFileCallback, userdata any, window *SDL_Window, filters []SDL_DialogFileFilter, default_location string, allow_many bool) {
+	props := SDL_CreateProperties()
+	if props == 0 {
+		if callback != nil {
+			callback(userdata, nil, -1)
+		}
+		return
+	}
+	defer SDL_DestroyProperties(props)
+
+	if filters != nil {
+		SDL_SetPointerProperty(props, SDL_PROP_FILE_DIALOG_FILTERS_POINTER, filters)
+	}
+	if window != nil {
+		SDL_SetPointerProperty(props, SDL_PROP_FILE_DIALOG_WINDOW_POINTER, window)
+	}
+	if default_location != "" {
+		SDL_SetStringProperty(props, SDL_PROP_FILE_DIALOG_LOCATION_STRING, default_location)
+	}
+	SDL_SetBooleanProperty(props, SDL_PROP_FILE_DIALOG_MANY_BOOLEAN, allow_many)
+	SDL_ShowFileDialogWithProperties(kind, callback, userdata, props)
+}
+
+/**
+ * Displays a dialog that lets the user select a file on their filesystem.
+ *
+ * This is an asynchronous function; it will return immediately, and the
+ * result will be passed to the callback.
+ *
+ * The callback will be invoked with a list of files the user chose. The list
+ * will be empty if the user canceled the dialog, and it will be nil if an
+ * error occurred.
+ *
+ * Note that the callback may be called from a different goroutine than the
+ * one the function was invoked on.
+ *
+ * Depending on the platform, the user may be allowed to input paths that
+ * don't yet exist.
+ *
+ * On Linux, dialogs may require XDG Portals, which requires DBus, which
+ * requires an event-handling loop. Apps that do not use SDL to handle events
+ * should add a call to SDL_PumpEvents in their main loop.
+ *
+ * - callback a function pointer to be invoked when the user selects a
+ *                 file and accepts, or cancels the dialog, or an error
+ *                 occurs.
+ * - userdata an optional pointer to pass extra data to the callback when
+ *                 it will be invoked.
+ * - window the window that the dialog should be modal for, may be nil.
+ *               Not all platforms support this option.
+ * - filters a slice of SDL_DialogFileFilter's, may be nil. Not all
+ *                platforms support this option, and platforms that do
+ *                support it may allow the user to ignore the filters.
+ * - default_location the default folder or file to start the dialog at,
+ *                         may be "". Not all platforms support this option.
+ * - allow_many if non-zero, the user will be allowed to select multiple
+ *                   entries. Not all platforms support this option.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DialogFileCallback
+ * See also SDL_DialogFileFilter
+ * See also SDL_ShowSaveFileDialog
+ * See also SDL_ShowOpenFolderDialog
+ * See also SDL_ShowFileDialogWithProperties
+ */
+func SDL_ShowOpenFileDialog(callback SDL_DialogFileCallback, userdata any, window *SDL_Window, filters []SDL_DialogFileFilter, default_location string, allow_many bool) {
+	sdlShowFileDialog(SDL_FILEDIALOG_OPENFILE, callback, userdata, window, filters, default_location, allow_many)
+}
+
+/**
+ * Displays a dialog that lets the user choose a new or existing file on their
+ * filesystem.
+ *
+ * This is an asynchronous function; it will return immediately, and the
+ * result will be passed to the callback.
+ *
+ * The callback will be invoked with a list of files the user chose. The list
+ * will be empty if the user canceled the dialog, and it will be nil if an
+ * error occurred.
+ *
+ * Note that the callback may be called from a different goroutine than the
+ * one the function was invoked on.
+ *
+ * The chosen file may or may not already exist.
+ *
+ * On Linux, dialogs may require XDG Portals, which requires DBus, which
+ * requires an event-handling loop. Apps that do not use SDL to handle events
+ * should add a call to SDL_PumpEvents in their main loop.
+ *
+ * - callback a function pointer to be invoked when the user selects a
+ *                 file and accepts, or cancels the dialog, or an error
+ *                 occurs.
+ * - userdata an optional pointer to pass extra data to the callback when
+ *                 it will be invoked.
+ * - window the window that the dialog should be modal for, may be nil.
+ *               Not all platforms support this option.
+ * - filters a slice of SDL_DialogFileFilter's, may be nil. Not all
+ *                platforms support this option, and platforms that do
+ *                support it may allow the user to ignore the filters.
+ * - default_location the default folder or file to start the dialog at,
+ *                         may be "". Not all platforms support this option.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DialogFileCallback
+ * See also SDL_DialogFileFilter
+ * See also SDL_ShowOpenFileDialog
+ * See also SDL_ShowOpenFolderDialog
+ * See also SDL_ShowFileDialogWithProperties
+ */
+func SDL_ShowSaveFileDialog(callback SDL_DialogFileCallback, userdata any, window *SDL_Window, filters []SDL_DialogFileFilter, default_location string) {
+	sdlShowFileDialog(SDL_FILEDIALOG_SAVEFILE, callback, userdata, window, filters, default_location, false)
+}
+
+/**
+ * Displays a dialog that lets the user select a folder on their filesystem.
+ *
+ * This is an asynchronous function; it will return immediately, and the
+ * result will be passed to the callback.
+ *
+ * The callback will be invoked with a list of folders the user chose. The
+ * list will be empty if the user canceled the dialog, and it will be nil if
+ * an error occurred.
+ *
+ * Note that the callback may be called from a different goroutine than the
+ * one the function was invoked on.
+ *
+ * Depending on the platform, the user may be allowed to input paths that
+ * don't yet exist.
+ *
+ * On Linux, dialogs may require XDG Portals, which requires DBus, which
+ * requires an event-handling loop. Apps that do not use SDL to handle events
+ * should add a call to SDL_PumpEvents in their main loop.
+ *
+ * - callback a function pointer to be invoked when the user selects a
+ *                 file and accepts, or cancels the dialog, or an error
+ *                 occurs.
+ * - userdata an optional pointer to pass extra data to the callback when
+ *                 it will be invoked.
+ * - window the window that the dialog should be modal for, may be nil.
+ *               Not all platforms support this option.
+ * - default_location the default folder or file to start the dialog at,
+ *                         may be "". Not all platforms support this option.
+ * - allow_many if non-zero, the user will be allowed to select multiple
+ *                   entries. Not all platforms support this option.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DialogFileCallback
+ * See also SDL_ShowOpenFileDialog
+ * See also SDL_ShowSaveFileDialog
+ * See also SDL_ShowFileDialogWithProperties
+ */
+func SDL_ShowOpenFolderDialog(callback SDL_DialogFileCallback, userdata any, window *SDL_Window, default_location string, allow_many bool) {
+	sdlShowFileDialog(SDL_FILEDIALOG_OPENFOLDER, callback, userdata, window, nil, default_location, allow_many)
+}
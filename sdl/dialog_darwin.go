@@ -0,0 +1,123 @@
+package sdl
+
+import "bytes"
+import "errors"
+import "fmt"
+import "os"
+import "os/exec"
+import "path/filepath"
+import "strings"
+
+/*
+ * File dialogs on macOS, through the "choose file" commands of AppleScript
+ * run by osascript, which every macOS version ships. This avoids an
+ * Objective-C bridge to NSOpenPanel and NSSavePanel.
+ *
+ * AppleScript has no filter menu: the extensions of all filters are
+ * allowed at once, unless one of them is "*", and the first filter matching
+ * the chosen file is reported. The dialogs aren't sheets of the parent
+ * window, and the accept and cancel labels can't be changed.
+ */
+
+func init() {
+	fileDialogBackend = &sdlFileDialogBackend{
+		ShowFileDialog: sdlDarwinShowFileDialog,
+	}
+}
+
+/* Quote a string for AppleScript */
+func sdlAppleScriptQuote(str string) string {
+	str = strings.ReplaceAll(str, `\`, `\\`)
+	str = strings.ReplaceAll(str, `"`, `\"`)
+	return `"` + str + `"`
+}
+
+/* The extensions allowed by a set of filters, nil to allow every file */
+func sdlDarwinDialogTypes(filters []SDL_DialogFileFilter) []string {
+	var types []string
+	for _, filter := range filters {
+		for _, ext := range strings.Split(filter.Pattern, ";") {
+			if ext == "*" {
+				return nil
+			} else if ext != "" {
+				types = append(types, sdlAppleScriptQuote(ext))
+			}
+		}
+	}
+	return types
+}
+
+/* The AppleScript showing a dialog, it returns the selected POSIX paths, one per line */
+func sdlDarwinDialogScript(dialog *sdlFileDialog) string {
+	var command strings.Builder
+	switch dialog.kind {
+	case SDL_FILEDIALOG_SAVEFILE:
+		command.WriteString("choose file name")
+	case SDL_FILEDIALOG_OPENFOLDER:
+		command.WriteString("choose folder")
+	default:
+		command.WriteString("choose file")
+	}
+	if dialog.title != "" {
+		command.WriteString(" with prompt " + sdlAppleScriptQuote(dialog.title))
+	}
+	if dialog.kind == SDL_FILEDIALOG_OPENFILE {
+		if types := sdlDarwinDialogTypes(dialog.filters); types != nil {
+			command.WriteString(" of type {" + strings.Join(types, ", ") + "}")
+		}
+	}
+	if location := dialog.location; location != "" {
+		/* A folder to start in, or a file to suggest */
+		folder, name := location, ""
+		if info, err := os.Stat(location); err != nil || !info.IsDir() {
+			folder, name = filepath.Split(location)
+		}
+		if folder != "" {
+			command.WriteString(" default location (POSIX file " + sdlAppleScriptQuote(folder) + ")")
+		}
+		if name != "" && dialog.kind == SDL_FILEDIALOG_SAVEFILE {
+			command.WriteString(" default name " + sdlAppleScriptQuote(name))
+		}
+	}
+	if dialog.many && dialog.kind != SDL_FILEDIALOG_SAVEFILE {
+		command.WriteString(" with multiple selections allowed")
+	}
+
+	return "activate\n" +
+		"set chosen to " + command.String() + "\n" +
+		"if class of chosen is not list then set chosen to {chosen}\n" +
+		"set output to \"\"\n" +
+		"repeat with f in chosen\n" +
+		"set output to output & POSIX path of f & linefeed\n" +
+		"end repeat\n" +
+		"return output\n"
+}
+
+func sdlDarwinShowFileDialog(dialog *sdlFileDialog, done func(filelist []string, filter int, err string)) {
+	script := sdlDarwinDialogScript(dialog)
+
+	sdlGo(func() {
+		cmd := exec.Command("/usr/bin/osascript", "-")
+		cmd.Stdin = strings.NewReader(script)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			var exit *exec.ExitError
+			if errors.As(err, &exit) && strings.Contains(stderr.String(), "(-128)") {
+				/* User canceled */
+				done([]string{}, -1, "")
+				return
+			}
+			done(nil, -1, fmt.Sprintf("osascript failed: %v: %s", err, strings.TrimSpace(stderr.String())))
+			return
+		}
+
+		filelist := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+		if len(filelist) == 1 && filelist[0] == "" {
+			done([]string{}, -1, "")
+			return
+		}
+		done(filelist, sdlMatchDialogFilter(dialog.filters, filelist[0]), "")
+	})
+}
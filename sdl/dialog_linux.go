@@ -0,0 +1,131 @@
+package sdl
+
+import "errors"
+import "fmt"
+import "os"
+import "os/exec"
+import "strconv"
+import "strings"
+
+/*
+ * File dialogs on Linux, through the FileChooser desktop portal when it is
+ * available, see portal_linux.go, and zenity otherwise. GNOME ships zenity
+ * and the other desktops have it or a compatible program, e.g. qarma on KDE.
+ * SDL_HINT_FILE_DIALOG_DRIVER picks one of them.
+ *
+ * zenity doesn't report the filter the user selected, the first filter
+ * matching the chosen file is reported instead. It has no option to
+ * preselect a filter either: the filters are passed in the application's
+ * order and zenity always starts with the first one, so the remembered
+ * filter is only preselected by the portal.
+ */
+
+func init() {
+	fileDialogBackend = &sdlFileDialogBackend{
+		ShowFileDialog: sdlLinuxShowFileDialog,
+	}
+}
+
+func sdlLinuxShowFileDialog(dialog *sdlFileDialog, done func(filelist []string, filter int, err string)) {
+	switch driver := SDL_GetHint(SDL_HINT_FILE_DIALOG_DRIVER); driver {
+	case "":
+		if sdlPortalAvailable() {
+			sdlPortalShowFileDialog(dialog, done)
+		} else {
+			sdlZenityShowFileDialog(dialog, done)
+		}
+	case "portal":
+		if !sdlPortalAvailable() {
+			done(nil, -1, "The desktop portal isn't available")
+			return
+		}
+		sdlPortalShowFileDialog(dialog, done)
+	case "zenity":
+		sdlZenityShowFileDialog(dialog, done)
+	default:
+		done(nil, -1, fmt.Sprintf("File dialog driver '%s' not available", driver))
+	}
+}
+
+/* The zenity command line for a dialog */
+func sdlZenityArgs(dialog *sdlFileDialog) []string {
+	args := []string{"--file-selection", "--separator=\n"}
+	switch dialog.kind {
+	case SDL_FILEDIALOG_SAVEFILE:
+		args = append(args, "--save")
+	case SDL_FILEDIALOG_OPENFOLDER:
+		args = append(args, "--directory")
+	}
+	if dialog.many {
+		args = append(args, "--multiple")
+	}
+
+	if location := dialog.location; location != "" {
+		/* zenity opens a folder only with a trailing slash, otherwise it selects the entry */
+		if info, err := os.Stat(location); err == nil && info.IsDir() && !strings.HasSuffix(location, "/") {
+			location += "/"
+		}
+		args = append(args, "--filename="+location)
+	}
+	if dialog.title != "" {
+		args = append(args, "--title="+dialog.title)
+	}
+	if dialog.accept != "" {
+		args = append(args, "--ok-label="+dialog.accept)
+	}
+	if dialog.cancel != "" {
+		args = append(args, "--cancel-label="+dialog.cancel)
+	}
+
+	if dialog.window != nil {
+		args = append(args, "--modal")
+		xid := SDL_GetNumberProperty(SDL_GetWindowProperties(dialog.window), SDL_PROP_WINDOW_X11_WINDOW_NUMBER, 0)
+		if xid != 0 {
+			args = append(args, "--attach="+strconv.FormatInt(xid, 10))
+		}
+	}
+
+	for _, filter := range dialog.filters {
+		patterns := make([]string, 0, 4)
+		for _, ext := range strings.Split(filter.Pattern, ";") {
+			if ext == "*" {
+				patterns = append(patterns, "*")
+			} else if ext != "" {
+				patterns = append(patterns, "*."+ext)
+			}
+		}
+		args = append(args, "--file-filter="+filter.Name+" | "+strings.Join(patterns, " "))
+	}
+	return args
+}
+
+func sdlZenityShowFileDialog(dialog *sdlFileDialog, done func(filelist []string, filter int, err string)) {
+	path, err := exec.LookPath("zenity")
+	if err != nil {
+		done(nil, -1, "File dialogs need zenity, which isn't installed")
+		return
+	}
+	/* The window properties are read here, the dialog runs on its own goroutine */
+	args := sdlZenityArgs(dialog)
+
+	sdlGo(func() {
+		output, err := exec.Command(path, args...).Output()
+		if err != nil {
+			var exit *exec.ExitError
+			if errors.As(err, &exit) && exit.ExitCode() == 1 {
+				/* Canceled */
+				done([]string{}, -1, "")
+				return
+			}
+			done(nil, -1, fmt.Sprintf("zenity failed: %v", err))
+			return
+		}
+
+		filelist := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+		if len(filelist) == 1 && filelist[0] == "" {
+			done([]string{}, -1, "")
+			return
+		}
+		done(filelist, sdlMatchDialogFilter(dialog.filters, filelist[0]), "")
+	})
+}
@@ -0,0 +1,135 @@
+package sdl
+
+import "errors"
+import "fmt"
+import "os/exec"
+import "strconv"
+import "strings"
+import "syscall"
+
+/*
+ * File dialogs on Windows, through the Windows Forms dialogs run by
+ * PowerShell, which every supported Windows version ships. This avoids
+ * calling into comdlg32 and COM without cgo.
+ *
+ * The dialogs aren't modal for the parent window: there is no Win32 video
+ * driver yet, so SDL windows have no HWND to hand over. The accept and
+ * cancel labels can't be changed, and folder dialogs pick a single folder.
+ */
+
+func init() {
+	fileDialogBackend = &sdlFileDialogBackend{
+		ShowFileDialog: sdlWindowsShowFileDialog,
+	}
+}
+
+/* Quote a string for a PowerShell script, single quoted strings only escape the quote */
+func sdlPowerShellQuote(str string) string {
+	return "'" + strings.ReplaceAll(str, "'", "''") + "'"
+}
+
+/* The filter of a Windows Forms dialog, e.g. "Images|*.png;*.jpg|All files|*.*" */
+func sdlWindowsDialogFilter(filters []SDL_DialogFileFilter) string {
+	entries := make([]string, 0, 2*len(filters))
+	for _, filter := range filters {
+		patterns := make([]string, 0, 4)
+		for _, ext := range strings.Split(filter.Pattern, ";") {
+			if ext == "*" {
+				patterns = append(patterns, "*.*")
+			} else if ext != "" {
+				patterns = append(patterns, "*."+ext)
+			}
+		}
+		entries = append(entries, strings.ReplaceAll(filter.Name, "|", " "), strings.Join(patterns, ";"))
+	}
+	return strings.Join(entries, "|")
+}
+
+/*
+ * The PowerShell script showing a dialog. It prints the 1-based index of the
+ * selected filter and then the selected paths, one per line, and exits with
+ * 1 when the dialog is canceled.
+ */
+func sdlWindowsDialogScript(dialog *sdlFileDialog) string {
+	var script strings.Builder
+	script.WriteString("Add-Type -AssemblyName System.Windows.Forms\n")
+	script.WriteString("[Console]::OutputEncoding = [System.Text.Encoding]::UTF8\n")
+
+	if dialog.kind == SDL_FILEDIALOG_OPENFOLDER {
+		script.WriteString("$d = New-Object System.Windows.Forms.FolderBrowserDialog\n")
+		if dialog.title != "" {
+			script.WriteString("$d.Description = " + sdlPowerShellQuote(dialog.title) + "\n")
+		}
+		if dialog.location != "" {
+			script.WriteString("$d.SelectedPath = " + sdlPowerShellQuote(dialog.location) + "\n")
+		}
+		script.WriteString("if ($d.ShowDialog() -ne 'OK') { exit 1 }\n")
+		script.WriteString("0\n")
+		script.WriteString("$d.SelectedPath\n")
+		return script.String()
+	}
+
+	if dialog.kind == SDL_FILEDIALOG_SAVEFILE {
+		script.WriteString("$d = New-Object System.Windows.Forms.SaveFileDialog\n")
+	} else {
+		script.WriteString("$d = New-Object System.Windows.Forms.OpenFileDialog\n")
+		if dialog.many {
+			script.WriteString("$d.Multiselect = $true\n")
+		}
+	}
+	if len(dialog.filters) > 0 {
+		script.WriteString("$d.Filter = " + sdlPowerShellQuote(sdlWindowsDialogFilter(dialog.filters)) + "\n")
+		script.WriteString("$d.FilterIndex = " + strconv.Itoa(dialog.current+1) + "\n")
+	}
+	if dialog.title != "" {
+		script.WriteString("$d.Title = " + sdlPowerShellQuote(dialog.title) + "\n")
+	}
+	if dialog.location != "" {
+		/* A folder to start in, or a file to suggest */
+		script.WriteString("$l = " + sdlPowerShellQuote(dialog.location) + "\n")
+		script.WriteString("if (Test-Path -LiteralPath $l -PathType Container) { $d.InitialDirectory = $l } else { $d.FileName = $l }\n")
+	}
+	script.WriteString("if ($d.ShowDialog() -ne 'OK') { exit 1 }\n")
+	script.WriteString("$d.FilterIndex\n")
+	script.WriteString("$d.FileNames\n")
+	return script.String()
+}
+
+func sdlWindowsShowFileDialog(dialog *sdlFileDialog, done func(filelist []string, filter int, err string)) {
+	path, err := exec.LookPath("powershell.exe")
+	if err != nil {
+		done(nil, -1, "File dialogs need PowerShell, which isn't available")
+		return
+	}
+	script := sdlWindowsDialogScript(dialog)
+	hasFilters := len(dialog.filters) > 0 && dialog.kind != SDL_FILEDIALOG_OPENFOLDER
+
+	sdlGo(func() {
+		/* Windows Forms dialogs need a single threaded apartment */
+		cmd := exec.Command(path, "-NoProfile", "-NonInteractive", "-STA", "-Command", "-")
+		cmd.Stdin = strings.NewReader(script)
+		cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+		output, err := cmd.Output()
+		if err != nil {
+			var exit *exec.ExitError
+			if errors.As(err, &exit) && exit.ExitCode() == 1 {
+				/* Canceled */
+				done([]string{}, -1, "")
+				return
+			}
+			done(nil, -1, fmt.Sprintf("PowerShell failed: %v", err))
+			return
+		}
+
+		lines := strings.Split(strings.TrimRight(strings.ReplaceAll(string(output), "\r\n", "\n"), "\n"), "\n")
+		if len(lines) < 2 {
+			done([]string{}, -1, "")
+			return
+		}
+		filter := -1
+		if index, err := strconv.Atoi(strings.TrimSpace(lines[0])); err == nil && hasFilters {
+			filter = index - 1
+		}
+		done(lines[1:], filter, "")
+	})
+}
@@ -0,0 +1,280 @@
+package sdl
+
+import "unsafe"
+
+/*
+ * EGL support, for the OpenGL ES paths of Wayland, Android, KMSDRM and the
+ * embedded drivers.
+ *
+ * A driver using EGL sets egl_data in the video device when it loads the EGL
+ * library and clears it when unloading it. The attribute callbacks set by
+ * the application are kept in the device, drivers merge their results into
+ * the attribute lists they build with sdlEGLPlatformAttribs(),
+ * sdlEGLSurfaceAttribs() and sdlEGLContextAttribs().
+ *
+ * None of the video drivers of this port uses EGL yet, loading libEGL needs
+ * cgo, so these entry points are unsupported: the getters always fail and
+ * the attribute callbacks are stored but never called. They are kept so
+ * applications written for SDL build, and for the drivers that will use
+ * them.
+ */
+
+/**
+ * Opaque EGL types.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_EGLDisplay unsafe.Pointer
+type SDL_EGLConfig unsafe.Pointer
+type SDL_EGLSurface unsafe.Pointer
+type SDL_EGLAttrib int
+type SDL_EGLint int32
+
+/* Terminates EGL attribute lists */
+const sdlEGL_NONE = 0x3038
+
+/**
+ * EGL platform attribute initialization callback.
+ *
+ * This is called when SDL is attempting to create an EGL context, to let the
+ * app add extra attributes to its eglGetPlatformDisplay() call.
+ *
+ * The callback should return attribute/value pairs, a trailing EGL_NONE is
+ * allowed. If this function returns nil, the SDL_CreateWindow process will
+ * fail gracefully.
+ *
+ * The returned slice is not kept by SDL after the call.
+ *
+ * - userdata an app-controlled value passed to
+ *                 SDL_EGL_SetAttributeCallbacks.
+ * Returns the attributes to add, or nil to fail.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_EGL_SetAttributeCallbacks
+ */
+type SDL_EGLAttribArrayCallback func(userdata any) []SDL_EGLAttrib
+
+/**
+ * EGL surface/context attribute initialization callback types.
+ *
+ * This is called when SDL is attempting to create an EGL surface, to let the
+ * app add extra attributes to its eglCreateWindowSurface() or
+ * eglCreateContext calls.
+ *
+ * For convenience, the EGLDisplay and EGLConfig to use are provided to the
+ * callback.
+ *
+ * The callback should return attribute/value pairs, a trailing EGL_NONE is
+ * allowed. If this function returns nil, the SDL_CreateWindow process will
+ * fail gracefully.
+ *
+ * The returned slice is not kept by SDL after the call.
+ *
+ * - userdata an app-controlled value passed to
+ *                 SDL_EGL_SetAttributeCallbacks.
+ * - display the EGL display to be used.
+ * - config the EGL config to be used.
+ * Returns the attributes to add, or nil to fail.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_EGL_SetAttributeCallbacks
+ */
+type SDL_EGLIntArrayCallback func(userdata any, display SDL_EGLDisplay, config SDL_EGLConfig) []SDL_EGLint
+
+/* The EGL library loaded by a driver */
+type sdlEGLData struct {
+	display SDL_EGLDisplay
+	config  SDL_EGLConfig
+
+	/* eglGetProcAddress(), or a lookup in the library for EGL before 1.5 */
+	GetProcAddress func(proc string) SDL_FunctionPointer
+}
+
+/* Attribute/value pairs of a callback result, without the EGL_NONE terminator */
+func sdlEGLTrimAttribs[T SDL_EGLAttrib | SDL_EGLint](attribs []T) []T {
+	for i := 0; i < len(attribs); i += 2 {
+		if attribs[i] == sdlEGL_NONE {
+			return attribs[:i]
+		}
+	}
+	return attribs[:len(attribs)&^1]
+}
+
+/*
+ * The application attributes for eglGetPlatformDisplay(), ok is false if
+ * the callback failed and the display must not be created.
+ */
+func sdlEGLPlatformAttribs(device *sdlVideoDevice) (attribs []SDL_EGLAttrib, ok bool) {
+	if device.egl_platformattrib_callback == nil {
+		return nil, true
+	}
+	attribs = device.egl_platformattrib_callback(device.egl_attrib_callback_userdata)
+	if attribs == nil {
+		return nil, SDL_SetError("EGL platform attribute callback returned nil pointer")
+	}
+	return sdlEGLTrimAttribs(attribs), true
+}
+
+/* The application attributes for eglCreateWindowSurface(), see sdlEGLPlatformAttribs() */
+func sdlEGLSurfaceAttribs(device *sdlVideoDevice) (attribs []SDL_EGLint, ok bool) {
+	if device.egl_surfaceattrib_callback == nil || device.egl_data == nil {
+		return nil, true
+	}
+	attribs = device.egl_surfaceattrib_callback(device.egl_attrib_callback_userdata, device.egl_data.display, device.egl_data.config)
+	if attribs == nil {
+		return nil, SDL_SetError("EGL surface attribute callback returned nil pointer")
+	}
+	return sdlEGLTrimAttribs(attribs), true
+}
+
+/* The application attributes for eglCreateContext(), see sdlEGLPlatformAttribs() */
+func sdlEGLContextAttribs(device *sdlVideoDevice) (attribs []SDL_EGLint, ok bool) {
+	if device.egl_contextattrib_callback == nil || device.egl_data == nil {
+		return nil, true
+	}
+	attribs = device.egl_contextattrib_callback(device.egl_attrib_callback_userdata, device.egl_data.display, device.egl_data.config)
+	if attribs == nil {
+		return nil, SDL_SetError("EGL context attribute callback returned nil pointer")
+	}
+	return sdlEGLTrimAttribs(attribs), true
+}
+
+/**
+ * Get an EGL library function by name.
+ *
+ * If an EGL library is loaded, this function allows applications to get
+ * entry points for EGL functions. This is useful to provide to an EGL API and
+ * extension loader.
+ *
+ * No video driver loads an EGL library yet, so this currently always returns
+ * nil.
+ *
+ * - proc the name of an EGL function.
+ * Returns a pointer to the named EGL function. The returned pointer should
+ *          be cast to the appropriate function signature.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_EGL_GetCurrentDisplay
+ */
+func SDL_EGL_GetProcAddress(proc string) SDL_FunctionPointer {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return nil
+	}
+	if device.egl_data == nil || device.egl_data.GetProcAddress == nil {
+		SDL_SetError("No EGL library has been loaded")
+		return nil
+	}
+	return device.egl_data.GetProcAddress(proc)
+}
+
+/**
+ * Get the currently active EGL display.
+ *
+ * This currently always fails, there's no EGL display without a video
+ * driver using EGL.
+ *
+ * Returns the currently active EGL display or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_EGL_GetCurrentDisplay() SDL_EGLDisplay {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return nil
+	}
+	if device.egl_data == nil {
+		SDL_SetError("There is no current EGL display")
+		return nil
+	}
+	return device.egl_data.display
+}
+
+/**
+ * Get the currently active EGL config.
+ *
+ * Like SDL_EGL_GetCurrentDisplay(), this currently always fails.
+ *
+ * Returns the currently active EGL config or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_EGL_GetCurrentConfig() SDL_EGLConfig {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return nil
+	}
+	if device.egl_data == nil {
+		SDL_SetError("There is no current EGL display")
+		return nil
+	}
+	return device.egl_data.config
+}
+
+/**
+ * Get the EGL surface associated with the window.
+ *
+ * Windows don't have EGL surfaces yet, so this currently always fails.
+ *
+ * - window the window to query.
+ * Returns the EGLSurface pointer associated with the window, or nil on
+ *          failure.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_EGL_GetWindowSurface(window *SDL_Window) SDL_EGLSurface {
+	if !sdlCheckWindow(window) {
+		return nil
+	}
+
+	device := sdlGetVideoDevice()
+	if device.egl_data == nil {
+		SDL_SetError("There is no current EGL display")
+		return nil
+	}
+	if device.GL_GetEGLSurface == nil {
+		SDL_Unsupported()
+		return nil
+	}
+	return device.GL_GetEGLSurface(device, window)
+}
+
+/**
+ * Sets the callbacks for defining custom EGLAttrib arrays for EGL
+ * initialization.
+ *
+ * Callbacks that aren't needed can be set to nil.
+ *
+ * NOTE: These callback pointers will be reset after SDL_GL_ResetAttributes.
+ *
+ * The callbacks are only called by video drivers using EGL, and there are
+ * none yet.
+ *
+ * - platformAttribCallback callback for attributes to pass to
+ *                               eglGetPlatformDisplay. May be nil.
+ * - surfaceAttribCallback callback for attributes to pass to
+ *                              eglCreateSurface. May be nil.
+ * - contextAttribCallback callback for attributes to pass to
+ *                              eglCreateContext. May be nil.
+ * - userdata a pointer that is passed to the callbacks.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_EGL_SetAttributeCallbacks(platformAttribCallback SDL_EGLAttribArrayCallback,
+	surfaceAttribCallback SDL_EGLIntArrayCallback,
+	contextAttribCallback SDL_EGLIntArrayCallback,
+	userdata any) {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return
+	}
+	device.egl_platformattrib_callback = platformAttribCallback
+	device.egl_surfaceattrib_callback = surfaceAttribCallback
+	device.egl_contextattrib_callback = contextAttribCallback
+	device.egl_attrib_callback_userdata = userdata
+}
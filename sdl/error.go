@@ -0,0 +1,336 @@
+package sdl
+
+import "bytes"
+import "container/list"
+import "errors"
+import "fmt"
+import "runtime"
+import "strconv"
+import "sync/atomic"
+
+/*
+ * The C library keeps the error message in thread-local storage. Go has no
+ * goroutine-local storage, so the messages are kept in a map keyed by the
+ * goroutine id. An entry lives until SDL_ClearError() is called from the
+ * goroutine that owns it, goroutines started by SDL itself drop theirs when
+ * they exit, see sdlGo(). Application goroutines that exit without clearing
+ * their error would still leak, so the map is bounded: when it is full, the
+ * message that was set the longest time ago is forgotten, even if its
+ * goroutine is still running.
+ */
+const sdlMaxErrorMessages = 1024
+
+type sdlErrorEntry struct {
+	id  uint64 /* the goroutine owning the message */
+	err *Error
+}
+
+var errorMutex = sdlMutex{name: "error"}
+var errorMessages = map[uint64]*list.Element{}
+var errorOrder = list.New() /* the sdlErrorEntry values in the order they were set, oldest first */
+
+/* The number of entries in errorMessages, read without the lock to skip looking up goroutines without errors */
+var errorCount atomic.Int32
+
+/*
+ * Get the id of the calling goroutine, taken from the header line of its
+ * stack trace: "goroutine 42 [running]:".
+ */
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i >= 0 {
+		header = header[:i]
+	}
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
+
+/**
+ * Set the SDL error message for the current goroutine.
+ *
+ * Calling this function will replace any previous error message that was
+ * set.
+ *
+ * This function always returns false, since SDL frequently uses false to
+ * signify a failing result, leading to this idiom:
+ *
+ * ```go
+ * if error_code {
+ *     return SDL_SetError("This operation has failed")
+ * }
+ * ```
+ *
+ * - str the error message
+ * Returns false.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetErrorf
+ * See also SDL_ClearError
+ * See also SDL_GetError
+ */
+func SDL_SetError(str string) bool {
+	return sdlSetErrorCode(SDL_ERROR_GENERIC, str)
+}
+
+/* Set the error message of the current goroutine along with its category. */
+func sdlSetErrorCode(code SDL_ErrorCode, str string) bool {
+	id := goroutineID()
+
+	errorMutex.Lock()
+	defer errorMutex.Unlock()
+
+	entry := sdlErrorEntry{id: id, err: &Error{Code: code, Message: str}}
+	if element, ok := errorMessages[id]; ok {
+		element.Value = entry
+		errorOrder.MoveToBack(element)
+	} else {
+		if errorOrder.Len() >= sdlMaxErrorMessages {
+			oldest := errorOrder.Front()
+			delete(errorMessages, oldest.Value.(sdlErrorEntry).id)
+			errorOrder.Remove(oldest)
+		}
+		errorMessages[id] = errorOrder.PushBack(entry)
+	}
+	errorCount.Store(int32(len(errorMessages)))
+	return false
+}
+
+/* Get the error of the current goroutine, or nil */
+func sdlGetErrorEntry() *Error {
+	if errorCount.Load() == 0 {
+		return nil
+	}
+	id := goroutineID()
+
+	errorMutex.Lock()
+	defer errorMutex.Unlock()
+	if element, ok := errorMessages[id]; ok {
+		return element.Value.(sdlErrorEntry).err
+	}
+	return nil
+}
+
+/*
+ * Run fn in a new goroutine that forgets its error message when it exits.
+ * Use it for the goroutines started by SDL, they report failures to their
+ * caller, e.g. through a done callback, rather than with SDL_SetError().
+ */
+func sdlGo(fn func()) {
+	go func() {
+		defer SDL_ClearError()
+		fn()
+	}()
+}
+
+/**
+ * Set the SDL error message for the current goroutine, formatted like
+ * fmt.Sprintf.
+ *
+ * - format a fmt.Sprintf()-style message format string
+ * - args additional parameters matching % tokens in the `format` string
+ * Returns false.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetError
+ * See also SDL_ClearError
+ * See also SDL_GetError
+ */
+func SDL_SetErrorf(format string, args ...any) bool {
+	return SDL_SetError(fmt.Sprintf(format, args...))
+}
+
+/**
+ * Set an error indicating that memory allocation failed.
+ *
+ * This function does not do any memory allocation.
+ *
+ * Returns false.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_OutOfMemory() bool {
+	return sdlSetErrorCode(SDL_ERROR_OUT_OF_MEMORY, "Out of memory")
+}
+
+/**
+ * Retrieve a message about the last error that occurred on the current
+ * goroutine.
+ *
+ * It is possible for multiple errors to occur before calling SDL_GetError().
+ * Only the last error is returned.
+ *
+ * The message is only applicable when an SDL function has signaled an error.
+ * You must check the return values of SDL function calls to determine when to
+ * appropriately call SDL_GetError(). You should *not* use the results of
+ * SDL_GetError() to decide if an error has occurred! Sometimes SDL will set
+ * an error string even when reporting success.
+ *
+ * SDL will *not* clear the error string for successful API calls. You *must*
+ * check return values for failure cases before you can assume the error
+ * string applies.
+ *
+ * Error strings are set per-goroutine, so an error set in a different
+ * goroutine will not interfere with the current goroutine's operation. At
+ * most 1024 goroutines keep a message at a time; beyond that, the message
+ * set the longest time ago is forgotten, so call SDL_ClearError() before a
+ * goroutine exits.
+ *
+ * Returns a message with information about the specific error that
+ *          occurred, or an empty string if there hasn't been an error message
+ *          set since the last call to SDL_ClearError().
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_ClearError
+ * See also SDL_SetError
+ */
+func SDL_GetError() string {
+	if err := sdlGetErrorEntry(); err != nil {
+		return err.Message
+	}
+	return ""
+}
+
+/**
+ * Clear any previous error message for this goroutine.
+ *
+ * This also releases the storage used for the message, goroutines that set
+ * errors should call it before they exit.
+ *
+ * Returns true.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetError
+ * See also SDL_SetError
+ */
+func SDL_ClearError() bool {
+	if errorCount.Load() == 0 {
+		return true
+	}
+	id := goroutineID()
+
+	errorMutex.Lock()
+	if element, ok := errorMessages[id]; ok {
+		errorOrder.Remove(element)
+		delete(errorMessages, id)
+	}
+	errorCount.Store(int32(len(errorMessages)))
+	errorMutex.Unlock()
+	return true
+}
+
+/**
+ * Set an error indicating that an operation is not supported.
+ */
+func SDL_Unsupported() bool {
+	return sdlSetErrorCode(SDL_ERROR_UNSUPPORTED, "That operation is not supported")
+}
+
+/**
+ * Set an error indicating that a parameter is invalid.
+ */
+func SDL_InvalidParamError(param string) bool {
+	return sdlSetErrorCode(SDL_ERROR_INVALID_PARAM, fmt.Sprintf("Parameter '%s' is invalid", param))
+}
+
+/**
+ * The category of an SDL error.
+ *
+ * SDL_SetError() records SDL_ERROR_GENERIC, the helpers like
+ * SDL_Unsupported() and SDL_InvalidParamError() record their own category
+ * so Go code can test for it with errors.Is().
+ */
+type SDL_ErrorCode int
+
+const (
+	SDL_ERROR_GENERIC       SDL_ErrorCode = iota /**< Any error without a more specific category */
+	SDL_ERROR_OUT_OF_MEMORY                      /**< Set by SDL_OutOfMemory() */
+	SDL_ERROR_UNSUPPORTED                        /**< Set by SDL_Unsupported() */
+	SDL_ERROR_INVALID_PARAM                      /**< Set by SDL_InvalidParamError() */
+	SDL_ERROR_DEVICE_LOST                        /**< Set by SDL_DeviceLostError() */
+)
+
+// Error is the Go error value behind the SDL error message of a goroutine.
+//
+// The fallible functions that create objects or do I/O have a Go flavored
+// wrapper named without the SDL_ prefix, like CreateWindow() or SaveBMP(),
+// returning an *Error instead of nil or false.
+//
+// Two errors match with errors.Is() when their codes are equal, so the
+// message does not need to be compared:
+//
+//	if err := sdl.Init(sdl.SDL_INIT_VIDEO); errors.Is(err, sdl.ErrUnsupported) {
+//		...
+//	}
+type Error struct {
+	Code    SDL_ErrorCode
+	Message string
+}
+
+// Sentinel errors for the common categories, for use with errors.Is().
+var (
+	ErrOutOfMemory  = &Error{Code: SDL_ERROR_OUT_OF_MEMORY, Message: "Out of memory"}
+	ErrUnsupported  = &Error{Code: SDL_ERROR_UNSUPPORTED, Message: "That operation is not supported"}
+	ErrInvalidParam = &Error{Code: SDL_ERROR_INVALID_PARAM, Message: "Invalid parameter"}
+	ErrDeviceLost   = &Error{Code: SDL_ERROR_DEVICE_LOST, Message: "Device lost"}
+)
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is an *Error of the same category.
+// SDL_ERROR_GENERIC errors only match themselves.
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+	if e.Code == SDL_ERROR_GENERIC {
+		return e == t
+	}
+	return e.Code == t.Code
+}
+
+/**
+ * Set an error indicating that a device has been lost or disconnected.
+ */
+func SDL_DeviceLostError() bool {
+	return sdlSetErrorCode(SDL_ERROR_DEVICE_LOST, "Device lost")
+}
+
+// GetError returns the last error set on the current goroutine as an *Error,
+// or nil if there is none. It is the Go counterpart of SDL_GetError().
+func GetError() error {
+	if err := sdlGetErrorEntry(); err != nil {
+		return err
+	}
+	return nil
+}
+
+/*
+ * Convert the boolean result of an SDL function into a Go error, used by the
+ * Go flavored wrappers of fallible functions.
+ */
+func errorFromResult(ok bool) error {
+	if ok {
+		return nil
+	}
+	if err := GetError(); err != nil {
+		return err
+	}
+	return &Error{Code: SDL_ERROR_GENERIC, Message: "Unknown error"}
+}
+
+/* Convert the object returned by an SDL function, nil on failure, into the object and a Go error */
+func errorFromObject[T any](object *T) (*T, error) {
+	if object == nil {
+		return nil, errorFromResult(false)
+	}
+	return object, nil
+}
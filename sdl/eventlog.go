@@ -0,0 +1,267 @@
+package sdl
+
+import "fmt"
+import "strings"
+import "sync/atomic"
+
+/*
+ * Event logging, see SDL_HINT_EVENT_LOGGING.
+ *
+ * Events are logged as they are added to the queue, before the queue lock is
+ * taken, so a log output function may safely push events of its own.
+ */
+
+/**
+ * A variable controlling whether SDL logs all events pushed onto its
+ * internal queue.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "0": Don't log any events. (default)
+ * - "1": Log most events (other than the really spammy ones).
+ * - "2": Include mouse and finger motion events.
+ *
+ * This is generally meant to be used to debug SDL itself, but can be useful
+ * for application developers that need better visibility into what is going
+ * on in the event queue. Logged events are sent through SDL_Log(), which
+ * means by default they appear on stdout on most platforms or maybe
+ * OutputDebugString() on Windows, and can be funneled by the app with
+ * SDL_SetLogOutputFunction(), etc.
+ *
+ * This hint can be set anytime.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_EVENT_LOGGING = "SDL_EVENT_LOGGING"
+
+var eventLoggingVerbosity atomic.Int32
+
+func sdlEventLoggingChanged(userdata any, name, oldValue, hint string) {
+	verbosity := int32(0)
+	if hint != "" {
+		fmt.Sscan(hint, &verbosity)
+	}
+	eventLoggingVerbosity.Store(min(max(verbosity, 0), 2))
+}
+
+var eventNames = map[SDL_EventType]string{
+	SDL_EVENT_QUIT:                          "SDL_EVENT_QUIT",
+	SDL_EVENT_TERMINATING:                   "SDL_EVENT_TERMINATING",
+	SDL_EVENT_LOW_MEMORY:                    "SDL_EVENT_LOW_MEMORY",
+	SDL_EVENT_WILL_ENTER_BACKGROUND:         "SDL_EVENT_WILL_ENTER_BACKGROUND",
+	SDL_EVENT_DID_ENTER_BACKGROUND:          "SDL_EVENT_DID_ENTER_BACKGROUND",
+	SDL_EVENT_WILL_ENTER_FOREGROUND:         "SDL_EVENT_WILL_ENTER_FOREGROUND",
+	SDL_EVENT_DID_ENTER_FOREGROUND:          "SDL_EVENT_DID_ENTER_FOREGROUND",
+	SDL_EVENT_LOCALE_CHANGED:                "SDL_EVENT_LOCALE_CHANGED",
+	SDL_EVENT_SYSTEM_THEME_CHANGED:          "SDL_EVENT_SYSTEM_THEME_CHANGED",
+	SDL_EVENT_DISPLAY_ORIENTATION:           "SDL_EVENT_DISPLAY_ORIENTATION",
+	SDL_EVENT_DISPLAY_ADDED:                 "SDL_EVENT_DISPLAY_ADDED",
+	SDL_EVENT_DISPLAY_REMOVED:               "SDL_EVENT_DISPLAY_REMOVED",
+	SDL_EVENT_DISPLAY_MOVED:                 "SDL_EVENT_DISPLAY_MOVED",
+	SDL_EVENT_DISPLAY_DESKTOP_MODE_CHANGED:  "SDL_EVENT_DISPLAY_DESKTOP_MODE_CHANGED",
+	SDL_EVENT_DISPLAY_CURRENT_MODE_CHANGED:  "SDL_EVENT_DISPLAY_CURRENT_MODE_CHANGED",
+	SDL_EVENT_DISPLAY_CONTENT_SCALE_CHANGED: "SDL_EVENT_DISPLAY_CONTENT_SCALE_CHANGED",
+	SDL_EVENT_WINDOW_SHOWN:                  "SDL_EVENT_WINDOW_SHOWN",
+	SDL_EVENT_WINDOW_HIDDEN:                 "SDL_EVENT_WINDOW_HIDDEN",
+	SDL_EVENT_WINDOW_EXPOSED:                "SDL_EVENT_WINDOW_EXPOSED",
+	SDL_EVENT_WINDOW_MOVED:                  "SDL_EVENT_WINDOW_MOVED",
+	SDL_EVENT_WINDOW_RESIZED:                "SDL_EVENT_WINDOW_RESIZED",
+	SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED:     "SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED",
+	SDL_EVENT_WINDOW_METAL_VIEW_RESIZED:     "SDL_EVENT_WINDOW_METAL_VIEW_RESIZED",
+	SDL_EVENT_WINDOW_MINIMIZED:              "SDL_EVENT_WINDOW_MINIMIZED",
+	SDL_EVENT_WINDOW_MAXIMIZED:              "SDL_EVENT_WINDOW_MAXIMIZED",
+	SDL_EVENT_WINDOW_RESTORED:               "SDL_EVENT_WINDOW_RESTORED",
+	SDL_EVENT_WINDOW_MOUSE_ENTER:            "SDL_EVENT_WINDOW_MOUSE_ENTER",
+	SDL_EVENT_WINDOW_MOUSE_LEAVE:            "SDL_EVENT_WINDOW_MOUSE_LEAVE",
+	SDL_EVENT_WINDOW_FOCUS_GAINED:           "SDL_EVENT_WINDOW_FOCUS_GAINED",
+	SDL_EVENT_WINDOW_FOCUS_LOST:             "SDL_EVENT_WINDOW_FOCUS_LOST",
+	SDL_EVENT_WINDOW_CLOSE_REQUESTED:        "SDL_EVENT_WINDOW_CLOSE_REQUESTED",
+	SDL_EVENT_WINDOW_HIT_TEST:               "SDL_EVENT_WINDOW_HIT_TEST",
+	SDL_EVENT_WINDOW_ICCPROF_CHANGED:        "SDL_EVENT_WINDOW_ICCPROF_CHANGED",
+	SDL_EVENT_WINDOW_DISPLAY_CHANGED:        "SDL_EVENT_WINDOW_DISPLAY_CHANGED",
+	SDL_EVENT_WINDOW_DISPLAY_SCALE_CHANGED:  "SDL_EVENT_WINDOW_DISPLAY_SCALE_CHANGED",
+	SDL_EVENT_WINDOW_SAFE_AREA_CHANGED:      "SDL_EVENT_WINDOW_SAFE_AREA_CHANGED",
+	SDL_EVENT_WINDOW_OCCLUDED:               "SDL_EVENT_WINDOW_OCCLUDED",
+	SDL_EVENT_WINDOW_ENTER_FULLSCREEN:       "SDL_EVENT_WINDOW_ENTER_FULLSCREEN",
+	SDL_EVENT_WINDOW_LEAVE_FULLSCREEN:       "SDL_EVENT_WINDOW_LEAVE_FULLSCREEN",
+	SDL_EVENT_WINDOW_DESTROYED:              "SDL_EVENT_WINDOW_DESTROYED",
+	SDL_EVENT_WINDOW_HDR_STATE_CHANGED:      "SDL_EVENT_WINDOW_HDR_STATE_CHANGED",
+	SDL_EVENT_KEY_DOWN:                      "SDL_EVENT_KEY_DOWN",
+	SDL_EVENT_KEY_UP:                        "SDL_EVENT_KEY_UP",
+	SDL_EVENT_TEXT_EDITING:                  "SDL_EVENT_TEXT_EDITING",
+	SDL_EVENT_TEXT_INPUT:                    "SDL_EVENT_TEXT_INPUT",
+	SDL_EVENT_KEYMAP_CHANGED:                "SDL_EVENT_KEYMAP_CHANGED",
+	SDL_EVENT_KEYBOARD_ADDED:                "SDL_EVENT_KEYBOARD_ADDED",
+	SDL_EVENT_KEYBOARD_REMOVED:              "SDL_EVENT_KEYBOARD_REMOVED",
+	SDL_EVENT_TEXT_EDITING_CANDIDATES:       "SDL_EVENT_TEXT_EDITING_CANDIDATES",
+	SDL_EVENT_MOUSE_MOTION:                  "SDL_EVENT_MOUSE_MOTION",
+	SDL_EVENT_MOUSE_BUTTON_DOWN:             "SDL_EVENT_MOUSE_BUTTON_DOWN",
+	SDL_EVENT_MOUSE_BUTTON_UP:               "SDL_EVENT_MOUSE_BUTTON_UP",
+	SDL_EVENT_MOUSE_WHEEL:                   "SDL_EVENT_MOUSE_WHEEL",
+	SDL_EVENT_MOUSE_ADDED:                   "SDL_EVENT_MOUSE_ADDED",
+	SDL_EVENT_MOUSE_REMOVED:                 "SDL_EVENT_MOUSE_REMOVED",
+	SDL_EVENT_JOYSTICK_AXIS_MOTION:          "SDL_EVENT_JOYSTICK_AXIS_MOTION",
+	SDL_EVENT_JOYSTICK_BALL_MOTION:          "SDL_EVENT_JOYSTICK_BALL_MOTION",
+	SDL_EVENT_JOYSTICK_HAT_MOTION:           "SDL_EVENT_JOYSTICK_HAT_MOTION",
+	SDL_EVENT_JOYSTICK_BUTTON_DOWN:          "SDL_EVENT_JOYSTICK_BUTTON_DOWN",
+	SDL_EVENT_JOYSTICK_BUTTON_UP:            "SDL_EVENT_JOYSTICK_BUTTON_UP",
+	SDL_EVENT_JOYSTICK_ADDED:                "SDL_EVENT_JOYSTICK_ADDED",
+	SDL_EVENT_JOYSTICK_REMOVED:              "SDL_EVENT_JOYSTICK_REMOVED",
+	SDL_EVENT_JOYSTICK_BATTERY_UPDATED:      "SDL_EVENT_JOYSTICK_BATTERY_UPDATED",
+	SDL_EVENT_JOYSTICK_UPDATE_COMPLETE:      "SDL_EVENT_JOYSTICK_UPDATE_COMPLETE",
+	SDL_EVENT_GAMEPAD_AXIS_MOTION:           "SDL_EVENT_GAMEPAD_AXIS_MOTION",
+	SDL_EVENT_GAMEPAD_BUTTON_DOWN:           "SDL_EVENT_GAMEPAD_BUTTON_DOWN",
+	SDL_EVENT_GAMEPAD_BUTTON_UP:             "SDL_EVENT_GAMEPAD_BUTTON_UP",
+	SDL_EVENT_GAMEPAD_ADDED:                 "SDL_EVENT_GAMEPAD_ADDED",
+	SDL_EVENT_GAMEPAD_REMOVED:               "SDL_EVENT_GAMEPAD_REMOVED",
+	SDL_EVENT_GAMEPAD_REMAPPED:              "SDL_EVENT_GAMEPAD_REMAPPED",
+	SDL_EVENT_GAMEPAD_TOUCHPAD_DOWN:         "SDL_EVENT_GAMEPAD_TOUCHPAD_DOWN",
+	SDL_EVENT_GAMEPAD_TOUCHPAD_MOTION:       "SDL_EVENT_GAMEPAD_TOUCHPAD_MOTION",
+	SDL_EVENT_GAMEPAD_TOUCHPAD_UP:           "SDL_EVENT_GAMEPAD_TOUCHPAD_UP",
+	SDL_EVENT_GAMEPAD_SENSOR_UPDATE:         "SDL_EVENT_GAMEPAD_SENSOR_UPDATE",
+	SDL_EVENT_GAMEPAD_UPDATE_COMPLETE:       "SDL_EVENT_GAMEPAD_UPDATE_COMPLETE",
+	SDL_EVENT_GAMEPAD_STEAM_HANDLE_UPDATED:  "SDL_EVENT_GAMEPAD_STEAM_HANDLE_UPDATED",
+	SDL_EVENT_FINGER_DOWN:                   "SDL_EVENT_FINGER_DOWN",
+	SDL_EVENT_FINGER_UP:                     "SDL_EVENT_FINGER_UP",
+	SDL_EVENT_FINGER_MOTION:                 "SDL_EVENT_FINGER_MOTION",
+	SDL_EVENT_FINGER_CANCELED:               "SDL_EVENT_FINGER_CANCELED",
+	SDL_EVENT_CLIPBOARD_UPDATE:              "SDL_EVENT_CLIPBOARD_UPDATE",
+	SDL_EVENT_DROP_FILE:                     "SDL_EVENT_DROP_FILE",
+	SDL_EVENT_DROP_TEXT:                     "SDL_EVENT_DROP_TEXT",
+	SDL_EVENT_DROP_BEGIN:                    "SDL_EVENT_DROP_BEGIN",
+	SDL_EVENT_DROP_COMPLETE:                 "SDL_EVENT_DROP_COMPLETE",
+	SDL_EVENT_DROP_POSITION:                 "SDL_EVENT_DROP_POSITION",
+	SDL_EVENT_AUDIO_DEVICE_ADDED:            "SDL_EVENT_AUDIO_DEVICE_ADDED",
+	SDL_EVENT_AUDIO_DEVICE_REMOVED:          "SDL_EVENT_AUDIO_DEVICE_REMOVED",
+	SDL_EVENT_AUDIO_DEVICE_FORMAT_CHANGED:   "SDL_EVENT_AUDIO_DEVICE_FORMAT_CHANGED",
+	SDL_EVENT_SENSOR_UPDATE:                 "SDL_EVENT_SENSOR_UPDATE",
+	SDL_EVENT_PEN_PROXIMITY_IN:              "SDL_EVENT_PEN_PROXIMITY_IN",
+	SDL_EVENT_PEN_PROXIMITY_OUT:             "SDL_EVENT_PEN_PROXIMITY_OUT",
+	SDL_EVENT_PEN_DOWN:                      "SDL_EVENT_PEN_DOWN",
+	SDL_EVENT_PEN_UP:                        "SDL_EVENT_PEN_UP",
+	SDL_EVENT_PEN_BUTTON_DOWN:               "SDL_EVENT_PEN_BUTTON_DOWN",
+	SDL_EVENT_PEN_BUTTON_UP:                 "SDL_EVENT_PEN_BUTTON_UP",
+	SDL_EVENT_PEN_MOTION:                    "SDL_EVENT_PEN_MOTION",
+	SDL_EVENT_PEN_AXIS:                      "SDL_EVENT_PEN_AXIS",
+	SDL_EVENT_CAMERA_DEVICE_ADDED:           "SDL_EVENT_CAMERA_DEVICE_ADDED",
+	SDL_EVENT_CAMERA_DEVICE_REMOVED:         "SDL_EVENT_CAMERA_DEVICE_REMOVED",
+	SDL_EVENT_CAMERA_DEVICE_APPROVED:        "SDL_EVENT_CAMERA_DEVICE_APPROVED",
+	SDL_EVENT_CAMERA_DEVICE_DENIED:          "SDL_EVENT_CAMERA_DEVICE_DENIED",
+	SDL_EVENT_PERMISSION_CHANGED:            "SDL_EVENT_PERMISSION_CHANGED",
+	SDL_EVENT_RENDER_TARGETS_RESET:          "SDL_EVENT_RENDER_TARGETS_RESET",
+	SDL_EVENT_RENDER_DEVICE_RESET:           "SDL_EVENT_RENDER_DEVICE_RESET",
+	SDL_EVENT_RENDER_DEVICE_LOST:            "SDL_EVENT_RENDER_DEVICE_LOST",
+	SDL_EVENT_QUEUE_OVERFLOW:                "SDL_EVENT_QUEUE_OVERFLOW",
+	SDL_EVENT_POLL_SENTINEL:                 "SDL_EVENT_POLL_SENTINEL",
+}
+
+/* The name of an event type, e.g. "SDL_EVENT_WINDOW_MOVED" */
+func sdlEventTypeName(kind SDL_EventType) string {
+	if name, ok := eventNames[kind]; ok {
+		return name
+	}
+	switch {
+	case kind >= SDL_EVENT_USER && kind <= SDL_EVENT_LAST:
+		return fmt.Sprintf("SDL_EVENT_USER+%d", kind-SDL_EVENT_USER)
+	case kind >= SDL_EVENT_PRIVATE0 && kind <= SDL_EVENT_PRIVATE3:
+		return fmt.Sprintf("SDL_EVENT_PRIVATE%d", kind-SDL_EVENT_PRIVATE0)
+	}
+	return fmt.Sprintf("UNKNOWN SDL EVENT 0x%X", uint32(kind))
+}
+
+/* Whether an event is only logged at the highest verbosity, because it's sent constantly */
+func sdlIsSpammyEvent(kind SDL_EventType) bool {
+	switch kind {
+	case SDL_EVENT_MOUSE_MOTION,
+		SDL_EVENT_FINGER_MOTION,
+		SDL_EVENT_PEN_AXIS,
+		SDL_EVENT_PEN_MOTION,
+		SDL_EVENT_GAMEPAD_AXIS_MOTION,
+		SDL_EVENT_GAMEPAD_SENSOR_UPDATE,
+		SDL_EVENT_GAMEPAD_TOUCHPAD_MOTION,
+		SDL_EVENT_GAMEPAD_UPDATE_COMPLETE,
+		SDL_EVENT_JOYSTICK_AXIS_MOTION,
+		SDL_EVENT_JOYSTICK_BALL_MOTION,
+		SDL_EVENT_JOYSTICK_UPDATE_COMPLETE,
+		SDL_EVENT_SENSOR_UPDATE,
+		SDL_EVENT_POLL_SENTINEL:
+		return true
+	}
+	return false
+}
+
+/* The decoded payload of an event, as "field=value" pairs */
+func sdlEventDetails(event *SDL_Event) string {
+	kind := event.Type
+	switch {
+	case kind >= SDL_EVENT_DISPLAY_FIRST && kind <= SDL_EVENT_DISPLAY_LAST:
+		e := &event.Display
+		return fmt.Sprintf("displayID=%d data1=%d data2=%d", e.DisplayID, e.Data1, e.Data2)
+	case kind >= SDL_EVENT_WINDOW_FIRST && kind <= SDL_EVENT_WINDOW_LAST:
+		e := &event.Window
+		return fmt.Sprintf("windowid=%d data1=%d data2=%d", e.WindowID, e.Data1, e.Data2)
+	case kind >= SDL_EVENT_USER:
+		e := &event.User
+		return fmt.Sprintf("windowid=%d code=%d data1=%v data2=%v", e.WindowID, e.Code, e.Data1, e.Data2)
+	}
+
+	switch kind {
+	case SDL_EVENT_CLIPBOARD_UPDATE:
+		e := &event.Clipboard
+		return fmt.Sprintf("owner=%t mime_types=[%s]", e.Owner, strings.Join(e.MimeTypes, " "))
+	case SDL_EVENT_TEXT_EDITING_CANDIDATES:
+		e := &event.EditCandidates
+		return fmt.Sprintf("windowid=%d num_candidates=%d selected_candidate=%d horizontal=%t", e.WindowID, len(e.Candidates), e.SelectedCandidate, e.Horizontal)
+	case SDL_EVENT_MOUSE_MOTION:
+		e := &event.Motion
+		return fmt.Sprintf("windowid=%d which=%d state=%d x=%g y=%g xrel=%g yrel=%g", e.WindowID, e.Which, e.State, e.X, e.Y, e.Xrel, e.Yrel)
+	case SDL_EVENT_MOUSE_BUTTON_DOWN, SDL_EVENT_MOUSE_BUTTON_UP:
+		e := &event.Button
+		return fmt.Sprintf("windowid=%d which=%d button=%d down=%t clicks=%d x=%g y=%g", e.WindowID, e.Which, e.Button, e.Down, e.Clicks, e.X, e.Y)
+	case SDL_EVENT_MOUSE_WHEEL:
+		e := &event.Wheel
+		return fmt.Sprintf("windowid=%d which=%d x=%g y=%g direction=%d mouse_x=%g mouse_y=%g", e.WindowID, e.Which, e.X, e.Y, e.Direction, e.MouseX, e.MouseY)
+	case SDL_EVENT_FINGER_DOWN, SDL_EVENT_FINGER_UP, SDL_EVENT_FINGER_MOTION, SDL_EVENT_FINGER_CANCELED:
+		e := &event.TFinger
+		return fmt.Sprintf("touchid=%d fingerid=%d x=%g y=%g dx=%g dy=%g pressure=%g windowid=%d", e.TouchID, e.FingerID, e.X, e.Y, e.Dx, e.Dy, e.Pressure, e.WindowID)
+	case SDL_EVENT_PEN_PROXIMITY_IN, SDL_EVENT_PEN_PROXIMITY_OUT:
+		e := &event.PProximity
+		return fmt.Sprintf("windowid=%d which=%d", e.WindowID, e.Which)
+	case SDL_EVENT_PEN_DOWN, SDL_EVENT_PEN_UP:
+		e := &event.PTouch
+		return fmt.Sprintf("windowid=%d which=%d state=%d x=%g y=%g eraser=%t down=%t", e.WindowID, e.Which, e.Pen_state, e.X, e.Y, e.Eraser, e.Down)
+	case SDL_EVENT_PEN_MOTION:
+		e := &event.PMotion
+		return fmt.Sprintf("windowid=%d which=%d state=%d x=%g y=%g", e.WindowID, e.Which, e.Pen_state, e.X, e.Y)
+	case SDL_EVENT_PEN_BUTTON_DOWN, SDL_EVENT_PEN_BUTTON_UP:
+		e := &event.PButton
+		return fmt.Sprintf("windowid=%d which=%d state=%d x=%g y=%g button=%d down=%t", e.WindowID, e.Which, e.Pen_state, e.X, e.Y, e.Button, e.Down)
+	case SDL_EVENT_PEN_AXIS:
+		e := &event.PAxis
+		return fmt.Sprintf("windowid=%d which=%d state=%d x=%g y=%g axis=%d value=%g", e.WindowID, e.Which, e.Pen_state, e.X, e.Y, e.Axis, e.Value)
+	case SDL_EVENT_DROP_FILE, SDL_EVENT_DROP_TEXT, SDL_EVENT_DROP_BEGIN, SDL_EVENT_DROP_COMPLETE, SDL_EVENT_DROP_POSITION:
+		e := &event.Drop
+		return fmt.Sprintf("windowid=%d x=%g y=%g source=%q data=%q", e.WindowID, e.X, e.Y, e.Source, e.Data)
+	case SDL_EVENT_PERMISSION_CHANGED:
+		e := &event.Permission
+		return fmt.Sprintf("permission=%d state=%d", e.Permission, e.State)
+	case SDL_EVENT_QUEUE_OVERFLOW:
+		e := &event.Overflow
+		return fmt.Sprintf("dropped=%d merged=%d", e.Dropped, e.Merged)
+	}
+	return ""
+}
+
+/* Log an event entering the queue, if enabled with SDL_HINT_EVENT_LOGGING */
+func sdlLogEvent(event *SDL_Event) {
+	verbosity := eventLoggingVerbosity.Load()
+	if verbosity == 0 || (verbosity < 2 && sdlIsSpammyEvent(event.Type)) {
+		return
+	}
+
+	details := fmt.Sprintf("timestamp=%d", event.Timestamp)
+	if fields := sdlEventDetails(event); fields != "" {
+		details += " " + fields
+	}
+	SDL_Log("SDL EVENT: %s (%s)", sdlEventTypeName(event.Type), details)
+}
@@ -0,0 +1,1756 @@
+package sdl
+
+import "context"
+import "math"
+import "reflect"
+import "slices"
+import "sync"
+import "sync/atomic"
+import "time"
+
+/**
+ * Event queue management.
+ *
+ * It's extremely common--often required--that an app deal with SDL's event
+ * queue. Almost all useful information about interactions with the real
+ * world flow through here: the user interacting with the computer and app,
+ * hardware coming and going, the system changing in some way, etc.
+ *
+ * An app generally takes a moment, perhaps at the start of a new frame, to
+ * examine any events that have occured since the last time and process or
+ * ignore them. This is generally done by calling SDL_PollEvent() in a loop
+ * until it returns false (or, if using the main callbacks, events are
+ * provided one at a time in calls to SDL_AppEvent() before the next call to
+ * SDL_AppIterate()).
+ */
+
+/**
+ * The types of events that can be delivered.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_EventType uint32
+
+const (
+	SDL_EVENT_FIRST SDL_EventType = 0 /**< Unused (do not remove) */
+
+	/* Application events */
+	SDL_EVENT_QUIT SDL_EventType = 0x100 /**< User-requested quit */
+
+	/* These application events have special meaning on iOS and Android, see README-ios.md and README-android.md for details */
+	SDL_EVENT_TERMINATING           SDL_EventType = 0x101 /**< The application is being terminated by the OS. */
+	SDL_EVENT_LOW_MEMORY            SDL_EventType = 0x102 /**< The application is low on memory, free memory if possible. */
+	SDL_EVENT_WILL_ENTER_BACKGROUND SDL_EventType = 0x103 /**< The application is about to enter the background. */
+	SDL_EVENT_DID_ENTER_BACKGROUND  SDL_EventType = 0x104 /**< The application did enter the background and may not get CPU for some time. */
+	SDL_EVENT_WILL_ENTER_FOREGROUND SDL_EventType = 0x105 /**< The application is about to enter the foreground. */
+	SDL_EVENT_DID_ENTER_FOREGROUND  SDL_EventType = 0x106 /**< The application is now interactive. */
+	SDL_EVENT_LOCALE_CHANGED        SDL_EventType = 0x107 /**< The user's locale preferences have changed. */
+	SDL_EVENT_SYSTEM_THEME_CHANGED  SDL_EventType = 0x108 /**< The system theme changed */
+
+	/* Display events */
+	SDL_EVENT_DISPLAY_ORIENTATION           SDL_EventType = 0x151 /**< Display orientation has changed to data1 */
+	SDL_EVENT_DISPLAY_ADDED                 SDL_EventType = 0x152 /**< Display has been added to the system */
+	SDL_EVENT_DISPLAY_REMOVED               SDL_EventType = 0x153 /**< Display has been removed from the system */
+	SDL_EVENT_DISPLAY_MOVED                 SDL_EventType = 0x154 /**< Display has changed position */
+	SDL_EVENT_DISPLAY_DESKTOP_MODE_CHANGED  SDL_EventType = 0x155 /**< Display has changed desktop mode */
+	SDL_EVENT_DISPLAY_CURRENT_MODE_CHANGED  SDL_EventType = 0x156 /**< Display has changed current mode */
+	SDL_EVENT_DISPLAY_CONTENT_SCALE_CHANGED SDL_EventType = 0x157 /**< Display has changed content scale */
+	SDL_EVENT_DISPLAY_FIRST                               = SDL_EVENT_DISPLAY_ORIENTATION
+	SDL_EVENT_DISPLAY_LAST                                = SDL_EVENT_DISPLAY_CONTENT_SCALE_CHANGED
+
+	/* Window events */
+	SDL_EVENT_WINDOW_SHOWN                 SDL_EventType = 0x202 /**< Window has been shown */
+	SDL_EVENT_WINDOW_HIDDEN                SDL_EventType = 0x203 /**< Window has been hidden */
+	SDL_EVENT_WINDOW_EXPOSED               SDL_EventType = 0x204 /**< Window has been exposed and should be redrawn */
+	SDL_EVENT_WINDOW_MOVED                 SDL_EventType = 0x205 /**< Window has been moved to data1, data2 */
+	SDL_EVENT_WINDOW_RESIZED               SDL_EventType = 0x206 /**< Window has been resized to data1xdata2 */
+	SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED    SDL_EventType = 0x207 /**< The pixel size of the window has changed to data1xdata2 */
+	SDL_EVENT_WINDOW_METAL_VIEW_RESIZED    SDL_EventType = 0x208 /**< The pixel size of a Metal view associated with the window has changed */
+	SDL_EVENT_WINDOW_MINIMIZED             SDL_EventType = 0x209 /**< Window has been minimized */
+	SDL_EVENT_WINDOW_MAXIMIZED             SDL_EventType = 0x20A /**< Window has been maximized */
+	SDL_EVENT_WINDOW_RESTORED              SDL_EventType = 0x20B /**< Window has been restored to normal size and position */
+	SDL_EVENT_WINDOW_MOUSE_ENTER           SDL_EventType = 0x20C /**< Window has gained mouse focus */
+	SDL_EVENT_WINDOW_MOUSE_LEAVE           SDL_EventType = 0x20D /**< Window has lost mouse focus */
+	SDL_EVENT_WINDOW_FOCUS_GAINED          SDL_EventType = 0x20E /**< Window has gained keyboard focus */
+	SDL_EVENT_WINDOW_FOCUS_LOST            SDL_EventType = 0x20F /**< Window has lost keyboard focus */
+	SDL_EVENT_WINDOW_CLOSE_REQUESTED       SDL_EventType = 0x210 /**< The window manager requests that the window be closed */
+	SDL_EVENT_WINDOW_HIT_TEST              SDL_EventType = 0x211 /**< Window had a hit test that wasn't SDL_HITTEST_NORMAL */
+	SDL_EVENT_WINDOW_ICCPROF_CHANGED       SDL_EventType = 0x212 /**< The ICC profile of the window's display has changed */
+	SDL_EVENT_WINDOW_DISPLAY_CHANGED       SDL_EventType = 0x213 /**< Window has been moved to display data1 */
+	SDL_EVENT_WINDOW_DISPLAY_SCALE_CHANGED SDL_EventType = 0x214 /**< Window display scale has been changed */
+	SDL_EVENT_WINDOW_SAFE_AREA_CHANGED     SDL_EventType = 0x215 /**< The window safe area has been changed */
+	SDL_EVENT_WINDOW_OCCLUDED              SDL_EventType = 0x216 /**< The window has been occluded */
+	SDL_EVENT_WINDOW_ENTER_FULLSCREEN      SDL_EventType = 0x217 /**< The window has entered fullscreen mode */
+	SDL_EVENT_WINDOW_LEAVE_FULLSCREEN      SDL_EventType = 0x218 /**< The window has left fullscreen mode */
+	SDL_EVENT_WINDOW_DESTROYED             SDL_EventType = 0x219 /**< The window with the associated ID is being or has been destroyed. */
+	SDL_EVENT_WINDOW_HDR_STATE_CHANGED     SDL_EventType = 0x21A /**< Window HDR properties have changed */
+	SDL_EVENT_WINDOW_FIRST                               = SDL_EVENT_WINDOW_SHOWN
+	SDL_EVENT_WINDOW_LAST                                = SDL_EVENT_WINDOW_HDR_STATE_CHANGED
+
+	/* Keyboard events */
+	SDL_EVENT_KEY_DOWN                SDL_EventType = 0x300 /**< Key pressed */
+	SDL_EVENT_KEY_UP                  SDL_EventType = 0x301 /**< Key released */
+	SDL_EVENT_TEXT_EDITING            SDL_EventType = 0x302 /**< Keyboard text editing (composition) */
+	SDL_EVENT_TEXT_INPUT              SDL_EventType = 0x303 /**< Keyboard text input */
+	SDL_EVENT_KEYMAP_CHANGED          SDL_EventType = 0x304 /**< Keymap changed due to a system event such as an input language or keyboard layout change. */
+	SDL_EVENT_KEYBOARD_ADDED          SDL_EventType = 0x305 /**< A new keyboard has been inserted into the system */
+	SDL_EVENT_KEYBOARD_REMOVED        SDL_EventType = 0x306 /**< A keyboard has been removed */
+	SDL_EVENT_TEXT_EDITING_CANDIDATES SDL_EventType = 0x307 /**< Keyboard text editing candidates */
+
+	/* Mouse events */
+	SDL_EVENT_MOUSE_MOTION      SDL_EventType = 0x400 /**< Mouse moved */
+	SDL_EVENT_MOUSE_BUTTON_DOWN SDL_EventType = 0x401 /**< Mouse button pressed */
+	SDL_EVENT_MOUSE_BUTTON_UP   SDL_EventType = 0x402 /**< Mouse button released */
+	SDL_EVENT_MOUSE_WHEEL       SDL_EventType = 0x403 /**< Mouse wheel motion */
+	SDL_EVENT_MOUSE_ADDED       SDL_EventType = 0x404 /**< A new mouse has been inserted into the system */
+	SDL_EVENT_MOUSE_REMOVED     SDL_EventType = 0x405 /**< A mouse has been removed */
+
+	/* Joystick events */
+	SDL_EVENT_JOYSTICK_AXIS_MOTION     SDL_EventType = 0x600 /**< Joystick axis motion */
+	SDL_EVENT_JOYSTICK_BALL_MOTION     SDL_EventType = 0x601 /**< Joystick trackball motion */
+	SDL_EVENT_JOYSTICK_HAT_MOTION      SDL_EventType = 0x602 /**< Joystick hat position change */
+	SDL_EVENT_JOYSTICK_BUTTON_DOWN     SDL_EventType = 0x603 /**< Joystick button pressed */
+	SDL_EVENT_JOYSTICK_BUTTON_UP       SDL_EventType = 0x604 /**< Joystick button released */
+	SDL_EVENT_JOYSTICK_ADDED           SDL_EventType = 0x605 /**< A new joystick has been inserted into the system */
+	SDL_EVENT_JOYSTICK_REMOVED         SDL_EventType = 0x606 /**< An opened joystick has been removed */
+	SDL_EVENT_JOYSTICK_BATTERY_UPDATED SDL_EventType = 0x607 /**< Joystick battery level change */
+	SDL_EVENT_JOYSTICK_UPDATE_COMPLETE SDL_EventType = 0x608 /**< Joystick update is complete */
+
+	/* Gamepad events */
+	SDL_EVENT_GAMEPAD_AXIS_MOTION          SDL_EventType = 0x650 /**< Gamepad axis motion */
+	SDL_EVENT_GAMEPAD_BUTTON_DOWN          SDL_EventType = 0x651 /**< Gamepad button pressed */
+	SDL_EVENT_GAMEPAD_BUTTON_UP            SDL_EventType = 0x652 /**< Gamepad button released */
+	SDL_EVENT_GAMEPAD_ADDED                SDL_EventType = 0x653 /**< A new gamepad has been inserted into the system */
+	SDL_EVENT_GAMEPAD_REMOVED              SDL_EventType = 0x654 /**< A gamepad has been removed */
+	SDL_EVENT_GAMEPAD_REMAPPED             SDL_EventType = 0x655 /**< The gamepad mapping was updated */
+	SDL_EVENT_GAMEPAD_TOUCHPAD_DOWN        SDL_EventType = 0x656 /**< Gamepad touchpad was touched */
+	SDL_EVENT_GAMEPAD_TOUCHPAD_MOTION      SDL_EventType = 0x657 /**< Gamepad touchpad finger was moved */
+	SDL_EVENT_GAMEPAD_TOUCHPAD_UP          SDL_EventType = 0x658 /**< Gamepad touchpad finger was lifted */
+	SDL_EVENT_GAMEPAD_SENSOR_UPDATE        SDL_EventType = 0x659 /**< Gamepad sensor was updated */
+	SDL_EVENT_GAMEPAD_UPDATE_COMPLETE      SDL_EventType = 0x65A /**< Gamepad update is complete */
+	SDL_EVENT_GAMEPAD_STEAM_HANDLE_UPDATED SDL_EventType = 0x65B /**< Gamepad Steam handle has changed */
+
+	/* Touch events */
+	SDL_EVENT_FINGER_DOWN     SDL_EventType = 0x700
+	SDL_EVENT_FINGER_UP       SDL_EventType = 0x701
+	SDL_EVENT_FINGER_MOTION   SDL_EventType = 0x702
+	SDL_EVENT_FINGER_CANCELED SDL_EventType = 0x703
+
+	/* Clipboard events */
+	SDL_EVENT_CLIPBOARD_UPDATE SDL_EventType = 0x900 /**< The clipboard or primary selection changed */
+
+	/* Drag and drop events */
+	SDL_EVENT_DROP_FILE     SDL_EventType = 0x1000 /**< The system requests a file open */
+	SDL_EVENT_DROP_TEXT     SDL_EventType = 0x1001 /**< text/plain drag-and-drop event */
+	SDL_EVENT_DROP_BEGIN    SDL_EventType = 0x1002 /**< A new set of drops is beginning (NULL filename) */
+	SDL_EVENT_DROP_COMPLETE SDL_EventType = 0x1003 /**< Current set of drops is now complete (NULL filename) */
+	SDL_EVENT_DROP_POSITION SDL_EventType = 0x1004 /**< Position while moving over the window */
+
+	/* Audio hotplug events */
+	SDL_EVENT_AUDIO_DEVICE_ADDED          SDL_EventType = 0x1100 /**< A new audio device is available */
+	SDL_EVENT_AUDIO_DEVICE_REMOVED        SDL_EventType = 0x1101 /**< An audio device has been removed. */
+	SDL_EVENT_AUDIO_DEVICE_FORMAT_CHANGED SDL_EventType = 0x1102 /**< An audio device's format has been changed by the system. */
+
+	/* Sensor events */
+	SDL_EVENT_SENSOR_UPDATE SDL_EventType = 0x1200 /**< A sensor was updated */
+
+	/* Pressure-sensitive pen events */
+	SDL_EVENT_PEN_PROXIMITY_IN  SDL_EventType = 0x1300 /**< Pressure-sensitive pen has become available */
+	SDL_EVENT_PEN_PROXIMITY_OUT SDL_EventType = 0x1301 /**< Pressure-sensitive pen has become unavailable */
+	SDL_EVENT_PEN_DOWN          SDL_EventType = 0x1302 /**< Pressure-sensitive pen touched drawing surface */
+	SDL_EVENT_PEN_UP            SDL_EventType = 0x1303 /**< Pressure-sensitive pen stopped touching drawing surface */
+	SDL_EVENT_PEN_BUTTON_DOWN   SDL_EventType = 0x1304 /**< Pressure-sensitive pen button pressed */
+	SDL_EVENT_PEN_BUTTON_UP     SDL_EventType = 0x1305 /**< Pressure-sensitive pen button released */
+	SDL_EVENT_PEN_MOTION        SDL_EventType = 0x1306 /**< Pressure-sensitive pen is moving on the tablet */
+	SDL_EVENT_PEN_AXIS          SDL_EventType = 0x1307 /**< Pressure-sensitive pen angle/pressure/etc changed */
+
+	/* Camera hotplug events */
+	SDL_EVENT_CAMERA_DEVICE_ADDED    SDL_EventType = 0x1400 /**< A new camera device is available */
+	SDL_EVENT_CAMERA_DEVICE_REMOVED  SDL_EventType = 0x1401 /**< A camera device has been removed. */
+	SDL_EVENT_CAMERA_DEVICE_APPROVED SDL_EventType = 0x1402 /**< A camera device has been approved for use by the user. */
+	SDL_EVENT_CAMERA_DEVICE_DENIED   SDL_EventType = 0x1403 /**< A camera device has been denied for use by the user. */
+
+	/* Render events */
+	SDL_EVENT_RENDER_TARGETS_RESET SDL_EventType = 0x2000 /**< The render targets have been reset and their contents need to be updated */
+	SDL_EVENT_RENDER_DEVICE_RESET  SDL_EventType = 0x2001 /**< The device has been reset and all textures need to be recreated */
+	SDL_EVENT_RENDER_DEVICE_LOST   SDL_EventType = 0x2002 /**< The device has been lost and can't be recovered. */
+
+	/* Permission events */
+	SDL_EVENT_PERMISSION_CHANGED SDL_EventType = 0x1500 /**< The state of a permission has changed */
+
+	/* Reserved events for private platforms */
+	SDL_EVENT_PRIVATE0 SDL_EventType = 0x4000
+	SDL_EVENT_PRIVATE1 SDL_EventType = 0x4001
+	SDL_EVENT_PRIVATE2 SDL_EventType = 0x4002
+	SDL_EVENT_PRIVATE3 SDL_EventType = 0x4003
+
+	/* Event queue events */
+	SDL_EVENT_QUEUE_OVERFLOW SDL_EventType = 0x7E00 /**< Events were dropped or merged because the queue was full */
+
+	/* Internal events */
+	SDL_EVENT_POLL_SENTINEL SDL_EventType = 0x7F00 /**< Signals the end of an event poll cycle */
+
+	/** Events SDL_EVENT_USER through SDL_EVENT_LAST are for your use,
+	 *  and should be allocated with SDL_RegisterEvents()
+	 */
+	SDL_EVENT_USER SDL_EventType = 0x8000
+
+	/**
+	 *  This last event is only for bounding internal arrays
+	 */
+	SDL_EVENT_LAST SDL_EventType = 0xFFFF
+)
+
+/**
+ * Fields shared by every event
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_CommonEvent struct {
+	Type      SDL_EventType /**< Event type, shared with all events */
+	Reserved  uint32
+	Timestamp uint64 /**< In nanoseconds, populated using SDL_GetTicksNS() */
+}
+
+/**
+ * Mouse motion event structure (event.Motion.*)
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_MouseMotionEvent struct {
+	WindowID SDL_WindowID         /**< The window with mouse focus, if any */
+	Which    SDL_MouseID          /**< The mouse instance id or SDL_TOUCH_MOUSEID */
+	State    SDL_MouseButtonFlags /**< The current button state */
+	X        float32              /**< X coordinate, relative to window */
+	Y        float32              /**< Y coordinate, relative to window */
+	Xrel     float32              /**< The relative motion in the X direction */
+	Yrel     float32              /**< The relative motion in the Y direction */
+}
+
+/**
+ * Mouse button event structure (event.Button.*)
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_MouseButtonEvent struct {
+	WindowID SDL_WindowID /**< The window with mouse focus, if any */
+	Which    SDL_MouseID  /**< The mouse instance id or SDL_TOUCH_MOUSEID */
+	Button   uint8        /**< The mouse button index */
+	Down     bool         /**< true if the button is pressed */
+	Clicks   uint8        /**< 1 for single-click, 2 for double-click, etc. */
+	X        float32      /**< X coordinate, relative to window */
+	Y        float32      /**< Y coordinate, relative to window */
+}
+
+/**
+ * Mouse wheel event structure (event.Wheel.*)
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_MouseWheelEvent struct {
+	WindowID  SDL_WindowID            /**< The window with mouse focus, if any */
+	Which     SDL_MouseID             /**< The mouse instance id or SDL_TOUCH_MOUSEID */
+	X         float32                 /**< The amount scrolled horizontally, positive to the right and negative to the left */
+	Y         float32                 /**< The amount scrolled vertically, positive away from the user and negative toward the user */
+	Direction SDL_MouseWheelDirection /**< Set to one of the SDL_MOUSEWHEEL_* defines. When FLIPPED the values in X and Y will be opposite. Multiply by -1 to change them back */
+	MouseX    float32                 /**< X coordinate, relative to window */
+	MouseY    float32                 /**< Y coordinate, relative to window */
+}
+
+/**
+ * Touch finger event structure (event.TFinger.*)
+ *
+ * Coordinates in this event are normalized. `X` and `Y` are normalized to a
+ * range between 0.0f and 1.0f, relative to the window, so (0,0) is the top
+ * left and (1,1) is the bottom right. Delta coordinates `Dx` and `Dy` are
+ * normalized in the ranges of -1.0f (traversed all the way from the bottom or
+ * right to all the way up or left) to 1.0f (traversed all the way from the
+ * top or left to all the way down or right).
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_TouchFingerEvent struct {
+	TouchID  SDL_TouchID /**< The touch device id */
+	FingerID SDL_FingerID
+	X        float32      /**< Normalized in the range 0...1 */
+	Y        float32      /**< Normalized in the range 0...1 */
+	Dx       float32      /**< Normalized in the range -1...1 */
+	Dy       float32      /**< Normalized in the range -1...1 */
+	Pressure float32      /**< Normalized in the range 0...1 */
+	WindowID SDL_WindowID /**< The window underneath the finger, if any */
+}
+
+/**
+ * Pressure-sensitive pen proximity event structure (event.PProximity.*)
+ *
+ * When a pen becomes visible to the system (it is close enough to a tablet,
+ * etc), SDL will send an SDL_EVENT_PEN_PROXIMITY_IN event with the new pen's
+ * ID. This ID is valid until the pen leaves proximity again (it has been
+ * removed from the tablet's area, the tablet has been unplugged, etc). If the
+ * same pen reenters proximity again, it will be given a new ID.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_PenProximityEvent struct {
+	WindowID SDL_WindowID /**< The window with pen focus, if any */
+	Which    SDL_PenID    /**< The pen instance id */
+}
+
+/**
+ * Pressure-sensitive pen motion event structure (event.PMotion.*)
+ *
+ * Depending on the hardware, you may get motion events when the pen is not
+ * touching a tablet, for tracking a pen even when it isn't drawing. You
+ * should listen for SDL_EVENT_PEN_DOWN and SDL_EVENT_PEN_UP events, or check
+ * `Pen_state & SDL_PEN_INPUT_DOWN` to decide if a pen is "drawing" when
+ * dealing with pen motion.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_PenMotionEvent struct {
+	WindowID  SDL_WindowID      /**< The window with pen focus, if any */
+	Which     SDL_PenID         /**< The pen instance id */
+	Pen_state SDL_PenInputFlags /**< Complete pen input state at time of event */
+	X         float32           /**< X coordinate, relative to window */
+	Y         float32           /**< Y coordinate, relative to window */
+}
+
+/**
+ * Pressure-sensitive pen touched event structure (event.PTouch.*)
+ *
+ * These events come when a pen touches a surface (a tablet, etc), or lifts
+ * off from one.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_PenTouchEvent struct {
+	WindowID  SDL_WindowID      /**< The window with pen focus, if any */
+	Which     SDL_PenID         /**< The pen instance id */
+	Pen_state SDL_PenInputFlags /**< Complete pen input state at time of event */
+	X         float32           /**< X coordinate, relative to window */
+	Y         float32           /**< Y coordinate, relative to window */
+	Eraser    bool              /**< true if eraser end is used (not all pens support this). */
+	Down      bool              /**< true if the pen is touching or false if the pen is lifted off */
+}
+
+/**
+ * Pressure-sensitive pen button event structure (event.PButton.*)
+ *
+ * This is for buttons on the pen itself that the user might click. The pen
+ * itself pressing down to draw triggers a SDL_EVENT_PEN_DOWN event instead.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_PenButtonEvent struct {
+	WindowID  SDL_WindowID      /**< The window with mouse focus, if any */
+	Which     SDL_PenID         /**< The pen instance id */
+	Pen_state SDL_PenInputFlags /**< Complete pen input state at time of event */
+	X         float32           /**< X coordinate, relative to window */
+	Y         float32           /**< Y coordinate, relative to window */
+	Button    uint8             /**< The pen button index (first button is 1). */
+	Down      bool              /**< true if the button is pressed */
+}
+
+/**
+ * Pressure-sensitive pen pressure / angle event structure (event.PAxis.*)
+ *
+ * You might get some of these events even if the pen isn't touching the
+ * tablet.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_PenAxisEvent struct {
+	WindowID  SDL_WindowID      /**< The window with pen focus, if any */
+	Which     SDL_PenID         /**< The pen instance id */
+	Pen_state SDL_PenInputFlags /**< Complete pen input state at time of event */
+	X         float32           /**< X coordinate, relative to window */
+	Y         float32           /**< Y coordinate, relative to window */
+	Axis      SDL_PenAxis       /**< Axis that has changed */
+	Value     float32           /**< New value of axis */
+}
+
+/**
+ * An event used to drop text or request a file open by the system
+ * (event.Drop.*)
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_DropEvent struct {
+	WindowID SDL_WindowID /**< The window that was dropped on, if any */
+	X        float32      /**< X coordinate, relative to window (not on begin) */
+	Y        float32      /**< Y coordinate, relative to window (not on begin) */
+	Source   string       /**< The source app that sent this drop event, or "" if that isn't available */
+	Data     string       /**< The text for SDL_EVENT_DROP_TEXT and the file name for SDL_EVENT_DROP_FILE, "" for other events */
+}
+
+/**
+ * A user-defined event type (event.User.*)
+ *
+ * This event is unique; it is never created by SDL, but only by the
+ * application. The event can be pushed onto the event queue using
+ * SDL_PushEvent(). The contents of the structure members are completely up to
+ * the programmer; the only requirement is that `Type` is a value obtained
+ * from SDL_RegisterEvents().
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_UserEvent struct {
+	WindowID SDL_WindowID /**< The associated window if any */
+	Code     int32        /**< User defined event code */
+	Data1    any          /**< User defined data */
+	Data2    any          /**< User defined data */
+}
+
+/**
+ * Event queue overflow event structure (event.Overflow.*)
+ *
+ * Sent at most once per overflow: while the notification is still queued,
+ * further losses are added to it instead of sending another one.
+ */
+type SDL_QueueOverflowEvent struct {
+	Dropped uint32 /**< The number of events that were discarded */
+	Merged  uint32 /**< The number of motion events merged into neighbouring ones */
+}
+
+/**
+ * Permission event structure (event.Permission.*)
+ *
+ * See also SDL_GetPermissionState
+ */
+type SDL_PermissionEvent struct {
+	Permission SDL_Permission      /**< The permission that changed */
+	State      SDL_PermissionState /**< The new state of the permission */
+}
+
+/**
+ * The structure for all events in SDL.
+ *
+ * Go has no unions, so this is a tagged struct instead: the fields shared by
+ * all events are embedded from SDL_CommonEvent, and the event specific data
+ * lives in the member matching `Type`. The other members are zero.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_Event struct {
+	SDL_CommonEvent                                /**< Common event data */
+	Display         SDL_DisplayEvent               /**< Display event data */
+	Clipboard       SDL_ClipboardEvent             /**< Clipboard event data */
+	Window          SDL_WindowEvent                /**< Window event data */
+	EditCandidates  SDL_TextEditingCandidatesEvent /**< Text editing candidates event data */
+	Motion          SDL_MouseMotionEvent           /**< Mouse motion event data */
+	Button          SDL_MouseButtonEvent           /**< Mouse button event data */
+	Wheel           SDL_MouseWheelEvent            /**< Mouse wheel event data */
+	TFinger         SDL_TouchFingerEvent           /**< Touch finger event data */
+	PProximity      SDL_PenProximityEvent          /**< Pen proximity event data */
+	PTouch          SDL_PenTouchEvent              /**< Pen tip touching event data */
+	PMotion         SDL_PenMotionEvent             /**< Pen motion event data */
+	PButton         SDL_PenButtonEvent             /**< Pen button event data */
+	PAxis           SDL_PenAxisEvent               /**< Pen axis event data */
+	Drop            SDL_DropEvent                  /**< Drag and drop event data */
+	Overflow        SDL_QueueOverflowEvent         /**< Event queue overflow event data */
+	Permission      SDL_PermissionEvent            /**< Permission event data */
+	User            SDL_UserEvent                  /**< Custom event data */
+}
+
+/* Whether the event type is in the inclusive range [minType, maxType] */
+func (event *SDL_Event) inRange(minType, maxType SDL_EventType) bool {
+	return minType <= event.Type && event.Type <= maxType
+}
+
+/* An arbitrary limit so we don't have unbounded growth */
+const sdlMaxQueuedEvents = 65535
+
+/**
+ * What happens to new events when the event queue is full.
+ *
+ * See also SDL_SetEventQueueLimit
+ */
+type SDL_EventQueueOverflowPolicy int
+
+const (
+	SDL_EVENT_QUEUE_DROP_NEWEST     SDL_EventQueueOverflowPolicy = iota /**< New events are discarded and SDL_PushEvent() fails, the default */
+	SDL_EVENT_QUEUE_DROP_OLDEST                                         /**< The oldest queued events are discarded to make room */
+	SDL_EVENT_QUEUE_COALESCE_MOTION                                     /**< Consecutive motion events are merged to make room, new events are discarded if that's not possible */
+)
+
+/* Private data -- event queue */
+type sdlEventQueue struct {
+	lock            sdlMutex
+	active          bool
+	events          []SDL_Event /* queued events, oldest first, a window into storage */
+	storage         []SDL_Event /* the backing array of events, its front is reused once consumed */
+	max_events_seen int         /* high water mark, for debugging */
+	max_events      int         /* the queue limit, see SDL_SetEventQueueLimit() */
+	coalesce_motion bool        /* SDL_HINT_EVENT_COALESCE_MOTION */
+	overflow_policy SDL_EventQueueOverflowPolicy
+	wakeup          chan struct{} /* closed and replaced when events are added */
+}
+
+var eventQ = sdlEventQueue{lock: sdlMutex{name: "events.queue"}, max_events: sdlMaxQueuedEvents, coalesce_motion: true}
+
+/**
+ * A variable controlling whether consecutive motion events are merged in the
+ * event queue.
+ *
+ * When enabled, a mouse or finger motion event that directly follows a
+ * motion event from the same source still in the queue is merged into it:
+ * the position is the newest one and the relative motion (`Xrel`/`Yrel`,
+ * `Dx`/`Dy`) is accumulated, so the total motion is preserved while fewer
+ * events are queued at high polling rates.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "0": Every motion sample is delivered as its own event, e.g. for
+ *   drawing applications.
+ * - "1": Consecutive motion events are merged. (default)
+ *
+ * This hint can be set anytime.
+ */
+const SDL_HINT_EVENT_COALESCE_MOTION = "SDL_EVENT_COALESCE_MOTION"
+
+/* Event pumps of the subsystems feeding the queue, see sdlAddEventPump() */
+var eventPumpsLock = sdlRWMutex{name: "events.pumps"}
+var eventPumps []func()
+
+/* Whether another goroutine asked the main thread to pump */
+var eventPumpRequested atomic.Bool
+
+func init() {
+	sdlRegisterSubsystem(SDL_INIT_EVENTS, sdlInitEvents, sdlQuitEvents)
+}
+
+/*
+ * Register a function gathering pending input from a device or the OS and
+ * feeding it into the queue, called from SDL_PumpEvents().
+ *
+ * Waiting for events doesn't poll the pumps: a backend must call
+ * sdlPumpEventsProxied() when its input becomes readable, e.g. from the
+ * goroutine blocked on the OS handle, which wakes up a waiting main thread
+ * to pump it.
+ */
+func sdlAddEventPump(pump func()) {
+	eventPumpsLock.Lock()
+	defer eventPumpsLock.Unlock()
+
+	eventPumps = append(eventPumps, pump)
+}
+
+/* Unregister a pump added with sdlAddEventPump(), e.g. when its subsystem quits */
+func sdlRemoveEventPump(pump func()) {
+	eventPumpsLock.Lock()
+	defer eventPumpsLock.Unlock()
+
+	/* Functions can't be compared, match the code pointer */
+	target := reflect.ValueOf(pump).Pointer()
+	eventPumps = slices.DeleteFunc(slices.Clone(eventPumps), func(f func()) bool {
+		return reflect.ValueOf(f).Pointer() == target
+	})
+}
+
+func sdlEventCoalesceMotionChanged(userdata any, name, oldValue, hint string) {
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	eventQ.coalesce_motion = sdlGetStringBoolean(hint, true)
+}
+
+/* The hint callbacks of the events subsystem, added by sdlInitEvents() */
+var eventCoalesceMotionCallback, eventLoggingCallback SDL_HintCallbackID
+
+func sdlInitEvents() bool {
+	eventQ.lock.Lock()
+	eventQ.active = true
+	eventQ.events = nil
+	eventQ.storage = nil
+	eventQ.max_events_seen = 0
+	eventQ.wakeup = make(chan struct{})
+	eventQ.lock.Unlock()
+
+	eventCoalesceMotionCallback = SDL_AddHintCallback(SDL_HINT_EVENT_COALESCE_MOTION, sdlEventCoalesceMotionChanged, nil)
+	eventLoggingCallback = SDL_AddHintCallback(SDL_HINT_EVENT_LOGGING, sdlEventLoggingChanged, nil)
+	sdlInitQuit()
+	return true
+}
+
+func sdlQuitEvents() {
+	SDL_RemoveHintCallback(SDL_HINT_EVENT_COALESCE_MOTION, eventCoalesceMotionCallback)
+	SDL_RemoveHintCallback(SDL_HINT_EVENT_LOGGING, eventLoggingCallback)
+	eventLoggingVerbosity.Store(0)
+	sdlQuitQuit()
+	sdlQuitKeyboard()
+	sdlCancelMainThreadCallbacks()
+	eventPumpRequested.Store(false)
+
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	eventQ.active = false
+	eventQ.events = nil
+	eventQ.storage = nil
+	eventQ.max_events_seen = 0
+	eventQ.max_events = sdlMaxQueuedEvents
+	eventQ.overflow_policy = SDL_EVENT_QUEUE_DROP_NEWEST
+
+	for i := range disabledEvents {
+		disabledEvents[i].Store(0)
+	}
+
+	/* Wake up anybody still waiting, they'll notice the queue is gone */
+	eventQ.wakeLocked()
+}
+
+/* Must be called with the queue lock held. */
+func (q *sdlEventQueue) wakeLocked() {
+	if q.wakeup != nil {
+		close(q.wakeup)
+	}
+	q.wakeup = make(chan struct{})
+}
+
+/* Events that only report a new position, superseded by the next one of their kind */
+func sdlIsMotionEvent(kind SDL_EventType) bool {
+	switch kind {
+	case SDL_EVENT_MOUSE_MOTION, SDL_EVENT_FINGER_MOTION:
+		return true
+	}
+	return false
+}
+
+/*
+ * Merge the motion event newer into older, which directly precedes it in
+ * the queue: the position is the newer one and the relative motion is
+ * accumulated. Returns false if they don't come from the same source and
+ * can't be merged.
+ */
+func sdlCoalesceMotion(older *SDL_Event, newer *SDL_Event) bool {
+	if !sdlIsMotionEvent(older.Type) || older.Type != newer.Type {
+		return false
+	}
+
+	switch newer.Type {
+	case SDL_EVENT_MOUSE_MOTION:
+		a, b := &older.Motion, &newer.Motion
+		if a.WindowID != b.WindowID || a.Which != b.Which || a.State != b.State {
+			return false
+		}
+		xrel, yrel := a.Xrel+b.Xrel, a.Yrel+b.Yrel
+		*a = *b
+		a.Xrel, a.Yrel = xrel, yrel
+
+	case SDL_EVENT_FINGER_MOTION:
+		a, b := &older.TFinger, &newer.TFinger
+		if a.TouchID != b.TouchID || a.FingerID != b.FingerID || a.WindowID != b.WindowID {
+			return false
+		}
+		dx, dy := a.Dx+b.Dx, a.Dy+b.Dy
+		*a = *b
+		a.Dx, a.Dy = dx, dy
+	}
+	older.Timestamp = newer.Timestamp
+	return true
+}
+
+/*
+ * Record events lost to an overflow in the pending SDL_EVENT_QUEUE_OVERFLOW
+ * event, queueing one if needed. The notification may exceed the queue
+ * limit by one so it can't be lost itself.
+ * Must be called with the queue lock held.
+ */
+func (q *sdlEventQueue) noteOverflowLocked(dropped, merged uint32) {
+	for i := len(q.events) - 1; i >= 0; i-- {
+		if q.events[i].Type == SDL_EVENT_QUEUE_OVERFLOW {
+			q.events[i].Overflow.Dropped += dropped
+			q.events[i].Overflow.Merged += merged
+			return
+		}
+	}
+
+	var event SDL_Event
+	event.Type = SDL_EVENT_QUEUE_OVERFLOW
+	event.Timestamp = SDL_GetTicksNS()
+	event.Overflow = SDL_QueueOverflowEvent{Dropped: dropped, Merged: merged}
+	q.appendLocked(&event)
+}
+
+/*
+ * Make room for event in a full queue according to the overflow policy.
+ * Returns false if event has to be discarded, and true with merged set if
+ * it was merged into the queue instead of needing a slot of its own.
+ * Must be called with the queue lock held.
+ */
+func (q *sdlEventQueue) overflowLocked(event *SDL_Event) (ok bool, merged bool) {
+	switch q.overflow_policy {
+	case SDL_EVENT_QUEUE_DROP_OLDEST:
+		for i := range q.events {
+			if q.events[i].Type != SDL_EVENT_QUEUE_OVERFLOW {
+				q.removeLocked(i)
+				q.noteOverflowLocked(1, 0)
+				return true, false
+			}
+		}
+
+	case SDL_EVENT_QUEUE_COALESCE_MOTION:
+		/* Merge into the newest event if possible, so ordering is kept */
+		if n := len(q.events); n > 0 && sdlCoalesceMotion(&q.events[n-1], event) {
+			q.noteOverflowLocked(0, 1)
+			return true, true
+		}
+		for i := 0; i+1 < len(q.events); i++ {
+			if sdlCoalesceMotion(&q.events[i], &q.events[i+1]) {
+				q.removeLocked(i + 1)
+				q.noteOverflowLocked(0, 1)
+				return true, false
+			}
+		}
+	}
+
+	q.noteOverflowLocked(1, 0)
+	return false, false
+}
+
+/* Must be called with the queue lock held. */
+func (q *sdlEventQueue) addLocked(event *SDL_Event) bool {
+	/* Merge motion into the newest queued event while it hasn't been read */
+	if n := len(q.events); q.coalesce_motion && n > 0 && sdlCoalesceMotion(&q.events[n-1], event) {
+		q.wakeLocked()
+		return true
+	}
+
+	if len(q.events) >= q.max_events {
+		ok, merged := q.overflowLocked(event)
+		if !ok {
+			q.wakeLocked()
+			return SDL_SetErrorf("Event queue is full (%d events)", len(q.events))
+		}
+		if merged {
+			q.wakeLocked()
+			return true
+		}
+	}
+
+	q.appendLocked(event)
+	if len(q.events) > q.max_events_seen {
+		q.max_events_seen = len(q.events)
+	}
+	q.wakeLocked()
+	return true
+}
+
+/*
+ * Add an event at the back of the queue. Once the end of the storage is
+ * reached, the events are moved back to its front if at least half of it
+ * was consumed, so taking events from the front and adding them stay O(1)
+ * amortized.
+ * Must be called with the queue lock held.
+ */
+func (q *sdlEventQueue) appendLocked(event *SDL_Event) {
+	n := len(q.events)
+	if consumed := cap(q.storage) - cap(q.events); n == cap(q.events) && consumed > 0 && consumed >= n {
+		copy(q.storage, q.events)
+		clear(q.storage[n : n+consumed]) /* don't keep payloads alive */
+		q.events = q.storage[:n]
+	}
+
+	capacity := cap(q.events)
+	q.events = append(q.events, *event)
+	if cap(q.events) != capacity {
+		q.storage = q.events[:cap(q.events)]
+	}
+}
+
+/*
+ * Remove the event at index i. Taking the oldest event is O(1), otherwise
+ * the newer events are moved down, the indices of the older ones don't
+ * change.
+ * Must be called with the queue lock held.
+ */
+func (q *sdlEventQueue) removeLocked(i int) {
+	if i == 0 {
+		q.events[0] = SDL_Event{} /* don't keep payloads alive */
+		q.events = q.events[1:]
+	} else {
+		copy(q.events[i:], q.events[i+1:])
+		q.events[len(q.events)-1] = SDL_Event{}
+		q.events = q.events[:len(q.events)-1]
+	}
+	if len(q.events) == 0 {
+		q.events = q.storage[:0]
+	}
+}
+
+/*
+ * Remove the oldest events in the [minType, maxType] range, storing them in
+ * events, or all of them when events is nil. Matching events at the front
+ * are taken in O(1) each, the others in a single pass over the queue.
+ * Returns the number of events removed.
+ * Must be called with the queue lock held.
+ */
+func (q *sdlEventQueue) takeLocked(events []SDL_Event, minType, maxType SDL_EventType) int {
+	numevents := len(events)
+	if events == nil {
+		numevents = len(q.events)
+	}
+
+	used := 0
+	for used < numevents && len(q.events) > 0 && q.events[0].inRange(minType, maxType) {
+		if events != nil {
+			events[used] = q.events[0]
+		}
+		used++
+		q.removeLocked(0)
+	}
+
+	first := slices.IndexFunc(q.events, func(event SDL_Event) bool { return event.inRange(minType, maxType) })
+	if used == numevents || first < 0 {
+		return used
+	}
+	if used+1 == numevents {
+		if events != nil {
+			events[used] = q.events[first]
+		}
+		q.removeLocked(first)
+		return used + 1
+	}
+
+	kept := q.events[:first]
+	for _, event := range q.events[first:] {
+		if used < numevents && event.inRange(minType, maxType) {
+			if events != nil {
+				events[used] = event
+			}
+			used++
+			continue
+		}
+		kept = append(kept, event)
+	}
+	clear(q.events[len(kept):]) /* don't keep payloads alive */
+	q.events = kept
+	if len(q.events) == 0 {
+		q.events = q.storage[:0]
+	}
+	return used
+}
+
+/*
+ * The body of SDL_PeepEvents(), see there. Up to numevents events are
+ * stored in events, which may be nil to only count matching events.
+ * Must be called with the queue lock held.
+ */
+func (q *sdlEventQueue) peepLocked(events []SDL_Event, numevents int, action SDL_EventAction, minType, maxType SDL_EventType) int {
+	used := 0
+	switch action {
+	case SDL_ADDEVENT:
+		for i := 0; i < numevents; i++ {
+			if !q.addLocked(&events[i]) {
+				break
+			}
+			used++
+		}
+
+	case SDL_PEEKEVENT:
+		for i := 0; i < len(q.events) && used < numevents; i++ {
+			if q.events[i].inRange(minType, maxType) {
+				if events != nil {
+					events[used] = q.events[i]
+				}
+				used++
+			}
+		}
+
+	case SDL_GETEVENT:
+		if events == nil {
+			/* Nowhere to store them, so they stay in the queue */
+			return q.peepLocked(nil, numevents, SDL_PEEKEVENT, minType, maxType)
+		}
+		used = q.takeLocked(events[:min(numevents, len(events))], minType, maxType)
+	}
+	return used
+}
+
+/**
+ * The type of action to request from SDL_PeepEvents().
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_EventAction int
+
+const (
+	SDL_ADDEVENT  SDL_EventAction = iota /**< Add events to the back of the queue. */
+	SDL_PEEKEVENT                        /**< Check but don't remove events from the queue front. */
+	SDL_GETEVENT                         /**< Retrieve/remove events from the front of the queue. */
+)
+
+/**
+ * Check the event queue for messages and optionally return them.
+ *
+ * `action` may be any of the following:
+ *
+ * - `SDL_ADDEVENT`: up to `len(events)` events will be added to the back of
+ *   the event queue.
+ * - `SDL_PEEKEVENT`: up to `len(events)` events at the front of the event
+ *   queue, within the specified minimum and maximum type, will be returned to
+ *   the caller and will _not_ be removed from the queue. If you pass nil for
+ *   `events`, then the number of matching events in the queue is returned.
+ * - `SDL_GETEVENT`: up to `len(events)` events at the front of the event
+ *   queue, within the specified minimum and maximum type, will be returned to
+ *   the caller and will be removed from the queue.
+ *
+ * The whole batch is handled with a single acquisition of the queue lock, so
+ * draining many events, e.g. all mouse motion of a frame, is cheap and no
+ * other goroutine can add or remove events in the middle of it.
+ *
+ * You may have to call SDL_PumpEvents() before calling this function.
+ * Otherwise, the events may not be ready to be filtered when you call
+ * SDL_PeepEvents().
+ *
+ * - events destination buffer for the retrieved events, may be nil to
+ *               leave the events in the queue and return the number of
+ *               events that would have been stored.
+ * - action action to take; see above for details.
+ * - minType minimum value of the event type to be considered;
+ *                SDL_EVENT_FIRST is a safe choice.
+ * - maxType maximum value of the event type to be considered;
+ *                SDL_EVENT_LAST is a safe choice.
+ * Returns the number of events actually stored or -1 on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is thread-safe.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_PollEvent
+ * See also SDL_PumpEvents
+ * See also SDL_PushEvent
+ */
+func SDL_PeepEvents(events []SDL_Event, action SDL_EventAction, minType, maxType SDL_EventType) int {
+	numevents := len(events)
+	switch action {
+	case SDL_ADDEVENT:
+		if events == nil {
+			SDL_InvalidParamError("events")
+			return -1
+		}
+	case SDL_PEEKEVENT, SDL_GETEVENT:
+		if events == nil {
+			numevents = math.MaxInt
+		}
+	default:
+		SDL_InvalidParamError("action")
+		return -1
+	}
+	if action == SDL_ADDEVENT {
+		for i := range events {
+			sdlLogEvent(&events[i])
+		}
+	}
+
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	/* Don't look after we've quit */
+	if !eventQ.active {
+		/* We get a few spurious events at shutdown, so don't warn then */
+		if action == SDL_GETEVENT {
+			SDL_SetError("The event system has been shut down")
+		}
+		return -1
+	}
+	return eventQ.peepLocked(events, numevents, action, minType, maxType)
+}
+
+/**
+ * Pump the event loop, gathering events from the input devices.
+ *
+ * This function updates the event queue and internal input device state.
+ *
+ * SDL_PumpEvents() gathers all the pending input information from devices
+ * and places it in the event queue. Without calls to SDL_PumpEvents() no
+ * events would ever be placed on the queue. Often the need for calls to
+ * SDL_PumpEvents() is hidden from the user since SDL_PollEvent() and
+ * SDL_WaitEvent() implicitly call SDL_PumpEvents(). However, if you are not
+ * polling or waiting for events, then you must call SDL_PumpEvents() to force
+ * an event queue update.
+ *
+ * This function should only be called on the main thread, see
+ * SDL_IsMainThread(); platform backends expect that goroutine to be locked
+ * to the main OS thread with runtime.LockOSThread(). Callbacks queued with
+ * SDL_RunOnMainThread() are run here. In debug
+ * builds (SDL_ASSERT_LEVEL 3 or higher) calling it from another goroutine
+ * triggers an assertion. SDL_PollEvent(), SDL_WaitEvent() and event
+ * subscriptions can be used from any goroutine: when they need the queue
+ * pumped elsewhere, they ask the main goroutine to pump on its next call
+ * instead.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_PollEvent
+ * See also SDL_WaitEvent
+ */
+func SDL_PumpEvents() {
+	if SDL_ASSERT_LEVEL >= 3 && !SDL_IsMainThread() {
+		SDL_LogError(SDL_LOG_CATEGORY_ASSERT, "SDL_PumpEvents() called from a goroutine other than the main thread")
+		SDL_assert(false)
+	}
+	sdlRunEventPumps()
+}
+
+/* Must be called on the main thread */
+func sdlRunEventPumps() {
+	eventPumpRequested.Store(false)
+	sdlRunMainThreadCallbacks()
+
+	eventPumpsLock.RLock()
+	pumps := eventPumps
+	eventPumpsLock.RUnlock()
+
+	for _, pump := range pumps {
+		pump()
+	}
+}
+
+/*
+ * Pump the event queue from any goroutine: directly on the main goroutine,
+ * elsewhere by leaving a request for it and waking it up in case it's
+ * waiting for events. The request is served by its next pump.
+ */
+func sdlPumpEventsProxied() {
+	/* Called for every event poll, skip finding out which goroutine this is when there's nothing to do */
+	eventPumpsLock.RLock()
+	pumping := len(eventPumps) > 0
+	eventPumpsLock.RUnlock()
+	if !pumping && !sdlHasMainThreadCallbacks() {
+		return
+	}
+
+	if SDL_IsMainThread() {
+		sdlRunEventPumps()
+		return
+	}
+
+	if pumping && !eventPumpRequested.Swap(true) {
+		sdlWakeEventQueue()
+	}
+}
+
+/* Wake up the goroutines waiting for events, e.g. for the main thread to notice queued work */
+func sdlWakeEventQueue() {
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	eventQ.wakeLocked()
+}
+
+/**
+ * Check for the existence of a certain event type in the event queue.
+ *
+ * If you need to check for a range of event types, use SDL_HasEvents()
+ * instead.
+ *
+ * - kind the type of event to be queried; see SDL_EventType for details.
+ * Returns true if events matching `kind` are present, or false if events
+ *          matching `kind` are not present.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_HasEvents
+ */
+func SDL_HasEvent(kind SDL_EventType) bool {
+	return SDL_HasEvents(kind, kind)
+}
+
+/**
+ * Check for the existence of certain event types in the event queue.
+ *
+ * If you need to check for a single event type, use SDL_HasEvent() instead.
+ *
+ * - minType the low end of event type to be queried, inclusive; see
+ *                SDL_EventType for details.
+ * - maxType the high end of event type to be queried, inclusive; see
+ *                SDL_EventType for details.
+ * Returns true if events with type >= `minType` and <= `maxType` are
+ *          present, or false if not.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_HasEvents
+ */
+func SDL_HasEvents(minType, maxType SDL_EventType) bool {
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	return eventQ.active && eventQ.peepLocked(nil, 1, SDL_PEEKEVENT, minType, maxType) > 0
+}
+
+/**
+ * Clear events of a specific type from the event queue.
+ *
+ * This will unconditionally remove any events from the queue that match
+ * `kind`. If you need to remove a range of event types, use
+ * SDL_FlushEvents() instead.
+ *
+ * It's also normal to just ignore events you don't care about in your event
+ * loop without calling this function.
+ *
+ * This function only affects currently queued events. If you want to make
+ * sure that all pending OS events are flushed, you can call SDL_PumpEvents()
+ * on the main thread immediately before the flush call.
+ *
+ * - kind the type of event to be cleared; see SDL_EventType for details.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_FlushEvents
+ */
+func SDL_FlushEvent(kind SDL_EventType) {
+	SDL_FlushEvents(kind, kind)
+}
+
+/**
+ * Clear events of a range of types from the event queue.
+ *
+ * This will unconditionally remove any events from the queue that are in the
+ * range of `minType` to `maxType`, inclusive. If you need to remove a single
+ * event type, use SDL_FlushEvent() instead.
+ *
+ * It's also normal to just ignore events you don't care about in your event
+ * loop without calling this function.
+ *
+ * This function only affects currently queued events. If you want to make
+ * sure that all pending OS events are flushed, you can call SDL_PumpEvents()
+ * on the main thread immediately before the flush call.
+ *
+ * - minType the low end of event type to be cleared, inclusive; see
+ *                SDL_EventType for details.
+ * - maxType the high end of event type to be cleared, inclusive; see
+ *                SDL_EventType for details.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_FlushEvent
+ */
+func SDL_FlushEvents(minType, maxType SDL_EventType) {
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	/* Don't look after we've quit */
+	if !eventQ.active {
+		return
+	}
+
+	eventQ.takeLocked(nil, minType, maxType)
+}
+
+/*
+ * Take the oldest event in the [minType, maxType] range from the queue, or
+ * just report whether there is one when event is nil.
+ */
+func sdlTakeEvent(event *SDL_Event, minType, maxType SDL_EventType) bool {
+	var buf [1]SDL_Event
+	var events []SDL_Event
+	if event != nil {
+		events = buf[:]
+	}
+
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	if !eventQ.active || eventQ.peepLocked(events, 1, SDL_GETEVENT, minType, maxType) == 0 {
+		return false
+	}
+	if event != nil {
+		*event = buf[0]
+	}
+	return true
+}
+
+/**
+ * Poll for currently pending events.
+ *
+ * If `event` is not nil, the next event is removed from the queue and stored
+ * in the SDL_Event structure pointed to by `event`. The true returned refers
+ * to this event, immediately stored in the SDL Event structure -- not an
+ * event to follow.
+ *
+ * If `event` is nil, it simply returns true if there is an event in the
+ * queue, but will not remove it from the queue.
+ *
+ * This function implicitly pumps the queue, see SDL_PumpEvents(). Called
+ * from another goroutine than the main one, pumping is left to the main
+ * goroutine and only events it already queued are seen.
+ *
+ * SDL_PollEvent() is the favored way of receiving system events since it can
+ * be done from the main loop and does not suspend the main loop while waiting
+ * on an event to be posted.
+ *
+ * The common practice is to fully process the event queue once every frame,
+ * usually as a first step before updating the game's state:
+ *
+ * ```go
+ * for game_is_still_running {
+ *     var event sdl.SDL_Event
+ *     for sdl.SDL_PollEvent(&event) {  // poll until all events are handled!
+ *         // decide what to do with this event.
+ *     }
+ *
+ *     // update game state, draw the current frame
+ * }
+ * ```
+ *
+ * - event the SDL_Event structure to be filled with the next event from
+ *              the queue, or nil.
+ * Returns true if this got an event or false if there are none available.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_PushEvent
+ * See also SDL_WaitEvent
+ */
+func SDL_PollEvent(event *SDL_Event) bool {
+	return sdlWaitEventTimeoutNS(event, 0)
+}
+
+/**
+ * Wait indefinitely for the next available event.
+ *
+ * If `event` is not nil, the next event is removed from the queue and stored
+ * in the SDL_Event structure pointed to by `event`.
+ *
+ * This function implicitly pumps the queue while waiting, see
+ * SDL_PumpEvents(). Called from another goroutine than the main one, the
+ * main goroutine is asked to pump, so events arrive once it does.
+ *
+ * - event the SDL_Event structure to be filled in with the next event
+ *              from the queue, or nil.
+ * Returns true on success or false if there was an error while waiting for
+ *          events; call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_PollEvent
+ * See also SDL_PushEvent
+ * See also SDL_WaitEventTimeout
+ */
+func SDL_WaitEvent(event *SDL_Event) bool {
+	return sdlWaitEventTimeoutNS(event, -1)
+}
+
+/**
+ * Wait until the specified timeout (in milliseconds) for the next available
+ * event.
+ *
+ * If `event` is not nil, the next event is removed from the queue and stored
+ * in the SDL_Event structure pointed to by `event`.
+ *
+ * This function implicitly pumps the queue while waiting, see
+ * SDL_PumpEvents(). The wait itself blocks on the queue, it wakes up as soon
+ * as an event is pushed or device input arrives rather than polling, and
+ * the timeout is normally honored within a millisecond.
+ *
+ * The timeout is not guaranteed, the actual wait time could be longer due to
+ * system scheduling.
+ *
+ * - event the SDL_Event structure to be filled in with the next event
+ *              from the queue, or nil.
+ * - timeoutMS the maximum number of milliseconds to wait for the next
+ *                  available event, or -1 to wait indefinitely.
+ * Returns true if this got an event or false if the timeout elapsed without
+ *          any events available.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_PollEvent
+ * See also SDL_PushEvent
+ * See also SDL_WaitEvent
+ * See also SDL_WaitEventContext
+ */
+func SDL_WaitEventTimeout(event *SDL_Event, timeoutMS int32) bool {
+	timeoutNS := int64(-1)
+	if timeoutMS >= 0 {
+		timeoutNS = int64(SDL_MS_TO_NS(uint64(timeoutMS)))
+	}
+	return sdlWaitEventTimeoutNS(event, timeoutNS)
+}
+
+/**
+ * Wait for the next available event until a context is done.
+ *
+ * This works like SDL_WaitEvent(), but returns false when `ctx` is
+ * canceled or its deadline passes, so a goroutine running an event loop can
+ * be shut down without pushing a dummy event:
+ *
+ * ```go
+ * var event sdl.SDL_Event
+ * for sdl.SDL_WaitEventContext(ctx, &event) {
+ *     // decide what to do with this event.
+ * }
+ * ```
+ *
+ * - ctx the context bounding the wait.
+ * - event the SDL_Event structure to be filled in with the next event
+ *              from the queue, or nil.
+ * Returns true if this got an event or false if `ctx` is done or there was
+ *          an error while waiting for events; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_WaitEvent
+ * See also SDL_WaitEventTimeout
+ */
+func SDL_WaitEventContext(ctx context.Context, event *SDL_Event) bool {
+	if ctx == nil {
+		return SDL_InvalidParamError("ctx")
+	}
+	if err := ctx.Err(); err != nil {
+		return SDL_SetError(err.Error())
+	}
+	if sdlWaitEventRange(event, SDL_EVENT_FIRST, SDL_EVENT_LAST, -1, ctx.Done()) {
+		return true
+	}
+	if err := ctx.Err(); err != nil {
+		return SDL_SetError(err.Error())
+	}
+	return false
+}
+
+/* Poll or wait for an event of any type, see sdlWaitEventRange() */
+func sdlWaitEventTimeoutNS(event *SDL_Event, timeoutNS int64) bool {
+	return sdlWaitEventRange(event, SDL_EVENT_FIRST, SDL_EVENT_LAST, timeoutNS, nil)
+}
+
+/*
+ * The core of polling and waiting: pump, then take an event in the
+ * [minType, maxType] range, waiting up to timeoutNS nanoseconds for one to
+ * arrive. A negative timeout waits forever, 0 polls. Closing cancel stops
+ * the wait early, and false is returned without setting an error.
+ */
+func sdlWaitEventRange(event *SDL_Event, minType, maxType SDL_EventType, timeoutNS int64, cancel <-chan struct{}) bool {
+	var deadline time.Time
+	if timeoutNS > 0 {
+		deadline = time.Now().Add(time.Duration(timeoutNS))
+	}
+
+	for {
+		/* Get the wakeup channel first, so nothing pushed or requested after looking is missed */
+		eventQ.lock.Lock()
+		active, wakeup := eventQ.active, eventQ.wakeup
+		eventQ.lock.Unlock()
+
+		sdlPumpEventsProxied()
+		if sdlTakeEvent(event, minType, maxType) {
+			return true
+		}
+		if timeoutNS == 0 {
+			return false
+		}
+		if !active {
+			return SDL_SetError("The event system has been shut down")
+		}
+
+		/*
+		 * Sleep until an event is pushed, the deadline passes or a pump has
+		 * input waiting. Go timers wake up within microseconds of their
+		 * deadline on the supported platforms, there's no need to poll.
+		 */
+		wait := time.Duration(-1)
+		if timeoutNS > 0 {
+			wait = time.Until(deadline)
+			if wait <= 0 {
+				return false
+			}
+		}
+
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if wait >= 0 {
+			timer = time.NewTimer(wait)
+			timeout = timer.C
+		}
+		cancelled := false
+		select {
+		case <-wakeup:
+		case <-timeout:
+		case <-cancel:
+			cancelled = true
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if cancelled {
+			return false
+		}
+	}
+}
+
+/**
+ * Add an event to the event queue.
+ *
+ * The event queue can actually be used as a two way communication channel.
+ * Not only can events be read from the queue, but the user can also push
+ * their own events onto it. `event` is a pointer to the event structure you
+ * wish to push onto the queue. The event is copied into the queue, and the
+ * caller may dispose of the memory pointed to after SDL_PushEvent() returns.
+ *
+ * Note: Pushing device input events onto the queue doesn't modify the state
+ * of the device within SDL.
+ *
+ * For pushing application-specific events, please use SDL_RegisterEvents() to
+ * get an event type that does not conflict with other code that also wants
+ * its own custom event types.
+ *
+ * This function is thread-safe, and can be called from other goroutines
+ * safely.
+ *
+ * - event the SDL_Event to be added to the queue.
+ * Returns true on success, false if the event type is disabled or on
+ *          failure; call SDL_GetError() for more information. A common
+ *          reason for error is the event queue being full.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_PollEvent
+ * See also SDL_RegisterEvents
+ * See also SDL_SetEventEnabled
+ */
+func SDL_PushEvent(event *SDL_Event) bool {
+	if event == nil {
+		return SDL_InvalidParamError("event")
+	}
+	if !SDL_EventEnabled(event.Type) {
+		return false
+	}
+	if event.Timestamp == 0 {
+		event.Timestamp = SDL_GetTicksNS()
+	}
+	sdlLogEvent(event)
+
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	/* Don't look after we've quit */
+	if !eventQ.active {
+		return false
+	}
+	return eventQ.addLocked(event)
+}
+
+/*
+ * One bit per event type, set when the type is disabled. Event sources check
+ * it before building an event, so it's read without taking any lock.
+ */
+var disabledEvents [(SDL_EVENT_LAST + 1) / 32]atomic.Uint32
+
+/**
+ * Set the state of processing events by type.
+ *
+ * Disabled event types are dropped at the source: they are never queued and
+ * modules generating them skip the work of building them. Disabling a type
+ * also removes the events of that type already in the queue.
+ *
+ * - kind the type of event; see SDL_EventType for details.
+ * - enabled whether to process the event or not.
+ *
+ * This function is thread-safe.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_EventEnabled
+ */
+func SDL_SetEventEnabled(kind SDL_EventType, enabled bool) {
+	if kind > SDL_EVENT_LAST {
+		return
+	}
+
+	word, bit := &disabledEvents[kind/32], uint32(1)<<(kind%32)
+	if enabled {
+		word.And(^bit)
+	} else if word.Or(bit)&bit == 0 {
+		SDL_FlushEvent(kind)
+	}
+}
+
+/**
+ * Query the state of processing events by type.
+ *
+ * - kind the type of event; see SDL_EventType for details.
+ * Returns true if the event is being processed, false otherwise.
+ *
+ * This function is thread-safe.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetEventEnabled
+ */
+func SDL_EventEnabled(kind SDL_EventType) bool {
+	if kind > SDL_EVENT_LAST {
+		return true
+	}
+	return disabledEvents[kind/32].Load()&(1<<(kind%32)) == 0
+}
+
+/* The next event type handed out by SDL_RegisterEvents() */
+var userEventsLock = sdlMutex{name: "events.user"}
+var userEvents = SDL_EVENT_USER
+
+/**
+ * Allocate a set of user-defined events, and return the beginning event
+ * number for that set of events.
+ *
+ * The whole range SDL_EVENT_USER through SDL_EVENT_LAST is shared by every
+ * caller and never reused, so a request that doesn't fit in what's left of
+ * it fails, rather than handing out types that collide with earlier ones.
+ *
+ * - numevents the number of events to be allocated.
+ * Returns the beginning event number, or 0 if numevents is invalid or if
+ *          there are not enough user-defined events left; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is thread-safe.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_PushEvent
+ */
+func SDL_RegisterEvents(numevents int) SDL_EventType {
+	if numevents <= 0 {
+		SDL_InvalidParamError("numevents")
+		return 0
+	}
+
+	userEventsLock.Lock()
+	defer userEventsLock.Unlock()
+
+	if numevents > int(SDL_EVENT_LAST-userEvents) {
+		SDL_SetErrorf("Couldn't register %d events, only %d user events left", numevents, SDL_EVENT_LAST-userEvents)
+		return 0
+	}
+	event_base := userEvents
+	userEvents += SDL_EventType(numevents)
+	return event_base
+}
+
+/**
+ * Set the maximum number of events in the event queue and what happens when
+ * it is full.
+ *
+ * By default up to 65535 events are queued and further events are discarded,
+ * with SDL_PushEvent() failing. Whenever events are discarded or merged, an
+ * SDL_EVENT_QUEUE_OVERFLOW event is queued reporting how many, so input loss
+ * is never silent.
+ *
+ * Lowering the limit doesn't discard events that are already queued. The
+ * settings are reset to the defaults when the events subsystem is quit.
+ *
+ * - max_events the maximum number of queued events, at least 1.
+ * - policy the SDL_EventQueueOverflowPolicy applied to new events when the
+ *               queue is full.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is thread-safe.
+ *
+ * See also SDL_GetEventQueueLimit
+ */
+func SDL_SetEventQueueLimit(max_events int, policy SDL_EventQueueOverflowPolicy) bool {
+	if max_events < 1 {
+		return SDL_InvalidParamError("max_events")
+	}
+	switch policy {
+	case SDL_EVENT_QUEUE_DROP_NEWEST, SDL_EVENT_QUEUE_DROP_OLDEST, SDL_EVENT_QUEUE_COALESCE_MOTION:
+	default:
+		return SDL_InvalidParamError("policy")
+	}
+
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	eventQ.max_events = max_events
+	eventQ.overflow_policy = policy
+	return true
+}
+
+/**
+ * Get the maximum number of events in the event queue and what happens when
+ * it is full.
+ *
+ * Returns the maximum number of queued events and the overflow policy.
+ *
+ * This function is thread-safe.
+ *
+ * See also SDL_SetEventQueueLimit
+ */
+func SDL_GetEventQueueLimit() (int, SDL_EventQueueOverflowPolicy) {
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	return eventQ.max_events, eventQ.overflow_policy
+}
+
+/*
+ * Channel delivery: a goroutine per subscription waits on the queue and
+ * forwards matching events to a buffered channel. While the channel is full
+ * the forwarder stops taking events, they stay in the queue and the queue
+ * limit and overflow policy decide what happens to new ones.
+ */
+
+/* The channel buffer size of SDL_Events() */
+const sdlEventChannelBuffer = 128
+
+/**
+ * A subscription delivering events through a channel.
+ *
+ * See also SDL_SubscribeEvents
+ */
+type SDL_EventSubscription struct {
+	C <-chan SDL_Event /**< Receives the events, closed when the subscription ends */
+
+	cancel chan struct{} /* closed by SDL_UnsubscribeEvents() */
+	done   chan struct{} /* closed when the forwarding goroutine exits */
+	once   sync.Once
+}
+
+/**
+ * Receive events of a range of types through a channel.
+ *
+ * This is an alternative to SDL_PollEvent() and SDL_WaitEvent() for
+ * programs built around `select` loops. A goroutine takes events in the
+ * [minType, maxType] range from the event queue, pumping it while waiting
+ * like SDL_WaitEvent() does, and sends them to the channel `C` of the
+ * subscription:
+ *
+ * ```go
+ * sub := sdl.SDL_SubscribeEvents(sdl.SDL_EVENT_FIRST, sdl.SDL_EVENT_LAST, 64)
+ * defer sdl.SDL_UnsubscribeEvents(sub)
+ * for {
+ *     select {
+ *     case event, ok := <-sub.C:
+ *         if !ok {
+ *             return // the event system has been shut down
+ *         }
+ *         // decide what to do with this event.
+ *     case <-ticker.C:
+ *         sdl.SDL_PumpEvents()
+ *         // update game state, draw the current frame
+ *     }
+ * }
+ * ```
+ *
+ * The video drivers gather their events on the main thread, so the
+ * subscription can't pump the event queue itself: while the main goroutine
+ * is blocked in `select`, it only leaves a request and waits. The main
+ * goroutine must keep pumping, with SDL_PumpEvents() or any function that
+ * pumps like SDL_PollEvent(), e.g. once per frame as above. Without video
+ * drivers, events pushed from any goroutine are delivered right away.
+ *
+ * Events are taken from the queue, so they are not seen by SDL_PollEvent()
+ * or by other subscriptions with an overlapping range. When the channel
+ * buffer is full, events wait in the event queue until there is room again,
+ * and SDL_SetEventQueueLimit() controls whether new or old events are
+ * dropped once the queue fills up too.
+ *
+ * `C` is closed after SDL_UnsubscribeEvents() or when the event subsystem
+ * is shut down; a subscription must be made after
+ * SDL_Init(SDL_INIT_EVENTS).
+ *
+ * This function is thread-safe.
+ *
+ * - minType the low end of event types to deliver, inclusive.
+ * - maxType the high end of event types to deliver, inclusive.
+ * - buffer the capacity of the channel, 0 for an unbuffered channel.
+ * Returns a new subscription or nil on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_Events
+ * See also SDL_UnsubscribeEvents
+ */
+func SDL_SubscribeEvents(minType, maxType SDL_EventType, buffer int) *SDL_EventSubscription {
+	if buffer < 0 {
+		SDL_InvalidParamError("buffer")
+		return nil
+	}
+
+	eventQ.lock.Lock()
+	active := eventQ.active
+	eventQ.lock.Unlock()
+	if !active {
+		SDL_SetError("The event system has not been initialized")
+		return nil
+	}
+
+	events := make(chan SDL_Event, buffer)
+	sub := &SDL_EventSubscription{C: events, cancel: make(chan struct{}), done: make(chan struct{})}
+	sdlGo(func() {
+		defer close(sub.done)
+		defer close(events)
+
+		var event SDL_Event
+		for sdlWaitEventRange(&event, minType, maxType, -1, sub.cancel) {
+			select {
+			case events <- event:
+			case <-sub.cancel:
+				return
+			}
+		}
+	})
+	return sub
+}
+
+/**
+ * End a subscription made with SDL_SubscribeEvents().
+ *
+ * No more events are taken from the queue for the subscription, and its
+ * channel is closed once the forwarding goroutine has stopped; events still
+ * buffered in the channel can be received until then. It is safe to call
+ * this more than once.
+ *
+ * This function is thread-safe.
+ *
+ * - sub the subscription to end.
+ *
+ * See also SDL_SubscribeEvents
+ */
+func SDL_UnsubscribeEvents(sub *SDL_EventSubscription) {
+	if sub == nil {
+		return
+	}
+	sub.once.Do(func() { close(sub.cancel) })
+}
+
+/* The subscription behind SDL_Events(), guarded by eventsChannelLock */
+var eventsChannelLock = sdlMutex{name: "events.channel"}
+var eventsChannel *SDL_EventSubscription
+
+/**
+ * Get a channel receiving all events.
+ *
+ * This is a shared subscription to every event type, made with
+ * SDL_SubscribeEvents() on first use with a buffer of 128 events; every
+ * call returns the same channel until the event subsystem is shut down, at
+ * which point the channel is closed and the next call after SDL_Init() makes
+ * a new one. See SDL_SubscribeEvents() for how events are delivered; the
+ * main goroutine must keep pumping events for the channel to receive the
+ * events of the video drivers.
+ *
+ * This function is thread-safe.
+ *
+ * Returns a channel receiving the events or nil on failure; call
+ * SDL_GetError() for more information.
+ *
+ * See also SDL_SubscribeEvents
+ */
+func SDL_Events() <-chan SDL_Event {
+	eventsChannelLock.Lock()
+	defer eventsChannelLock.Unlock()
+
+	if eventsChannel != nil {
+		select {
+		case <-eventsChannel.done:
+		default:
+			return eventsChannel.C
+		}
+	}
+
+	eventsChannel = SDL_SubscribeEvents(SDL_EVENT_FIRST, SDL_EVENT_LAST, sdlEventChannelBuffer)
+	if eventsChannel == nil {
+		return nil
+	}
+	return eventsChannel.C
+}
@@ -0,0 +1,104 @@
+package sdl
+
+import "slices"
+import "testing"
+import "time"
+
+/*
+ * How late a wait may return, as the median of several runs to ride out
+ * scheduling noise. The bound is generous so loaded machines don't fail the
+ * tests, it catches waits that sleep in coarse steps or until the timeout.
+ * The latency checks are skipped with -short.
+ */
+const testWaitLatency = 50 * time.Millisecond
+
+/* A timeout that is never reached when a wait works */
+const testWaitNeverMS = 10000
+
+const testWaitRuns = 21
+
+func testInitEvents(t *testing.T) {
+	t.Helper()
+	if !SDL_Init(SDL_INIT_EVENTS) {
+		t.Fatalf("SDL_Init failed: %s", SDL_GetError())
+	}
+	t.Cleanup(SDL_Quit)
+}
+
+func testMedian(samples []time.Duration) time.Duration {
+	slices.Sort(samples)
+	return samples[len(samples)/2]
+}
+
+func TestWaitEventTimeoutWakesOnPush(t *testing.T) {
+	testInitEvents(t)
+
+	latencies := make([]time.Duration, 0, testWaitRuns)
+	for range testWaitRuns {
+		pushed := make(chan time.Time, 1)
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: SDL_EVENT_USER}}
+			pushed <- time.Now()
+			SDL_PushEvent(&event)
+		}()
+
+		var event SDL_Event
+		start := time.Now()
+		if !SDL_WaitEventTimeout(&event, testWaitNeverMS) {
+			t.Fatalf("SDL_WaitEventTimeout didn't get the pushed event: %s", SDL_GetError())
+		}
+		woken := time.Now()
+		if event.Type != SDL_EVENT_USER {
+			t.Fatalf("got event 0x%x, want SDL_EVENT_USER", event.Type)
+		}
+		if woken.Sub(start) >= testWaitNeverMS*time.Millisecond {
+			t.Fatal("SDL_WaitEventTimeout slept until its timeout instead of waking up on the push")
+		}
+		latencies = append(latencies, woken.Sub(<-pushed))
+	}
+
+	if testing.Short() {
+		return
+	}
+	if median := testMedian(latencies); median > testWaitLatency {
+		t.Errorf("median wakeup latency after a push is %v, want at most %v", median, testWaitLatency)
+	}
+}
+
+func TestWaitEventTimeoutDeadline(t *testing.T) {
+	testInitEvents(t)
+
+	const timeoutMS = 5
+	lateness := make([]time.Duration, 0, testWaitRuns)
+	for range testWaitRuns {
+		start := time.Now()
+		if SDL_WaitEventTimeout(nil, timeoutMS) {
+			t.Fatal("SDL_WaitEventTimeout reported an event in an empty queue")
+		}
+		elapsed := time.Since(start)
+		if elapsed < timeoutMS*time.Millisecond {
+			t.Fatalf("SDL_WaitEventTimeout returned after %v, before its %dms timeout", elapsed, timeoutMS)
+		}
+		lateness = append(lateness, elapsed-timeoutMS*time.Millisecond)
+	}
+
+	if testing.Short() {
+		return
+	}
+	if median := testMedian(lateness); median > testWaitLatency {
+		t.Errorf("median lateness of the timeout is %v, want at most %v", median, testWaitLatency)
+	}
+}
+
+func TestWaitEventTimeoutPoll(t *testing.T) {
+	testInitEvents(t)
+
+	start := time.Now()
+	if SDL_WaitEventTimeout(nil, 0) {
+		t.Fatal("SDL_WaitEventTimeout reported an event in an empty queue")
+	}
+	if elapsed := time.Since(start); elapsed > testWaitLatency && !testing.Short() {
+		t.Errorf("a 0 timeout took %v, it should only poll", elapsed)
+	}
+}
@@ -0,0 +1,304 @@
+package sdl
+
+import "math"
+
+/**
+ * The value of Pi, as a double-precision floating point literal.
+ *
+ * This macro is available since SDL 3.0.0.
+ *
+ * See also SDL_PI_F
+ */
+const SDL_PI_D float64 = 3.141592653589793238462643383279502884
+
+/**
+ * The value of Pi, as a single-precision floating point literal.
+ *
+ * This macro is available since SDL 3.0.0.
+ *
+ * See also SDL_PI_D
+ */
+const SDL_PI_F float32 = 3.141592653589793238462643383279502884
+
+/*
+ * Quarter of a sine period sampled at sinTableSize points, plus a guard
+ * entry so the interpolation never reads past the end. The other three
+ * quarters are derived by symmetry.
+ */
+const sinTableSize = 1024
+
+var sinTable [sinTableSize + 1]float32
+
+func init() {
+	for i := range sinTable {
+		sinTable[i] = float32(math.Sin(float64(i) * (math.Pi / 2) / sinTableSize))
+	}
+}
+
+/**
+ * Compute an approximation of the sine of x, using a lookup table with
+ * linear interpolation.
+ *
+ * The absolute error is around 1e-6 within a few periods of 0 and grows
+ * with |x| as float32 runs out of precision. That is plenty for rotating
+ * sprites and geometry, but use SDL_sinf() where accuracy matters.
+ *
+ * - x floating point value, in radians.
+ * Returns approximate sine of `x`.
+ *
+ * See also SDL_FastCosf
+ */
+func SDL_FastSinf(x float32) float32 {
+	/* Map x to table units, a full period is 4 * sinTableSize */
+	t := x * (2 * sinTableSize / SDL_PI_F)
+	if t < 0 {
+		return -SDL_FastSinf(-x)
+	}
+	if !(t < 1<<24) {
+		/* Too large to be represented precisely in table units, or NaN */
+		return SDL_sinf(x)
+	}
+
+	i := int(t)
+	frac := t - float32(i)
+	quadrant := (i / sinTableSize) & 3
+	i %= sinTableSize
+
+	switch quadrant {
+	case 0:
+		return sinTable[i] + (sinTable[i+1]-sinTable[i])*frac
+	case 1:
+		i = sinTableSize - i
+		return sinTable[i] + (sinTable[i-1]-sinTable[i])*frac
+	case 2:
+		return -(sinTable[i] + (sinTable[i+1]-sinTable[i])*frac)
+	default:
+		i = sinTableSize - i
+		return -(sinTable[i] + (sinTable[i-1]-sinTable[i])*frac)
+	}
+}
+
+/**
+ * Compute an approximation of the cosine of x, see SDL_FastSinf().
+ *
+ * - x floating point value, in radians.
+ * Returns approximate cosine of `x`.
+ *
+ * See also SDL_FastSinf
+ */
+func SDL_FastCosf(x float32) float32 {
+	if x < 0 {
+		x = -x
+	}
+	return SDL_FastSinf(x + SDL_PI_F/2)
+}
+
+/**
+ * Compute an approximation of 1/sqrt(x), the classic bit trick refined
+ * by two Newton-Raphson iterations (relative error below 5e-6).
+ *
+ * Values the bit trick can't handle, zero, denormals, infinity and NaN, are
+ * computed exactly, so 0 gives +Inf and +Inf gives 0.
+ *
+ * - x floating point value, must be > 0.
+ * Returns approximate reciprocal square root of `x`.
+ */
+func SDL_FastInvSqrtf(x float32) float32 {
+	if !(x >= 0x1p-126 && x <= math.MaxFloat32) {
+		return 1 / SDL_sqrtf(x)
+	}
+	half := 0.5 * x
+	y := math.Float32frombits(0x5f375a86 - (math.Float32bits(x) >> 1))
+	y = y * (1.5 - half*y*y)
+	y = y * (1.5 - half*y*y)
+	return y
+}
+
+/**
+ * Compute the sine of x.
+ *
+ * - x floating point value, in radians.
+ * Returns sine of `x`.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_cosf
+ */
+func SDL_sinf(x float32) float32 {
+	return float32(math.Sin(float64(x)))
+}
+
+/**
+ * Compute the cosine of x.
+ *
+ * - x floating point value, in radians.
+ * Returns cosine of `x`.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_sinf
+ */
+func SDL_cosf(x float32) float32 {
+	return float32(math.Cos(float64(x)))
+}
+
+/**
+ * Compute the square root of x.
+ *
+ * - x floating point value. Must be greater than or equal to 0.
+ * Returns square root of `x`.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_sqrtf(x float32) float32 {
+	return float32(math.Sqrt(float64(x)))
+}
+
+/*
+ * Vector helpers on SDL_FPoint, used when computing rotated and transformed
+ * geometry.
+ */
+
+// SDL_FPointAdd returns a + b.
+func SDL_FPointAdd(a, b SDL_FPoint) SDL_FPoint {
+	return SDL_FPoint{a.X + b.X, a.Y + b.Y}
+}
+
+// SDL_FPointSub returns a - b.
+func SDL_FPointSub(a, b SDL_FPoint) SDL_FPoint {
+	return SDL_FPoint{a.X - b.X, a.Y - b.Y}
+}
+
+// SDL_FPointScale returns p scaled by s.
+func SDL_FPointScale(p SDL_FPoint, s float32) SDL_FPoint {
+	return SDL_FPoint{p.X * s, p.Y * s}
+}
+
+// SDL_FPointDot returns the dot product of a and b.
+func SDL_FPointDot(a, b SDL_FPoint) float32 {
+	return a.X*b.X + a.Y*b.Y
+}
+
+// SDL_FPointLength returns the euclidean length of p.
+func SDL_FPointLength(p SDL_FPoint) float32 {
+	return SDL_sqrtf(SDL_FPointDot(p, p))
+}
+
+// SDL_FPointNormalize returns p scaled to unit length, the zero vector stays
+// zero.
+func SDL_FPointNormalize(p SDL_FPoint) SDL_FPoint {
+	lengthSquared := SDL_FPointDot(p, p)
+	if lengthSquared == 0 {
+		return p
+	}
+	return SDL_FPointScale(p, SDL_FastInvSqrtf(lengthSquared))
+}
+
+/**
+ * Rotate a point around a center, clockwise in screen coordinates (y down),
+ * matching the angle convention of SDL_RenderTextureRotated().
+ *
+ * - p the point to rotate.
+ * - center the point to rotate around.
+ * - angle the rotation in degrees.
+ * Returns the rotated point.
+ */
+func SDL_RotateFPoint(p, center SDL_FPoint, angle float64) SDL_FPoint {
+	rad := float32(angle * (SDL_PI_D / 180))
+	s, c := SDL_FastSinf(rad), SDL_FastCosf(rad)
+	d := SDL_FPointSub(p, center)
+	return SDL_FPoint{
+		X: center.X + d.X*c - d.Y*s,
+		Y: center.Y + d.X*s + d.Y*c,
+	}
+}
+
+/**
+ * A 2D affine transform, mapping a point (x, y) to
+ *
+ *	x' = A*x + C*y + Tx
+ *	y' = B*x + D*y + Ty
+ *
+ * The zero value is not the identity, use SDL_IdentityTransform().
+ */
+type SDL_AffineTransform struct {
+	A, B   float32
+	C, D   float32
+	Tx, Ty float32
+}
+
+// SDL_IdentityTransform returns the transform that leaves points unchanged.
+func SDL_IdentityTransform() SDL_AffineTransform {
+	return SDL_AffineTransform{A: 1, D: 1}
+}
+
+/**
+ * Combine two transforms. The result applies `first` and then `second`.
+ *
+ * - first the transform applied first.
+ * - second the transform applied to the result of `first`.
+ * Returns the combined transform.
+ */
+func SDL_MultiplyTransforms(first, second SDL_AffineTransform) SDL_AffineTransform {
+	return SDL_AffineTransform{
+		A:  second.A*first.A + second.C*first.B,
+		B:  second.B*first.A + second.D*first.B,
+		C:  second.A*first.C + second.C*first.D,
+		D:  second.B*first.C + second.D*first.D,
+		Tx: second.A*first.Tx + second.C*first.Ty + second.Tx,
+		Ty: second.B*first.Tx + second.D*first.Ty + second.Ty,
+	}
+}
+
+// SDL_TranslateTransform returns t followed by a translation of (dx, dy).
+func SDL_TranslateTransform(t SDL_AffineTransform, dx, dy float32) SDL_AffineTransform {
+	t.Tx += dx
+	t.Ty += dy
+	return t
+}
+
+// SDL_ScaleTransform returns t followed by a scale of (sx, sy) around the
+// origin.
+func SDL_ScaleTransform(t SDL_AffineTransform, sx, sy float32) SDL_AffineTransform {
+	return SDL_MultiplyTransforms(t, SDL_AffineTransform{A: sx, D: sy})
+}
+
+// SDL_RotateTransform returns t followed by a rotation of angle degrees
+// around the origin, clockwise in screen coordinates like SDL_RotateFPoint().
+func SDL_RotateTransform(t SDL_AffineTransform, angle float64) SDL_AffineTransform {
+	rad := float32(angle * (SDL_PI_D / 180))
+	s, c := SDL_FastSinf(rad), SDL_FastCosf(rad)
+	return SDL_MultiplyTransforms(t, SDL_AffineTransform{A: c, B: s, C: -s, D: c})
+}
+
+/**
+ * Compute the inverse of a transform.
+ *
+ * - t the transform to invert.
+ * - result filled in with the inverse of `t`.
+ * Returns true on success or false if `t` is not invertible.
+ */
+func SDL_InvertTransform(t SDL_AffineTransform, result *SDL_AffineTransform) bool {
+	det := t.A*t.D - t.B*t.C
+	if det == 0 {
+		return SDL_SetError("Transform is not invertible")
+	}
+	inv := 1 / det
+	*result = SDL_AffineTransform{
+		A:  t.D * inv,
+		B:  -t.B * inv,
+		C:  -t.C * inv,
+		D:  t.A * inv,
+		Tx: (t.C*t.Ty - t.D*t.Tx) * inv,
+		Ty: (t.B*t.Tx - t.A*t.Ty) * inv,
+	}
+	return true
+}
+
+// SDL_TransformFPoint applies t to p.
+func SDL_TransformFPoint(t SDL_AffineTransform, p SDL_FPoint) SDL_FPoint {
+	return SDL_FPoint{
+		X: t.A*p.X + t.C*p.Y + t.Tx,
+		Y: t.B*p.X + t.D*p.Y + t.Ty,
+	}
+}
@@ -0,0 +1,121 @@
+package sdl
+
+import "math"
+import "testing"
+
+/* The sampled range, a few periods around 0 where the documented error holds */
+const testFastMathRange = 8 * math.Pi
+
+const testFastMathSamples = 100000
+
+func testFastMathInputs() []float32 {
+	inputs := make([]float32, testFastMathSamples)
+	for i := range inputs {
+		inputs[i] = float32(-testFastMathRange + 2*testFastMathRange*float64(i)/(testFastMathSamples-1))
+	}
+	return inputs
+}
+
+func TestFastSinfAccuracy(t *testing.T) {
+	for _, x := range testFastMathInputs() {
+		if diff := math.Abs(float64(SDL_FastSinf(x)) - math.Sin(float64(x))); diff > 2e-6 {
+			t.Fatalf("SDL_FastSinf(%g) is off by %g", x, diff)
+		}
+	}
+}
+
+func TestFastCosfAccuracy(t *testing.T) {
+	for _, x := range testFastMathInputs() {
+		if diff := math.Abs(float64(SDL_FastCosf(x)) - math.Cos(float64(x))); diff > 2e-6 {
+			t.Fatalf("SDL_FastCosf(%g) is off by %g", x, diff)
+		}
+	}
+}
+
+func TestFastInvSqrtfAccuracy(t *testing.T) {
+	for _, x := range []float32{0x1p-126, 1e-30, 1e-6, 0.25, 1, 2, 3, 1000, 1e20, math.MaxFloat32} {
+		want := 1 / math.Sqrt(float64(x))
+		if diff := math.Abs(float64(SDL_FastInvSqrtf(x))-want) / want; diff > 5e-6 {
+			t.Errorf("SDL_FastInvSqrtf(%g) has a relative error of %g", x, diff)
+		}
+	}
+}
+
+func TestFastMathSpecialValues(t *testing.T) {
+	nan := float32(math.NaN())
+	inf := float32(math.Inf(1))
+
+	for _, x := range []float32{nan, inf, -inf} {
+		if result := SDL_FastSinf(x); !math.IsNaN(float64(result)) {
+			t.Errorf("SDL_FastSinf(%g) = %g, want NaN", x, result)
+		}
+		if result := SDL_FastCosf(x); !math.IsNaN(float64(result)) {
+			t.Errorf("SDL_FastCosf(%g) = %g, want NaN", x, result)
+		}
+	}
+
+	tests := []struct {
+		x, want float32
+	}{
+		{0, inf},
+		{inf, 0},
+		{nan, nan},
+		{-1, nan},
+		{0x1p-149, 1 / SDL_sqrtf(0x1p-149)},
+	}
+	for _, test := range tests {
+		result := SDL_FastInvSqrtf(test.x)
+		if math.IsNaN(float64(test.want)) {
+			if !math.IsNaN(float64(result)) {
+				t.Errorf("SDL_FastInvSqrtf(%g) = %g, want NaN", test.x, result)
+			}
+		} else if result != test.want {
+			t.Errorf("SDL_FastInvSqrtf(%g) = %g, want %g", test.x, result, test.want)
+		}
+	}
+}
+
+/* Keeps the compiler from dropping the benchmarked calls */
+var testFastMathSink float32
+
+func BenchmarkFastSinf(b *testing.B) {
+	inputs := testFastMathInputs()
+	for i := 0; i < b.N; i++ {
+		testFastMathSink += SDL_FastSinf(inputs[i%len(inputs)])
+	}
+}
+
+func BenchmarkMathSin(b *testing.B) {
+	inputs := testFastMathInputs()
+	for i := 0; i < b.N; i++ {
+		testFastMathSink += float32(math.Sin(float64(inputs[i%len(inputs)])))
+	}
+}
+
+func BenchmarkFastCosf(b *testing.B) {
+	inputs := testFastMathInputs()
+	for i := 0; i < b.N; i++ {
+		testFastMathSink += SDL_FastCosf(inputs[i%len(inputs)])
+	}
+}
+
+func BenchmarkMathCos(b *testing.B) {
+	inputs := testFastMathInputs()
+	for i := 0; i < b.N; i++ {
+		testFastMathSink += float32(math.Cos(float64(inputs[i%len(inputs)])))
+	}
+}
+
+func BenchmarkFastInvSqrtf(b *testing.B) {
+	inputs := testFastMathInputs()
+	for i := 0; i < b.N; i++ {
+		testFastMathSink += SDL_FastInvSqrtf(1 + inputs[i%len(inputs)]*inputs[i%len(inputs)])
+	}
+}
+
+func BenchmarkMathInvSqrt(b *testing.B) {
+	inputs := testFastMathInputs()
+	for i := 0; i < b.N; i++ {
+		testFastMathSink += float32(1 / math.Sqrt(float64(1+inputs[i%len(inputs)]*inputs[i%len(inputs)])))
+	}
+}
@@ -0,0 +1,756 @@
+package sdl
+
+import "unsafe"
+
+/*
+ * OpenGL support.
+ *
+ * The attributes requested with SDL_GL_SetAttribute() are kept in the video
+ * device and read by the driver when it creates a context. The driver does
+ * the platform work through the GL_* hooks of the device, this file keeps
+ * the state every platform shares.
+ *
+ * The browser driver is the only one with OpenGL support so far, it creates
+ * WebGL contexts, see video_js.go. The native backends (GLX, EGL, WGL,
+ * NSOpenGL) have to call into the system libraries through cgo and are
+ * follow-up work. With the other drivers SDL_GL_LoadLibrary(), and creating
+ * a window with SDL_WINDOW_OPENGL, fail with an error.
+ *
+ * The C library tracks the current context per thread. Go has no
+ * goroutine-local storage, so the current window and context are kept by
+ * goroutine id, like the error messages. A GL context is bound to an OS
+ * thread, a goroutine using one must call runtime.LockOSThread() first.
+ *
+ * No driver implements the GL_GetProcAddress hook yet: WebGL functions are
+ * methods of the context object rather than function pointers. Until a
+ * native backend does, SDL_GL_GetProcAddress() always fails, Go OpenGL
+ * bindings can't load their functions through it.
+ */
+
+/**
+ * A generic function pointer.
+ *
+ * In theory, generic function pointers should use this, instead of
+ * `unsafe.Pointer`, since some platforms could treat code addresses
+ * differently than data addresses. It is an alias so the functions returning
+ * it fit the loader callbacks of Go bindings.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_GetProcAddress
+ */
+type SDL_FunctionPointer = unsafe.Pointer
+
+/**
+ * An opaque handle to an OpenGL context.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_CreateContext
+ */
+type SDL_GLContext *SDL_GLContextState
+
+/* The state of a context, owned by the video driver that created it */
+type SDL_GLContextState struct {
+	driverdata any
+}
+
+/**
+ * An enumeration of OpenGL configuration attributes.
+ *
+ * While you can set most OpenGL attributes normally, the attributes listed
+ * above must be known before SDL creates the window that will be used with
+ * the OpenGL context. These attributes are set and read with
+ * SDL_GL_SetAttribute() and SDL_GL_GetAttribute().
+ *
+ * In some cases, these attributes are minimum requests; the GL does not
+ * promise to give you exactly what you asked for. It's possible to ask for a
+ * 16-bit depth buffer and get a 24-bit one instead, for example, or to ask
+ * for no stencil buffer and still have one available. Context creation should
+ * fail if the GL can't provide your requested attributes at a minimum, but
+ * you should check to see exactly what you got.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_GLAttr int
+
+const (
+	SDL_GL_RED_SIZE                   SDL_GLAttr = iota /**< the minimum number of bits for the red channel of the color buffer; defaults to 8. */
+	SDL_GL_GREEN_SIZE                                   /**< the minimum number of bits for the green channel of the color buffer; defaults to 8. */
+	SDL_GL_BLUE_SIZE                                    /**< the minimum number of bits for the blue channel of the color buffer; defaults to 8. */
+	SDL_GL_ALPHA_SIZE                                   /**< the minimum number of bits for the alpha channel of the color buffer; defaults to 8. */
+	SDL_GL_BUFFER_SIZE                                  /**< the minimum number of bits for frame buffer size; defaults to 0. */
+	SDL_GL_DOUBLEBUFFER                                 /**< whether the output is single or double buffered; defaults to double buffering on. */
+	SDL_GL_DEPTH_SIZE                                   /**< the minimum number of bits in the depth buffer; defaults to 24. */
+	SDL_GL_STENCIL_SIZE                                 /**< the minimum number of bits in the stencil buffer; defaults to 0. */
+	SDL_GL_ACCUM_RED_SIZE                               /**< the minimum number of bits for the red channel of the accumulation buffer; defaults to 0. */
+	SDL_GL_ACCUM_GREEN_SIZE                             /**< the minimum number of bits for the green channel of the accumulation buffer; defaults to 0. */
+	SDL_GL_ACCUM_BLUE_SIZE                              /**< the minimum number of bits for the blue channel of the accumulation buffer; defaults to 0. */
+	SDL_GL_ACCUM_ALPHA_SIZE                             /**< the minimum number of bits for the alpha channel of the accumulation buffer; defaults to 0. */
+	SDL_GL_STEREO                                       /**< whether the output is stereo 3D; defaults to off. */
+	SDL_GL_MULTISAMPLEBUFFERS                           /**< the number of buffers used for multisample anti-aliasing; defaults to 0. */
+	SDL_GL_MULTISAMPLESAMPLES                           /**< the number of samples used around the current pixel used for multisample anti-aliasing. */
+	SDL_GL_ACCELERATED_VISUAL                           /**< set to 1 to require hardware acceleration, set to 0 to force software rendering; defaults to allow either. */
+	SDL_GL_RETAINED_BACKING                             /**< not used (deprecated). */
+	SDL_GL_CONTEXT_MAJOR_VERSION                        /**< OpenGL context major version. */
+	SDL_GL_CONTEXT_MINOR_VERSION                        /**< OpenGL context minor version. */
+	SDL_GL_CONTEXT_FLAGS                                /**< some combination of 0 or more of elements of the SDL_GLContextFlag enumeration; defaults to 0. */
+	SDL_GL_CONTEXT_PROFILE_MASK                         /**< type of GL context (Core, Compatibility, ES). See SDL_GLProfile; default value depends on platform. */
+	SDL_GL_SHARE_WITH_CURRENT_CONTEXT                   /**< OpenGL context sharing; defaults to 0. */
+	SDL_GL_FRAMEBUFFER_SRGB_CAPABLE                     /**< requests sRGB capable visual; defaults to 0. */
+	SDL_GL_CONTEXT_RELEASE_BEHAVIOR                     /**< sets context the release behavior. See SDL_GLContextReleaseFlag; defaults to FLUSH. */
+	SDL_GL_CONTEXT_RESET_NOTIFICATION                   /**< set context reset notification. See SDL_GLContextResetNotification; defaults to NO_NOTIFICATION. */
+	SDL_GL_CONTEXT_NO_ERROR
+	SDL_GL_FLOATBUFFERS
+	SDL_GL_EGL_PLATFORM
+)
+
+/**
+ * Possible values to be set for the SDL_GL_CONTEXT_PROFILE_MASK attribute.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_GLProfile uint32
+
+const (
+	SDL_GL_CONTEXT_PROFILE_CORE          SDL_GLProfile = 0x0001 /**< OpenGL Core Profile context */
+	SDL_GL_CONTEXT_PROFILE_COMPATIBILITY SDL_GLProfile = 0x0002 /**< OpenGL Compatibility Profile context */
+	SDL_GL_CONTEXT_PROFILE_ES            SDL_GLProfile = 0x0004 /**< GLX_CONTEXT_ES2_PROFILE_BIT_EXT */
+)
+
+/**
+ * Possible flags to be set for the SDL_GL_CONTEXT_FLAGS attribute.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_GLContextFlag uint32
+
+const (
+	SDL_GL_CONTEXT_DEBUG_FLAG              SDL_GLContextFlag = 0x0001
+	SDL_GL_CONTEXT_FORWARD_COMPATIBLE_FLAG SDL_GLContextFlag = 0x0002
+	SDL_GL_CONTEXT_ROBUST_ACCESS_FLAG      SDL_GLContextFlag = 0x0004
+	SDL_GL_CONTEXT_RESET_ISOLATION_FLAG    SDL_GLContextFlag = 0x0008
+)
+
+/**
+ * Possible values to be set for the SDL_GL_CONTEXT_RELEASE_BEHAVIOR
+ * attribute.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_GLContextReleaseFlag uint32
+
+const (
+	SDL_GL_CONTEXT_RELEASE_BEHAVIOR_NONE  SDL_GLContextReleaseFlag = 0x0000
+	SDL_GL_CONTEXT_RELEASE_BEHAVIOR_FLUSH SDL_GLContextReleaseFlag = 0x0001
+)
+
+/**
+ * Possible values to be set SDL_GL_CONTEXT_RESET_NOTIFICATION attribute.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_GLContextResetNotification uint32
+
+const (
+	SDL_GL_CONTEXT_RESET_NO_NOTIFICATION SDL_GLContextResetNotification = 0x0000
+	SDL_GL_CONTEXT_RESET_LOSE_CONTEXT    SDL_GLContextResetNotification = 0x0001
+)
+
+/* The attribute values, indexed by SDL_GLAttr */
+type sdlGLConfig [SDL_GL_EGL_PLATFORM + 1]int
+
+/* The attribute defaults, see SDL_GL_ResetAttributes() */
+var sdlDefaultGLConfig = func() (config sdlGLConfig) {
+	config[SDL_GL_RED_SIZE] = 8
+	config[SDL_GL_GREEN_SIZE] = 8
+	config[SDL_GL_BLUE_SIZE] = 8
+	config[SDL_GL_ALPHA_SIZE] = 8
+	config[SDL_GL_DOUBLEBUFFER] = 1
+	config[SDL_GL_DEPTH_SIZE] = 24
+	config[SDL_GL_ACCELERATED_VISUAL] = -1 /* either */
+	config[SDL_GL_RETAINED_BACKING] = 1
+	config[SDL_GL_CONTEXT_MAJOR_VERSION] = 2
+	config[SDL_GL_CONTEXT_MINOR_VERSION] = 1
+	config[SDL_GL_FRAMEBUFFER_SRGB_CAPABLE] = -1 /* either */
+	config[SDL_GL_CONTEXT_RELEASE_BEHAVIOR] = int(SDL_GL_CONTEXT_RELEASE_BEHAVIOR_FLUSH)
+	config[SDL_GL_CONTEXT_RESET_NOTIFICATION] = int(SDL_GL_CONTEXT_RESET_NO_NOTIFICATION)
+	return config
+}()
+
+/* The window and context made current by a goroutine */
+type sdlGLCurrent struct {
+	window  *SDL_Window
+	context SDL_GLContext
+}
+
+/* Private data -- current contexts by goroutine id, guarded by glCurrentLock */
+var glCurrentLock = sdlMutex{name: "video.gl_current"}
+var glCurrent = map[uint64]sdlGLCurrent{}
+
+func sdlGetGLCurrent() sdlGLCurrent {
+	glCurrentLock.Lock()
+	defer glCurrentLock.Unlock()
+
+	return glCurrent[goroutineID()]
+}
+
+func sdlSetGLCurrent(current sdlGLCurrent) {
+	glCurrentLock.Lock()
+	defer glCurrentLock.Unlock()
+
+	if current.context == nil {
+		delete(glCurrent, goroutineID())
+	} else {
+		glCurrent[goroutineID()] = current
+	}
+}
+
+/* Forget a window or context on every goroutine that has it current, when it is destroyed */
+func sdlForgetGLCurrent(window *SDL_Window, context SDL_GLContext) {
+	glCurrentLock.Lock()
+	defer glCurrentLock.Unlock()
+
+	for id, current := range glCurrent {
+		if (window != nil && current.window == window) || (context != nil && current.context == context) {
+			delete(glCurrent, id)
+		}
+	}
+}
+
+/**
+ * Dynamically load an OpenGL library.
+ *
+ * This should be done after initializing the video driver, but before
+ * creating any OpenGL windows. If no OpenGL library is loaded, the default
+ * library will be loaded upon creation of the first OpenGL window.
+ *
+ * If you do this, you need to retrieve all of the GL functions used in your
+ * program from the dynamic library using SDL_GL_GetProcAddress().
+ *
+ * - path the platform dependent OpenGL library name, or "" to open the
+ *             default OpenGL library.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_UnloadLibrary
+ */
+func SDL_GL_LoadLibrary(path string) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+
+	if device.gl_library_loaded > 0 {
+		if path != "" && path != device.gl_library_path {
+			return SDL_SetError("OpenGL library already loaded")
+		}
+	} else {
+		if device.GL_LoadLibrary == nil {
+			return SDL_SetErrorf("No dynamic GL support in current SDL video driver (%s)", device.name)
+		}
+		if !device.GL_LoadLibrary(device, path) {
+			return false
+		}
+		device.gl_library_path = path
+	}
+	device.gl_library_loaded++
+	return true
+}
+
+/**
+ * Get an OpenGL function by name.
+ *
+ * If the GL library is loaded at runtime with SDL_GL_LoadLibrary(), then all
+ * GL functions must be retrieved this way. Usually this is used to retrieve
+ * function pointers to OpenGL extensions.
+ *
+ * There are some quirks to looking up OpenGL functions that require some
+ * extra care from the application. If you code carefully, you can handle
+ * these quirks without any platform-specific code, though:
+ *
+ * - On Windows, function pointers are specific to the current GL context;
+ *   this means you need to have created a GL context and made it current
+ *   before calling SDL_GL_GetProcAddress(). If you recreate your context or
+ *   create a second context, you should assume that any existing function
+ *   pointers aren't valid to use with it. This is (currently) a
+ *   Windows-specific limitation, and in practice lots of drivers don't suffer
+ *   this limitation, but it is still the way the wgl API is documented to
+ *   work and you should expect crashes if you don't respect it. Store a copy
+ *   of the function pointers that comes and goes with context lifespan.
+ * - On X11, function pointers returned by this function are valid for any
+ *   context, and can even be looked up before a context is created at all.
+ *   This means that, for at least some common OpenGL implementations, if you
+ *   look up a function that doesn't exist, you'll get a non-nil result that
+ *   is _NOT_ safe to call. You must always make sure the function is actually
+ *   available for a given GL context before calling it, by checking for the
+ *   existence of the appropriate extension in GL_EXTENSIONS, or verifying that the version of OpenGL you're using offers the function
+ *   as core functionality.
+ * - Some OpenGL drivers, on all platforms, *will* return nil if a function
+ *   isn't supported, but you can't count on this behavior. Check for
+ *   extensions you use, and if you get a nil anyway, act as if that
+ *   extension wasn't available. This is probably a bug in the driver, but you
+ *   can code defensively for this scenario anyhow.
+ * - Just because you're on Linux/Unix, don't assume you'll be using X11.
+ *   Next-gen display servers are waiting to replace it, and may or may not
+ *   make the same promises about function pointers.
+ * - OpenGL function pointers must be called with the platform calling
+ *   convention, through cgo or a binding generated for it, the Go OpenGL
+ *   bindings take care of this.
+ *
+ * None of the video drivers of this port can look up OpenGL functions yet,
+ * so this function currently always returns nil with an error set. With
+ * the browser driver, use the WebGL context from the
+ * SDL_PROP_WINDOW_JS_WEBGL_CONTEXT_POINTER window property instead.
+ *
+ * - proc the name of an OpenGL function.
+ * Returns a pointer to the named OpenGL function. The returned pointer
+ *          should be cast to the appropriate function signature.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_LoadLibrary
+ */
+func SDL_GL_GetProcAddress(proc string) SDL_FunctionPointer {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return nil
+	}
+	if device.gl_library_loaded == 0 {
+		SDL_SetError("No GL driver has been loaded")
+		return nil
+	}
+	if device.GL_GetProcAddress == nil {
+		SDL_SetErrorf("No dynamic GL support in current SDL video driver (%s)", device.name)
+		return nil
+	}
+	return device.GL_GetProcAddress(device, proc)
+}
+
+/**
+ * Unload the OpenGL library previously loaded by SDL_GL_LoadLibrary().
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_LoadLibrary
+ */
+func SDL_GL_UnloadLibrary() {
+	device := sdlGetVideoDevice()
+	if device == nil || device.gl_library_loaded == 0 {
+		return
+	}
+
+	device.gl_library_loaded--
+	if device.gl_library_loaded == 0 {
+		if device.GL_UnloadLibrary != nil {
+			device.GL_UnloadLibrary(device)
+		}
+		device.gl_library_path = ""
+	}
+}
+
+/**
+ * Reset all previously set OpenGL context attributes to their default values.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_GetAttribute
+ * See also SDL_GL_SetAttribute
+ */
+func SDL_GL_ResetAttributes() {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return
+	}
+	device.gl_config = sdlDefaultGLConfig
+	device.egl_platformattrib_callback = nil
+	device.egl_surfaceattrib_callback = nil
+	device.egl_contextattrib_callback = nil
+	device.egl_attrib_callback_userdata = nil
+}
+
+/**
+ * Set an OpenGL window attribute before window creation.
+ *
+ * This function sets the OpenGL attribute `attr` to `value`. The requested
+ * attributes should be set before creating an OpenGL window. You should use
+ * SDL_GL_GetAttribute() to check the values after creating the OpenGL
+ * context, since the values obtained can differ from the requested ones.
+ *
+ * - attr an SDL_GLAttr enum value specifying the OpenGL attribute to
+ *             set.
+ * - value the desired value for the attribute.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_GetAttribute
+ * See also SDL_GL_ResetAttributes
+ */
+func SDL_GL_SetAttribute(attr SDL_GLAttr, value int) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+	if attr < 0 || int(attr) >= len(device.gl_config) {
+		return SDL_SetErrorf("Unknown OpenGL attribute %d", attr)
+	}
+
+	switch attr {
+	case SDL_GL_CONTEXT_FLAGS:
+		known := SDL_GL_CONTEXT_DEBUG_FLAG | SDL_GL_CONTEXT_FORWARD_COMPATIBLE_FLAG |
+			SDL_GL_CONTEXT_ROBUST_ACCESS_FLAG | SDL_GL_CONTEXT_RESET_ISOLATION_FLAG
+		if SDL_GLContextFlag(value)&^known != 0 {
+			return SDL_SetErrorf("Unknown OpenGL context flag %d", value)
+		}
+	case SDL_GL_CONTEXT_PROFILE_MASK:
+		switch SDL_GLProfile(value) {
+		case 0, SDL_GL_CONTEXT_PROFILE_CORE, SDL_GL_CONTEXT_PROFILE_COMPATIBILITY, SDL_GL_CONTEXT_PROFILE_ES:
+		default:
+			return SDL_SetErrorf("Unknown OpenGL context profile %d", value)
+		}
+	case SDL_GL_CONTEXT_RELEASE_BEHAVIOR:
+		if value != int(SDL_GL_CONTEXT_RELEASE_BEHAVIOR_NONE) && value != int(SDL_GL_CONTEXT_RELEASE_BEHAVIOR_FLUSH) {
+			return SDL_SetErrorf("Unknown OpenGL context release behavior %d", value)
+		}
+	case SDL_GL_CONTEXT_RESET_NOTIFICATION:
+		if value != int(SDL_GL_CONTEXT_RESET_NO_NOTIFICATION) && value != int(SDL_GL_CONTEXT_RESET_LOSE_CONTEXT) {
+			return SDL_SetErrorf("Unknown OpenGL context reset notification %d", value)
+		}
+	}
+	device.gl_config[attr] = value
+	return true
+}
+
+/**
+ * Get the actual value for an attribute from the current context.
+ *
+ * Before a context is created this is the value requested with
+ * SDL_GL_SetAttribute(). Once a context is current, drivers that can query
+ * it report what the context actually got.
+ *
+ * - attr an SDL_GLAttr enum value specifying the OpenGL attribute to
+ *             get.
+ * - value a pointer filled in with the current value of `attr`.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_ResetAttributes
+ * See also SDL_GL_SetAttribute
+ */
+func SDL_GL_GetAttribute(attr SDL_GLAttr, value *int) bool {
+	if value == nil {
+		return SDL_InvalidParamError("value")
+	}
+	*value = 0
+
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+	if attr < 0 || int(attr) >= len(device.gl_config) {
+		return SDL_SetErrorf("Unknown OpenGL attribute %d", attr)
+	}
+
+	if current := sdlGetGLCurrent(); current.context != nil && device.GL_GetAttribute != nil {
+		if actual, ok := device.GL_GetAttribute(device, current.context, attr); ok {
+			*value = actual
+			return true
+		}
+	}
+	*value = device.gl_config[attr]
+	return true
+}
+
+/**
+ * Create an OpenGL context for an OpenGL window, and make it current.
+ *
+ * The window must have been created with the SDL_WINDOW_OPENGL flag. The
+ * context is created with the attributes set with SDL_GL_SetAttribute(),
+ * sharing objects with the current context if
+ * SDL_GL_SHARE_WITH_CURRENT_CONTEXT is set.
+ *
+ * - window the window to associate with the context.
+ * Returns the OpenGL context associated with `window` or nil on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_DestroyContext
+ * See also SDL_GL_MakeCurrent
+ */
+func SDL_GL_CreateContext(window *SDL_Window) SDL_GLContext {
+	if !sdlCheckWindow(window) {
+		return nil
+	}
+	if window.flags&SDL_WINDOW_OPENGL == 0 {
+		SDL_SetError("The specified window isn't an OpenGL window")
+		return nil
+	}
+
+	device := sdlGetVideoDevice()
+	if device.GL_CreateContext == nil {
+		SDL_SetError("No OpenGL support in video driver")
+		return nil
+	}
+	context := device.GL_CreateContext(device, window)
+	if context == nil {
+		return nil
+	}
+	if !SDL_GL_MakeCurrent(window, context) {
+		SDL_GL_DestroyContext(context)
+		return nil
+	}
+	return context
+}
+
+// GL_CreateContext is SDL_GL_CreateContext() returning a Go error instead of
+// nil.
+func GL_CreateContext(window *SDL_Window) (SDL_GLContext, error) {
+	context := SDL_GL_CreateContext(window)
+	if context == nil {
+		return nil, errorFromResult(false)
+	}
+	return context, nil
+}
+
+/**
+ * Set up an OpenGL context for rendering into an OpenGL window.
+ *
+ * The context must have been created with a compatible window.
+ *
+ * - window the window to associate with the context.
+ * - context the OpenGL context to associate with the window, or nil to
+ *                release the current context.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_CreateContext
+ */
+func SDL_GL_MakeCurrent(window *SDL_Window, context SDL_GLContext) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+
+	if context == nil {
+		window = nil
+	} else if window != nil {
+		if !sdlCheckWindow(window) {
+			return false
+		}
+		if window.flags&SDL_WINDOW_OPENGL == 0 {
+			return SDL_SetError("The specified window isn't an OpenGL window")
+		}
+	} else if !device.gl_allow_no_surface {
+		return SDL_SetError("Use of OpenGL without a window is not supported on this platform")
+	}
+
+	current := sdlGetGLCurrent()
+	if current.window == window && current.context == context {
+		return true
+	}
+	if device.GL_MakeCurrent == nil {
+		if context == nil {
+			return true
+		}
+		return SDL_SetError("No OpenGL support in video driver")
+	}
+	if !device.GL_MakeCurrent(device, window, context) {
+		return false
+	}
+	sdlSetGLCurrent(sdlGLCurrent{window: window, context: context})
+	return true
+}
+
+// GL_MakeCurrent is SDL_GL_MakeCurrent() returning a Go error instead of a
+// boolean.
+func GL_MakeCurrent(window *SDL_Window, context SDL_GLContext) error {
+	return errorFromResult(SDL_GL_MakeCurrent(window, context))
+}
+
+/**
+ * Get the currently active OpenGL window.
+ *
+ * Returns the currently active OpenGL window on success or nil on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GL_GetCurrentWindow() *SDL_Window {
+	if sdlGetVideoDevice() == nil {
+		return nil
+	}
+	return sdlGetGLCurrent().window
+}
+
+/**
+ * Get the currently active OpenGL context.
+ *
+ * Returns the currently active OpenGL context or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_MakeCurrent
+ */
+func SDL_GL_GetCurrentContext() SDL_GLContext {
+	if sdlGetVideoDevice() == nil {
+		return nil
+	}
+	return sdlGetGLCurrent().context
+}
+
+/**
+ * Set the swap interval for the current OpenGL context.
+ *
+ * Some systems allow specifying -1 for the interval, to enable adaptive
+ * vsync. Adaptive vsync works the same as vsync, but if you've already missed
+ * the vertical retrace for a given frame, it swaps buffers immediately, which
+ * might be less jarring for the user during occasional framerate drops. If an
+ * application requests adaptive vsync and the system does not support it,
+ * this function will fail and return false. In such a case, you should
+ * probably retry the call with 1 for the interval.
+ *
+ * - interval 0 for immediate updates, 1 for updates synchronized with
+ *                 the vertical retrace, -1 for adaptive vsync.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_GetSwapInterval
+ */
+func SDL_GL_SetSwapInterval(interval int) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+	if sdlGetGLCurrent().context == nil {
+		return SDL_SetError("No OpenGL context has been made current")
+	}
+	if device.GL_SetSwapInterval == nil {
+		return SDL_SetError("Setting the swap interval is not supported")
+	}
+	return device.GL_SetSwapInterval(device, interval)
+}
+
+/**
+ * Get the swap interval for the current OpenGL context.
+ *
+ * If the system can't determine the swap interval, or there's no valid
+ * current context, this function will set *interval to 0 as a safe default.
+ *
+ * - interval output interval value. 0 if there is no vertical retrace
+ *                 synchronization, 1 if the buffer swap is synchronized with
+ *                 the vertical retrace, and -1 if late swaps happen
+ *                 immediately instead of waiting for the next retrace.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_SetSwapInterval
+ */
+func SDL_GL_GetSwapInterval(interval *int) bool {
+	if interval == nil {
+		return SDL_InvalidParamError("interval")
+	}
+	*interval = 0
+
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+	if sdlGetGLCurrent().context == nil {
+		return SDL_SetError("no current context")
+	}
+	if device.GL_GetSwapInterval == nil {
+		return SDL_SetError("Getting the swap interval is not supported")
+	}
+	value, ok := device.GL_GetSwapInterval(device)
+	if !ok {
+		return false
+	}
+	*interval = value
+	return true
+}
+
+/**
+ * Update a window with OpenGL rendering.
+ *
+ * This is used with double-buffered OpenGL contexts, which are the default.
+ *
+ * On macOS, make sure you bind 0 to the draw framebuffer before swapping the
+ * window, otherwise nothing will happen. If you aren't using
+ * glBindFramebuffer(), this is the default and you won't have to do anything
+ * extra.
+ *
+ * - window the window to change.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GL_SwapWindow(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if window.flags&SDL_WINDOW_OPENGL == 0 {
+		return SDL_SetError("The specified window isn't an OpenGL window")
+	}
+	if sdlGetGLCurrent().window != window {
+		return SDL_SetError("The specified window has not been made current")
+	}
+
+	device := sdlGetVideoDevice()
+	if device.GL_SwapWindow == nil {
+		return SDL_SetError("No OpenGL support in video driver")
+	}
+	return device.GL_SwapWindow(device, window)
+}
+
+// GL_SwapWindow is SDL_GL_SwapWindow() returning a Go error instead of a
+// boolean.
+func GL_SwapWindow(window *SDL_Window) error {
+	return errorFromResult(SDL_GL_SwapWindow(window))
+}
+
+/**
+ * Delete an OpenGL context.
+ *
+ * The context is released on every goroutine that has it current.
+ *
+ * - context the OpenGL context to be deleted.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GL_CreateContext
+ */
+func SDL_GL_DestroyContext(context SDL_GLContext) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+	if context == nil {
+		return SDL_InvalidParamError("context")
+	}
+
+	if sdlGetGLCurrent().context == context {
+		SDL_GL_MakeCurrent(nil, nil)
+	}
+	sdlForgetGLCurrent(nil, context)
+	if device.GL_DestroyContext == nil {
+		return SDL_SetError("No OpenGL support in video driver")
+	}
+	return device.GL_DestroyContext(device, context)
+}
@@ -0,0 +1,229 @@
+package sdl
+
+/*
+ * Keyboard and mouse grabs.
+ *
+ * SDL_WINDOW_MOUSE_GRABBED and SDL_WINDOW_KEYBOARD_GRABBED record what the
+ * application asked for, the grab is only applied while the window has the
+ * input focus. Losing the focus releases the grab in the driver, and gaining
+ * it back grabs again, so an application never holds the input of a window
+ * the user switched away from. One window holds the grab at a time, grabbing
+ * from another window takes it away from the previous one.
+ */
+
+/**
+ * A variable controlling whether grabbing input grabs the keyboard.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "0": Grab will affect only the mouse. (default)
+ * - "1": Grab will affect mouse and keyboard.
+ *
+ * By default SDL will not grab the keyboard so system shortcuts still work.
+ *
+ * This hint can be set anytime.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_GRAB_KEYBOARD = "SDL_GRAB_KEYBOARD"
+
+/**
+ * A variable controlling whether the window manager shortcut to switch
+ * windows, like Alt+Tab, still works while the keyboard is grabbed.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "0": Alt+Tab and similar shortcuts are delivered to the application.
+ * - "1": Alt+Tab and similar shortcuts switch windows, the window loses the
+ *   focus and with it the grab. (default)
+ *
+ * Video drivers implementing keyboard grabs check this hint when applying
+ * one.
+ *
+ * This hint can be set anytime.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_ALLOW_ALT_TAB_WHILE_GRABBED = "SDL_ALLOW_ALT_TAB_WHILE_GRABBED"
+
+/*
+ * Apply the grab flags of a window to the driver, according to its focus,
+ * taking the grab away from any other window holding it.
+ */
+func sdlUpdateWindowGrab(window *SDL_Window) {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return
+	}
+
+	var mouse_grabbed, keyboard_grabbed bool
+	if window.flags&SDL_WINDOW_INPUT_FOCUS != 0 {
+		mouse_grabbed = window.flags&SDL_WINDOW_MOUSE_GRABBED != 0
+		keyboard_grabbed = window.flags&SDL_WINDOW_KEYBOARD_GRABBED != 0 ||
+			(mouse_grabbed && SDL_GetHintBoolean(SDL_HINT_GRAB_KEYBOARD, false))
+	}
+
+	if mouse_grabbed || keyboard_grabbed {
+		if previous := device.grabbed_window; previous != nil && previous != window {
+			/* Stealing the grab from another window */
+			previous.flags &^= SDL_WINDOW_MOUSE_GRABBED | SDL_WINDOW_KEYBOARD_GRABBED
+			if device.SetWindowMouseGrab != nil {
+				device.SetWindowMouseGrab(device, previous, false)
+			}
+			if device.SetWindowKeyboardGrab != nil {
+				device.SetWindowKeyboardGrab(device, previous, false)
+			}
+		}
+		device.grabbed_window = window
+	} else if device.grabbed_window == window {
+		device.grabbed_window = nil
+	}
+
+	/* A driver that can't grab leaves the window without the flag */
+	if device.SetWindowMouseGrab != nil && !device.SetWindowMouseGrab(device, window, mouse_grabbed) && mouse_grabbed {
+		window.flags &^= SDL_WINDOW_MOUSE_GRABBED
+	}
+	if device.SetWindowKeyboardGrab != nil && !device.SetWindowKeyboardGrab(device, window, keyboard_grabbed) && keyboard_grabbed {
+		window.flags &^= SDL_WINDOW_KEYBOARD_GRABBED
+	}
+	if device.grabbed_window == window && window.flags&(SDL_WINDOW_MOUSE_GRABBED|SDL_WINDOW_KEYBOARD_GRABBED) == 0 {
+		device.grabbed_window = nil
+	}
+}
+
+/* Set a grab flag of a window and apply it, returns false if the grab was requested but couldn't be applied */
+func sdlSetWindowGrab(window *SDL_Window, flag SDL_WindowFlags, grabbed bool) bool {
+	if (window.flags&flag != 0) == grabbed {
+		return true
+	}
+	if grabbed {
+		window.flags |= flag
+	} else {
+		window.flags &^= flag
+	}
+
+	/* A hidden window has no focus, the grab is applied once it gets it */
+	if window.flags&SDL_WINDOW_HIDDEN != 0 {
+		return true
+	}
+	sdlUpdateWindowGrab(window)
+	if grabbed && window.flags&flag == 0 {
+		return SDL_SetError("The video driver couldn't grab the input")
+	}
+	return true
+}
+
+/**
+ * Set a window's keyboard grab mode.
+ *
+ * Keyboard grab enables capture of system keyboard shortcuts like Alt+Tab or
+ * the Meta/Super key. Note that not all system keyboard shortcuts can be
+ * captured by applications (one example is Ctrl+Alt+Del on Windows).
+ *
+ * This is primarily intended for specialized applications such as VNC
+ * clients or VM frontends. Normal games should not use keyboard grab.
+ *
+ * When keyboard grab is enabled, SDL will continue to handle Alt+Tab when the
+ * window is full-screen to ensure the user is not trapped in your
+ * application. If you have a custom keyboard shortcut to exit fullscreen
+ * mode, you may suppress this behavior with
+ * `SDL_HINT_ALLOW_ALT_TAB_WHILE_GRABBED`.
+ *
+ * If the caller enables a grab while another window is currently grabbed,
+ * the other window loses its grab in favor of the caller's window.
+ *
+ * - window the window for which the keyboard grab mode should be set.
+ * - grabbed this is true to grab keyboard, and false to release.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowKeyboardGrab
+ * See also SDL_SetWindowMouseGrab
+ */
+func SDL_SetWindowKeyboardGrab(window *SDL_Window, grabbed bool) bool {
+	if !sdlCheckWindowNotPopup(window) {
+		return false
+	}
+	return sdlSetWindowGrab(window, SDL_WINDOW_KEYBOARD_GRABBED, grabbed)
+}
+
+/**
+ * Set a window's mouse grab mode.
+ *
+ * Mouse grab confines the mouse cursor to the window.
+ *
+ * If the caller enables a grab while another window is currently grabbed,
+ * the other window loses its grab in favor of the caller's window.
+ *
+ * - window the window for which the mouse grab mode should be set.
+ * - grabbed this is true to grab mouse, and false to release.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowMouseGrab
+ * See also SDL_SetWindowKeyboardGrab
+ */
+func SDL_SetWindowMouseGrab(window *SDL_Window, grabbed bool) bool {
+	if !sdlCheckWindowNotPopup(window) {
+		return false
+	}
+	return sdlSetWindowGrab(window, SDL_WINDOW_MOUSE_GRABBED, grabbed)
+}
+
+/**
+ * Get a window's keyboard grab mode.
+ *
+ * - window the window to query.
+ * Returns true if keyboard is grabbed, and false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowKeyboardGrab
+ */
+func SDL_GetWindowKeyboardGrab(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	return window.flags&SDL_WINDOW_KEYBOARD_GRABBED != 0
+}
+
+/**
+ * Get a window's mouse grab mode.
+ *
+ * - window the window to query.
+ * Returns true if mouse is grabbed, and false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowMouseGrab
+ */
+func SDL_GetWindowMouseGrab(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	return window.flags&SDL_WINDOW_MOUSE_GRABBED != 0
+}
+
+/**
+ * Get the window that currently has an input grab enabled.
+ *
+ * Returns the window if input is grabbed or nil otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowMouseGrab
+ * See also SDL_SetWindowKeyboardGrab
+ */
+func SDL_GetGrabbedWindow() *SDL_Window {
+	videoLock.RLock()
+	defer videoLock.RUnlock()
+
+	if video == nil {
+		return nil
+	}
+	return video.grabbed_window
+}
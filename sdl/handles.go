@@ -0,0 +1,113 @@
+package sdl
+
+/*
+ * Handle table for the objects the application destroys explicitly.
+ *
+ * Every window, renderer, texture and audio stream gets a handle when it's
+ * created: an index into the table with the generation of its slot.
+ * Destroying the object frees the slot, and reusing it bumps the generation,
+ * so a handle kept past the destruction no longer resolves, even once the
+ * slot holds a new object.
+ *
+ * The handle of an object never changes, and the table is only read under
+ * its lock, so a destroyed object is detected reliably from any goroutine
+ * rather than through a flag racing with the destruction.
+ */
+
+/* An object handle: the slot index in the high 32 bits, its generation in the low ones. 0 is never valid. */
+type sdlHandle uint64
+
+/* The kinds of objects in the handle table, a handle only resolves for its own kind */
+type sdlHandleType int
+
+const (
+	sdlHandleTypeWindow sdlHandleType = iota + 1
+	sdlHandleTypeRenderer
+	sdlHandleTypeTexture
+	sdlHandleTypeAudioStream
+)
+
+type sdlHandleSlot struct {
+	generation uint32
+	kind       sdlHandleType /* 0 when the slot is free */
+	object     any
+}
+
+/* Private data -- the handle table, guarded by handlesLock */
+var handlesLock = sdlRWMutex{name: "handles"}
+var handleSlots []sdlHandleSlot
+var freeHandleSlots []uint32
+
+func (handle sdlHandle) index() uint32 {
+	return uint32(handle >> 32)
+}
+
+func (handle sdlHandle) generation() uint32 {
+	return uint32(handle)
+}
+
+/* Allocate a handle for a newly created object */
+func sdlCreateHandle(kind sdlHandleType, object any) sdlHandle {
+	SDL_assert(kind != 0 && object != nil)
+
+	handlesLock.Lock()
+	defer handlesLock.Unlock()
+
+	var index uint32
+	if n := len(freeHandleSlots); n > 0 {
+		index = freeHandleSlots[n-1]
+		freeHandleSlots = freeHandleSlots[:n-1]
+	} else {
+		index = uint32(len(handleSlots))
+		handleSlots = append(handleSlots, sdlHandleSlot{})
+	}
+
+	slot := &handleSlots[index]
+	slot.generation++
+	if slot.generation == 0 {
+		slot.generation = 1 /* keep the handle nonzero when the generation wraps */
+	}
+	slot.kind = kind
+	slot.object = object
+	return sdlHandle(index)<<32 | sdlHandle(slot.generation)
+}
+
+/* Get the object of a live handle, or nil if it was destroyed or is of another kind */
+func sdlResolveHandle(kind sdlHandleType, handle sdlHandle) any {
+	handlesLock.RLock()
+	defer handlesLock.RUnlock()
+
+	index := handle.index()
+	if handle == 0 || index >= uint32(len(handleSlots)) {
+		return nil
+	}
+	slot := &handleSlots[index]
+	if slot.kind != kind || slot.generation != handle.generation() {
+		return nil
+	}
+	return slot.object
+}
+
+/* Whether the handle is live and still refers to this object */
+func sdlValidHandle(kind sdlHandleType, handle sdlHandle, object any) bool {
+	return object != nil && sdlResolveHandle(kind, handle) == object
+}
+
+/* Release the handle of a destroyed object, returns false if it had already been released */
+func sdlDestroyHandle(kind sdlHandleType, handle sdlHandle) bool {
+	handlesLock.Lock()
+	defer handlesLock.Unlock()
+
+	index := handle.index()
+	if handle == 0 || index >= uint32(len(handleSlots)) {
+		return false
+	}
+	slot := &handleSlots[index]
+	if slot.kind != kind || slot.generation != handle.generation() {
+		return false
+	}
+	slot.kind = 0
+	slot.object = nil
+	freeHandleSlots = append(freeHandleSlots, index)
+	return true
+}
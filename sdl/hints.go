@@ -0,0 +1,458 @@
+package sdl
+
+import "os"
+import "strings"
+
+/**
+ * Hints are variables that can be set to change the behavior of SDL.
+ *
+ * The hints can be set from the environment with a variable of the same
+ * name, or programmatically with SDL_SetHint() and friends.
+ */
+
+/**
+ * A variable setting the app name, used by subsystems that want to show the
+ * application name, e.g. audio mixers and desktop launchers.
+ *
+ * This hint is consulted by SDL_GetAppMetadataProperty() when
+ * SDL_PROP_APP_METADATA_NAME_STRING isn't set.
+ *
+ * This hint should be set before SDL is initialized.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_APP_NAME = "SDL_APP_NAME"
+
+/**
+ * A variable setting the app ID string.
+ *
+ * This string is used by desktop compositors to identify and group windows
+ * together, as well as match applications with associated desktop settings
+ * and icons.
+ *
+ * This hint is consulted by SDL_GetAppMetadataProperty() when
+ * SDL_PROP_APP_METADATA_IDENTIFIER_STRING isn't set.
+ *
+ * This hint should be set before SDL is initialized.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_APP_ID = "SDL_APP_ID"
+
+/**
+ * A variable that controls the default response to a failed assertion,
+ * bypassing the interactive prompt.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "abort": Program terminates immediately.
+ * - "break": Program triggers a debugger breakpoint.
+ * - "retry": Program reruns the SDL_assert's test again.
+ * - "ignore": Program continues on, ignoring this assertion failure this
+ *   time.
+ * - "always_ignore": Program continues on, ignoring this assertion failure
+ *   for the rest of the run.
+ *
+ * This hint can be set anytime.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_ASSERT = "SDL_ASSERT"
+
+/**
+ * An enumeration of hint priorities.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_HintPriority int
+
+const (
+	SDL_HINT_DEFAULT SDL_HintPriority = iota
+	SDL_HINT_NORMAL
+	SDL_HINT_OVERRIDE
+)
+
+type sdlHint struct {
+	value     string
+	isSet     bool
+	priority  SDL_HintPriority
+	callbacks []*sdlHintWatch
+}
+
+type sdlHintWatch struct {
+	id       SDL_HintCallbackID
+	callback SDL_HintCallback
+	userdata any
+}
+
+/*
+ * A pending callback invocation, collected while hintsLock is held and run
+ * after it is released so callbacks are free to query and set hints.
+ */
+type sdlHintNotification struct {
+	watch    *sdlHintWatch
+	name     string
+	oldValue string
+	newValue string
+}
+
+/* Must be called with hintsLock held. */
+func (hint *sdlHint) notifications(name, oldValue, newValue string) []sdlHintNotification {
+	if oldValue == newValue {
+		return nil
+	}
+	pending := make([]sdlHintNotification, 0, len(hint.callbacks))
+	for _, watch := range hint.callbacks {
+		pending = append(pending, sdlHintNotification{watch, name, oldValue, newValue})
+	}
+	return pending
+}
+
+func sdlRunHintNotifications(pending []sdlHintNotification) {
+	for _, n := range pending {
+		n.watch.callback(n.watch.userdata, n.name, n.oldValue, n.newValue)
+	}
+}
+
+/* The hint registry, guarded by hintsLock */
+var hintsLock = sdlRWMutex{name: "hints"}
+var hints = map[string]*sdlHint{}
+var hintCallbackLastID SDL_HintCallbackID
+
+/**
+ * Set a hint with a specific priority.
+ *
+ * The priority controls the behavior when setting a hint that already has a
+ * value. Hints will replace existing hints of their priority and lower.
+ * Environment variables are considered to have override priority.
+ *
+ * - name the hint to set.
+ * - value the value of the hint variable.
+ * - priority the SDL_HintPriority level for the hint.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetHint
+ * See also SDL_ResetHint
+ * See also SDL_SetHint
+ */
+func SDL_SetHintWithPriority(name string, value string, priority SDL_HintPriority) bool {
+	if name == "" {
+		return SDL_InvalidParamError("name")
+	}
+
+	if _, ok := os.LookupEnv(name); ok && priority < SDL_HINT_OVERRIDE {
+		return SDL_SetError("An environment variable is taking priority")
+	}
+
+	hintsLock.Lock()
+
+	hint := hints[name]
+	if hint == nil {
+		hint = &sdlHint{}
+		hints[name] = hint
+	} else if hint.isSet && priority < hint.priority {
+		hintsLock.Unlock()
+		return true
+	}
+
+	oldValue := hint.value
+	if !hint.isSet {
+		oldValue = os.Getenv(name)
+	}
+	pending := hint.notifications(name, oldValue, value)
+	hint.value = value
+	hint.isSet = true
+	hint.priority = priority
+	hintsLock.Unlock()
+
+	sdlRunHintNotifications(pending)
+	return true
+}
+
+/**
+ * Set a hint with normal priority.
+ *
+ * Hints will not be set if there is an existing override hint or environment
+ * variable that takes precedence. You can use SDL_SetHintWithPriority() to
+ * set the hint with override priority instead.
+ *
+ * - name the hint to set.
+ * - value the value of the hint variable.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetHint
+ * See also SDL_ResetHint
+ * See also SDL_SetHintWithPriority
+ */
+func SDL_SetHint(name string, value string) bool {
+	return SDL_SetHintWithPriority(name, value, SDL_HINT_NORMAL)
+}
+
+// SetHint is SDL_SetHint() returning a Go error instead of a boolean.
+func SetHint(name string, value string) error {
+	return errorFromResult(SDL_SetHint(name, value))
+}
+
+/**
+ * Reset a hint to the default value.
+ *
+ * This will reset a hint to the value of the environment variable, or
+ * unset it if the environment variable isn't set.
+ *
+ * - name the hint to set.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetHint
+ * See also SDL_ResetHints
+ */
+func SDL_ResetHint(name string) bool {
+	if name == "" {
+		return SDL_InvalidParamError("name")
+	}
+
+	hintsLock.Lock()
+
+	var pending []sdlHintNotification
+	if hint := hints[name]; hint != nil {
+		pending = sdlResetHintLocked(name, hint)
+	}
+	hintsLock.Unlock()
+
+	sdlRunHintNotifications(pending)
+	return true
+}
+
+/* Must be called with hintsLock held for writing. */
+func sdlResetHintLocked(name string, hint *sdlHint) []sdlHintNotification {
+	var pending []sdlHintNotification
+	if hint.isSet {
+		pending = hint.notifications(name, hint.value, os.Getenv(name))
+	}
+	hint.value = ""
+	hint.isSet = false
+	hint.priority = SDL_HINT_DEFAULT
+	return pending
+}
+
+/**
+ * Reset all hints to the default values.
+ *
+ * This will reset all hints to the value of the associated environment
+ * variable, or unset them if the environment variable isn't set.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_ResetHint
+ */
+func SDL_ResetHints() {
+	hintsLock.Lock()
+
+	var pending []sdlHintNotification
+	for name, hint := range hints {
+		pending = append(pending, sdlResetHintLocked(name, hint)...)
+	}
+	hintsLock.Unlock()
+
+	sdlRunHintNotifications(pending)
+}
+
+/**
+ * Get the value of a hint.
+ *
+ * - name the hint to query.
+ * Returns the string value of a hint or an empty string if the hint isn't
+ *          set.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetHint
+ * See also SDL_SetHintWithPriority
+ */
+func SDL_GetHint(name string) string {
+	value, _ := sdlLookupHint(name)
+	return value
+}
+
+/*
+ * Get the value of a hint and whether it is set at all, either from the
+ * environment or programmatically.
+ */
+func sdlLookupHint(name string) (string, bool) {
+	value, isSet := os.LookupEnv(name)
+
+	hintsLock.RLock()
+	defer hintsLock.RUnlock()
+
+	if hint := hints[name]; hint != nil && hint.isSet {
+		if !isSet || hint.priority == SDL_HINT_OVERRIDE {
+			return hint.value, true
+		}
+	}
+	return value, isSet
+}
+
+/*
+ * Interpret a hint value as a boolean, "0" and "false" (in any case) are
+ * false and everything else is true. An empty value yields default_value.
+ */
+func sdlGetStringBoolean(value string, default_value bool) bool {
+	if value == "" {
+		return default_value
+	}
+	return value != "0" && !strings.EqualFold(value, "false")
+}
+
+/**
+ * Get the boolean value of a hint variable.
+ *
+ * - name the name of the hint to get the boolean value from.
+ * - default_value the value to return if the hint does not exist.
+ * Returns the boolean value of a hint or the provided default value if the
+ *          hint does not exist.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetHint
+ * See also SDL_SetHint
+ */
+func SDL_GetHintBoolean(name string, default_value bool) bool {
+	return sdlGetStringBoolean(SDL_GetHint(name), default_value)
+}
+
+/**
+ * A callback used to send notifications of hint value changes.
+ *
+ * This is called an initial time during SDL_AddHintCallback with the hint's
+ * current value, and then again each time the hint's value changes.
+ *
+ * - userdata what was passed as `userdata` to SDL_AddHintCallback().
+ * - name what was passed as `name` to SDL_AddHintCallback().
+ * - oldValue the previous hint value.
+ * - newValue the new value hint is to be set to.
+ *
+ * This callback is fired from whatever goroutine is setting a new hint
+ * value. SDL holds no lock while calling it, so it is safe to query and set
+ * hints from within it.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_AddHintCallback
+ */
+type SDL_HintCallback func(userdata any, name string, oldValue string, newValue string)
+
+/**
+ * A handle for a hint callback, returned by SDL_AddHintCallback().
+ *
+ * Go funcs can't be compared, so callbacks are removed by this handle
+ * instead of by the callback and its userdata. The value 0 is an invalid
+ * handle.
+ *
+ * See also SDL_AddHintCallback
+ * See also SDL_RemoveHintCallback
+ */
+type SDL_HintCallbackID uint32
+
+/**
+ * Add a function to watch a particular hint.
+ *
+ * The callback function is called _during_ this function, to provide it an
+ * initial value, and again each time the hint's value changes.
+ *
+ * Each call adds a new watch, even for a callback that is already watching
+ * the hint; keep the returned handle to remove it.
+ *
+ * - name the hint to watch.
+ * - callback An SDL_HintCallback function that will be called when the
+ *                 hint value changes.
+ * - userdata a pointer to pass to the callback function.
+ * Returns a handle for SDL_RemoveHintCallback() on success or 0 on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_RemoveHintCallback
+ */
+func SDL_AddHintCallback(name string, callback SDL_HintCallback, userdata any) SDL_HintCallbackID {
+	if name == "" {
+		SDL_InvalidParamError("name")
+		return 0
+	}
+	if callback == nil {
+		SDL_InvalidParamError("callback")
+		return 0
+	}
+
+	hintsLock.Lock()
+	hintCallbackLastID++
+	watch := &sdlHintWatch{id: hintCallbackLastID, callback: callback, userdata: userdata}
+	hint := hints[name]
+	if hint == nil {
+		hint = &sdlHint{}
+		hints[name] = hint
+	}
+	hint.callbacks = append(hint.callbacks, watch)
+	hintsLock.Unlock()
+
+	/* Now call it with the current value */
+	callback(userdata, name, SDL_GetHint(name), SDL_GetHint(name))
+	return watch.id
+}
+
+/**
+ * Remove a function watching a particular hint.
+ *
+ * - name the hint being watched.
+ * - id the handle returned by SDL_AddHintCallback().
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_AddHintCallback
+ */
+func SDL_RemoveHintCallback(name string, id SDL_HintCallbackID) {
+	hintsLock.Lock()
+	defer hintsLock.Unlock()
+
+	hint := hints[name]
+	if hint == nil {
+		return
+	}
+	for i, watch := range hint.callbacks {
+		if watch.id == id {
+			hint.callbacks = append(hint.callbacks[:i], hint.callbacks[i+1:]...)
+			return
+		}
+	}
+}
+
+/* Called by SDL_Quit() to drop every hint and hint callback. */
+func sdlQuitHints() {
+	hintsLock.Lock()
+	defer hintsLock.Unlock()
+
+	hints = map[string]*sdlHint{}
+}
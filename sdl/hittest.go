@@ -0,0 +1,222 @@
+package sdl
+
+/*
+ * Window hit-testing, for windows drawing their own decorations.
+ *
+ * The application callback classifies a point of the client area as
+ * normal, draggable or a resize edge. Drivers with a window manager hand the
+ * classification over to it, so the native move and resize loops run. The
+ * drivers without one call sdlHitTestButtonDown(), sdlHitTestMotion() and
+ * sdlHitTestButtonUp() with their pointer input, and the window is moved and
+ * resized here through SDL_SetWindowPosition() and SDL_SetWindowSize(). A
+ * resize is a live resize, see windowevents.go.
+ */
+
+/**
+ * Possible return values from the SDL_HitTest callback.
+ *
+ * This enum is available since SDL 3.0.0.
+ *
+ * See also SDL_HitTest
+ */
+type SDL_HitTestResult int
+
+const (
+	SDL_HITTEST_NORMAL             SDL_HitTestResult = iota /**< Region is normal. No special properties. */
+	SDL_HITTEST_DRAGGABLE                                   /**< Region can drag entire window. */
+	SDL_HITTEST_RESIZE_TOPLEFT                              /**< Region is the resizable top-left corner border. */
+	SDL_HITTEST_RESIZE_TOP                                  /**< Region is the resizable top border. */
+	SDL_HITTEST_RESIZE_TOPRIGHT                             /**< Region is the resizable top-right corner border. */
+	SDL_HITTEST_RESIZE_RIGHT                                /**< Region is the resizable right border. */
+	SDL_HITTEST_RESIZE_BOTTOMRIGHT                          /**< Region is the resizable bottom-right corner border. */
+	SDL_HITTEST_RESIZE_BOTTOM                               /**< Region is the resizable bottom border. */
+	SDL_HITTEST_RESIZE_BOTTOMLEFT                           /**< Region is the resizable bottom-left corner border. */
+	SDL_HITTEST_RESIZE_LEFT                                 /**< Region is the resizable left border. */
+)
+
+/**
+ * Callback used for hit-testing.
+ *
+ * - win the SDL_Window where hit-testing was set on.
+ * - area an SDL_Point which should be hit-tested.
+ * - data what was passed as `callback_data` to SDL_SetWindowHitTest().
+ * Returns an SDL_HitTestResult value.
+ *
+ * See also SDL_SetWindowHitTest
+ */
+type SDL_HitTest func(win *SDL_Window, area *SDL_Point, data any) SDL_HitTestResult
+
+/* An interactive move or resize started on a hit-test region, in global coordinates */
+type sdlHitTestDrag struct {
+	result SDL_HitTestResult /* SDL_HITTEST_NORMAL when no drag is in progress */
+	start  SDL_Point         /* the pointer position the drag started at */
+	rect   SDL_Rect          /* the window geometry the drag started from */
+}
+
+/**
+ * Provide a callback that decides if a window region has special properties.
+ *
+ * Normally windows are dragged and resized by decorations provided by the
+ * system window manager (a title bar, borders, etc), but for some apps, it
+ * makes sense to drag them from somewhere else inside the window itself; for
+ * example, one might have a borderless window that wants to be draggable from
+ * any part, or simulate its own title bar, etc.
+ *
+ * This function lets the app provide a callback that designates pieces of a
+ * given window as special. This callback is run during event processing if we
+ * need to tell the OS to treat a region of the window specially; the use of
+ * this callback is known as "hit testing."
+ *
+ * Mouse input may not be delivered to your application if it is within a
+ * special area; the OS will often apply that input to moving the window or
+ * resizing the window and not deliver it to the application.
+ *
+ * Specifying nil for a callback disables hit-testing. Hit-testing is
+ * disabled by default.
+ *
+ * Platforms that don't support this functionality will return false
+ * unconditionally, even if you're attempting to disable hit-testing.
+ *
+ * Your callback may fire at any time, and its firing does not indicate any
+ * specific behavior (for example, on Windows, this certainly might fire when
+ * the OS is deciding whether to drag your window, but it fires for lots of
+ * other reasons, too, some unrelated to anything you probably care about _and
+ * when the mouse isn't actually at the location it is testing_). Since this
+ * can fire at any time, you should try to keep your callback efficient,
+ * devoid of allocations, etc.
+ *
+ * - window the window to set hit-testing on.
+ * - callback the function to call when doing a hit-test.
+ * - callback_data an app-defined value passed to **callback**.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_SetWindowHitTest(window *SDL_Window, callback SDL_HitTest, callback_data any) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+
+	device := sdlGetVideoDevice()
+	if device.SetWindowHitTest != nil && !device.SetWindowHitTest(device, window, callback != nil) {
+		return false
+	}
+	window.hit_test = callback
+	window.hit_test_data = callback_data
+	if callback == nil {
+		sdlHitTestButtonUp(window)
+	}
+	return true
+}
+
+/* Run the hit-test callback of a window for a point of its client area, for drivers asked by the window manager */
+func sdlHitTestWindow(window *SDL_Window, x, y int) SDL_HitTestResult {
+	if window.hit_test == nil {
+		return SDL_HITTEST_NORMAL
+	}
+	area := SDL_Point{X: x, Y: y}
+	return window.hit_test(window, &area, window.hit_test_data)
+}
+
+/*
+ * A primary button press at a point of the client area, returns true if it
+ * started a move or resize, in which case the driver doesn't deliver the
+ * press to the application.
+ */
+func sdlHitTestButtonDown(window *SDL_Window, x, y int) bool {
+	if window.flags&SDL_WINDOW_FULLSCREEN != 0 {
+		return false
+	}
+	result := sdlHitTestWindow(window, x, y)
+	if result == SDL_HITTEST_NORMAL {
+		return false
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_HIT_TEST, 0, 0)
+
+	/* Track the pointer globally, the window moves under it */
+	gx, gy := sdlRelativeToGlobalForWindow(window, window.x+x, window.y+y)
+	window.hit_test_drag = sdlHitTestDrag{
+		result: result,
+		start:  SDL_Point{X: gx, Y: gy},
+		rect:   SDL_Rect{X: window.x, Y: window.y, W: window.w, H: window.h},
+	}
+	if result != SDL_HITTEST_DRAGGABLE {
+		sdlBeginWindowLiveResize(window)
+	}
+	return true
+}
+
+/* Pointer motion to a point of the client area, returns true if it was consumed by a move or resize */
+func sdlHitTestMotion(window *SDL_Window, x, y int) bool {
+	drag := window.hit_test_drag
+	if drag.result == SDL_HITTEST_NORMAL {
+		return false
+	}
+
+	gx, gy := sdlRelativeToGlobalForWindow(window, window.x+x, window.y+y)
+	dx, dy := gx-drag.start.X, gy-drag.start.Y
+	if drag.result == SDL_HITTEST_DRAGGABLE {
+		SDL_SetWindowPosition(window, drag.rect.X+dx, drag.rect.Y+dy)
+		return true
+	}
+
+	/* Move the dragged edges, the opposite ones stay in place */
+	var left, top, right, bottom bool
+	switch drag.result {
+	case SDL_HITTEST_RESIZE_TOPLEFT:
+		left, top = true, true
+	case SDL_HITTEST_RESIZE_TOP:
+		top = true
+	case SDL_HITTEST_RESIZE_TOPRIGHT:
+		right, top = true, true
+	case SDL_HITTEST_RESIZE_RIGHT:
+		right = true
+	case SDL_HITTEST_RESIZE_BOTTOMRIGHT:
+		right, bottom = true, true
+	case SDL_HITTEST_RESIZE_BOTTOM:
+		bottom = true
+	case SDL_HITTEST_RESIZE_BOTTOMLEFT:
+		left, bottom = true, true
+	case SDL_HITTEST_RESIZE_LEFT:
+		left = true
+	}
+
+	w, h := drag.rect.W, drag.rect.H
+	if left {
+		w -= dx
+	} else if right {
+		w += dx
+	}
+	if top {
+		h -= dy
+	} else if bottom {
+		h += dy
+	}
+	w, h = window.clampSize(max(w, 1), max(h, 1))
+
+	x, y = drag.rect.X, drag.rect.Y
+	if left {
+		x += drag.rect.W - w
+	}
+	if top {
+		y += drag.rect.H - h
+	}
+	if x != window.x || y != window.y {
+		SDL_SetWindowPosition(window, x, y)
+	}
+	SDL_SetWindowSize(window, w, h)
+	return true
+}
+
+/* The primary button was released, returns true if that ended a move or resize */
+func sdlHitTestButtonUp(window *SDL_Window) bool {
+	if window.hit_test_drag.result == SDL_HITTEST_NORMAL {
+		return false
+	}
+	if window.hit_test_drag.result != SDL_HITTEST_DRAGGABLE {
+		sdlEndWindowLiveResize(window)
+	}
+	window.hit_test_drag = sdlHitTestDrag{}
+	return true
+}
@@ -0,0 +1,692 @@
+package sdl
+
+import "math/bits"
+import "sync/atomic"
+
+/**
+ * Initialization flags for SDL_Init and/or SDL_InitSubSystem
+ *
+ * These are the flags which may be passed to SDL_Init(). You should specify
+ * the subsystems which you will be using in your application.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_Init
+ * See also SDL_Quit
+ * See also SDL_InitSubSystem
+ * See also SDL_QuitSubSystem
+ */
+type SDL_InitFlags uint32
+
+const (
+	SDL_INIT_TIMER    SDL_InitFlags = 0x00000001
+	SDL_INIT_AUDIO    SDL_InitFlags = 0x00000010 /**< `SDL_INIT_AUDIO` implies `SDL_INIT_EVENTS` */
+	SDL_INIT_VIDEO    SDL_InitFlags = 0x00000020 /**< `SDL_INIT_VIDEO` implies `SDL_INIT_EVENTS` */
+	SDL_INIT_JOYSTICK SDL_InitFlags = 0x00000200 /**< `SDL_INIT_JOYSTICK` implies `SDL_INIT_EVENTS`, should be initialized on the same thread as SDL_INIT_VIDEO on Windows if you don't set SDL_HINT_JOYSTICK_THREAD */
+	SDL_INIT_HAPTIC   SDL_InitFlags = 0x00001000
+	SDL_INIT_GAMEPAD  SDL_InitFlags = 0x00002000 /**< `SDL_INIT_GAMEPAD` implies `SDL_INIT_JOYSTICK` */
+	SDL_INIT_EVENTS   SDL_InitFlags = 0x00004000
+	SDL_INIT_SENSOR   SDL_InitFlags = 0x00008000 /**< `SDL_INIT_SENSOR` implies `SDL_INIT_EVENTS` */
+	SDL_INIT_CAMERA   SDL_InitFlags = 0x00010000 /**< `SDL_INIT_CAMERA` implies `SDL_INIT_EVENTS` */
+)
+
+/*
+ * A subsystem hook is registered by the module implementing a subsystem,
+ * usually from an init() function. Init calls SDL_SetError() and returns
+ * false when the subsystem can't be brought up.
+ */
+type subsystemHook struct {
+	Init func() bool
+	Quit func()
+}
+
+/* Private subsystem management, guarded by subsystemMutex */
+var subsystemMutex = sdlMutex{name: "subsystems"}
+var subsystemRefCount [32]uint8
+var subsystemHooks [32]subsystemHook
+
+/*
+ * The order in which subsystems are brought up. Dependencies come first,
+ * SDL_Quit tears them down in reverse.
+ */
+var subsystemOrder = []SDL_InitFlags{
+	SDL_INIT_EVENTS,
+	SDL_INIT_TIMER,
+	SDL_INIT_VIDEO,
+	SDL_INIT_AUDIO,
+	SDL_INIT_JOYSTICK,
+	SDL_INIT_GAMEPAD,
+	SDL_INIT_HAPTIC,
+	SDL_INIT_SENSOR,
+	SDL_INIT_CAMERA,
+}
+
+/*
+ * Register the init and quit functions of a subsystem. Only one hook per
+ * subsystem flag is supported; a later registration replaces the former.
+ */
+func sdlRegisterSubsystem(subsystem SDL_InitFlags, init func() bool, quit func()) {
+	subsystemMutex.Lock()
+	defer subsystemMutex.Unlock()
+
+	subsystemHooks[subsystemIndex(subsystem)] = subsystemHook{Init: init, Quit: quit}
+}
+
+func subsystemIndex(subsystem SDL_InitFlags) int {
+	SDL_assert(SDL_HasExactlyOneBitSet32(uint32(subsystem)))
+	return bits.TrailingZeros32(uint32(subsystem))
+}
+
+/* Private helper to increment a subsystem's ref counter. */
+func sdlIncrSubsystemRefCount(subsystem SDL_InitFlags) {
+	idx := subsystemIndex(subsystem)
+	SDL_assert(subsystemRefCount[idx] < 255)
+	subsystemRefCount[idx]++
+}
+
+/* Private helper to decrement a subsystem's ref counter. */
+func sdlDecrSubsystemRefCount(subsystem SDL_InitFlags) {
+	idx := subsystemIndex(subsystem)
+	if subsystemRefCount[idx] > 0 {
+		subsystemRefCount[idx]--
+	}
+}
+
+/* Private helper to check if a system needs init. */
+func sdlShouldInitSubsystem(subsystem SDL_InitFlags) bool {
+	idx := subsystemIndex(subsystem)
+	SDL_assert(subsystemRefCount[idx] < 255)
+	return subsystemRefCount[idx] == 0
+}
+
+/* Private helper to check if a system needs to be quit. */
+func sdlShouldQuitSubsystem(subsystem SDL_InitFlags) bool {
+	idx := subsystemIndex(subsystem)
+	if subsystemRefCount[idx] == 0 {
+		return false
+	}
+
+	/* If we're in SDL_Quit, we shut down every subsystem, even if refcount
+	 * isn't zero.
+	 */
+	return subsystemRefCount[idx] == 1 || sdlMainQuitting
+}
+
+var sdlMainQuitting = false
+
+/*
+ * Add the subsystems implied by the requested ones, e.g. the gamepad
+ * subsystem needs the joystick subsystem which needs the events subsystem.
+ */
+func sdlAddImpliedSubsystems(flags SDL_InitFlags) SDL_InitFlags {
+	if flags&SDL_INIT_GAMEPAD != 0 {
+		flags |= SDL_INIT_JOYSTICK
+	}
+	if flags&(SDL_INIT_VIDEO|SDL_INIT_AUDIO|SDL_INIT_JOYSTICK|SDL_INIT_SENSOR|SDL_INIT_CAMERA) != 0 {
+		flags |= SDL_INIT_EVENTS
+	}
+	return flags
+}
+
+/* Must be called with subsystemMutex held. */
+func sdlInitSubsystemLocked(subsystem SDL_InitFlags) bool {
+	if sdlShouldInitSubsystem(subsystem) {
+		/* The goroutine initializing video becomes the main thread, the video backend pumps its events there */
+		if subsystem == SDL_INIT_VIDEO {
+			mainThreadID.Store(goroutineID())
+		}
+		hook := subsystemHooks[subsystemIndex(subsystem)]
+		if hook.Init != nil && !hook.Init() {
+			if subsystem == SDL_INIT_VIDEO {
+				mainThreadID.Store(0)
+			}
+			return false
+		}
+		if subsystem == SDL_INIT_VIDEO {
+			sdlAcceptMainThreadCallbacks()
+		}
+	}
+	sdlIncrSubsystemRefCount(subsystem)
+	return true
+}
+
+/* Must be called with subsystemMutex held. */
+func sdlQuitSubsystemLocked(subsystem SDL_InitFlags) {
+	if sdlShouldQuitSubsystem(subsystem) {
+		hook := subsystemHooks[subsystemIndex(subsystem)]
+		if hook.Quit != nil {
+			hook.Quit()
+		}
+		if subsystem == SDL_INIT_VIDEO {
+			sdlCancelMainThreadCallbacks()
+			mainThreadID.Store(0)
+		}
+	}
+	sdlDecrSubsystemRefCount(subsystem)
+}
+
+/**
+ * Initialize the SDL library.
+ *
+ * The file I/O (for example: SDL_IOFromFile) and threading (SDL_CreateThread)
+ * subsystems are initialized by default. Message boxes
+ * (SDL_ShowSimpleMessageBox) also attempt to work without initializing the
+ * video subsystem, in hopes of being useful in showing an error dialog when
+ * SDL_Init fails. You must specifically initialize other subsystems if you
+ * use them in your application.
+ *
+ * Logging (such as SDL_Log) works without initialization, too.
+ *
+ * `flags` may be any of the following OR'd together:
+ *
+ * - `SDL_INIT_TIMER`: timer subsystem
+ * - `SDL_INIT_AUDIO`: audio subsystem; automatically initializes the events
+ *   subsystem
+ * - `SDL_INIT_VIDEO`: video subsystem; automatically initializes the events
+ *   subsystem
+ * - `SDL_INIT_JOYSTICK`: joystick subsystem; automatically initializes the
+ *   events subsystem
+ * - `SDL_INIT_HAPTIC`: haptic (force feedback) subsystem
+ * - `SDL_INIT_GAMEPAD`: gamepad subsystem; automatically initializes the
+ *   joystick subsystem
+ * - `SDL_INIT_EVENTS`: events subsystem
+ * - `SDL_INIT_SENSOR`: sensor subsystem; automatically initializes the events
+ *   subsystem
+ * - `SDL_INIT_CAMERA`: camera subsystem; automatically initializes the events
+ *   subsystem
+ *
+ * Subsystem initialization is ref-counted, you must call SDL_QuitSubSystem()
+ * for each SDL_InitSubSystem() to correctly shutdown a subsystem manually (or
+ * call SDL_Quit() to force shutdown). If a subsystem is already loaded then
+ * this call will increase the ref-count and return.
+ *
+ * - flags subsystem initialization flags
+ * Returns true on success or false on failure.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_InitSubSystem
+ * See also SDL_Quit
+ * See also SDL_QuitSubSystem
+ */
+func SDL_Init(flags SDL_InitFlags) bool {
+	return SDL_InitSubSystem(flags)
+}
+
+/**
+ * Compatibility function to initialize the SDL library.
+ *
+ * This function and SDL_Init() are interchangeable.
+ *
+ * - flags any of the flags used by SDL_Init(); see SDL_Init for details.
+ * Returns true on success or false on failure.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Init
+ * See also SDL_Quit
+ * See also SDL_QuitSubSystem
+ */
+func SDL_InitSubSystem(flags SDL_InitFlags) bool {
+	subsystemMutex.Lock()
+	defer subsystemMutex.Unlock()
+
+	flags = sdlAddImpliedSubsystems(flags)
+
+	var initialized SDL_InitFlags
+	for _, subsystem := range subsystemOrder {
+		if flags&subsystem == 0 {
+			continue
+		}
+		if !sdlInitSubsystemLocked(subsystem) {
+			/* Undo whatever we brought up before the failure. */
+			for i := len(subsystemOrder) - 1; i >= 0; i-- {
+				if initialized&subsystemOrder[i] != 0 {
+					sdlQuitSubsystemLocked(subsystemOrder[i])
+				}
+			}
+			return false
+		}
+		initialized |= subsystem
+	}
+	return true
+}
+
+// Init is SDL_Init() returning a Go error instead of a boolean.
+func Init(flags SDL_InitFlags) error {
+	return errorFromResult(SDL_Init(flags))
+}
+
+// InitSubSystem is SDL_InitSubSystem() returning a Go error instead of a
+// boolean.
+func InitSubSystem(flags SDL_InitFlags) error {
+	return errorFromResult(SDL_InitSubSystem(flags))
+}
+
+/**
+ * Shut down specific SDL subsystems.
+ *
+ * You still need to call SDL_Quit() even if you close all open subsystems
+ * with SDL_QuitSubSystem().
+ *
+ * - flags any of the flags used by SDL_Init(); see SDL_Init for details.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_InitSubSystem
+ * See also SDL_Quit
+ */
+func SDL_QuitSubSystem(flags SDL_InitFlags) {
+	subsystemMutex.Lock()
+	defer subsystemMutex.Unlock()
+
+	flags = sdlAddImpliedSubsystems(flags)
+
+	for i := len(subsystemOrder) - 1; i >= 0; i-- {
+		if flags&subsystemOrder[i] != 0 {
+			sdlQuitSubsystemLocked(subsystemOrder[i])
+		}
+	}
+}
+
+/**
+ * Check which subsystems are initialized.
+ *
+ * - flags any of the flags used by SDL_Init(); see SDL_Init for details.
+ * Returns a mask of all initialized subsystems if `flags` is 0, otherwise it
+ *          returns the initialization status of the specified subsystems.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Init
+ * See also SDL_InitSubSystem
+ */
+func SDL_WasInit(flags SDL_InitFlags) SDL_InitFlags {
+	subsystemMutex.Lock()
+	defer subsystemMutex.Unlock()
+
+	if flags == 0 {
+		flags = ^SDL_InitFlags(0)
+	}
+
+	var initialized SDL_InitFlags
+	for idx, refcount := range subsystemRefCount {
+		subsystem := SDL_InitFlags(1) << idx
+		if flags&subsystem != 0 && refcount > 0 {
+			initialized |= subsystem
+		}
+	}
+	return initialized
+}
+
+/**
+ * Clean up all initialized subsystems.
+ *
+ * You should call this function even if you have already shutdown each
+ * initialized subsystem with SDL_QuitSubSystem(). It is safe to call this
+ * function even in the case of errors in initialization.
+ *
+ * You can use this function in a deferred call to ensure that it is run
+ * when your application is shutdown.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Init
+ * See also SDL_QuitSubSystem
+ */
+func SDL_Quit() {
+	sdlReportLeaks()
+
+	subsystemMutex.Lock()
+	sdlMainQuitting = true
+
+	for i := len(subsystemOrder) - 1; i >= 0; i-- {
+		sdlQuitSubsystemLocked(subsystemOrder[i])
+	}
+
+	/* Now that every subsystem has been quit, reset the subsystem refcount
+	 * and the list of initialized subsystems.
+	 */
+	subsystemRefCount = [32]uint8{}
+
+	sdlMainQuitting = false
+	subsystemMutex.Unlock()
+
+	mainThreadID.Store(0)
+
+	sdlQuitClipboard()
+	sdlQuitLog()
+	sdlQuitProperties()
+	sdlQuitHints()
+
+	SDL_AssertionsQuit()
+}
+
+/*
+ * The app metadata store. This is a plain string map for now, the values are
+ * read back by any subsystem that wants to show or report the app identity.
+ */
+var appMetadataMutex = sdlMutex{name: "init.metadata"}
+var appMetadata = map[string]string{}
+
+/**
+ * Specify basic metadata about your app.
+ *
+ * You can optionally provide metadata about your app to SDL. This is not
+ * required, but strongly encouraged.
+ *
+ * There are several locations where SDL can make use of metadata (an "About"
+ * box in the macOS menu bar, the name of the app can be shown on some audio
+ * mixers, etc). Any piece of metadata can be left as an empty string, if a
+ * specific detail doesn't make sense for the app.
+ *
+ * This function should be called as early as possible, before SDL_Init.
+ * Multiple calls to this function are allowed, but various state might not
+ * change once it has been set up with a previous call to this function.
+ *
+ * Passing an empty string removes any previous metadata.
+ *
+ * This is a simplified interface for the most important information. You can
+ * supply significantly more detailed metadata with
+ * SDL_SetAppMetadataProperty().
+ *
+ * - appname The name of the application ("My Game 2: Bad Guy's
+ *                Revenge!").
+ * - appversion The version of the application ("1.0.0beta5" or a git
+ *                   hash, or whatever makes sense).
+ * - appidentifier A unique string in reverse-domain format that
+ *                      identifies this app ("com.example.mygame2").
+ * Returns true on success or false on failure.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetAppMetadataProperty
+ */
+func SDL_SetAppMetadata(appname, appversion, appidentifier string) bool {
+	SDL_SetAppMetadataProperty(SDL_PROP_APP_METADATA_NAME_STRING, appname)
+	SDL_SetAppMetadataProperty(SDL_PROP_APP_METADATA_VERSION_STRING, appversion)
+	SDL_SetAppMetadataProperty(SDL_PROP_APP_METADATA_IDENTIFIER_STRING, appidentifier)
+	return true
+}
+
+/**
+ * Specify metadata about your app through a set of properties.
+ *
+ * You can optionally provide metadata about your app to SDL. This is not
+ * required, but strongly encouraged.
+ *
+ * There are several locations where SDL can make use of metadata (an "About"
+ * box in the macOS menu bar, the name of the app can be shown on some audio
+ * mixers, etc). Any piece of metadata can be left out, if a specific detail
+ * doesn't make sense for the app.
+ *
+ * This function should be called as early as possible, before SDL_Init.
+ * Multiple calls to this function are allowed, but various state might not
+ * change once it has been set up with a previous call to this function.
+ *
+ * Once set, this metadata can be read using SDL_GetAppMetadataProperty().
+ *
+ * These are the supported properties:
+ *
+ * - `SDL_PROP_APP_METADATA_NAME_STRING`: The human-readable name of the
+ *   application, like "My Game 2: Bad Guy's Revenge!". This will show up
+ *   anywhere the OS shows the name of the application separately from window
+ *   titles, such as volume control applets, etc. This defaults to "SDL
+ *   Application".
+ * - `SDL_PROP_APP_METADATA_VERSION_STRING`: The version of the app that is
+ *   running; there are no rules on format, so "1.0.3beta2" and "April 22nd,
+ *   2024" and a git hash are all valid options. This has no default.
+ * - `SDL_PROP_APP_METADATA_IDENTIFIER_STRING`: A unique string that
+ *   identifies this app. This must be in reverse-domain format, like
+ *   "com.example.mygame2". This string is used by desktop compositors to
+ *   identify and group windows together, as well as match applications with
+ *   associated desktop settings and icons. This has no default.
+ * - `SDL_PROP_APP_METADATA_CREATOR_STRING`: The human-readable name of the
+ *   creator/developer/maker of this app, like "MojoWorkshop, LLC"
+ * - `SDL_PROP_APP_METADATA_COPYRIGHT_STRING`: The human-readable copyright
+ *   notice, like "Copyright (c) 2024 MojoWorkshop, LLC" or whatnot. Keep this
+ *   to one line, don't paste a copy of a whole software license in here. This
+ *   has no default.
+ * - `SDL_PROP_APP_METADATA_URL_STRING`: A URL to the app on the web. Maybe a
+ *   product page, or a storefront, or even a GitHub repository, for user's
+ *   further information This has no default.
+ * - `SDL_PROP_APP_METADATA_TYPE_STRING`: The type of application this is.
+ *   Currently this string can be "game" for a video game, "mediaplayer" for a
+ *   media player, or generically "application" if nothing else applies.
+ *   Future versions of SDL might add new types. This defaults to
+ *   "application".
+ *
+ * - name the name of the metadata property to set.
+ * - value the value of the property, or an empty string to remove that
+ *              property.
+ * Returns true on success or false on failure.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetAppMetadataProperty
+ * See also SDL_SetAppMetadata
+ */
+func SDL_SetAppMetadataProperty(name, value string) bool {
+	appMetadataMutex.Lock()
+	defer appMetadataMutex.Unlock()
+
+	if value == "" {
+		delete(appMetadata, name)
+	} else {
+		appMetadata[name] = value
+	}
+	return true
+}
+
+const (
+	SDL_PROP_APP_METADATA_NAME_STRING       = "SDL.app.metadata.name"
+	SDL_PROP_APP_METADATA_VERSION_STRING    = "SDL.app.metadata.version"
+	SDL_PROP_APP_METADATA_IDENTIFIER_STRING = "SDL.app.metadata.identifier"
+	SDL_PROP_APP_METADATA_CREATOR_STRING    = "SDL.app.metadata.creator"
+	SDL_PROP_APP_METADATA_COPYRIGHT_STRING  = "SDL.app.metadata.copyright"
+	SDL_PROP_APP_METADATA_URL_STRING        = "SDL.app.metadata.url"
+	SDL_PROP_APP_METADATA_TYPE_STRING       = "SDL.app.metadata.type"
+)
+
+/**
+ * Get metadata about your app.
+ *
+ * This returns metadata previously set using SDL_SetAppMetadata() or
+ * SDL_SetAppMetadataProperty(). See SDL_SetAppMetadataProperty() for the list
+ * of available properties and their meanings.
+ *
+ * - name the name of the metadata property to get.
+ * Returns the current value of the metadata property, or the default if it
+ *          is not set, an empty string for properties with no default.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetAppMetadata
+ * See also SDL_SetAppMetadataProperty
+ */
+func SDL_GetAppMetadataProperty(name string) string {
+	appMetadataMutex.Lock()
+	value, ok := appMetadata[name]
+	appMetadataMutex.Unlock()
+
+	if ok {
+		return value
+	}
+
+	switch name {
+	case SDL_PROP_APP_METADATA_NAME_STRING:
+		if value := SDL_GetHint(SDL_HINT_APP_NAME); value != "" {
+			return value
+		}
+		return "SDL Application"
+	case SDL_PROP_APP_METADATA_IDENTIFIER_STRING:
+		return SDL_GetHint(SDL_HINT_APP_ID)
+	case SDL_PROP_APP_METADATA_TYPE_STRING:
+		return "application"
+	}
+	return ""
+}
+
+/* The goroutine that initialized video, 0 while video isn't initialized */
+var mainThreadID atomic.Uint64
+
+/**
+ * Return whether this is the main thread.
+ *
+ * On Apple platforms, the main thread is the thread that runs your program's
+ * main() entry point. On other platforms, the main thread is the one that
+ * calls SDL_Init(SDL_INIT_VIDEO), which should usually be the one that runs
+ * your program's main() entry point.
+ *
+ * In Go, this is the goroutine that called SDL_Init(SDL_INIT_VIDEO).
+ * Goroutines move between OS threads, so the main goroutine should call
+ * runtime.LockOSThread() from an init() function, which pins it to the main
+ * OS thread. While video isn't initialized every goroutine is considered the
+ * main thread.
+ *
+ * Returns true if this thread is the main thread, or false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_RunOnMainThread
+ */
+func SDL_IsMainThread() bool {
+	id := mainThreadID.Load()
+	return id == 0 || id == goroutineID()
+}
+
+/**
+ * Callback run on the main thread.
+ *
+ * - userdata an app-controlled pointer that is passed to the callback.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_RunOnMainThread
+ */
+type SDL_MainThreadCallback func(userdata any)
+
+type sdlMainThreadCallbackEntry struct {
+	callback SDL_MainThreadCallback
+	userdata any
+	done     chan bool /* receives whether the callback ran, nil for asynchronous calls */
+}
+
+/* Callbacks waiting for the main thread, guarded by mainCallbacksLock */
+var mainCallbacksLock = sdlMutex{name: "main.callbacks"}
+var mainCallbacks []sdlMainThreadCallbackEntry
+var mainCallbacksAccepted bool /* whether a main thread is pumping events to run them, i.e. video is initialized */
+
+/**
+ * Call a function on the main thread during event processing.
+ *
+ * If this is called on the main thread, the callback is executed
+ * immediately. If this is called on another thread, this callback is queued
+ * for execution on the main thread during event processing, that is the
+ * next time the main thread calls SDL_PumpEvents(), SDL_PollEvent() or
+ * waits for events.
+ *
+ * Be careful of deadlocks when using this functionality. You should not have
+ * the main thread wait for the current thread while this function is being
+ * called with `wait_complete` true.
+ *
+ * The main thread is the goroutine that initialized video, so this fails
+ * while the video subsystem isn't initialized, instead of running the
+ * callback on the calling goroutine. Callbacks still queued when video or
+ * the event subsystem is shut down are not run; waiting callers return
+ * false.
+ *
+ * - callback the callback to call on the main thread.
+ * - userdata a pointer that is passed to `callback`.
+ * - wait_complete true to wait for the callback to complete, false to return
+ *                      immediately.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_IsMainThread
+ */
+func SDL_RunOnMainThread(callback SDL_MainThreadCallback, userdata any, wait_complete bool) bool {
+	if callback == nil {
+		return SDL_InvalidParamError("callback")
+	}
+	if mainThreadID.Load() == goroutineID() {
+		callback(userdata)
+		return true
+	}
+
+	entry := sdlMainThreadCallbackEntry{callback: callback, userdata: userdata}
+	if wait_complete {
+		entry.done = make(chan bool, 1)
+	}
+	/* Checked under the lock so a shutdown can't miss the callback and leave the caller waiting */
+	mainCallbacksLock.Lock()
+	if !mainCallbacksAccepted {
+		mainCallbacksLock.Unlock()
+		return SDL_SetError("Video subsystem not initialized, there is no main thread to run the callback")
+	}
+	mainCallbacks = append(mainCallbacks, entry)
+	mainCallbacksLock.Unlock()
+
+	/* Wake up the main thread in case it's waiting for events */
+	sdlWakeEventQueue()
+
+	if !wait_complete {
+		return true
+	}
+	if !<-entry.done {
+		return SDL_SetError("Callback was canceled")
+	}
+	return true
+}
+
+/* Whether callbacks are waiting for the main thread */
+func sdlHasMainThreadCallbacks() bool {
+	mainCallbacksLock.Lock()
+	defer mainCallbacksLock.Unlock()
+	return len(mainCallbacks) > 0
+}
+
+/* Run the queued callbacks, called from the event pump on the main thread */
+func sdlRunMainThreadCallbacks() {
+	mainCallbacksLock.Lock()
+	pending := mainCallbacks
+	mainCallbacks = nil
+	mainCallbacksLock.Unlock()
+
+	for _, entry := range pending {
+		entry.callback(entry.userdata)
+		if entry.done != nil {
+			entry.done <- true
+		}
+	}
+}
+
+/* Start queueing callbacks, called once video is initialized and its goroutine is the main thread */
+func sdlAcceptMainThreadCallbacks() {
+	mainCallbacksLock.Lock()
+	mainCallbacksAccepted = true
+	mainCallbacksLock.Unlock()
+}
+
+/* Drop the queued callbacks, releasing anybody waiting for them, and refuse new ones until video is initialized again */
+func sdlCancelMainThreadCallbacks() {
+	mainCallbacksLock.Lock()
+	pending := mainCallbacks
+	mainCallbacks = nil
+	mainCallbacksAccepted = false
+	mainCallbacksLock.Unlock()
+
+	for _, entry := range pending {
+		if entry.done != nil {
+			entry.done <- false
+		}
+	}
+}
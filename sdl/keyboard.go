@@ -0,0 +1,330 @@
+package sdl
+
+import "strings"
+
+/**
+ * A variable describing what IME UI elements the application can display.
+ *
+ * By default IME UI is handled using native components by the OS where
+ * possible, however this can interfere with or not be visible when exclusive
+ * fullscreen mode is used.
+ *
+ * The variable can be set to a comma separated list containing the following
+ * items:
+ *
+ * - "none" or "0": The application can't render any IME elements, and native
+ *   UI should be used. (default)
+ * - "composition": The application handles SDL_EVENT_TEXT_EDITING events and
+ *   can render the composition text.
+ * - "candidates": The application handles SDL_EVENT_TEXT_EDITING_CANDIDATES
+ *   and can render the candidate list.
+ *
+ * This hint should be set before SDL is initialized.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_IME_IMPLEMENTED_UI = "SDL_IME_IMPLEMENTED_UI"
+
+/**
+ * Keyboard IME candidates event structure (event.EditCandidates.*)
+ *
+ * Sent while composing text with SDL_HINT_IME_IMPLEMENTED_UI including
+ * "candidates", whenever the conversion candidates or the selection change.
+ * An event with no candidates means the candidate list should be hidden.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_TextEditingCandidatesEvent struct {
+	WindowID          SDL_WindowID /**< The window with keyboard focus, if any */
+	Candidates        []string     /**< The list of candidates, or nil if there are no candidates available */
+	SelectedCandidate int32        /**< The index of the selected candidate, or -1 if no candidate is selected */
+	Horizontal        bool         /**< true if the list is horizontal, false if it's vertical */
+}
+
+/* The candidate list currently shown in each window, guarded by editCandidatesLock */
+var editCandidatesLock = sdlMutex{name: "keyboard.candidates"}
+var editCandidates = map[SDL_WindowID]SDL_TextEditingCandidatesEvent{}
+
+/* Whether the application asked to draw the IME candidate list itself */
+func sdlIMEImplementsCandidates() bool {
+	for _, item := range strings.Split(SDL_GetHint(SDL_HINT_IME_IMPLEMENTED_UI), ",") {
+		if strings.EqualFold(strings.TrimSpace(item), "candidates") {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ * Called by IME backends when the conversion candidates of a window change.
+ * An empty list hides the candidates. Returns false if the application
+ * doesn't render candidates, in which case the backend shows the native UI.
+ */
+func sdlSendEditingTextCandidates(windowID SDL_WindowID, candidates []string, selected_candidate int32, horizontal bool) bool {
+	if !sdlIMEImplementsCandidates() {
+		return false
+	}
+
+	state := SDL_TextEditingCandidatesEvent{
+		WindowID:          windowID,
+		SelectedCandidate: -1,
+		Horizontal:        horizontal,
+	}
+	if len(candidates) > 0 {
+		state.Candidates = append([]string(nil), candidates...)
+		if selected_candidate >= 0 && int(selected_candidate) < len(candidates) {
+			state.SelectedCandidate = selected_candidate
+		}
+	}
+
+	editCandidatesLock.Lock()
+	if state.Candidates == nil {
+		delete(editCandidates, windowID)
+	} else {
+		editCandidates[windowID] = state
+	}
+	editCandidatesLock.Unlock()
+
+	if SDL_EventEnabled(SDL_EVENT_TEXT_EDITING_CANDIDATES) {
+		event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: SDL_EVENT_TEXT_EDITING_CANDIDATES}}
+		event.EditCandidates = state
+		event.EditCandidates.Candidates = append([]string(nil), state.Candidates...)
+		SDL_PushEvent(&event)
+	}
+	return true
+}
+
+/* Called by IME backends when composition ends in a window */
+func sdlClearEditingTextCandidates(windowID SDL_WindowID) {
+	editCandidatesLock.Lock()
+	_, shown := editCandidates[windowID]
+	editCandidatesLock.Unlock()
+
+	if shown {
+		sdlSendEditingTextCandidates(windowID, nil, -1, false)
+	}
+}
+
+/**
+ * Get the IME conversion candidates currently shown for a window.
+ *
+ * This is the state described by the latest
+ * SDL_EVENT_TEXT_EDITING_CANDIDATES event of the window, for applications
+ * that draw their IME UI each frame instead of tracking events.
+ *
+ * - windowID the window to query.
+ * Returns the candidates, or nil if there are none, and the index of the
+ *          selected candidate, or -1 if no candidate is selected, and
+ *          whether the list is horizontal.
+ *
+ * See also SDL_HINT_IME_IMPLEMENTED_UI
+ */
+func SDL_GetTextEditingCandidates(windowID SDL_WindowID) ([]string, int32, bool) {
+	editCandidatesLock.Lock()
+	defer editCandidatesLock.Unlock()
+
+	state, ok := editCandidates[windowID]
+	if !ok {
+		return nil, -1, false
+	}
+	return append([]string(nil), state.Candidates...), state.SelectedCandidate, state.Horizontal
+}
+
+func sdlQuitKeyboard() {
+	editCandidatesLock.Lock()
+	defer editCandidatesLock.Unlock()
+
+	clear(editCandidates)
+}
+
+/**
+ * Text input type.
+ *
+ * These are the valid values for SDL_PROP_TEXTINPUT_TYPE_NUMBER. Not every
+ * value is valid on every platform, but where a value isn't supported, a
+ * reasonable fallback will be used.
+ *
+ * This enum is available since SDL 3.0.0.
+ *
+ * See also SDL_StartTextInputWithProperties
+ */
+type SDL_TextInputType int
+
+const (
+	SDL_TEXTINPUT_TYPE_TEXT                    SDL_TextInputType = iota /**< The input is text */
+	SDL_TEXTINPUT_TYPE_TEXT_NAME                                        /**< The input is a person's name */
+	SDL_TEXTINPUT_TYPE_TEXT_EMAIL                                       /**< The input is an e-mail address */
+	SDL_TEXTINPUT_TYPE_TEXT_USERNAME                                    /**< The input is a username */
+	SDL_TEXTINPUT_TYPE_TEXT_PASSWORD_HIDDEN                             /**< The input is a secure password that is hidden */
+	SDL_TEXTINPUT_TYPE_TEXT_PASSWORD_VISIBLE                            /**< The input is a secure password that is visible */
+	SDL_TEXTINPUT_TYPE_NUMBER                                           /**< The input is a number */
+	SDL_TEXTINPUT_TYPE_NUMBER_PASSWORD_HIDDEN                           /**< The input is a secure PIN that is hidden */
+	SDL_TEXTINPUT_TYPE_NUMBER_PASSWORD_VISIBLE                          /**< The input is a secure PIN that is visible */
+)
+
+/**
+ * Auto capitalization type.
+ *
+ * These are the valid values for SDL_PROP_TEXTINPUT_CAPITALIZATION_NUMBER.
+ * Not every value is valid on every platform, but where a value isn't
+ * supported, a reasonable fallback will be used.
+ *
+ * This enum is available since SDL 3.0.0.
+ *
+ * See also SDL_StartTextInputWithProperties
+ */
+type SDL_Capitalization int
+
+const (
+	SDL_CAPITALIZE_NONE      SDL_Capitalization = iota /**< No auto-capitalization will be done */
+	SDL_CAPITALIZE_SENTENCES                           /**< The first letter of sentences will be capitalized */
+	SDL_CAPITALIZE_WORDS                               /**< The first letter of words will be capitalized */
+	SDL_CAPITALIZE_LETTERS                             /**< All letters will be capitalized */
+)
+
+const SDL_PROP_TEXTINPUT_TYPE_NUMBER = "SDL.textinput.type"
+const SDL_PROP_TEXTINPUT_CAPITALIZATION_NUMBER = "SDL.textinput.capitalization"
+const SDL_PROP_TEXTINPUT_AUTOCORRECT_BOOLEAN = "SDL.textinput.autocorrect"
+const SDL_PROP_TEXTINPUT_MULTILINE_BOOLEAN = "SDL.textinput.multiline"
+
+/**
+ * Start accepting Unicode text input events in a window.
+ *
+ * This function will enable text input (SDL_EVENT_TEXT_INPUT and
+ * SDL_EVENT_TEXT_EDITING events) in the specified window. Please use this
+ * function paired with SDL_StopTextInput().
+ *
+ * Text input events are not received by default.
+ *
+ * On some platforms using this function shows the screen keyboard and/or
+ * activates an IME, which can prevent some key press events from being
+ * passed through.
+ *
+ * - window the window to enable text input.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_StartTextInputWithProperties
+ * See also SDL_StopTextInput
+ * See also SDL_TextInputActive
+ */
+func SDL_StartTextInput(window *SDL_Window) bool {
+	return SDL_StartTextInputWithProperties(window, 0)
+}
+
+/* The capitalization used when SDL_PROP_TEXTINPUT_CAPITALIZATION_NUMBER isn't set */
+func sdlDefaultTextInputCapitalization(kind SDL_TextInputType) SDL_Capitalization {
+	switch kind {
+	case SDL_TEXTINPUT_TYPE_TEXT:
+		return SDL_CAPITALIZE_SENTENCES
+	case SDL_TEXTINPUT_TYPE_TEXT_NAME:
+		return SDL_CAPITALIZE_WORDS
+	default:
+		return SDL_CAPITALIZE_NONE
+	}
+}
+
+/**
+ * Start accepting Unicode text input events in a window, with properties
+ * describing the input.
+ *
+ * This function will enable text input (SDL_EVENT_TEXT_INPUT and
+ * SDL_EVENT_TEXT_EDITING events) in the specified window. Please use this
+ * function paired with SDL_StopTextInput().
+ *
+ * Text input events are not received by default.
+ *
+ * On some platforms using this function shows the screen keyboard and/or
+ * activates an IME, which can prevent some key press events from being
+ * passed through.
+ *
+ * These are the supported properties:
+ *
+ * - `SDL_PROP_TEXTINPUT_TYPE_NUMBER` - an SDL_TextInputType value that
+ *   describes text being input, defaults to SDL_TEXTINPUT_TYPE_TEXT.
+ * - `SDL_PROP_TEXTINPUT_CAPITALIZATION_NUMBER` - an SDL_Capitalization value
+ *   that describes how text should be capitalized, defaults to
+ *   SDL_CAPITALIZE_SENTENCES for normal text entry, SDL_CAPITALIZE_WORDS for
+ *   names, and SDL_CAPITALIZE_NONE for e-mail addresses, usernames, and
+ *   passwords.
+ * - `SDL_PROP_TEXTINPUT_AUTOCORRECT_BOOLEAN` - true to enable auto completion
+ *   and auto correction, defaults to true.
+ * - `SDL_PROP_TEXTINPUT_MULTILINE_BOOLEAN` - true if multiple lines of text
+ *   are allowed. This defaults to true.
+ *
+ * Calling this again while text input is active updates the properties.
+ *
+ * - window the window to enable text input.
+ * - props the properties to use, or 0 for the defaults.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_StartTextInput
+ * See also SDL_StopTextInput
+ * See also SDL_TextInputActive
+ */
+func SDL_StartTextInputWithProperties(window *SDL_Window, props SDL_PropertiesID) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+
+	kind := SDL_TextInputType(SDL_GetNumberProperty(props, SDL_PROP_TEXTINPUT_TYPE_NUMBER, int64(SDL_TEXTINPUT_TYPE_TEXT)))
+	if kind < SDL_TEXTINPUT_TYPE_TEXT || kind > SDL_TEXTINPUT_TYPE_NUMBER_PASSWORD_VISIBLE {
+		kind = SDL_TEXTINPUT_TYPE_TEXT
+	}
+	capitalization := SDL_Capitalization(SDL_GetNumberProperty(props, SDL_PROP_TEXTINPUT_CAPITALIZATION_NUMBER, int64(sdlDefaultTextInputCapitalization(kind))))
+	if capitalization < SDL_CAPITALIZE_NONE || capitalization > SDL_CAPITALIZE_LETTERS {
+		capitalization = sdlDefaultTextInputCapitalization(kind)
+	}
+
+	window.text_input_type = kind
+	window.text_input_capitalization = capitalization
+	window.text_input_autocorrect = SDL_GetBooleanProperty(props, SDL_PROP_TEXTINPUT_AUTOCORRECT_BOOLEAN, true)
+	window.text_input_multiline = SDL_GetBooleanProperty(props, SDL_PROP_TEXTINPUT_MULTILINE_BOOLEAN, true)
+	window.text_input_active = true
+	return true
+}
+
+/**
+ * Check whether or not Unicode text input events are enabled for a window.
+ *
+ * - window the window to check.
+ * Returns true if text input events are enabled else false.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_StartTextInput
+ */
+func SDL_TextInputActive(window *SDL_Window) bool {
+	return window != nil && sdlValidHandle(sdlHandleTypeWindow, window.handle, window) && window.text_input_active
+}
+
+/**
+ * Stop receiving any text input events in a window.
+ *
+ * If SDL_StartTextInput() showed the screen keyboard, this function will hide
+ * it. Any composition in progress is discarded, along with its candidate
+ * list.
+ *
+ * - window the window to disable text input.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_StartTextInput
+ */
+func SDL_StopTextInput(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if window.text_input_active {
+		window.text_input_active = false
+		sdlClearEditingTextCandidates(window.id)
+	}
+	return true
+}
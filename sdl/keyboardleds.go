@@ -0,0 +1,106 @@
+package sdl
+
+/*
+ * Keyboard LED control.
+ *
+ * This is an extension to the SDL API. The lock LEDs are driven directly,
+ * without changing the lock state the keyboard reports, so they can be used
+ * as visual cues, e.g. for accessibility or rhythm game feedback. Keyboards
+ * with an RGB backlight can also be tinted.
+ *
+ * A platform backend registers its implementation from an init() function;
+ * on platforms without one, the functions fail with SDL_Unsupported().
+ */
+
+/**
+ * Keyboard LEDs, a bitmask.
+ *
+ * See also SDL_SetKeyboardLEDs
+ */
+type SDL_KeyboardLEDFlags uint32
+
+const (
+	SDL_KEYBOARD_LED_NUM_LOCK    SDL_KeyboardLEDFlags = 0x01 /**< The Num Lock LED */
+	SDL_KEYBOARD_LED_CAPS_LOCK   SDL_KeyboardLEDFlags = 0x02 /**< The Caps Lock LED */
+	SDL_KEYBOARD_LED_SCROLL_LOCK SDL_KeyboardLEDFlags = 0x04 /**< The Scroll Lock LED */
+	SDL_KEYBOARD_LED_ALL                              = SDL_KEYBOARD_LED_NUM_LOCK | SDL_KEYBOARD_LED_CAPS_LOCK | SDL_KEYBOARD_LED_SCROLL_LOCK
+)
+
+/* Entry points of the platform LED backend, the functions set an error on failure */
+type sdlKeyboardLEDBackend struct {
+	SetLEDs     func(leds SDL_KeyboardLEDFlags, mask SDL_KeyboardLEDFlags) bool
+	GetLEDs     func() (SDL_KeyboardLEDFlags, bool)
+	SetLEDColor func(r, g, b uint8) bool
+}
+
+var keyboardLEDBackend *sdlKeyboardLEDBackend
+
+/**
+ * Turn keyboard LEDs on or off.
+ *
+ * Only the LEDs in `mask` are changed, the others are left alone. The LEDs
+ * of every connected keyboard that allows it are set.
+ *
+ * The lock state itself isn't changed, the keyboard may turn the LEDs back
+ * to match it when a lock key is pressed.
+ *
+ * - leds the LEDs to turn on, the other LEDs in `mask` are turned off.
+ * - mask the LEDs to change.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_GetKeyboardLEDs
+ * See also SDL_SetKeyboardLEDColor
+ */
+func SDL_SetKeyboardLEDs(leds SDL_KeyboardLEDFlags, mask SDL_KeyboardLEDFlags) bool {
+	if mask&^SDL_KEYBOARD_LED_ALL != 0 {
+		return SDL_InvalidParamError("mask")
+	}
+	if keyboardLEDBackend == nil || keyboardLEDBackend.SetLEDs == nil {
+		return SDL_Unsupported()
+	}
+	if mask == 0 {
+		return true
+	}
+	return keyboardLEDBackend.SetLEDs(leds&mask, mask)
+}
+
+/**
+ * Query which keyboard LEDs are lit.
+ *
+ * With several keyboards connected, an LED counts as lit if it is lit on
+ * any of them.
+ *
+ * Returns the lit LEDs, or 0 on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_SetKeyboardLEDs
+ */
+func SDL_GetKeyboardLEDs() SDL_KeyboardLEDFlags {
+	if keyboardLEDBackend == nil || keyboardLEDBackend.GetLEDs == nil {
+		SDL_Unsupported()
+		return 0
+	}
+	leds, _ := keyboardLEDBackend.GetLEDs()
+	return leds
+}
+
+/**
+ * Set the color of an RGB keyboard backlight.
+ *
+ * Black turns the backlight off.
+ *
+ * - r the red component of the color.
+ * - g the green component of the color.
+ * - b the blue component of the color.
+ * Returns true on success or false on failure, e.g. if no keyboard has an RGB
+ *          backlight; call SDL_GetError() for more information.
+ *
+ * See also SDL_SetKeyboardLEDs
+ */
+func SDL_SetKeyboardLEDColor(r uint8, g uint8, b uint8) bool {
+	if keyboardLEDBackend == nil || keyboardLEDBackend.SetLEDColor == nil {
+		return SDL_Unsupported()
+	}
+	return keyboardLEDBackend.SetLEDColor(r, g, b)
+}
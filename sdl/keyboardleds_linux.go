@@ -0,0 +1,187 @@
+package sdl
+
+import "encoding/binary"
+import "fmt"
+import "os"
+import "path/filepath"
+import "strconv"
+import "strings"
+
+/*
+ * Keyboard LEDs on Linux, through the LED class devices the input layer
+ * creates for each keyboard, e.g. /sys/class/leds/input3::capslock. Writing
+ * them usually needs root or a udev rule granting access.
+ *
+ * RGB backlights are multicolor LED class devices named like
+ * "rgb:kbd_backlight", their channels are listed in multi_index.
+ *
+ * Without any readable LED class device, e.g. in a container or on a remote
+ * display, the LEDs are queried from the X server with GetKeyboardControl.
+ * They aren't set through X: the default XKB indicator maps don't allow
+ * explicit changes of the lock LEDs, so ChangeKeyboardControl is ignored.
+ */
+
+const sdlLEDClassPath = "/sys/class/leds"
+
+var sdlLinuxKeyboardLEDNames = []struct {
+	led  SDL_KeyboardLEDFlags
+	name string
+}{
+	{SDL_KEYBOARD_LED_NUM_LOCK, "numlock"},
+	{SDL_KEYBOARD_LED_CAPS_LOCK, "capslock"},
+	{SDL_KEYBOARD_LED_SCROLL_LOCK, "scrolllock"},
+}
+
+func init() {
+	keyboardLEDBackend = &sdlKeyboardLEDBackend{
+		SetLEDs:     sdlLinuxSetKeyboardLEDs,
+		GetLEDs:     sdlLinuxGetKeyboardLEDs,
+		SetLEDColor: sdlLinuxSetKeyboardLEDColor,
+	}
+}
+
+/* The LED class devices of every keyboard for an LED */
+func sdlLinuxFindKeyboardLEDs(name string) []string {
+	paths, _ := filepath.Glob(filepath.Join(sdlLEDClassPath, "input*::"+name))
+	return paths
+}
+
+func sdlLinuxReadLEDValue(path, attribute string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(path, attribute))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func sdlLinuxWriteLEDValue(path, attribute, value string) error {
+	return os.WriteFile(filepath.Join(path, attribute), []byte(value), 0)
+}
+
+func sdlLinuxSetKeyboardLEDs(leds SDL_KeyboardLEDFlags, mask SDL_KeyboardLEDFlags) bool {
+	found := false
+	for _, entry := range sdlLinuxKeyboardLEDNames {
+		if mask&entry.led == 0 {
+			continue
+		}
+		value := tern(leds&entry.led != 0, "1", "0")
+		for _, path := range sdlLinuxFindKeyboardLEDs(entry.name) {
+			if err := sdlLinuxWriteLEDValue(path, "brightness", value); err != nil {
+				return SDL_SetErrorf("Couldn't set keyboard LED %s: %v", filepath.Base(path), err)
+			}
+			found = true
+		}
+	}
+	if !found {
+		return SDL_SetError("No keyboard LEDs found")
+	}
+	return true
+}
+
+func sdlLinuxGetKeyboardLEDs() (SDL_KeyboardLEDFlags, bool) {
+	var leds SDL_KeyboardLEDFlags
+	found := false
+	for _, entry := range sdlLinuxKeyboardLEDNames {
+		for _, path := range sdlLinuxFindKeyboardLEDs(entry.name) {
+			brightness, err := sdlLinuxReadLEDValue(path, "brightness")
+			if err != nil {
+				continue
+			}
+			found = true
+			if brightness > 0 {
+				leds |= entry.led
+			}
+		}
+	}
+	if !found {
+		if os.Getenv("DISPLAY") != "" {
+			return sdlX11GetKeyboardLEDs()
+		}
+		return 0, SDL_SetError("No keyboard LEDs found")
+	}
+	return leds, true
+}
+
+/*
+ * The LED numbers of the X keyboard, as xkeyboard-config assigns them to the
+ * "Caps Lock", "Num Lock" and "Scroll Lock" indicators.
+ */
+var sdlX11KeyboardLEDNumbers = []struct {
+	led    SDL_KeyboardLEDFlags
+	number int
+}{
+	{SDL_KEYBOARD_LED_CAPS_LOCK, 1},
+	{SDL_KEYBOARD_LED_NUM_LOCK, 2},
+	{SDL_KEYBOARD_LED_SCROLL_LOCK, 3},
+}
+
+/* Query the LEDs from the led-mask of the X server's keyboard control */
+func sdlX11GetKeyboardLEDs() (SDL_KeyboardLEDFlags, bool) {
+	x := sdlX11Connect()
+	if x == nil {
+		return 0, false
+	}
+	defer x.Close()
+
+	reply := x.Call(sdlX11GetKeyboardControl, 0)
+	if reply == nil {
+		return 0, false
+	}
+	if len(reply) < 12 {
+		return 0, SDL_SetError("Invalid GetKeyboardControl reply")
+	}
+	return sdlX11KeyboardLEDsFromMask(binary.LittleEndian.Uint32(reply[8:])), true
+}
+
+func sdlX11KeyboardLEDsFromMask(mask uint32) SDL_KeyboardLEDFlags {
+	var leds SDL_KeyboardLEDFlags
+	for _, entry := range sdlX11KeyboardLEDNumbers {
+		if mask&(1<<(entry.number-1)) != 0 {
+			leds |= entry.led
+		}
+	}
+	return leds
+}
+
+func sdlLinuxSetKeyboardLEDColor(r, g, b uint8) bool {
+	paths, _ := filepath.Glob(filepath.Join(sdlLEDClassPath, "*rgb:kbd_backlight*"))
+	if len(paths) == 0 {
+		return SDL_SetError("No RGB keyboard backlight found")
+	}
+
+	for _, path := range paths {
+		index, err := os.ReadFile(filepath.Join(path, "multi_index"))
+		if err != nil {
+			return SDL_SetErrorf("Couldn't read %s channels: %v", filepath.Base(path), err)
+		}
+		/* Channel intensities go up to max_brightness, like the overall brightness */
+		max_brightness, err := sdlLinuxReadLEDValue(path, "max_brightness")
+		if err != nil {
+			return SDL_SetErrorf("Couldn't read %s: %v", filepath.Base(path), err)
+		}
+		scale := func(c uint8) string {
+			return strconv.Itoa(int(c) * max_brightness / 255)
+		}
+		var intensities []string
+		for _, channel := range strings.Fields(string(index)) {
+			switch channel {
+			case "red":
+				intensities = append(intensities, scale(r))
+			case "green":
+				intensities = append(intensities, scale(g))
+			case "blue":
+				intensities = append(intensities, scale(b))
+			default:
+				intensities = append(intensities, "0")
+			}
+		}
+
+		if err := sdlLinuxWriteLEDValue(path, "multi_intensity", strings.Join(intensities, " ")); err != nil {
+			return SDL_SetErrorf("Couldn't set %s color: %v", filepath.Base(path), err)
+		}
+		if err := sdlLinuxWriteLEDValue(path, "brightness", fmt.Sprint(max_brightness)); err != nil {
+			return SDL_SetErrorf("Couldn't set %s brightness: %v", filepath.Base(path), err)
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package sdl
+
+/*
+ * Kiosk mode, for unattended deployments like museum installations and
+ * arcade cabinets.
+ *
+ * Kiosk mode is a combination of the regular window settings: borderless
+ * desktop fullscreen on the chosen display, always on top, keyboard and
+ * mouse grabs, and the screen saver disabled. The keyboard grab keeps the
+ * system shortcuts away from the user where the platform allows it. The
+ * previous settings are saved and restored when leaving kiosk mode.
+ */
+
+/* The window and global settings to restore when leaving kiosk mode */
+type sdlKioskState struct {
+	windowed        SDL_Rect
+	bordered        bool
+	always_on_top   bool
+	fullscreen      bool
+	fullscreen_mode SDL_DisplayMode /* W is 0 for desktop fullscreen */
+	mouse_grab      bool
+	keyboard_grab   bool
+	screensaver     bool /* the screen saver was enabled */
+	alt_tab_hint    bool /* SDL_HINT_ALLOW_ALT_TAB_WHILE_GRABBED was set for kiosk mode */
+}
+
+/* Put back the settings changed for kiosk mode */
+func sdlRestoreKioskState(window *SDL_Window, state *sdlKioskState) {
+	SDL_SetWindowMouseGrab(window, state.mouse_grab)
+	SDL_SetWindowKeyboardGrab(window, state.keyboard_grab)
+	if state.alt_tab_hint {
+		SDL_ResetHint(SDL_HINT_ALLOW_ALT_TAB_WHILE_GRABBED)
+	}
+	if state.screensaver {
+		SDL_EnableScreenSaver()
+	}
+
+	if state.fullscreen_mode.W != 0 {
+		SDL_SetWindowFullscreenMode(window, &state.fullscreen_mode)
+	} else {
+		SDL_SetWindowFullscreenMode(window, nil)
+	}
+	SDL_SetWindowFullscreen(window, state.fullscreen)
+	SDL_SetWindowAlwaysOnTop(window, state.always_on_top)
+	SDL_SetWindowBordered(window, state.bordered)
+	if !state.fullscreen {
+		SDL_SetWindowPosition(window, state.windowed.X, state.windowed.Y)
+	}
+}
+
+/**
+ * Put a window in kiosk mode on a display.
+ *
+ * The window becomes a borderless desktop fullscreen window on the display,
+ * always on top of the others, grabs the keyboard and the mouse, and the
+ * screen saver is disabled while it is in kiosk mode.
+ *
+ * The keyboard grab keeps system shortcuts like Alt+Tab from reaching the
+ * window manager where the platform allows it, unless the application set
+ * `SDL_HINT_ALLOW_ALT_TAB_WHILE_GRABBED` itself. Some shortcuts can't be
+ * captured by any application, e.g. Ctrl+Alt+Del on Windows, and some video
+ * drivers can't grab the keyboard at all; the window is in kiosk mode
+ * without the grab then.
+ *
+ * - window the window to put in kiosk mode.
+ * - displayID the display to cover, or 0 for the display the window is on.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_LeaveKioskMode
+ * See also SDL_GetWindowKioskMode
+ */
+func SDL_EnterKioskMode(window *SDL_Window, displayID SDL_DisplayID) bool {
+	if !sdlCheckWindowNotPopup(window) {
+		return false
+	}
+	if window.kiosk != nil {
+		return SDL_SetError("Window is already in kiosk mode")
+	}
+	if displayID == 0 {
+		displayID = SDL_GetDisplayForWindow(window)
+	}
+	var bounds SDL_Rect
+	if !SDL_GetDisplayBounds(displayID, &bounds) {
+		return false
+	}
+
+	state := &sdlKioskState{
+		windowed:        SDL_Rect{X: window.x, Y: window.y, W: window.w, H: window.h},
+		bordered:        window.flags&SDL_WINDOW_BORDERLESS == 0,
+		always_on_top:   window.flags&SDL_WINDOW_ALWAYS_ON_TOP != 0,
+		fullscreen:      window.flags&SDL_WINDOW_FULLSCREEN != 0,
+		fullscreen_mode: window.requested_fullscreen_mode,
+		mouse_grab:      window.flags&SDL_WINDOW_MOUSE_GRABBED != 0,
+		keyboard_grab:   window.flags&SDL_WINDOW_KEYBOARD_GRABBED != 0,
+	}
+	if state.fullscreen {
+		state.windowed = window.windowed
+	}
+
+	/* Desktop fullscreen follows the window to its display */
+	if state.fullscreen && window.fullscreen_display != displayID {
+		SDL_SetWindowFullscreen(window, false)
+	}
+	SDL_SetWindowFullscreenMode(window, nil)
+	if window.flags&SDL_WINDOW_FULLSCREEN == 0 {
+		SDL_SetWindowPosition(window, SDL_WINDOWPOS_CENTERED_DISPLAY(displayID), SDL_WINDOWPOS_CENTERED_DISPLAY(displayID))
+	}
+	SDL_SetWindowBordered(window, false)
+	SDL_SetWindowAlwaysOnTop(window, true)
+	if !SDL_SetWindowFullscreen(window, true) {
+		sdlRestoreKioskState(window, state)
+		return false
+	}
+	SDL_ShowWindow(window)
+	SDL_RaiseWindow(window)
+
+	if _, isSet := sdlLookupHint(SDL_HINT_ALLOW_ALT_TAB_WHILE_GRABBED); !isSet {
+		SDL_SetHint(SDL_HINT_ALLOW_ALT_TAB_WHILE_GRABBED, "0")
+		state.alt_tab_hint = true
+	}
+	/* Not every driver can grab, kiosk mode goes on without */
+	SDL_SetWindowKeyboardGrab(window, true)
+	SDL_SetWindowMouseGrab(window, true)
+
+	if SDL_ScreenSaverEnabled() {
+		SDL_DisableScreenSaver()
+		state.screensaver = true
+	}
+
+	window.kiosk = state
+	return true
+}
+
+/**
+ * Take a window out of kiosk mode.
+ *
+ * The window settings changed by SDL_EnterKioskMode() are restored, and the
+ * screen saver is enabled again if it was enabled before.
+ *
+ * - window the window in kiosk mode.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_EnterKioskMode
+ */
+func SDL_LeaveKioskMode(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	state := window.kiosk
+	if state == nil {
+		return SDL_SetError("Window is not in kiosk mode")
+	}
+
+	window.kiosk = nil
+	sdlRestoreKioskState(window, state)
+	return true
+}
+
+/**
+ * Get whether a window is in kiosk mode.
+ *
+ * - window the window to query.
+ * Returns true if the window is in kiosk mode, false otherwise.
+ *
+ * See also SDL_EnterKioskMode
+ */
+func SDL_GetWindowKioskMode(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	return window.kiosk != nil
+}
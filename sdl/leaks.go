@@ -0,0 +1,185 @@
+package sdl
+
+import "cmp"
+import "fmt"
+import "runtime"
+import "slices"
+import "strings"
+import "weak"
+
+/*
+ * Resource leak detection.
+ *
+ * In debug builds, with SDL_ASSERT_LEVEL 3 or higher, objects the
+ * application must destroy are recorded with the stack that created them.
+ * SDL_Quit() reports the ones still alive before the subsystems clean them
+ * up, along with the events nobody read, and raises an assertion if any
+ * object leaked. Objects SDL owns itself, like window surfaces, aren't
+ * tracked.
+ *
+ * Recording a stack costs an allocation per object, so in release builds
+ * nothing is tracked.
+ *
+ * The records hold weak pointers, so tracking doesn't keep an object alive.
+ * Objects that only hold memory, like surfaces, also get a cleanup that
+ * runs if the garbage collector reclaims them before they were destroyed:
+ * it releases what the object still accounted for and logs a warning with
+ * the creation site, so a forgotten SDL_DestroySurface() is found without
+ * waiting for SDL_Quit().
+ *
+ * Objects with a handle, windows, renderers, textures and audio streams,
+ * can't get a cleanup: the handle table references them until they are
+ * destroyed, so the garbage collector never reclaims them and the cleanup
+ * would never run. They are only reported by SDL_Quit(), their constructors
+ * call sdlTrackObject() and their destructors sdlUntrackObject(). Windows
+ * and renderers do; textures and audio streams have handle kinds reserved
+ * but no implementation yet, and must follow the same pattern when they are
+ * added.
+ */
+
+/* The deepest creation stack recorded for a leaked object */
+const sdlLeakStackDepth = 16
+
+type sdlLeakRecord struct {
+	kind  string    /* e.g. "window" */
+	id    uint64    /* a serial number, to keep the report in creation order */
+	stack []uintptr /* the creation stack, see runtime.Callers() */
+}
+
+/* Private data -- live objects by weak pointer, guarded by leaksLock */
+var leaksLock = sdlMutex{name: "leaks"}
+var leakedObjects = map[any]sdlLeakRecord{}
+var leakSerial uint64
+
+func sdlLeakDetectionEnabled() bool {
+	return SDL_ASSERT_LEVEL >= 3
+}
+
+/* Record the creation of an object the application must destroy */
+func sdlTrackObject[T any](kind string, object *T) {
+	if !sdlLeakDetectionEnabled() {
+		return
+	}
+	stack := make([]uintptr, sdlLeakStackDepth)
+	stack = stack[:runtime.Callers(3, stack)] /* skip runtime.Callers, this function and the constructor */
+
+	leaksLock.Lock()
+	defer leaksLock.Unlock()
+
+	leakSerial++
+	leakedObjects[weak.Make(object)] = sdlLeakRecord{kind: kind, id: leakSerial, stack: stack}
+}
+
+/* Forget an object, when it is destroyed or SDL takes ownership of it */
+func sdlUntrackObject[T any](object *T) {
+	leaksLock.Lock()
+	defer leaksLock.Unlock()
+
+	delete(leakedObjects, weak.Make(object))
+}
+
+/* What the cleanup of a collected object needs, it must not reference the object */
+type sdlCollectedObject struct {
+	kind    string
+	key     any        /* the weak pointer of the object, its key in leakedObjects */
+	caller  [1]uintptr /* the creation site, when there is no leak record with the whole stack */
+	release func()     /* releases what the object accounted for, may be nil */
+}
+
+/*
+ * Add the safety net of an object the application must destroy, the
+ * returned cleanup is stopped when it is. Called by the constructor, right
+ * after sdlTrackObject().
+ */
+func sdlAddObjectCleanup[T any](kind string, object *T, release func()) runtime.Cleanup {
+	collected := &sdlCollectedObject{kind: kind, key: weak.Make(object), release: release}
+	runtime.Callers(3, collected.caller[:]) /* skip runtime.Callers, this function and the constructor */
+	return runtime.AddCleanup(object, sdlOnObjectCollected, collected)
+}
+
+/* Runs on the cleanup goroutine once a leaked object is unreachable */
+func sdlOnObjectCollected(collected *sdlCollectedObject) {
+	if collected.release != nil {
+		collected.release()
+	}
+
+	leaksLock.Lock()
+	record, tracked := leakedObjects[collected.key]
+	delete(leakedObjects, collected.key)
+	leaksLock.Unlock()
+
+	stack := collected.caller[:]
+	if tracked {
+		stack = record.stack
+	}
+	SDL_LogWarn(SDL_LOG_CATEGORY_ASSERT, "A %s was garbage collected without being destroyed, created at:%s", collected.kind, sdlFormatLeakStack(stack))
+}
+
+func sdlFormatLeakStack(stack []uintptr) string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "\n\t    %s\n\t        %s:%d", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+/* Count the queued events by type, in order of first appearance */
+func sdlUndeliveredEvents() string {
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	counts := map[SDL_EventType]int{}
+	var order []SDL_EventType
+	for i := range eventQ.events {
+		kind := eventQ.events[i].Type
+		if counts[kind] == 0 {
+			order = append(order, kind)
+		}
+		counts[kind]++
+	}
+
+	var parts []string
+	for _, kind := range order {
+		parts = append(parts, fmt.Sprintf("%s x%d", sdlEventTypeName(kind), counts[kind]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+/*
+ * Report the objects still alive and the events still queued, called by
+ * SDL_Quit() before the subsystems are shut down.
+ */
+func sdlReportLeaks() {
+	if !sdlLeakDetectionEnabled() {
+		return
+	}
+
+	if events := sdlUndeliveredEvents(); events != "" {
+		SDL_LogWarn(SDL_LOG_CATEGORY_ASSERT, "Undelivered events at SDL_Quit(): %s", events)
+	}
+
+	leaksLock.Lock()
+	records := make([]sdlLeakRecord, 0, len(leakedObjects))
+	for _, record := range leakedObjects {
+		records = append(records, record)
+	}
+	clear(leakedObjects)
+	leaksLock.Unlock()
+
+	if len(records) == 0 {
+		return
+	}
+	slices.SortFunc(records, func(a, b sdlLeakRecord) int {
+		return cmp.Compare(a.id, b.id)
+	})
+	for _, record := range records {
+		SDL_LogError(SDL_LOG_CATEGORY_ASSERT, "Leaked %s, created at:%s", record.kind, sdlFormatLeakStack(record.stack))
+	}
+	SDL_LogError(SDL_LOG_CATEGORY_ASSERT, "%d object(s) were not destroyed before SDL_Quit()", len(records))
+	SDL_assert(false)
+}
@@ -0,0 +1,168 @@
+package sdl
+
+import "fmt"
+import "sync"
+
+/*
+ * Lock order verification for the package mutexes.
+ *
+ * Every package-level lock that can be held while another one is taken is a
+ * sdlMutex or sdlRWMutex with a class name. In debug builds, with
+ * SDL_ASSERT_LEVEL 3 or higher, each acquisition is recorded against the
+ * locks the goroutine already holds, building a graph of "A was held while
+ * taking B" edges. Taking B while holding A after A has been taken while
+ * holding B (directly or through other locks) could deadlock. It is
+ * reported with SDL_assert once the goroutine has released all of its
+ * locks, since logging and the assertion handler take locks themselves.
+ *
+ * Bookkeeping costs a goroutine id lookup per lock operation, so in release
+ * builds the wrappers are plain mutexes.
+ */
+
+/* A mutex taking part in lock order verification */
+type sdlMutex struct {
+	mutex sync.Mutex
+	name  string
+}
+
+func (m *sdlMutex) Lock() {
+	sdlLockOrderAcquire(m.name)
+	m.mutex.Lock()
+}
+
+func (m *sdlMutex) Unlock() {
+	m.mutex.Unlock()
+	sdlLockOrderRelease(m.name)
+}
+
+/* A reader/writer mutex taking part in lock order verification */
+type sdlRWMutex struct {
+	mutex sync.RWMutex
+	name  string
+}
+
+func (m *sdlRWMutex) Lock() {
+	sdlLockOrderAcquire(m.name)
+	m.mutex.Lock()
+}
+
+func (m *sdlRWMutex) Unlock() {
+	m.mutex.Unlock()
+	sdlLockOrderRelease(m.name)
+}
+
+/* Readers are checked too, a reader waiting on a writer deadlocks just the same */
+func (m *sdlRWMutex) RLock() {
+	sdlLockOrderAcquire(m.name)
+	m.mutex.RLock()
+}
+
+func (m *sdlRWMutex) RUnlock() {
+	m.mutex.RUnlock()
+	sdlLockOrderRelease(m.name)
+}
+
+/* Private data -- lock order verifier, guarded by its own plain mutex */
+var lockOrder struct {
+	mutex    sync.Mutex
+	held     map[uint64][]string        /* locks held by each goroutine, in acquisition order */
+	after    map[string]map[string]bool /* after[A][B]: B was taken while holding A */
+	reported map[[2]string]bool         /* inversions already asserted on */
+	pending  map[uint64][]string        /* inversions found by each goroutine, reported when it holds no locks */
+}
+
+/* Reports an inversion, set in init() because logging takes verified locks */
+var lockOrderReport func(message string)
+
+func init() {
+	lockOrderReport = func(message string) {
+		SDL_LogError(SDL_LOG_CATEGORY_ASSERT, "%s", message)
+		SDL_assert(false)
+	}
+}
+
+func sdlLockOrderEnabled() bool {
+	return SDL_ASSERT_LEVEL >= 3
+}
+
+/* Whether `to` has been taken while holding `from`, directly or indirectly. Must be called with lockOrder.mutex held. */
+func sdlLockOrderReachableLocked(from, to string) bool {
+	visited := map[string]bool{from: true}
+	pending := []string{from}
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for next := range lockOrder.after[name] {
+			if next == to {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				pending = append(pending, next)
+			}
+		}
+	}
+	return false
+}
+
+func sdlLockOrderAcquire(name string) {
+	if !sdlLockOrderEnabled() {
+		return
+	}
+	id := goroutineID()
+
+	lockOrder.mutex.Lock()
+	defer lockOrder.mutex.Unlock()
+
+	if lockOrder.held == nil {
+		lockOrder.held = make(map[uint64][]string)
+		lockOrder.after = make(map[string]map[string]bool)
+		lockOrder.reported = make(map[[2]string]bool)
+		lockOrder.pending = make(map[uint64][]string)
+	}
+	for _, holding := range lockOrder.held[id] {
+		if holding == name {
+			continue
+		}
+		if sdlLockOrderReachableLocked(name, holding) && !lockOrder.reported[[2]string{holding, name}] {
+			lockOrder.reported[[2]string{holding, name}] = true
+			message := fmt.Sprintf("Lock order inversion: taking '%s' while holding '%s', which has been taken while holding '%s' before", name, holding, name)
+			lockOrder.pending[id] = append(lockOrder.pending[id], message)
+		}
+		if lockOrder.after[holding] == nil {
+			lockOrder.after[holding] = make(map[string]bool)
+		}
+		lockOrder.after[holding][name] = true
+	}
+	lockOrder.held[id] = append(lockOrder.held[id], name)
+}
+
+func sdlLockOrderRelease(name string) {
+	if !sdlLockOrderEnabled() {
+		return
+	}
+	id := goroutineID()
+
+	lockOrder.mutex.Lock()
+	held := lockOrder.held[id]
+	for i := len(held) - 1; i >= 0; i-- {
+		if held[i] == name {
+			held = append(held[:i], held[i+1:]...)
+			break
+		}
+	}
+	var pending []string
+	if len(held) == 0 {
+		delete(lockOrder.held, id)
+		pending = lockOrder.pending[id]
+		delete(lockOrder.pending, id)
+	} else {
+		lockOrder.held[id] = held
+	}
+	lockOrder.mutex.Unlock()
+
+	/* The outermost lock is released, reporting can't deadlock on the locks the goroutine held */
+	for _, message := range pending {
+		lockOrderReport(message)
+	}
+}
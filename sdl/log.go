@@ -0,0 +1,639 @@
+package sdl
+
+import "fmt"
+import "os"
+import "strconv"
+import "strings"
+
+/**
+ * Simple log messages with priorities and categories. A message's
+ * SDL_LogPriority signifies how important the message is. A message's
+ * SDL_LogCategory signifies from what domain it belongs to. Every category
+ * has a minimum priority specified: when a message belongs to that category,
+ * it will only be sent out if it has that minimum priority or higher.
+ *
+ * SDL's own logs are sent below the default priority threshold, so they are
+ * quiet by default.
+ *
+ * You can change the log verbosity programmatically using
+ * SDL_SetLogPriority() or with SDL_SetHint(SDL_HINT_LOGGING, ...), or with
+ * the "SDL_LOGGING" environment variable. This variable is a comma separated
+ * set of category=level tokens that define the default logging levels for
+ * SDL applications.
+ *
+ * The category can be a numeric category, one of "app", "error", "assert",
+ * "system", "audio", "video", "render", "input", "test", "gpu", or `*` for any
+ * unspecified category.
+ *
+ * The level can be a numeric level, one of "trace", "verbose", "debug",
+ * "info", "warn", "error", "critical", or "quiet" to disable that category.
+ *
+ * You can omit the category if you want to set the logging level for all
+ * categories.
+ *
+ * If this hint isn't set, the default log levels are equivalent to:
+ *
+ * `app=info,assert=warn,test=verbose,*=error`
+ *
+ * Here's where the messages go on different platforms:
+ *
+ * - Windows: debug output stream
+ * - Android: log output
+ * - Others: standard error output (stderr)
+ */
+
+/**
+ * A variable controlling the default SDL log levels.
+ *
+ * This variable is a comma separated set of category=level tokens that
+ * define the default logging levels for SDL applications, see the
+ * description at the top of log.go for the syntax.
+ *
+ * This hint can be set anytime.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_LOGGING = "SDL_LOGGING"
+
+/**
+ * The predefined log categories
+ *
+ * By default the application and gpu categories are enabled at the INFO
+ * level, the assert category is enabled at the WARN level, test is enabled
+ * at the VERBOSE level and all other categories are enabled at the ERROR
+ * level.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_LogCategory int
+
+const (
+	SDL_LOG_CATEGORY_APPLICATION SDL_LogCategory = iota
+	SDL_LOG_CATEGORY_ERROR
+	SDL_LOG_CATEGORY_ASSERT
+	SDL_LOG_CATEGORY_SYSTEM
+	SDL_LOG_CATEGORY_AUDIO
+	SDL_LOG_CATEGORY_VIDEO
+	SDL_LOG_CATEGORY_RENDER
+	SDL_LOG_CATEGORY_INPUT
+	SDL_LOG_CATEGORY_TEST
+	SDL_LOG_CATEGORY_GPU
+
+	/* Reserved for future SDL library use */
+	SDL_LOG_CATEGORY_RESERVED2
+	SDL_LOG_CATEGORY_RESERVED3
+	SDL_LOG_CATEGORY_RESERVED4
+	SDL_LOG_CATEGORY_RESERVED5
+	SDL_LOG_CATEGORY_RESERVED6
+	SDL_LOG_CATEGORY_RESERVED7
+	SDL_LOG_CATEGORY_RESERVED8
+	SDL_LOG_CATEGORY_RESERVED9
+	SDL_LOG_CATEGORY_RESERVED10
+
+	/* Beyond this point is reserved for application use, e.g.
+	   enum {
+	       MYAPP_CATEGORY_AWESOME1 = SDL_LOG_CATEGORY_CUSTOM,
+	       MYAPP_CATEGORY_AWESOME2,
+	       MYAPP_CATEGORY_AWESOME3,
+	       ...
+	   };
+	*/
+	SDL_LOG_CATEGORY_CUSTOM
+)
+
+/**
+ * The predefined log priorities
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_LogPriority int
+
+const (
+	SDL_LOG_PRIORITY_INVALID SDL_LogPriority = iota
+	SDL_LOG_PRIORITY_TRACE
+	SDL_LOG_PRIORITY_VERBOSE
+	SDL_LOG_PRIORITY_DEBUG
+	SDL_LOG_PRIORITY_INFO
+	SDL_LOG_PRIORITY_WARN
+	SDL_LOG_PRIORITY_ERROR
+	SDL_LOG_PRIORITY_CRITICAL
+	SDL_LOG_PRIORITY_COUNT
+)
+
+/* Names used in SDL_HINT_LOGGING, indexed by category */
+var logCategoryNames = []string{
+	"APP",
+	"ERROR",
+	"ASSERT",
+	"SYSTEM",
+	"AUDIO",
+	"VIDEO",
+	"RENDER",
+	"INPUT",
+	"TEST",
+	"GPU",
+}
+
+/* Names used in SDL_HINT_LOGGING, indexed by priority */
+var logPriorityNames = [SDL_LOG_PRIORITY_COUNT]string{
+	"",
+	"TRACE",
+	"VERBOSE",
+	"DEBUG",
+	"INFO",
+	"WARN",
+	"ERROR",
+	"CRITICAL",
+}
+
+var defaultLogPriorityPrefixes = [SDL_LOG_PRIORITY_COUNT]string{
+	"",
+	"TRACE: ",
+	"VERBOSE: ",
+	"DEBUG: ",
+	"INFO: ",
+	"WARN: ",
+	"ERROR: ",
+	"CRITICAL: ",
+}
+
+var logLock = sdlMutex{name: "log"}
+var logPriorities map[SDL_LogCategory]SDL_LogPriority
+var logDefaultPriority SDL_LogPriority = SDL_LOG_PRIORITY_INVALID
+var logPriorityPrefixes = defaultLogPriorityPrefixes
+var logFunction SDL_LogOutputFunction = sdlLogOutput
+var logUserdata any
+var logOutputLock sdlRecursiveMutex /* serializes calls to the output function, which may log again */
+
+/**
+ * Set the priority of all log categories.
+ *
+ * - priority the SDL_LogPriority to assign.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_ResetLogPriorities
+ * See also SDL_SetLogPriority
+ */
+func SDL_SetLogPriorities(priority SDL_LogPriority) {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	logPriorities = nil
+	logDefaultPriority = priority
+}
+
+/**
+ * Set the priority of a particular log category.
+ *
+ * - category the category to assign a priority to.
+ * - priority the SDL_LogPriority to assign.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetLogPriority
+ * See also SDL_ResetLogPriorities
+ * See also SDL_SetLogPriorities
+ */
+func SDL_SetLogPriority(category SDL_LogCategory, priority SDL_LogPriority) {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	if logPriorities == nil {
+		logPriorities = make(map[SDL_LogCategory]SDL_LogPriority)
+	}
+	logPriorities[category] = priority
+}
+
+/* Parse a category name or number from SDL_HINT_LOGGING */
+func sdlParseLogCategory(str string) (SDL_LogCategory, bool) {
+	if n, err := strconv.Atoi(str); err == nil {
+		return SDL_LogCategory(n), true
+	}
+	for i, name := range logCategoryNames {
+		if strings.EqualFold(str, name) {
+			return SDL_LogCategory(i), true
+		}
+	}
+	return 0, false
+}
+
+/* Parse a priority name or number from SDL_HINT_LOGGING */
+func sdlParseLogPriority(str string) (SDL_LogPriority, bool) {
+	if n, err := strconv.Atoi(str); err == nil {
+		if n == 0 {
+			/* 0 has a special meaning of "disable this category" */
+			return SDL_LOG_PRIORITY_COUNT, true
+		}
+		if n >= int(SDL_LOG_PRIORITY_TRACE) && n < int(SDL_LOG_PRIORITY_COUNT) {
+			return SDL_LogPriority(n), true
+		}
+		return 0, false
+	}
+
+	if strings.EqualFold(str, "quiet") {
+		return SDL_LOG_PRIORITY_COUNT, true
+	}
+	if strings.EqualFold(str, "warning") {
+		return SDL_LOG_PRIORITY_WARN, true
+	}
+	for i := SDL_LOG_PRIORITY_TRACE; i < SDL_LOG_PRIORITY_COUNT; i++ {
+		if strings.EqualFold(str, logPriorityNames[i]) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+/*
+ * Look up the priority of a category in a SDL_HINT_LOGGING value, e.g.
+ * "app=debug,video=warn,*=error". An entry naming the category wins; a
+ * bare priority or `*` only sets the priority of the categories that
+ * aren't named, so the rest of the hint is still searched. The first
+ * matching entry of each kind wins.
+ */
+func sdlParseLogCategoryPriority(hint string, category SDL_LogCategory) (SDL_LogPriority, bool) {
+	fallback, has_fallback := SDL_LOG_PRIORITY_INVALID, false
+	for _, entry := range strings.Split(hint, ",") {
+		entry = strings.TrimSpace(entry)
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			/* No category, this priority applies to everything else */
+			if priority, ok := sdlParseLogPriority(entry); ok && !has_fallback {
+				fallback, has_fallback = priority, true
+			}
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		priority, ok := sdlParseLogPriority(strings.TrimSpace(value))
+		if !ok {
+			continue
+		}
+		if name == "*" {
+			if !has_fallback {
+				fallback, has_fallback = priority, true
+			}
+			continue
+		}
+		if entry_category, ok := sdlParseLogCategory(name); ok && entry_category == category {
+			return priority, true
+		}
+	}
+	return fallback, has_fallback
+}
+
+/* The priority of a category when neither the application nor the hint set it */
+func sdlGetDefaultLogPriority(category SDL_LogCategory) SDL_LogPriority {
+	switch category {
+	case SDL_LOG_CATEGORY_APPLICATION, SDL_LOG_CATEGORY_GPU:
+		return SDL_LOG_PRIORITY_INFO
+	case SDL_LOG_CATEGORY_ASSERT:
+		return SDL_LOG_PRIORITY_WARN
+	case SDL_LOG_CATEGORY_TEST:
+		return SDL_LOG_PRIORITY_VERBOSE
+	default:
+		return SDL_LOG_PRIORITY_ERROR
+	}
+}
+
+/**
+ * Get the priority of a particular log category.
+ *
+ * Priorities set with SDL_SetLogPriority() or SDL_SetLogPriorities() take
+ * precedence over SDL_HINT_LOGGING, which takes precedence over the built in
+ * defaults.
+ *
+ * - category the category to query.
+ * Returns the SDL_LogPriority for the requested category.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetLogPriority
+ */
+func SDL_GetLogPriority(category SDL_LogCategory) SDL_LogPriority {
+	logLock.Lock()
+	priority, ok := logPriorities[category]
+	if !ok {
+		priority = logDefaultPriority
+	}
+	logLock.Unlock()
+
+	if priority != SDL_LOG_PRIORITY_INVALID {
+		return priority
+	}
+	if priority, ok := sdlParseLogCategoryPriority(SDL_GetHint(SDL_HINT_LOGGING), category); ok {
+		return priority
+	}
+	return sdlGetDefaultLogPriority(category)
+}
+
+/**
+ * Reset all priorities to default.
+ *
+ * This is called by SDL_Quit().
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetLogPriorities
+ * See also SDL_SetLogPriority
+ */
+func SDL_ResetLogPriorities() {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	logPriorities = nil
+	logDefaultPriority = SDL_LOG_PRIORITY_INVALID
+}
+
+/**
+ * Set the text prepended to log messages of a given priority.
+ *
+ * By default each priority has a prefix showing its name, e.g. "WARN: ".
+ *
+ * - priority the SDL_LogPriority to modify.
+ * - prefix the prefix to use for that log priority, or "" to use no
+ *               prefix.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetLogPriorities
+ * See also SDL_SetLogPriority
+ */
+func SDL_SetLogPriorityPrefix(priority SDL_LogPriority, prefix string) bool {
+	if priority <= SDL_LOG_PRIORITY_INVALID || priority >= SDL_LOG_PRIORITY_COUNT {
+		return SDL_InvalidParamError("priority")
+	}
+
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	logPriorityPrefixes[priority] = prefix
+	return true
+}
+
+/**
+ * Log a message with SDL_LOG_CATEGORY_APPLICATION and SDL_LOG_PRIORITY_INFO.
+ *
+ * - fmt a printf() style message format string.
+ * - args additional parameters matching % tokens in the `fmt` string, if
+ *        any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_LogMessage
+ */
+func SDL_Log(fmt string, args ...any) {
+	SDL_LogMessage(SDL_LOG_CATEGORY_APPLICATION, SDL_LOG_PRIORITY_INFO, fmt, args...)
+}
+
+/**
+ * Log a message with SDL_LOG_PRIORITY_TRACE.
+ *
+ * - category the category of the message.
+ * - fmt a printf() style message format string.
+ * - args additional parameters matching % tokens in the **fmt** string,
+ *        if any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Log
+ * See also SDL_LogMessage
+ */
+func SDL_LogTrace(category SDL_LogCategory, fmt string, args ...any) {
+	SDL_LogMessage(category, SDL_LOG_PRIORITY_TRACE, fmt, args...)
+}
+
+/**
+ * Log a message with SDL_LOG_PRIORITY_VERBOSE.
+ *
+ * - category the category of the message.
+ * - fmt a printf() style message format string.
+ * - args additional parameters matching % tokens in the **fmt** string,
+ *        if any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Log
+ * See also SDL_LogMessage
+ */
+func SDL_LogVerbose(category SDL_LogCategory, fmt string, args ...any) {
+	SDL_LogMessage(category, SDL_LOG_PRIORITY_VERBOSE, fmt, args...)
+}
+
+/**
+ * Log a message with SDL_LOG_PRIORITY_DEBUG.
+ *
+ * - category the category of the message.
+ * - fmt a printf() style message format string.
+ * - args additional parameters matching % tokens in the **fmt** string,
+ *        if any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Log
+ * See also SDL_LogMessage
+ */
+func SDL_LogDebug(category SDL_LogCategory, fmt string, args ...any) {
+	SDL_LogMessage(category, SDL_LOG_PRIORITY_DEBUG, fmt, args...)
+}
+
+/**
+ * Log a message with SDL_LOG_PRIORITY_INFO.
+ *
+ * - category the category of the message.
+ * - fmt a printf() style message format string.
+ * - args additional parameters matching % tokens in the **fmt** string,
+ *        if any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Log
+ * See also SDL_LogMessage
+ */
+func SDL_LogInfo(category SDL_LogCategory, fmt string, args ...any) {
+	SDL_LogMessage(category, SDL_LOG_PRIORITY_INFO, fmt, args...)
+}
+
+/**
+ * Log a message with SDL_LOG_PRIORITY_WARN.
+ *
+ * - category the category of the message.
+ * - fmt a printf() style message format string.
+ * - args additional parameters matching % tokens in the **fmt** string,
+ *        if any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Log
+ * See also SDL_LogMessage
+ */
+func SDL_LogWarn(category SDL_LogCategory, fmt string, args ...any) {
+	SDL_LogMessage(category, SDL_LOG_PRIORITY_WARN, fmt, args...)
+}
+
+/**
+ * Log a message with SDL_LOG_PRIORITY_ERROR.
+ *
+ * - category the category of the message.
+ * - fmt a printf() style message format string.
+ * - args additional parameters matching % tokens in the **fmt** string,
+ *        if any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Log
+ * See also SDL_LogMessage
+ */
+func SDL_LogError(category SDL_LogCategory, fmt string, args ...any) {
+	SDL_LogMessage(category, SDL_LOG_PRIORITY_ERROR, fmt, args...)
+}
+
+/**
+ * Log a message with SDL_LOG_PRIORITY_CRITICAL.
+ *
+ * - category the category of the message.
+ * - fmt a printf() style message format string.
+ * - args additional parameters matching % tokens in the **fmt** string,
+ *        if any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Log
+ * See also SDL_LogMessage
+ */
+func SDL_LogCritical(category SDL_LogCategory, fmt string, args ...any) {
+	SDL_LogMessage(category, SDL_LOG_PRIORITY_CRITICAL, fmt, args...)
+}
+
+/**
+ * Log a message with the specified category and priority.
+ *
+ * - category the category of the message.
+ * - priority the priority of the message.
+ * - format a printf() style message format string.
+ * - args additional parameters matching % tokens in the **fmt** string,
+ *        if any.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_Log
+ */
+func SDL_LogMessage(category SDL_LogCategory, priority SDL_LogPriority, format string, args ...any) {
+	/* Nothing to do if we don't have an output function */
+	logLock.Lock()
+	callback, userdata := logFunction, logUserdata
+	logLock.Unlock()
+	if callback == nil {
+		return
+	}
+
+	/* See if we want to do anything with this message */
+	if priority <= SDL_LOG_PRIORITY_INVALID || priority >= SDL_LOG_PRIORITY_COUNT {
+		return
+	}
+	if priority < SDL_GetLogPriority(category) {
+		return
+	}
+
+	message := format
+	if len(args) > 0 {
+		message = fmt.Sprintf(format, args...)
+	}
+
+	/* Chop off final endline. */
+	message = strings.TrimRight(message, "\r\n")
+
+	logOutputLock.Lock()
+	defer logOutputLock.Unlock()
+	callback(userdata, category, priority, message)
+}
+
+/**
+ * The prototype for the log output callback function.
+ *
+ * This function is called by SDL when there is new text to be logged. A
+ * mutex is held so that this function is never called by more than one
+ * goroutine at once. The mutex is recursive: the function may log messages
+ * itself, and is then called again from inside the outer call on the same
+ * goroutine. Logging from another goroutine it waits for would deadlock.
+ *
+ * - userdata what was passed as `userdata` to
+ *                 SDL_SetLogOutputFunction().
+ * - category the category of the message.
+ * - priority the priority of the message.
+ * - message the message being output.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_LogOutputFunction func(userdata any, category SDL_LogCategory, priority SDL_LogPriority, message string)
+
+func sdlLogOutput(userdata any, category SDL_LogCategory, priority SDL_LogPriority, message string) {
+	logLock.Lock()
+	prefix := logPriorityPrefixes[priority]
+	logLock.Unlock()
+
+	fmt.Fprintf(os.Stderr, "%s%s\n", prefix, message)
+}
+
+/**
+ * Get the default log output function.
+ *
+ * Returns the default log output callback.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetLogOutputFunction
+ * See also SDL_GetLogOutputFunction
+ */
+func SDL_GetDefaultLogOutputFunction() SDL_LogOutputFunction {
+	return sdlLogOutput
+}
+
+/**
+ * Get the current log output function.
+ *
+ * Returns the current log output callback and the userdata that was passed
+ * to SDL_SetLogOutputFunction().
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDefaultLogOutputFunction
+ * See also SDL_SetLogOutputFunction
+ */
+func SDL_GetLogOutputFunction() (SDL_LogOutputFunction, any) {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	return logFunction, logUserdata
+}
+
+/**
+ * Replace the default log output function with one of your own.
+ *
+ * - callback an SDL_LogOutputFunction to call instead of the default.
+ * - userdata a pointer that is passed to `callback`.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDefaultLogOutputFunction
+ * See also SDL_GetLogOutputFunction
+ */
+func SDL_SetLogOutputFunction(callback SDL_LogOutputFunction, userdata any) {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	logFunction = callback
+	logUserdata = userdata
+}
+
+func sdlQuitLog() {
+	SDL_ResetLogPriorities()
+
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	logPriorityPrefixes = defaultLogPriorityPrefixes
+}
@@ -0,0 +1,215 @@
+package sdl
+
+import "image/color"
+
+/*
+ * Mapping whole color arrays to pixel values and back.
+ *
+ * Generating pixels in Go one SDL_MapRGBA() call at a time pays the format
+ * checks for every pixel. These map a slice in one call, with the format
+ * decided once, and accept the image/color type as well as SDL_Color.
+ */
+
+/* Map one color of a packed format, the format is known not to be indexed */
+func sdlMapPacked(format *SDL_PixelFormatDetails, r, g, b, a uint8) uint32 {
+	return (uint32(r)>>(8-format.Rbits))<<format.Rshift |
+		(uint32(g)>>(8-format.Gbits))<<format.Gshift |
+		(uint32(b)>>(8-format.Bbits))<<format.Bshift |
+		((uint32(a)>>(8-format.Abits))<<format.Ashift)&format.Amask
+}
+
+/* Check the arguments shared by the batch functions, the output must have room for the input */
+func sdlCheckBatchFormat(format *SDL_PixelFormatDetails, palette *SDL_Palette, in, out int) bool {
+	if format == nil {
+		return SDL_InvalidParamError("format")
+	}
+	if SDL_ISPIXELFORMAT_INDEXED(format.Format) && palette == nil {
+		return SDL_InvalidParamError("palette")
+	}
+	if out < in {
+		return SDL_SetErrorf("Output holds %d values, %d are needed", out, in)
+	}
+	return true
+}
+
+/**
+ * Map an array of colors to pixel values for a given pixel format.
+ *
+ * This gives the same pixel values as calling SDL_MapRGBA() for each color,
+ * without the overhead of a call per color. For indexed formats the closest
+ * palette entry of each color is looked up, runs of the same color are only
+ * looked up once.
+ *
+ * - format a pointer to SDL_PixelFormatDetails describing the pixel
+ *               format.
+ * - palette an optional palette for indexed formats, may be nil.
+ * - colors the colors to map.
+ * - pixels filled in with the pixel value of each color, must be at least as
+ *               long as colors.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified.
+ *
+ * See also SDL_GetColors
+ * See also SDL_MapImageColors
+ * See also SDL_MapRGBA
+ */
+func SDL_MapColors(format *SDL_PixelFormatDetails, palette *SDL_Palette, colors []SDL_Color, pixels []uint32) bool {
+	if !sdlCheckBatchFormat(format, palette, len(colors), len(pixels)) {
+		return false
+	}
+
+	if SDL_ISPIXELFORMAT_INDEXED(format.Format) {
+		var last SDL_Color
+		var index uint32
+		for i, c := range colors {
+			if i == 0 || c != last {
+				last = c
+				index = uint32(sdlFindColor(palette, c.R, c.G, c.B, c.A))
+			}
+			pixels[i] = index
+		}
+		return true
+	}
+	for i, c := range colors {
+		pixels[i] = sdlMapPacked(format, c.R, c.G, c.B, c.A)
+	}
+	return true
+}
+
+/**
+ * Get the colors of an array of pixel values in the specified format.
+ *
+ * This gives the same colors as calling SDL_GetRGBA() for each pixel,
+ * without the overhead of a call per pixel.
+ *
+ * - pixels the pixel values.
+ * - format a pointer to SDL_PixelFormatDetails describing the pixel
+ *               format.
+ * - palette an optional palette for indexed formats, may be nil.
+ * - colors filled in with the color of each pixel, must be at least as long
+ *               as pixels.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified.
+ *
+ * See also SDL_GetImageColors
+ * See also SDL_GetRGBA
+ * See also SDL_MapColors
+ */
+func SDL_GetColors(pixels []uint32, format *SDL_PixelFormatDetails, palette *SDL_Palette, colors []SDL_Color) bool {
+	if !sdlCheckBatchFormat(format, palette, len(pixels), len(colors)) {
+		return false
+	}
+
+	for i, pixel := range pixels {
+		r, g, b, a := SDL_GetRGBA(pixel, format, palette)
+		colors[i] = SDL_Color{r, g, b, a}
+	}
+	return true
+}
+
+/* Convert an alpha-premultiplied image/color value to a straight alpha SDL_Color */
+func sdlColorFromImage(c color.RGBA) SDL_Color {
+	if c.A == 0xFF || c.A == 0 {
+		return SDL_Color{c.R, c.G, c.B, c.A}
+	}
+	unmul := func(v uint8) uint8 {
+		return uint8(min((uint32(v)*0xFF+uint32(c.A)/2)/uint32(c.A), 0xFF))
+	}
+	return SDL_Color{unmul(c.R), unmul(c.G), unmul(c.B), c.A}
+}
+
+/* Convert a straight alpha SDL_Color to an alpha-premultiplied image/color value */
+func sdlColorToImage(c SDL_Color) color.RGBA {
+	mul := func(v uint8) uint8 {
+		return uint8(sdlMul8(uint32(v), uint32(c.A)))
+	}
+	return color.RGBA{mul(c.R), mul(c.G), mul(c.B), c.A}
+}
+
+/**
+ * Map an array of image/color colors to pixel values for a given pixel
+ * format.
+ *
+ * This is SDL_MapColors() for colors generated with the Go image packages.
+ * color.RGBA holds alpha-premultiplied components, they are converted to the
+ * straight alpha SDL pixel formats use.
+ *
+ * - format a pointer to SDL_PixelFormatDetails describing the pixel
+ *               format.
+ * - palette an optional palette for indexed formats, may be nil.
+ * - colors the colors to map.
+ * - pixels filled in with the pixel value of each color, must be at least as
+ *               long as colors.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified.
+ *
+ * See also SDL_GetImageColors
+ * See also SDL_MapColors
+ */
+func SDL_MapImageColors(format *SDL_PixelFormatDetails, palette *SDL_Palette, colors []color.RGBA, pixels []uint32) bool {
+	if !sdlCheckBatchFormat(format, palette, len(colors), len(pixels)) {
+		return false
+	}
+
+	if SDL_ISPIXELFORMAT_INDEXED(format.Format) {
+		var last color.RGBA
+		var index uint32
+		for i, c := range colors {
+			if i == 0 || c != last {
+				last = c
+				s := sdlColorFromImage(c)
+				index = uint32(sdlFindColor(palette, s.R, s.G, s.B, s.A))
+			}
+			pixels[i] = index
+		}
+		return true
+	}
+	for i, c := range colors {
+		s := sdlColorFromImage(c)
+		pixels[i] = sdlMapPacked(format, s.R, s.G, s.B, s.A)
+	}
+	return true
+}
+
+/**
+ * Get the image/color colors of an array of pixel values in the specified
+ * format.
+ *
+ * This is SDL_GetColors() for use with the Go image packages, the colors are
+ * alpha-premultiplied as color.RGBA requires.
+ *
+ * - pixels the pixel values.
+ * - format a pointer to SDL_PixelFormatDetails describing the pixel
+ *               format.
+ * - palette an optional palette for indexed formats, may be nil.
+ * - colors filled in with the color of each pixel, must be at least as long
+ *               as pixels.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified.
+ *
+ * See also SDL_GetColors
+ * See also SDL_MapImageColors
+ */
+func SDL_GetImageColors(pixels []uint32, format *SDL_PixelFormatDetails, palette *SDL_Palette, colors []color.RGBA) bool {
+	if !sdlCheckBatchFormat(format, palette, len(pixels), len(colors)) {
+		return false
+	}
+
+	for i, pixel := range pixels {
+		r, g, b, a := SDL_GetRGBA(pixel, format, palette)
+		colors[i] = sdlColorToImage(SDL_Color{r, g, b, a})
+	}
+	return true
+}
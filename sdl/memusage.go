@@ -0,0 +1,118 @@
+package sdl
+
+import "sync/atomic"
+
+/*
+ * Memory accounting.
+ *
+ * This is an extension to the SDL API. The pixel and sample buffers SDL
+ * allocates on behalf of the application are counted per category, with a
+ * high-water mark, so games targeting low-memory devices can budget their
+ * assets. Memory the application passes in, e.g. to SDL_CreateSurfaceFrom(),
+ * isn't counted.
+ */
+
+/**
+ * The kinds of resources whose memory is accounted.
+ *
+ * See also SDL_GetMemoryUsage
+ */
+type SDL_MemoryCategory int
+
+const (
+	SDL_MEMORY_SURFACES SDL_MemoryCategory = iota /**< Surface pixels */
+	SDL_MEMORY_TEXTURES                           /**< Texture pixels kept by the renderers */
+	SDL_MEMORY_AUDIO                              /**< Audio stream and device buffers */
+	SDL_MEMORY_ALL                                /**< All of the above */
+	sdlMemoryCategoryCount
+)
+
+/**
+ * Memory usage of a category, see SDL_GetMemoryUsage().
+ */
+type SDL_MemoryUsage struct {
+	Bytes       int64 /**< The number of bytes currently allocated */
+	PeakBytes   int64 /**< The highest number of bytes allocated at once, since startup or SDL_ResetMemoryUsagePeak() */
+	Allocations int64 /**< The number of live allocations */
+}
+
+/* Private data -- the counters of each category */
+var memoryUsage [sdlMemoryCategoryCount]struct {
+	bytes       atomic.Int64
+	peak        atomic.Int64
+	allocations atomic.Int64
+}
+
+func sdlAddMemoryUsage(category SDL_MemoryCategory, bytes int64, allocations int64) {
+	for _, c := range []SDL_MemoryCategory{category, SDL_MEMORY_ALL} {
+		counters := &memoryUsage[c]
+		total := counters.bytes.Add(bytes)
+		counters.allocations.Add(allocations)
+		for {
+			peak := counters.peak.Load()
+			if total <= peak || counters.peak.CompareAndSwap(peak, total) {
+				break
+			}
+		}
+	}
+}
+
+/* Account for a buffer allocated by SDL */
+func sdlTrackAllocation(category SDL_MemoryCategory, bytes int) {
+	sdlAddMemoryUsage(category, int64(bytes), 1)
+}
+
+/* Account for a buffer tracked with sdlTrackAllocation() being released */
+func sdlTrackFree(category SDL_MemoryCategory, bytes int) {
+	sdlAddMemoryUsage(category, -int64(bytes), -1)
+}
+
+/**
+ * Get the memory used by a category of resources.
+ *
+ * - category the category to query, or SDL_MEMORY_ALL for the total.
+ * - usage a pointer filled in with the memory usage.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_ResetMemoryUsagePeak
+ */
+func SDL_GetMemoryUsage(category SDL_MemoryCategory, usage *SDL_MemoryUsage) bool {
+	if category < 0 || category >= sdlMemoryCategoryCount {
+		return SDL_InvalidParamError("category")
+	}
+	if usage == nil {
+		return SDL_InvalidParamError("usage")
+	}
+
+	counters := &memoryUsage[category]
+	usage.Bytes = counters.bytes.Load()
+	usage.PeakBytes = counters.peak.Load()
+	usage.Allocations = counters.allocations.Load()
+	return true
+}
+
+/**
+ * Reset the high-water mark of a category to its current usage.
+ *
+ * This can be used to measure the peak memory of a level or scene.
+ *
+ * - category the category to reset, or SDL_MEMORY_ALL to reset every
+ *                 category.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_GetMemoryUsage
+ */
+func SDL_ResetMemoryUsagePeak(category SDL_MemoryCategory) bool {
+	if category < 0 || category >= sdlMemoryCategoryCount {
+		return SDL_InvalidParamError("category")
+	}
+
+	for c := range memoryUsage {
+		if category == SDL_MEMORY_ALL || SDL_MemoryCategory(c) == category {
+			memoryUsage[c].peak.Store(memoryUsage[c].bytes.Load())
+		}
+	}
+	return true
+}
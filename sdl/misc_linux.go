@@ -0,0 +1,37 @@
+package sdl
+
+import "errors"
+import "os/exec"
+import "strings"
+
+/*
+ * Opening URLs on Linux, through the OpenURI desktop portal when it is
+ * available, which is the only way out of a Flatpak or Snap sandbox, and
+ * xdg-open otherwise.
+ *
+ * Local files go to xdg-open even with the portal: OpenURI only opens files
+ * passed as file descriptors.
+ */
+
+func init() {
+	openURLBackend = sdlLinuxOpenURL
+}
+
+func sdlLinuxOpenURL(url string) bool {
+	if !strings.HasPrefix(url, "file:") && sdlPortalOpenURI(url) {
+		return true
+	}
+
+	path, err := exec.LookPath("xdg-open")
+	if err != nil {
+		return SDL_SetError("Opening URLs needs xdg-open, which isn't installed")
+	}
+	if err := exec.Command(path, url).Run(); err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			return SDL_SetErrorf("xdg-open reported error or failed to launch: %d", exit.ExitCode())
+		}
+		return SDL_SetErrorf("xdg-open failed: %v", err)
+	}
+	return true
+}
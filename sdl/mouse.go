@@ -0,0 +1,80 @@
+package sdl
+
+/**
+ * This is a unique ID for a mouse for the time it is connected to the system,
+ * and is never reused for the lifetime of the application.
+ *
+ * If the mouse is disconnected and reconnected, it will get a new ID.
+ *
+ * The value 0 is an invalid ID.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_MouseID uint32
+
+/**
+ * Used as the device ID for mouse events simulated with touch input
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_TOUCH_MOUSEID SDL_MouseID = 0xFFFFFFFF
+
+/**
+ * Used as the device ID for mouse events simulated with pen input
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_PEN_MOUSEID SDL_MouseID = 0xFFFFFFFE
+
+/**
+ * Scroll direction types for the Scroll event
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_MouseWheelDirection uint32
+
+const (
+	SDL_MOUSEWHEEL_NORMAL  SDL_MouseWheelDirection = iota /**< The scroll direction is normal */
+	SDL_MOUSEWHEEL_FLIPPED                                /**< The scroll direction is flipped / natural */
+)
+
+/**
+ * A bitmask of pressed mouse buttons, as reported by SDL_GetMouseState, etc.
+ *
+ * - Button 1: Left mouse button
+ * - Button 2: Middle mouse button
+ * - Button 3: Right mouse button
+ * - Button 4: Side mouse button 1
+ * - Button 5: Side mouse button 2
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_MouseButtonFlags uint32
+
+const (
+	SDL_BUTTON_LEFT   = 1
+	SDL_BUTTON_MIDDLE = 2
+	SDL_BUTTON_RIGHT  = 3
+	SDL_BUTTON_X1     = 4
+	SDL_BUTTON_X2     = 5
+)
+
+/**
+ * A macro to convert a mouse button index to a bit in SDL_MouseButtonFlags.
+ *
+ * - X the button index, SDL_BUTTON_LEFT etc.
+ * Returns the bit for the button.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_BUTTON_MASK(X int) SDL_MouseButtonFlags {
+	return 1 << (X - 1)
+}
+
+const (
+	SDL_BUTTON_LMASK  SDL_MouseButtonFlags = 1 << (SDL_BUTTON_LEFT - 1)
+	SDL_BUTTON_MMASK  SDL_MouseButtonFlags = 1 << (SDL_BUTTON_MIDDLE - 1)
+	SDL_BUTTON_RMASK  SDL_MouseButtonFlags = 1 << (SDL_BUTTON_RIGHT - 1)
+	SDL_BUTTON_X1MASK SDL_MouseButtonFlags = 1 << (SDL_BUTTON_X1 - 1)
+	SDL_BUTTON_X2MASK SDL_MouseButtonFlags = 1 << (SDL_BUTTON_X2 - 1)
+)
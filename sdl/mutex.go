@@ -0,0 +1,37 @@
+package sdl
+
+import "sync"
+import "sync/atomic"
+
+/*
+ * A mutex that can be locked again by the goroutine that holds it, like the
+ * mutexes of the C library. Each Lock must be balanced by an Unlock.
+ *
+ * Ownership is tracked by goroutine id, so locking costs a stack header
+ * lookup; only use it where re-entrancy is part of the API contract.
+ */
+type sdlRecursiveMutex struct {
+	mutex sync.Mutex
+	owner atomic.Uint64
+	count int
+}
+
+func (m *sdlRecursiveMutex) Lock() {
+	id := goroutineID()
+	if m.owner.Load() == id {
+		m.count++
+		return
+	}
+	m.mutex.Lock()
+	m.owner.Store(id)
+	m.count = 1
+}
+
+func (m *sdlRecursiveMutex) Unlock() {
+	SDL_assert(m.owner.Load() == goroutineID())
+	m.count--
+	if m.count == 0 {
+		m.owner.Store(0)
+		m.mutex.Unlock()
+	}
+}
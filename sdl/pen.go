@@ -0,0 +1,54 @@
+package sdl
+
+/**
+ * SDL pen instance IDs.
+ *
+ * Zero is used to signify an invalid/null device.
+ *
+ * These show up in pen events when SDL sees input from them. They remain
+ * consistent as long as SDL can recognize a tool to be the same pen; but if a
+ * pen physically leaves the area and returns, it might get a new ID.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_PenID uint32
+
+/**
+ * Pen input flags, as reported by various pen events' `Pen_state` field.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_PenInputFlags uint32
+
+const (
+	SDL_PEN_INPUT_DOWN       SDL_PenInputFlags = 1 << 0  /**< pen is pressed down */
+	SDL_PEN_INPUT_BUTTON_1   SDL_PenInputFlags = 1 << 1  /**< button 1 is pressed */
+	SDL_PEN_INPUT_BUTTON_2   SDL_PenInputFlags = 1 << 2  /**< button 2 is pressed */
+	SDL_PEN_INPUT_BUTTON_3   SDL_PenInputFlags = 1 << 3  /**< button 3 is pressed */
+	SDL_PEN_INPUT_BUTTON_4   SDL_PenInputFlags = 1 << 4  /**< button 4 is pressed */
+	SDL_PEN_INPUT_BUTTON_5   SDL_PenInputFlags = 1 << 5  /**< button 5 is pressed */
+	SDL_PEN_INPUT_ERASER_TIP SDL_PenInputFlags = 1 << 30 /**< eraser tip is used */
+)
+
+/**
+ * Pen axis indices.
+ *
+ * These are the valid values for the `Axis` field in SDL_PenAxisEvent. All
+ * axes are either normalised to 0..1 or report a (positive or negative) angle
+ * in degrees, with 0.0 representing the centre. Not all pens/backends support
+ * all axes: unsupported axes are always "centred" or "zero".
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_PenAxis int
+
+const (
+	SDL_PEN_AXIS_PRESSURE            SDL_PenAxis = iota /**< Pen pressure. Unidirectional: 0 to 1.0 */
+	SDL_PEN_AXIS_XTILT                                  /**< Pen horizontal tilt angle. Bidirectional: -90.0 to 90.0 (left-to-right). */
+	SDL_PEN_AXIS_YTILT                                  /**< Pen vertical tilt angle. Bidirectional: -90.0 to 90.0 (top-to-down). */
+	SDL_PEN_AXIS_DISTANCE                               /**< Pen distance to drawing surface. Unidirectional: 0.0 to 1.0 */
+	SDL_PEN_AXIS_ROTATION                               /**< Pen barrel rotation. Bidirectional: -180 to 179.9 (clockwise, 0 is facing up, -180.0 is facing down). */
+	SDL_PEN_AXIS_SLIDER                                 /**< Pen finger wheel or slider. Unidirectional: 0 to 1.0 */
+	SDL_PEN_AXIS_TANGENTIAL_PRESSURE                    /**< Pressure from squeezing the pen ("barrel pressure"). */
+	SDL_PEN_AXIS_COUNT                                  /**< Total known pen axis types in this version of SDL. This number may grow in future releases! */
+)
@@ -0,0 +1,222 @@
+package sdl
+
+import (
+	"runtime"
+)
+
+/*
+ * Camera and microphone permissions.
+ *
+ * This is an extension to the SDL API. Platforms that guard the camera and
+ * the microphone ask the user the first time an application wants them, and
+ * refuse access silently afterwards if the user said no. These functions
+ * let the application ask at a time of its choosing, and find out about a
+ * denial so it can explain what's missing instead of showing a black
+ * camera feed or recording silence.
+ *
+ * A platform backend registers its implementation from an init() function.
+ * Platforms without a permission system, such as Linux outside of a
+ * sandbox, need no backend and both permissions are always approved. On
+ * platforms that have one but no backend yet, the state is unknown and
+ * requests fail as unsupported.
+ */
+
+/**
+ * The permissions that can be requested from the user.
+ *
+ * See also SDL_RequestPermission
+ */
+type SDL_Permission int
+
+const (
+	SDL_PERMISSION_CAMERA     SDL_Permission = iota /**< Access to the cameras */
+	SDL_PERMISSION_MICROPHONE                       /**< Access to the audio recording devices */
+	sdlNumPermissions
+)
+
+/**
+ * The state of a permission.
+ *
+ * See also SDL_GetPermissionState
+ */
+type SDL_PermissionState int
+
+const (
+	SDL_PERMISSION_STATE_NOT_DETERMINED SDL_PermissionState = iota /**< The user hasn't been asked yet, or the system doesn't tell */
+	SDL_PERMISSION_STATE_PENDING                                   /**< The user is being asked */
+	SDL_PERMISSION_STATE_APPROVED                                  /**< The user allowed access */
+	SDL_PERMISSION_STATE_DENIED                                    /**< The user or a system policy refused access */
+)
+
+/**
+ * Callback used by SDL_RequestPermission() with the answer.
+ *
+ * The callback may be called from any thread, before or after
+ * SDL_RequestPermission() returns.
+ *
+ * - userdata an app-provided pointer, for the callback's use.
+ * - permission the permission that was requested.
+ * - granted true if access was approved, false if it was denied or the
+ *                request failed; call SDL_GetError() for more information in
+ *                that case.
+ *
+ * See also SDL_RequestPermission
+ */
+type SDL_RequestPermissionCallback func(userdata any, permission SDL_Permission, granted bool)
+
+/* Entry points of the platform permission backend */
+type sdlPermissionBackend struct {
+	/* The state the system reports, without asking the user */
+	GetState func(permission SDL_Permission) SDL_PermissionState
+
+	/*
+	 * Ask the user, calling done from any goroutine with the answer. Failures
+	 * are reported as SDL_PERMISSION_STATE_NOT_DETERMINED, err is the error
+	 * message for the callbacks when the permission isn't approved.
+	 */
+	Request func(permission SDL_Permission, done func(state SDL_PermissionState, err string))
+}
+
+var permissionBackend *sdlPermissionBackend
+
+/* Whether the platform guards the camera and microphone, so SDL can't vouch for them without a backend */
+var permissionSystem = runtime.GOOS == "darwin" || runtime.GOOS == "ios" || runtime.GOOS == "windows" || runtime.GOOS == "android" || runtime.GOOS == "js"
+
+type sdlPermissionRequest struct {
+	callback SDL_RequestPermissionCallback
+	userdata any
+}
+
+/* The known permission states and the requests waiting for an answer, guarded by permissionsLock */
+var permissionsLock = sdlMutex{name: "permissions"}
+var permissionStates [sdlNumPermissions]SDL_PermissionState
+var permissionRequests [sdlNumPermissions][]sdlPermissionRequest
+
+/*
+ * Record the state of a permission, sending SDL_EVENT_PERMISSION_CHANGED
+ * if it changed. Backends call this when the user changes the permission
+ * in the system settings. Once the state is settled, the pending requests
+ * get their answer.
+ */
+func sdlSetPermissionState(permission SDL_Permission, state SDL_PermissionState) {
+	permissionsLock.Lock()
+	changed := permissionStates[permission] != state
+	permissionStates[permission] = state
+	var requests []sdlPermissionRequest
+	if state != SDL_PERMISSION_STATE_PENDING {
+		requests = permissionRequests[permission]
+		permissionRequests[permission] = nil
+	}
+	permissionsLock.Unlock()
+
+	if changed && SDL_EventEnabled(SDL_EVENT_PERMISSION_CHANGED) {
+		event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: SDL_EVENT_PERMISSION_CHANGED}}
+		event.Permission = SDL_PermissionEvent{Permission: permission, State: state}
+		SDL_PushEvent(&event)
+	}
+	for _, request := range requests {
+		request.callback(request.userdata, permission, state == SDL_PERMISSION_STATE_APPROVED)
+	}
+}
+
+/**
+ * Get the state of a permission.
+ *
+ * This doesn't ask the user, see SDL_RequestPermission() for that.
+ *
+ * - permission the permission to query.
+ * Returns the state of the permission; SDL_PERMISSION_STATE_NOT_DETERMINED
+ *          with an error set for an invalid permission, or on a platform
+ *          with a permission system SDL doesn't support yet.
+ *
+ * See also SDL_RequestPermission
+ */
+func SDL_GetPermissionState(permission SDL_Permission) SDL_PermissionState {
+	if permission < 0 || permission >= sdlNumPermissions {
+		SDL_InvalidParamError("permission")
+		return SDL_PERMISSION_STATE_NOT_DETERMINED
+	}
+	if permissionBackend == nil {
+		if permissionSystem {
+			SDL_Unsupported()
+			return SDL_PERMISSION_STATE_NOT_DETERMINED
+		}
+		return SDL_PERMISSION_STATE_APPROVED
+	}
+
+	permissionsLock.Lock()
+	state := permissionStates[permission]
+	permissionsLock.Unlock()
+	if state != SDL_PERMISSION_STATE_NOT_DETERMINED {
+		return state
+	}
+
+	/* Not asked through SDL yet, the system may know the answer from earlier runs */
+	if state = permissionBackend.GetState(permission); state != SDL_PERMISSION_STATE_NOT_DETERMINED {
+		permissionsLock.Lock()
+		if permissionStates[permission] == SDL_PERMISSION_STATE_NOT_DETERMINED {
+			permissionStates[permission] = state
+		}
+		state = permissionStates[permission]
+		permissionsLock.Unlock()
+	}
+	return state
+}
+
+/**
+ * Ask the user for a permission.
+ *
+ * The user is shown the system prompt if the permission isn't approved
+ * yet, and the callback gets the answer. A permission that was denied is
+ * asked for again, but most systems remember the denial and answer without
+ * asking the user; the application should then explain how to allow access
+ * in the system settings.
+ *
+ * SDL_EVENT_PERMISSION_CHANGED is sent when the state of the permission
+ * changes, as a result of the request or the user changing the system
+ * settings.
+ *
+ * - permission the permission to request.
+ * - callback a function pointer to be invoked with the answer.
+ * - userdata an optional pointer to pass extra data to the callback when
+ *                 it will be invoked.
+ * Returns true if the request was made or false on failure, such as on a
+ *          platform with a permission system SDL doesn't support yet; call
+ *          SDL_GetError() for more information.
+ *
+ * See also SDL_GetPermissionState
+ * See also SDL_RequestPermissionCallback
+ */
+func SDL_RequestPermission(permission SDL_Permission, callback SDL_RequestPermissionCallback, userdata any) bool {
+	if permission < 0 || permission >= sdlNumPermissions {
+		return SDL_InvalidParamError("permission")
+	}
+	if callback == nil {
+		return SDL_InvalidParamError("callback")
+	}
+	if SDL_GetPermissionState(permission) == SDL_PERMISSION_STATE_APPROVED {
+		callback(userdata, permission, true)
+		return true
+	}
+	if permissionBackend == nil {
+		return SDL_Unsupported()
+	}
+
+	/* Requests made while the user is being asked share the answer */
+	permissionsLock.Lock()
+	permissionRequests[permission] = append(permissionRequests[permission], sdlPermissionRequest{callback, userdata})
+	first := len(permissionRequests[permission]) == 1
+	permissionsLock.Unlock()
+	if !first {
+		return true
+	}
+
+	sdlSetPermissionState(permission, SDL_PERMISSION_STATE_PENDING)
+	permissionBackend.Request(permission, func(state SDL_PermissionState, err string) {
+		if err != "" {
+			SDL_SetError(err)
+		}
+		sdlSetPermissionState(permission, state)
+	})
+	return true
+}
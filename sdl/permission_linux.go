@@ -0,0 +1,58 @@
+package sdl
+
+import "os"
+
+/*
+ * Permissions on Linux.
+ *
+ * Outside of a sandbox, access to the devices is decided by file and
+ * PipeWire permissions, there's nothing to ask. Flatpak and Snap sandboxes
+ * get the camera through the Camera portal, which asks the user, see
+ * portal_linux.go. Microphone access is a static permission of the sandbox,
+ * granted at install time: an application without it can't ask for it.
+ */
+
+func init() {
+	if sdlLinuxIsSandboxed() {
+		permissionBackend = &sdlPermissionBackend{
+			GetState: sdlLinuxGetPermissionState,
+			Request:  sdlLinuxRequestPermission,
+		}
+	}
+}
+
+/* Whether the application runs in a Flatpak or Snap sandbox */
+func sdlLinuxIsSandboxed() bool {
+	if _, err := os.Stat("/.flatpak-info"); err == nil {
+		return true
+	}
+	return os.Getenv("SNAP") != ""
+}
+
+func sdlLinuxGetPermissionState(permission SDL_Permission) SDL_PermissionState {
+	if permission == SDL_PERMISSION_MICROPHONE {
+		return SDL_PERMISSION_STATE_APPROVED
+	}
+	/* The portal remembers the answer, but only tells when asked */
+	return SDL_PERMISSION_STATE_NOT_DETERMINED
+}
+
+func sdlLinuxRequestPermission(permission SDL_Permission, done func(state SDL_PermissionState, err string)) {
+	if permission == SDL_PERMISSION_MICROPHONE {
+		done(SDL_PERMISSION_STATE_APPROVED, "")
+		return
+	}
+
+	sdlGo(func() {
+		options := map[string]sdlDBusVariant{}
+		code, _, ok := sdlPortalRequest("org.freedesktop.portal.Camera", "AccessCamera", "a{sv}", nil, options)
+		switch {
+		case !ok:
+			done(SDL_PERMISSION_STATE_NOT_DETERMINED, SDL_GetError())
+		case code == sdlPortalResponseSuccess:
+			done(SDL_PERMISSION_STATE_APPROVED, "")
+		default:
+			done(SDL_PERMISSION_STATE_DENIED, "Camera access was denied")
+		}
+	})
+}
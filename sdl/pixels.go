@@ -0,0 +1,740 @@
+package sdl
+
+import "encoding/binary"
+
+/**
+ * A fully opaque 8-bit alpha value.
+ *
+ * This macro is available since SDL 3.0.0.
+ *
+ * See also SDL_ALPHA_TRANSPARENT
+ */
+const SDL_ALPHA_OPAQUE = 255
+
+/**
+ * A fully transparent 8-bit alpha value.
+ *
+ * This macro is available since SDL 3.0.0.
+ *
+ * See also SDL_ALPHA_OPAQUE
+ */
+const SDL_ALPHA_TRANSPARENT = 0
+
+/**
+ * Pixel type.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_PixelType int
+
+const (
+	SDL_PIXELTYPE_UNKNOWN SDL_PixelType = iota
+	SDL_PIXELTYPE_INDEX1
+	SDL_PIXELTYPE_INDEX4
+	SDL_PIXELTYPE_INDEX8
+	SDL_PIXELTYPE_PACKED8
+	SDL_PIXELTYPE_PACKED16
+	SDL_PIXELTYPE_PACKED32
+	SDL_PIXELTYPE_ARRAYU8
+	SDL_PIXELTYPE_ARRAYU16
+	SDL_PIXELTYPE_ARRAYU32
+	SDL_PIXELTYPE_ARRAYF16
+	SDL_PIXELTYPE_ARRAYF32
+	SDL_PIXELTYPE_INDEX2
+)
+
+/**
+ * Packed component order, high bit -> low bit.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_PackedOrder int
+
+const (
+	SDL_PACKEDORDER_NONE SDL_PackedOrder = iota
+	SDL_PACKEDORDER_XRGB
+	SDL_PACKEDORDER_RGBX
+	SDL_PACKEDORDER_ARGB
+	SDL_PACKEDORDER_RGBA
+	SDL_PACKEDORDER_XBGR
+	SDL_PACKEDORDER_BGRX
+	SDL_PACKEDORDER_ABGR
+	SDL_PACKEDORDER_BGRA
+)
+
+/**
+ * Packed component layout.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_PackedLayout int
+
+const (
+	SDL_PACKEDLAYOUT_NONE SDL_PackedLayout = iota
+	SDL_PACKEDLAYOUT_332
+	SDL_PACKEDLAYOUT_4444
+	SDL_PACKEDLAYOUT_1555
+	SDL_PACKEDLAYOUT_5551
+	SDL_PACKEDLAYOUT_565
+	SDL_PACKEDLAYOUT_8888
+	SDL_PACKEDLAYOUT_2101010
+	SDL_PACKEDLAYOUT_1010102
+)
+
+/**
+ * Pixel format.
+ *
+ * SDL's pixel formats have the following naming convention:
+ *
+ * - Names with a list of components and a single bit count, such as RGB24 and
+ *   ABGR32, define a platform-independent encoding into bytes in the order
+ *   specified. For example, in RGB24 data, each pixel is encoded in 3 bytes
+ *   (red, green, blue) in that order, and in ABGR32 data, each pixel is
+ *   encoded in 4 bytes alpha, blue, green, red) in that order. Use these
+ *   names if the property of a format that is important to you is the order
+ *   of the bytes in memory or on disk.
+ * - Names with a bit count per component, such as ARGB8888 and XRGB1555, are
+ *   "packed" into an appropriately-sized integer in the platform's native
+ *   endianness. For example, ARGB8888 is a sequence of 32-bit integers; in
+ *   each integer, the most significant bits are alpha, and the least
+ *   significant bits are blue. On a little-endian CPU such as x86, the least
+ *   significant bits of each integer are arranged first in memory, but on a
+ *   big-endian CPU such as s390x, the most significant bits are arranged
+ *   first. Use these names if the property of a format that is important to
+ *   you is the meaning of each bit position within a native-endianness
+ *   integer.
+ *
+ * The Go port supports the 8-bit indexed format and the 8, 16 and 32-bit
+ * packed formats listed below.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_PixelFormat uint32
+
+/**
+ * Define a format using type, order, layout, bits and bytes, as
+ * SDL_DEFINE_PIXELFORMAT does.
+ */
+func SDL_DEFINE_PIXELFORMAT(kind SDL_PixelType, order int, layout SDL_PackedLayout, bits int, bytes int) SDL_PixelFormat {
+	return SDL_PixelFormat((1 << 28) | (uint32(kind) << 24) | (uint32(order) << 20) |
+		(uint32(layout) << 16) | (uint32(bits) << 8) | uint32(bytes))
+}
+
+const (
+	SDL_PIXELFORMAT_UNKNOWN  SDL_PixelFormat = 0
+	SDL_PIXELFORMAT_INDEX8   SDL_PixelFormat = 0x13000801
+	SDL_PIXELFORMAT_RGB332   SDL_PixelFormat = 0x14110801
+	SDL_PIXELFORMAT_XRGB4444 SDL_PixelFormat = 0x15120c02
+	SDL_PIXELFORMAT_XRGB1555 SDL_PixelFormat = 0x15130f02
+	SDL_PIXELFORMAT_ARGB4444 SDL_PixelFormat = 0x15321002
+	SDL_PIXELFORMAT_RGBA4444 SDL_PixelFormat = 0x15421002
+	SDL_PIXELFORMAT_ABGR4444 SDL_PixelFormat = 0x15721002
+	SDL_PIXELFORMAT_BGRA4444 SDL_PixelFormat = 0x15821002
+	SDL_PIXELFORMAT_ARGB1555 SDL_PixelFormat = 0x15331002
+	SDL_PIXELFORMAT_RGBA5551 SDL_PixelFormat = 0x15441002
+	SDL_PIXELFORMAT_RGB565   SDL_PixelFormat = 0x15151002
+	SDL_PIXELFORMAT_BGR565   SDL_PixelFormat = 0x15551002
+	SDL_PIXELFORMAT_XRGB8888 SDL_PixelFormat = 0x16161804
+	SDL_PIXELFORMAT_RGBX8888 SDL_PixelFormat = 0x16261804
+	SDL_PIXELFORMAT_XBGR8888 SDL_PixelFormat = 0x16561804
+	SDL_PIXELFORMAT_BGRX8888 SDL_PixelFormat = 0x16661804
+	SDL_PIXELFORMAT_ARGB8888 SDL_PixelFormat = 0x16362004
+	SDL_PIXELFORMAT_RGBA8888 SDL_PixelFormat = 0x16462004
+	SDL_PIXELFORMAT_ABGR8888 SDL_PixelFormat = 0x16762004
+	SDL_PIXELFORMAT_BGRA8888 SDL_PixelFormat = 0x16862004
+)
+
+/*
+ * Aliases for RGBA byte arrays of color data, for the current platform.
+ * These depend on the byte order, so they are set up at init time.
+ */
+var (
+	SDL_PIXELFORMAT_RGBA32 SDL_PixelFormat
+	SDL_PIXELFORMAT_ARGB32 SDL_PixelFormat
+	SDL_PIXELFORMAT_BGRA32 SDL_PixelFormat
+	SDL_PIXELFORMAT_ABGR32 SDL_PixelFormat
+	SDL_PIXELFORMAT_RGBX32 SDL_PixelFormat
+	SDL_PIXELFORMAT_XRGB32 SDL_PixelFormat
+	SDL_PIXELFORMAT_BGRX32 SDL_PixelFormat
+	SDL_PIXELFORMAT_XBGR32 SDL_PixelFormat
+)
+
+func sdlIsLittleEndian() bool {
+	var probe [2]byte
+	binary.NativeEndian.PutUint16(probe[:], 1)
+	return probe[0] == 1
+}
+
+func init() {
+	if sdlIsLittleEndian() {
+		SDL_PIXELFORMAT_RGBA32 = SDL_PIXELFORMAT_ABGR8888
+		SDL_PIXELFORMAT_ARGB32 = SDL_PIXELFORMAT_BGRA8888
+		SDL_PIXELFORMAT_BGRA32 = SDL_PIXELFORMAT_ARGB8888
+		SDL_PIXELFORMAT_ABGR32 = SDL_PIXELFORMAT_RGBA8888
+		SDL_PIXELFORMAT_RGBX32 = SDL_PIXELFORMAT_XBGR8888
+		SDL_PIXELFORMAT_XRGB32 = SDL_PIXELFORMAT_BGRX8888
+		SDL_PIXELFORMAT_BGRX32 = SDL_PIXELFORMAT_XRGB8888
+		SDL_PIXELFORMAT_XBGR32 = SDL_PIXELFORMAT_RGBX8888
+	} else {
+		SDL_PIXELFORMAT_RGBA32 = SDL_PIXELFORMAT_RGBA8888
+		SDL_PIXELFORMAT_ARGB32 = SDL_PIXELFORMAT_ARGB8888
+		SDL_PIXELFORMAT_BGRA32 = SDL_PIXELFORMAT_BGRA8888
+		SDL_PIXELFORMAT_ABGR32 = SDL_PIXELFORMAT_ABGR8888
+		SDL_PIXELFORMAT_RGBX32 = SDL_PIXELFORMAT_RGBX8888
+		SDL_PIXELFORMAT_XRGB32 = SDL_PIXELFORMAT_XRGB8888
+		SDL_PIXELFORMAT_BGRX32 = SDL_PIXELFORMAT_BGRX8888
+		SDL_PIXELFORMAT_XBGR32 = SDL_PIXELFORMAT_XBGR8888
+	}
+}
+
+func SDL_PIXELFLAG(format SDL_PixelFormat) int {
+	return int(format>>28) & 0x0F
+}
+
+func SDL_PIXELTYPE(format SDL_PixelFormat) SDL_PixelType {
+	return SDL_PixelType(format>>24) & 0x0F
+}
+
+func SDL_PIXELORDER(format SDL_PixelFormat) int {
+	return int(format>>20) & 0x0F
+}
+
+func SDL_PIXELLAYOUT(format SDL_PixelFormat) SDL_PackedLayout {
+	return SDL_PackedLayout(format>>16) & 0x0F
+}
+
+func SDL_BITSPERPIXEL(format SDL_PixelFormat) int {
+	return int(format>>8) & 0xFF
+}
+
+func SDL_BYTESPERPIXEL(format SDL_PixelFormat) int {
+	return int(format) & 0xFF
+}
+
+func SDL_ISPIXELFORMAT_INDEXED(format SDL_PixelFormat) bool {
+	t := SDL_PIXELTYPE(format)
+	return t == SDL_PIXELTYPE_INDEX1 || t == SDL_PIXELTYPE_INDEX2 ||
+		t == SDL_PIXELTYPE_INDEX4 || t == SDL_PIXELTYPE_INDEX8
+}
+
+func SDL_ISPIXELFORMAT_PACKED(format SDL_PixelFormat) bool {
+	t := SDL_PIXELTYPE(format)
+	return t == SDL_PIXELTYPE_PACKED8 || t == SDL_PIXELTYPE_PACKED16 ||
+		t == SDL_PIXELTYPE_PACKED32
+}
+
+func SDL_ISPIXELFORMAT_ALPHA(format SDL_PixelFormat) bool {
+	if !SDL_ISPIXELFORMAT_PACKED(format) {
+		return false
+	}
+	order := SDL_PackedOrder(SDL_PIXELORDER(format))
+	return order == SDL_PACKEDORDER_ARGB || order == SDL_PACKEDORDER_RGBA ||
+		order == SDL_PACKEDORDER_ABGR || order == SDL_PACKEDORDER_BGRA
+}
+
+/**
+ * A structure that represents a color as RGBA components.
+ *
+ * The bits of this structure can be directly reinterpreted as an
+ * integer-packed color which uses the SDL_PIXELFORMAT_RGBA32 format
+ * (SDL_PIXELFORMAT_ABGR8888 on little-endian systems and
+ * SDL_PIXELFORMAT_RGBA8888 on big-endian systems).
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_Color struct {
+	R uint8
+	G uint8
+	B uint8
+	A uint8
+}
+
+/**
+ * The bits of this structure can be directly reinterpreted as a float-packed
+ * color which uses the SDL_PIXELFORMAT_RGBA128_FLOAT format
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_FColor struct {
+	R float32
+	G float32
+	B float32
+	A float32
+}
+
+/**
+ * A set of indexed colors representing a palette.
+ *
+ * This struct is available since SDL 3.0.0.
+ *
+ * See also SDL_SetPaletteColors
+ */
+type SDL_Palette struct {
+	Ncolors  int         /**< number of elements in `colors`. */
+	Colors   []SDL_Color /**< an array of colors, `ncolors` long. */
+	Version  uint32      /**< internal use only, do not touch. */
+	Refcount int         /**< internal use only, do not touch. */
+}
+
+/**
+ * Details about the format of a pixel.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_PixelFormatDetails struct {
+	Format          SDL_PixelFormat
+	Bits_per_pixel  uint8
+	Bytes_per_pixel uint8
+	Rmask           uint32
+	Gmask           uint32
+	Bmask           uint32
+	Amask           uint32
+	Rbits           uint8
+	Gbits           uint8
+	Bbits           uint8
+	Abits           uint8
+	Rshift          uint8
+	Gshift          uint8
+	Bshift          uint8
+	Ashift          uint8
+}
+
+/* Bit sizes of the components of a packed layout, high bit -> low bit */
+var packedLayoutBits = map[SDL_PackedLayout][]uint8{
+	SDL_PACKEDLAYOUT_332:     {3, 3, 2},
+	SDL_PACKEDLAYOUT_4444:    {4, 4, 4, 4},
+	SDL_PACKEDLAYOUT_1555:    {1, 5, 5, 5},
+	SDL_PACKEDLAYOUT_5551:    {5, 5, 5, 1},
+	SDL_PACKEDLAYOUT_565:     {5, 6, 5},
+	SDL_PACKEDLAYOUT_8888:    {8, 8, 8, 8},
+	SDL_PACKEDLAYOUT_2101010: {2, 10, 10, 10},
+	SDL_PACKEDLAYOUT_1010102: {10, 10, 10, 2},
+}
+
+/* Components of a packed order, high bit -> low bit */
+var packedOrderComponents = map[SDL_PackedOrder]string{
+	SDL_PACKEDORDER_XRGB: "XRGB",
+	SDL_PACKEDORDER_RGBX: "RGBX",
+	SDL_PACKEDORDER_ARGB: "ARGB",
+	SDL_PACKEDORDER_RGBA: "RGBA",
+	SDL_PACKEDORDER_XBGR: "XBGR",
+	SDL_PACKEDORDER_BGRX: "BGRX",
+	SDL_PACKEDORDER_ABGR: "ABGR",
+	SDL_PACKEDORDER_BGRA: "BGRA",
+}
+
+var pixelFormatNames = map[SDL_PixelFormat]string{
+	SDL_PIXELFORMAT_UNKNOWN:  "SDL_PIXELFORMAT_UNKNOWN",
+	SDL_PIXELFORMAT_INDEX8:   "SDL_PIXELFORMAT_INDEX8",
+	SDL_PIXELFORMAT_RGB332:   "SDL_PIXELFORMAT_RGB332",
+	SDL_PIXELFORMAT_XRGB4444: "SDL_PIXELFORMAT_XRGB4444",
+	SDL_PIXELFORMAT_XRGB1555: "SDL_PIXELFORMAT_XRGB1555",
+	SDL_PIXELFORMAT_ARGB4444: "SDL_PIXELFORMAT_ARGB4444",
+	SDL_PIXELFORMAT_RGBA4444: "SDL_PIXELFORMAT_RGBA4444",
+	SDL_PIXELFORMAT_ABGR4444: "SDL_PIXELFORMAT_ABGR4444",
+	SDL_PIXELFORMAT_BGRA4444: "SDL_PIXELFORMAT_BGRA4444",
+	SDL_PIXELFORMAT_ARGB1555: "SDL_PIXELFORMAT_ARGB1555",
+	SDL_PIXELFORMAT_RGBA5551: "SDL_PIXELFORMAT_RGBA5551",
+	SDL_PIXELFORMAT_RGB565:   "SDL_PIXELFORMAT_RGB565",
+	SDL_PIXELFORMAT_BGR565:   "SDL_PIXELFORMAT_BGR565",
+	SDL_PIXELFORMAT_XRGB8888: "SDL_PIXELFORMAT_XRGB8888",
+	SDL_PIXELFORMAT_RGBX8888: "SDL_PIXELFORMAT_RGBX8888",
+	SDL_PIXELFORMAT_XBGR8888: "SDL_PIXELFORMAT_XBGR8888",
+	SDL_PIXELFORMAT_BGRX8888: "SDL_PIXELFORMAT_BGRX8888",
+	SDL_PIXELFORMAT_ARGB8888: "SDL_PIXELFORMAT_ARGB8888",
+	SDL_PIXELFORMAT_RGBA8888: "SDL_PIXELFORMAT_RGBA8888",
+	SDL_PIXELFORMAT_ABGR8888: "SDL_PIXELFORMAT_ABGR8888",
+	SDL_PIXELFORMAT_BGRA8888: "SDL_PIXELFORMAT_BGRA8888",
+}
+
+/**
+ * Get the human readable name of a pixel format.
+ *
+ * - format the pixel format to query.
+ * Returns the human readable name of the specified pixel format or
+ *          "SDL_PIXELFORMAT_UNKNOWN" if the format isn't recognized.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetPixelFormatName(format SDL_PixelFormat) string {
+	if name, ok := pixelFormatNames[format]; ok {
+		return name
+	}
+	return "SDL_PIXELFORMAT_UNKNOWN"
+}
+
+/* The details are immutable once computed, so they're shared */
+var pixelFormatDetailsLock = sdlMutex{name: "pixels.formats"}
+var pixelFormatDetailsCache = map[SDL_PixelFormat]*SDL_PixelFormatDetails{}
+
+/**
+ * Create an SDL_PixelFormatDetails structure corresponding to a pixel format.
+ *
+ * Returned structure may come from a shared global cache (i.e. not newly
+ * allocated), and hence should not be modified, especially the palette. Weird
+ * errors such as `Blit combination not supported` may occur.
+ *
+ * - format one of the SDL_PixelFormat values.
+ * Returns a pointer to a SDL_PixelFormatDetails structure or nil on
+ *          failure; call SDL_GetError() for more information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetPixelFormatDetails(format SDL_PixelFormat) *SDL_PixelFormatDetails {
+	pixelFormatDetailsLock.Lock()
+	defer pixelFormatDetailsLock.Unlock()
+
+	if details := pixelFormatDetailsCache[format]; details != nil {
+		return details
+	}
+
+	details := &SDL_PixelFormatDetails{
+		Format:          format,
+		Bits_per_pixel:  uint8(SDL_BITSPERPIXEL(format)),
+		Bytes_per_pixel: uint8(SDL_BYTESPERPIXEL(format)),
+	}
+
+	switch {
+	case SDL_PIXELTYPE(format) == SDL_PIXELTYPE_INDEX8:
+		/* Indexed formats have no masks, the palette gives the colors */
+	case SDL_ISPIXELFORMAT_PACKED(format):
+		sizes := packedLayoutBits[SDL_PIXELLAYOUT(format)]
+		order := packedOrderComponents[SDL_PackedOrder(SDL_PIXELORDER(format))]
+		if sizes == nil || order == "" {
+			SDL_SetErrorf("Unknown pixel format %s", SDL_GetPixelFormatName(format))
+			return nil
+		}
+		if len(sizes) == 3 {
+			/* The padding of three component layouts has no bits */
+			order = stringsTrimByte(order, 'X')
+		}
+
+		shift := uint8(0)
+		for i := len(sizes) - 1; i >= 0; i-- {
+			bits := sizes[i]
+			mask := (uint32(1)<<bits - 1) << shift
+			switch order[i] {
+			case 'R':
+				details.Rmask, details.Rbits, details.Rshift = mask, bits, shift
+			case 'G':
+				details.Gmask, details.Gbits, details.Gshift = mask, bits, shift
+			case 'B':
+				details.Bmask, details.Bbits, details.Bshift = mask, bits, shift
+			case 'A':
+				details.Amask, details.Abits, details.Ashift = mask, bits, shift
+			}
+			shift += bits
+		}
+	default:
+		SDL_SetErrorf("Unsupported pixel format %s", SDL_GetPixelFormatName(format))
+		return nil
+	}
+
+	pixelFormatDetailsCache[format] = details
+	return details
+}
+
+func stringsTrimByte(s string, c byte) string {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != c {
+			out = append(out, s[i])
+		}
+	}
+	return string(out)
+}
+
+/**
+ * Create a palette structure with the specified number of color entries.
+ *
+ * The palette entries are initialized to white.
+ *
+ * - ncolors represents the number of color entries in the color palette.
+ * Returns a new SDL_Palette structure on success or nil on failure (e.g. if
+ *          there wasn't enough memory); call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DestroyPalette
+ * See also SDL_SetPaletteColors
+ * See also SDL_SetSurfacePalette
+ */
+func SDL_CreatePalette(ncolors int) *SDL_Palette {
+	/* Input validation */
+	if ncolors < 1 {
+		SDL_InvalidParamError("ncolors")
+		return nil
+	}
+
+	palette := &SDL_Palette{
+		Ncolors:  ncolors,
+		Colors:   make([]SDL_Color, ncolors),
+		Version:  1,
+		Refcount: 1,
+	}
+	for i := range palette.Colors {
+		palette.Colors[i] = SDL_Color{0xFF, 0xFF, 0xFF, 0xFF}
+	}
+	return palette
+}
+
+/**
+ * Set a range of colors in a palette.
+ *
+ * - palette the SDL_Palette structure to modify.
+ * - colors an array of SDL_Color structures to copy into the palette.
+ * - firstcolor the index of the first palette entry to modify.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified or destroyed in another thread.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_SetPaletteColors(palette *SDL_Palette, colors []SDL_Color, firstcolor int) bool {
+	if palette == nil {
+		return SDL_InvalidParamError("palette")
+	}
+	if firstcolor < 0 || firstcolor >= palette.Ncolors {
+		return SDL_InvalidParamError("firstcolor")
+	}
+
+	copy(palette.Colors[firstcolor:], colors)
+	palette.Version++
+	if palette.Version == 0 {
+		palette.Version = 1
+	}
+	return true
+}
+
+/**
+ * Free a palette created with SDL_CreatePalette().
+ *
+ * - palette the SDL_Palette structure to be freed.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified or destroyed in another thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreatePalette
+ */
+func SDL_DestroyPalette(palette *SDL_Palette) {
+	if palette == nil {
+		return
+	}
+	palette.Refcount--
+	if palette.Refcount > 0 {
+		return
+	}
+	palette.Colors = nil
+	palette.Ncolors = 0
+}
+
+/*
+ * Expand an n-bit component to 8 bits by replicating its high bits into the
+ * low bits, so 0 stays 0 and the maximum value becomes 255.
+ */
+func sdlExpandComponent(value uint32, bits uint8) uint8 {
+	if bits == 0 {
+		return 0
+	}
+	if bits >= 8 {
+		return uint8(value >> (bits - 8))
+	}
+	out := value << (8 - bits)
+	for s := bits; s < 8; s *= 2 {
+		out |= out >> s
+	}
+	return uint8(out)
+}
+
+/* Find the palette entry closest to the given color */
+func sdlFindColor(palette *SDL_Palette, r, g, b, a uint8) uint8 {
+	smallest := ^uint32(0)
+	pixel := 0
+	for i, color := range palette.Colors[:palette.Ncolors] {
+		rd := int(color.R) - int(r)
+		gd := int(color.G) - int(g)
+		bd := int(color.B) - int(b)
+		ad := int(color.A) - int(a)
+		distance := uint32(rd*rd + gd*gd + bd*bd + ad*ad)
+		if distance < smallest {
+			pixel = i
+			if distance == 0 { /* Perfect match! */
+				break
+			}
+			smallest = distance
+		}
+	}
+	return uint8(pixel)
+}
+
+/**
+ * Map an RGB triple to an opaque pixel value for a given pixel format.
+ *
+ * This function maps the RGB color value to the specified pixel format and
+ * returns the pixel value best approximating the given RGB color value for
+ * the given pixel format.
+ *
+ * If the format has a palette (8-bit) the index of the closest matching color
+ * in the palette will be returned.
+ *
+ * If the specified pixel format has an alpha component it will be returned as
+ * all 1 bits (fully opaque).
+ *
+ * - format a pointer to SDL_PixelFormatDetails describing the pixel
+ *               format.
+ * - palette an optional palette for indexed formats, may be nil.
+ * - r the red component of the pixel in the range 0-255.
+ * - g the green component of the pixel in the range 0-255.
+ * - b the blue component of the pixel in the range 0-255.
+ * Returns a pixel value.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPixelFormatDetails
+ * See also SDL_GetRGB
+ * See also SDL_MapRGBA
+ * See also SDL_MapSurfaceRGB
+ */
+func SDL_MapRGB(format *SDL_PixelFormatDetails, palette *SDL_Palette, r, g, b uint8) uint32 {
+	return SDL_MapRGBA(format, palette, r, g, b, SDL_ALPHA_OPAQUE)
+}
+
+/**
+ * Map an RGBA quadruple to a pixel value for a given pixel format.
+ *
+ * This function maps the RGBA color value to the specified pixel format and
+ * returns the pixel value best approximating the given RGBA color value for
+ * the given pixel format.
+ *
+ * If the specified pixel format has no alpha component the alpha value will
+ * be ignored (as it will be in formats with a palette).
+ *
+ * If the format has a palette (8-bit) the index of the closest matching color
+ * in the palette will be returned.
+ *
+ * - format a pointer to SDL_PixelFormatDetails describing the pixel
+ *               format.
+ * - palette an optional palette for indexed formats, may be nil.
+ * - r the red component of the pixel in the range 0-255.
+ * - g the green component of the pixel in the range 0-255.
+ * - b the blue component of the pixel in the range 0-255.
+ * - a the alpha component of the pixel in the range 0-255.
+ * Returns a pixel value.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPixelFormatDetails
+ * See also SDL_GetRGBA
+ * See also SDL_MapRGB
+ * See also SDL_MapSurfaceRGBA
+ */
+func SDL_MapRGBA(format *SDL_PixelFormatDetails, palette *SDL_Palette, r, g, b, a uint8) uint32 {
+	if format == nil {
+		SDL_InvalidParamError("format")
+		return 0
+	}
+
+	if SDL_ISPIXELFORMAT_INDEXED(format.Format) {
+		if palette == nil {
+			SDL_InvalidParamError("palette")
+			return 0
+		}
+		return uint32(sdlFindColor(palette, r, g, b, a))
+	}
+	return sdlMapPacked(format, r, g, b, a)
+}
+
+/**
+ * Get RGB values from a pixel in the specified format.
+ *
+ * This function uses the entire 8-bit [0..255] range when converting color
+ * components from pixel formats with less than 8-bits per RGB component
+ * (e.g., a completely white pixel in 16-bit RGB565 format would return [0xff,
+ * 0xff, 0xff] not [0xf8, 0xfc, 0xf8]).
+ *
+ * - pixel a pixel value.
+ * - format a pointer to SDL_PixelFormatDetails describing the pixel
+ *               format.
+ * - palette an optional palette for indexed formats, may be nil.
+ * Returns the red, green and blue components.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPixelFormatDetails
+ * See also SDL_GetRGBA
+ * See also SDL_MapRGB
+ * See also SDL_MapRGBA
+ */
+func SDL_GetRGB(pixel uint32, format *SDL_PixelFormatDetails, palette *SDL_Palette) (r, g, b uint8) {
+	r, g, b, _ = SDL_GetRGBA(pixel, format, palette)
+	return r, g, b
+}
+
+/**
+ * Get RGBA values from a pixel in the specified format.
+ *
+ * This function uses the entire 8-bit [0..255] range when converting color
+ * components from pixel formats with less than 8-bits per RGB component
+ * (e.g., a completely white pixel in 16-bit RGB565 format would return [0xff,
+ * 0xff, 0xff] not [0xf8, 0xfc, 0xf8]).
+ *
+ * If the surface has no alpha component, the alpha will be returned as 0xff
+ * (100% opaque).
+ *
+ * - pixel a pixel value.
+ * - format a pointer to SDL_PixelFormatDetails describing the pixel
+ *               format.
+ * - palette an optional palette for indexed formats, may be nil.
+ * Returns the red, green, blue and alpha components.
+ *
+ * It is safe to call this function from any thread, as long as the palette
+ * is not modified.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPixelFormatDetails
+ * See also SDL_GetRGB
+ * See also SDL_MapRGB
+ * See also SDL_MapRGBA
+ */
+func SDL_GetRGBA(pixel uint32, format *SDL_PixelFormatDetails, palette *SDL_Palette) (r, g, b, a uint8) {
+	if format == nil {
+		return 0, 0, 0, 0
+	}
+
+	if SDL_ISPIXELFORMAT_INDEXED(format.Format) {
+		if palette != nil && int(pixel) < palette.Ncolors {
+			c := palette.Colors[pixel]
+			return c.R, c.G, c.B, c.A
+		}
+		return 0, 0, 0, 0
+	}
+
+	r = sdlExpandComponent((pixel&format.Rmask)>>format.Rshift, format.Rbits)
+	g = sdlExpandComponent((pixel&format.Gmask)>>format.Gshift, format.Gbits)
+	b = sdlExpandComponent((pixel&format.Bmask)>>format.Bshift, format.Bbits)
+	if format.Amask != 0 {
+		a = sdlExpandComponent((pixel&format.Amask)>>format.Ashift, format.Abits)
+	} else {
+		a = SDL_ALPHA_OPAQUE
+	}
+	return r, g, b, a
+}
@@ -0,0 +1,130 @@
+package sdl
+
+import "fmt"
+import "strings"
+
+/*
+ * Window placement across display configurations.
+ *
+ * A window remembers its floating geometry for each display configuration
+ * it was used with, keyed by the names and bounds of the connected displays.
+ * When the configuration changes, e.g. a laptop is docked or a monitor is
+ * unplugged, windows go back to where they were the last time that
+ * configuration was in use. Windows left outside every display, because
+ * their display was disconnected, move to the primary display at the same
+ * offset, and a fullscreen window on a disconnected display goes fullscreen
+ * on the display it moved to.
+ *
+ * Popups are positioned relative to their parent and follow it.
+ */
+
+/* A key for the connected displays and their layout */
+func sdlDisplayConfiguration() string {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	var key strings.Builder
+	for _, display := range displays {
+		b := display.bounds
+		fmt.Fprintf(&key, "%s@%d,%d,%dx%d;", display.name, b.X, b.Y, b.W, b.H)
+	}
+	return key.String()
+}
+
+/* The display containing a point in screen coordinates, or 0 if it is outside all of them */
+func sdlGetDisplayAtPoint(x, y int) SDL_DisplayID {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	for _, display := range displays {
+		b := display.bounds
+		if x >= b.X && x < b.X+b.W && y >= b.Y && y < b.Y+b.H {
+			return display.id
+		}
+	}
+	return 0
+}
+
+/* Record the floating geometry of a window for the current display configuration */
+func sdlRememberWindowPlacement(window *SDL_Window) {
+	if sdlIsPopup(window) || window.flags&(SDL_WINDOW_FULLSCREEN|SDL_WINDOW_MAXIMIZED|SDL_WINDOW_MINIMIZED) != 0 {
+		return
+	}
+	/* Nothing to remember while the window is off screen, e.g. being migrated */
+	if sdlGetDisplayAtPoint(window.x+window.w/2, window.y+window.h/2) == 0 {
+		return
+	}
+
+	if window.placements == nil {
+		window.placements = make(map[string]SDL_Rect)
+	}
+	window.placements[sdlDisplayConfiguration()] = SDL_Rect{X: window.x, Y: window.y, W: window.w, H: window.h}
+}
+
+/*
+ * Move a window left outside every display to the display, keeping its
+ * offset from the origin of the display it was on when that is known, or
+ * centering it otherwise.
+ */
+func sdlMigrateWindow(window *SDL_Window, from SDL_Rect, displayID SDL_DisplayID) {
+	var bounds SDL_Rect
+	if !SDL_GetDisplayBounds(displayID, &bounds) {
+		return
+	}
+
+	x, y := bounds.X+(bounds.W-window.w)/2, bounds.Y+(bounds.H-window.h)/2
+	if from.W > 0 && from.H > 0 {
+		x = bounds.X + max(min(window.x-from.X, bounds.W-window.w), 0)
+		y = bounds.Y + max(min(window.y-from.Y, bounds.H-window.h), 0)
+	}
+	SDL_SetWindowPosition(window, x, y)
+}
+
+/*
+ * Called when a display is connected, disconnected or moved, after the
+ * display registry is updated. removed is the display that was disconnected
+ * and removed_bounds its last bounds, or 0 and an empty rectangle.
+ */
+func sdlOnDisplaysChanged(removed SDL_DisplayID, removed_bounds SDL_Rect) {
+	primary := SDL_GetPrimaryDisplay()
+	if primary == 0 {
+		/* Windows stay where they are until a display is connected */
+		return
+	}
+
+	key := sdlDisplayConfiguration()
+	for _, window := range SDL_GetWindows() {
+		if sdlIsPopup(window) {
+			continue
+		}
+
+		refullscreen := false
+		if window.flags&SDL_WINDOW_FULLSCREEN != 0 {
+			if removed == 0 || window.fullscreen_display != removed {
+				continue
+			}
+			sdlUpdateFullscreenMode(window, false)
+			if window.requested_fullscreen_mode.DisplayID == removed {
+				/* The exclusive mode went away with the display */
+				window.requested_fullscreen_mode = SDL_DisplayMode{}
+			}
+			refullscreen = true
+		}
+
+		rect, remembered := window.placements[key]
+		if remembered && window.flags&(SDL_WINDOW_MAXIMIZED|SDL_WINDOW_MINIMIZED) == 0 {
+			if rect.X != window.x || rect.Y != window.y {
+				SDL_SetWindowPosition(window, rect.X, rect.Y)
+			}
+			if rect.W != window.w || rect.H != window.h {
+				SDL_SetWindowSize(window, rect.W, rect.H)
+			}
+		} else if sdlGetDisplayAtPoint(window.x+window.w/2, window.y+window.h/2) == 0 {
+			sdlMigrateWindow(window, removed_bounds, primary)
+		}
+
+		if refullscreen {
+			sdlUpdateFullscreenMode(window, true)
+		}
+	}
+}
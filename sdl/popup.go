@@ -0,0 +1,206 @@
+package sdl
+
+/*
+ * Popup windows: tooltips and popup menus.
+ *
+ * A popup belongs to a parent window, which may be a popup itself for nested
+ * menus. Its position is an offset from the parent's position, so popups
+ * follow their parent around, and it can't be fullscreen, minimized or
+ * maximized on its own. Popups are hidden along with their parent and shown
+ * again with it, and destroyed with it.
+ *
+ * Tooltips never take the input focus. A popup menu takes it from its
+ * parent chain when it's shown, and gives it back to its parent when it's
+ * hidden or destroyed, so the keyboard drives the open menu.
+ */
+
+/* Whether the window is a tooltip or a popup menu */
+func sdlIsPopup(window *SDL_Window) bool {
+	return window.flags&(SDL_WINDOW_TOOLTIP|SDL_WINDOW_POPUP_MENU) != 0
+}
+
+/* sdlCheckWindow() for the operations that only apply to toplevel windows */
+func sdlCheckWindowNotPopup(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if sdlIsPopup(window) {
+		return SDL_SetError("Operation invalid on popup windows")
+	}
+	return true
+}
+
+/* Check that popup flags ask for exactly one kind of popup */
+func sdlCheckPopupFlags(flags SDL_WindowFlags) bool {
+	popup := flags & (SDL_WINDOW_TOOLTIP | SDL_WINDOW_POPUP_MENU)
+	if popup == 0 || popup == SDL_WINDOW_TOOLTIP|SDL_WINDOW_POPUP_MENU {
+		return SDL_SetError("Popup windows must specify either the 'SDL_WINDOW_TOOLTIP' or the 'SDL_WINDOW_POPUP_MENU' flag")
+	}
+	return true
+}
+
+/**
+ * Create a child popup window of the specified parent window.
+ *
+ * The flags parameter **must** contain at least one of the following:
+ *
+ * - `SDL_WINDOW_TOOLTIP`: The popup window is a tooltip and will not pass any
+ *   input events.
+ * - `SDL_WINDOW_POPUP_MENU`: The popup window is a popup menu. The topmost
+ *   popup menu will implicitly gain the keyboard focus.
+ *
+ * The following flags are not relevant to popup window creation and will be
+ * ignored:
+ *
+ * - `SDL_WINDOW_MINIMIZED`
+ * - `SDL_WINDOW_MAXIMIZED`
+ * - `SDL_WINDOW_FULLSCREEN`
+ * - `SDL_WINDOW_BORDERLESS`
+ *
+ * The following flags are incompatible with popup window creation and will
+ * cause it to fail:
+ *
+ * - `SDL_WINDOW_UTILITY`
+ *
+ * The parent parameter **must** be non-nil and a valid window. The parent of
+ * a popup window can be either a regular, toplevel window, or another popup
+ * window.
+ *
+ * Popup windows cannot be minimized, maximized, made fullscreen or raised.
+ * Attempts to do so will fail.
+ *
+ * Popup windows implicitly do not have a border/decorations and do not
+ * appear on the taskbar/dock or in lists of windows such as alt-tab menus.
+ *
+ * By default, popup window positions will automatically be constrained to
+ * keep the entire window within display bounds. This can be overridden with
+ * the `SDL_PROP_WINDOW_CREATE_CONSTRAIN_POPUP_BOOLEAN` property.
+ *
+ * If a parent window is hidden or destroyed, any child popup windows will be
+ * recursively hidden or destroyed as well. Child popup windows not
+ * explicitly hidden will be restored when the parent is shown.
+ *
+ * - parent the parent of the window, must not be nil.
+ * - offset_x the x position of the popup window relative to the origin of
+ *                 the parent.
+ * - offset_y the y position of the popup window relative to the origin of
+ *                 the parent window.
+ * - w the width of the window.
+ * - h the height of the window.
+ * - flags SDL_WINDOW_TOOLTIP or SDL_WINDOW_POPUP_MENU, and zero or more
+ *              additional SDL_WindowFlags OR'd together.
+ * Returns the window that was created or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateWindow
+ * See also SDL_CreateWindowWithProperties
+ * See also SDL_DestroyWindow
+ * See also SDL_GetWindowParent
+ */
+func SDL_CreatePopupWindow(parent *SDL_Window, offset_x int, offset_y int, w int, h int, flags SDL_WindowFlags) *SDL_Window {
+	if !sdlCheckWindow(parent) {
+		return nil
+	}
+	if !sdlCheckPopupFlags(flags) {
+		return nil
+	}
+
+	props := SDL_CreateProperties()
+	defer SDL_DestroyProperties(props)
+
+	SDL_SetPointerProperty(props, SDL_PROP_WINDOW_CREATE_PARENT_POINTER, parent)
+	SDL_SetNumberProperty(props, SDL_PROP_WINDOW_CREATE_X_NUMBER, int64(offset_x))
+	SDL_SetNumberProperty(props, SDL_PROP_WINDOW_CREATE_Y_NUMBER, int64(offset_y))
+	SDL_SetNumberProperty(props, SDL_PROP_WINDOW_CREATE_WIDTH_NUMBER, int64(w))
+	SDL_SetNumberProperty(props, SDL_PROP_WINDOW_CREATE_HEIGHT_NUMBER, int64(h))
+	SDL_SetNumberProperty(props, SDL_PROP_WINDOW_CREATE_FLAGS_NUMBER, int64(flags))
+	return SDL_CreateWindowWithProperties(props)
+}
+
+/**
+ * Get parent of a window.
+ *
+ * - window the window to query.
+ * Returns the parent of the window on success or nil if the window has no
+ *          parent.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreatePopupWindow
+ */
+func SDL_GetWindowParent(window *SDL_Window) *SDL_Window {
+	if !sdlCheckWindow(window) {
+		return nil
+	}
+	return window.parent
+}
+
+/* Convert a position relative to the parent of a window to global coordinates, for backends placing popups */
+func sdlRelativeToGlobalForWindow(window *SDL_Window, x, y int) (int, int) {
+	for ; sdlIsPopup(window) && window.parent != nil; window = window.parent {
+		x += window.parent.x
+		y += window.parent.y
+	}
+	return x, y
+}
+
+/* Convert a global position to one relative to the parent of a window */
+func sdlGlobalToRelativeForWindow(window *SDL_Window, x, y int) (int, int) {
+	for ; sdlIsPopup(window) && window.parent != nil; window = window.parent {
+		x -= window.parent.x
+		y -= window.parent.y
+	}
+	return x, y
+}
+
+/* Move a popup offset so the whole popup stays within the display of its parent */
+func sdlConstrainPopup(window *SDL_Window, x, y int) (int, int) {
+	if !sdlIsPopup(window) || !window.constrain_popup {
+		return x, y
+	}
+
+	var bounds SDL_Rect
+	if !SDL_GetDisplayBounds(SDL_GetDisplayForWindow(window.parent), &bounds) {
+		return x, y
+	}
+	gx, gy := sdlRelativeToGlobalForWindow(window, x, y)
+	gx = max(min(gx, bounds.X+bounds.W-window.w), bounds.X)
+	gy = max(min(gy, bounds.Y+bounds.H-window.h), bounds.Y)
+	return sdlGlobalToRelativeForWindow(window, gx, gy)
+}
+
+/* Move the input focus from the parent chain of a popup menu that was just shown */
+func sdlOnPopupShown(window *SDL_Window) {
+	if window.flags&(SDL_WINDOW_POPUP_MENU|SDL_WINDOW_NOT_FOCUSABLE) != SDL_WINDOW_POPUP_MENU {
+		return
+	}
+	for focused := window.parent; focused != nil; focused = focused.parent {
+		if focused.flags&SDL_WINDOW_INPUT_FOCUS != 0 {
+			sdlSendWindowEvent(focused, SDL_EVENT_WINDOW_FOCUS_LOST, 0, 0)
+			sdlSendWindowEvent(window, SDL_EVENT_WINDOW_FOCUS_GAINED, 0, 0)
+			return
+		}
+		if !sdlIsPopup(focused) {
+			return
+		}
+	}
+}
+
+/* Give the input focus back to the closest visible ancestor of a popup menu going away */
+func sdlOnPopupHidden(window *SDL_Window) {
+	if !sdlIsPopup(window) || window.flags&SDL_WINDOW_INPUT_FOCUS == 0 {
+		return
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_FOCUS_LOST, 0, 0)
+	for parent := window.parent; parent != nil; parent = parent.parent {
+		if parent.flags&(SDL_WINDOW_HIDDEN|SDL_WINDOW_NOT_FOCUSABLE) == 0 {
+			sdlSendWindowEvent(parent, SDL_EVENT_WINDOW_FOCUS_GAINED, 0, 0)
+			return
+		}
+		if !sdlIsPopup(parent) {
+			return
+		}
+	}
+}
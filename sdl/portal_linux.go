@@ -0,0 +1,358 @@
+package sdl
+
+import "fmt"
+import "image/png"
+import "net/url"
+import "os"
+import "path/filepath"
+import "strings"
+import "sync/atomic"
+import "unicode"
+
+/*
+ * The XDG desktop portals, the desktop services a Flatpak or Snap sandboxed
+ * application can use without holes in its sandbox, and which follow the
+ * desktop's look outside of one too. Used for the file dialogs, opening
+ * URLs, screenshots and the system theme.
+ *
+ * Methods showing a user interface return a request object right away and
+ * the result comes with its Response signal once the user is done. The
+ * request path is derived from a token of ours, so the signal is subscribed
+ * to before the method is called and can't be missed.
+ *
+ * The clipboard portal is left out: it only serves remote desktop sessions,
+ * not regular applications.
+ */
+
+const sdlPortalDestination = "org.freedesktop.portal.Desktop"
+const sdlPortalPath = "/org/freedesktop/portal/desktop"
+
+/* Response codes of the Request.Response signal */
+const (
+	sdlPortalResponseSuccess   = 0
+	sdlPortalResponseCancelled = 1
+)
+
+/* Numbers the request tokens */
+var portalRequestToken atomic.Uint32
+
+func init() {
+	screenshotBackend = sdlPortalCaptureScreenshot
+	systemThemeBackend = &sdlSystemThemeBackend{
+		Init: sdlPortalThemeInit,
+		Quit: sdlPortalThemeQuit,
+	}
+}
+
+/* Whether the desktop portal service can be used */
+func sdlPortalAvailable() bool {
+	bus := sdlDBusSession()
+	return bus != nil && bus.NameAvailable(sdlPortalDestination)
+}
+
+/* The portal identifier of a parent window, or "" if it has none */
+func sdlPortalParentWindow(window *SDL_Window) string {
+	if window == nil {
+		return ""
+	}
+	if xid := SDL_GetNumberProperty(SDL_GetWindowProperties(window), SDL_PROP_WINDOW_X11_WINDOW_NUMBER, 0); xid != 0 {
+		return fmt.Sprintf("x11:%x", xid)
+	}
+	return ""
+}
+
+/*
+ * Call a portal method taking options last and returning a request, and
+ * wait for the response. The handle token is added to the options. Returns
+ * the response code and results, or false with an error set.
+ */
+func sdlPortalRequest(iface, method, sig string, args []any, options map[string]sdlDBusVariant) (uint32, map[string]sdlDBusVariant, bool) {
+	bus := sdlDBusSession()
+	if bus == nil {
+		return 0, nil, false
+	}
+
+	token := fmt.Sprintf("sdl%d", portalRequestToken.Add(1))
+	sender := strings.ReplaceAll(strings.TrimPrefix(bus.name, ":"), ".", "_")
+	handle := sdlPortalPath + "/request/" + sender + "/" + token
+
+	responses := make(chan *sdlDBusMessage, 1)
+	id, ok := bus.AddSignalHandler(handle, "org.freedesktop.portal.Request", "Response", func(msg *sdlDBusMessage) {
+		select {
+		case responses <- msg:
+		default:
+		}
+	})
+	if !ok {
+		return 0, nil, false
+	}
+	defer bus.RemoveSignalHandler(id)
+
+	options["handle_token"] = sdlDBusVariant{sig: "s", value: token}
+	if _, ok := bus.Call(sdlPortalDestination, sdlPortalPath, iface, method, sig, append(args, options)...); !ok {
+		return 0, nil, false
+	}
+
+	msg := <-responses
+	if msg == nil {
+		return 0, nil, SDL_SetError("The D-Bus connection was lost")
+	}
+	if len(msg.body) != 2 {
+		return 0, nil, SDL_SetErrorf("Unexpected response from %s.%s", iface, method)
+	}
+	code, _ := msg.body[0].(uint32)
+	results, _ := msg.body[1].(map[string]sdlDBusVariant)
+	return code, results, true
+}
+
+/* The local path of a file:// URI, or "" for other URIs */
+func sdlPortalURIPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return u.Path
+}
+
+/* A glob matching an extension in any case, as the portal globs are case-sensitive */
+func sdlPortalGlob(ext string) string {
+	var glob strings.Builder
+	glob.WriteString("*.")
+	for _, r := range ext {
+		if lower, upper := unicode.ToLower(r), unicode.ToUpper(r); lower != upper {
+			fmt.Fprintf(&glob, "[%c%c]", lower, upper)
+		} else {
+			glob.WriteRune(r)
+		}
+	}
+	return glob.String()
+}
+
+/* A filter in the (sa(us)) form of the portal, the patterns are globs */
+func sdlPortalFilter(filter SDL_DialogFileFilter) []any {
+	patterns := []any{}
+	for _, ext := range strings.Split(filter.Pattern, ";") {
+		if ext == "*" {
+			patterns = append(patterns, []any{uint32(0), "*"})
+		} else if ext != "" {
+			patterns = append(patterns, []any{uint32(0), sdlPortalGlob(ext)})
+		}
+	}
+	return []any{filter.Name, patterns}
+}
+
+/* A path in the nul terminated byte string form of the portal */
+func sdlPortalBytes(path string) sdlDBusVariant {
+	return sdlDBusVariant{sig: "ay", value: append([]byte(path), 0)}
+}
+
+func sdlPortalShowFileDialog(dialog *sdlFileDialog, done func(filelist []string, filter int, err string)) {
+	method, title := "OpenFile", "Open File"
+	options := map[string]sdlDBusVariant{
+		"modal": {sig: "b", value: dialog.window != nil},
+	}
+
+	switch dialog.kind {
+	case SDL_FILEDIALOG_OPENFILE:
+		options["multiple"] = sdlDBusVariant{sig: "b", value: dialog.many}
+	case SDL_FILEDIALOG_SAVEFILE:
+		method, title = "SaveFile", "Save File"
+	case SDL_FILEDIALOG_OPENFOLDER:
+		title = "Open Folder"
+		options["multiple"] = sdlDBusVariant{sig: "b", value: dialog.many}
+		options["directory"] = sdlDBusVariant{sig: "b", value: true}
+	}
+	if dialog.title != "" {
+		title = dialog.title
+	}
+	if dialog.accept != "" {
+		options["accept_label"] = sdlDBusVariant{sig: "s", value: dialog.accept}
+	}
+
+	if location := dialog.location; location != "" {
+		if info, err := os.Stat(location); err == nil && info.IsDir() {
+			options["current_folder"] = sdlPortalBytes(location)
+		} else if dialog.kind == SDL_FILEDIALOG_SAVEFILE {
+			options["current_name"] = sdlDBusVariant{sig: "s", value: filepath.Base(location)}
+			if info, err := os.Stat(filepath.Dir(location)); err == nil && info.IsDir() {
+				options["current_folder"] = sdlPortalBytes(filepath.Dir(location))
+			}
+		}
+	}
+
+	if len(dialog.filters) > 0 {
+		filters := make([]any, 0, len(dialog.filters))
+		for _, filter := range dialog.filters {
+			filters = append(filters, sdlPortalFilter(filter))
+		}
+		options["filters"] = sdlDBusVariant{sig: "a(sa(us))", value: filters}
+		options["current_filter"] = sdlDBusVariant{sig: "(sa(us))", value: filters[dialog.current]}
+	}
+
+	/* The window properties are read here, the dialog runs on its own goroutine */
+	parent := sdlPortalParentWindow(dialog.window)
+
+	sdlGo(func() {
+		code, results, ok := sdlPortalRequest("org.freedesktop.portal.FileChooser", method, "ssa{sv}", []any{parent, title}, options)
+		if !ok {
+			done(nil, -1, SDL_GetError())
+			return
+		}
+		switch code {
+		case sdlPortalResponseSuccess:
+		case sdlPortalResponseCancelled:
+			done([]string{}, -1, "")
+			return
+		default:
+			done(nil, -1, "The file dialog portal failed")
+			return
+		}
+
+		filelist := []string{}
+		uris, _ := results["uris"].value.([]any)
+		for _, uri := range uris {
+			if uri, ok := uri.(string); ok {
+				if path := sdlPortalURIPath(uri); path != "" {
+					filelist = append(filelist, path)
+				}
+			}
+		}
+		if len(filelist) == 0 {
+			done(filelist, -1, "")
+			return
+		}
+
+		filter := -1
+		if current, ok := results["current_filter"].value.([]any); ok && len(current) == 2 {
+			for i, f := range dialog.filters {
+				if f.Name == current[0] {
+					filter = i
+					break
+				}
+			}
+		}
+		if filter < 0 {
+			filter = sdlMatchDialogFilter(dialog.filters, filelist[0])
+		}
+		done(filelist, filter, "")
+	})
+}
+
+/* Open a URI with the OpenURI portal, returns false with an error set if the portal can't */
+func sdlPortalOpenURI(uri string) bool {
+	bus := sdlDBusSession()
+	if bus == nil {
+		return false
+	}
+	/* The request completes once the handler is launched, there's nothing to wait for */
+	options := map[string]sdlDBusVariant{}
+	_, ok := bus.Call(sdlPortalDestination, sdlPortalPath, "org.freedesktop.portal.OpenURI", "OpenURI", "ssa{sv}", "", uri, options)
+	return ok
+}
+
+func sdlPortalCaptureScreenshot(window *SDL_Window, interactive bool, done func(surface *SDL_Surface, err string)) {
+	options := map[string]sdlDBusVariant{
+		"modal":       {sig: "b", value: window != nil},
+		"interactive": {sig: "b", value: interactive},
+	}
+	parent := sdlPortalParentWindow(window)
+
+	sdlGo(func() {
+		code, results, ok := sdlPortalRequest("org.freedesktop.portal.Screenshot", "Screenshot", "sa{sv}", []any{parent}, options)
+		if !ok {
+			done(nil, SDL_GetError())
+			return
+		}
+		switch code {
+		case sdlPortalResponseSuccess:
+		case sdlPortalResponseCancelled:
+			done(nil, "The screenshot was canceled")
+			return
+		default:
+			done(nil, "The screenshot portal failed")
+			return
+		}
+
+		uri, _ := results["uri"].value.(string)
+		path := sdlPortalURIPath(uri)
+		if path == "" {
+			done(nil, fmt.Sprintf("Unexpected screenshot location '%s'", uri))
+			return
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			done(nil, fmt.Sprintf("Couldn't open the screenshot: %v", err))
+			return
+		}
+		defer file.Close()
+		img, err := png.Decode(file)
+		if err != nil {
+			done(nil, fmt.Sprintf("Couldn't read the screenshot: %v", err))
+			return
+		}
+		surface := sdlCreateSurfaceFromImage(img)
+		if surface == nil {
+			done(nil, SDL_GetError())
+			return
+		}
+		done(surface, "")
+	})
+}
+
+/* The SettingChanged subscription while video is initialized, guarded by the subsystem lock */
+var portalThemeBus *sdlDBusConnection
+var portalThemeHandler int
+
+/* The theme for an org.freedesktop.appearance color-scheme value */
+func sdlPortalColorScheme(value any) SDL_SystemTheme {
+	/* Settings.Read wraps the value in a second variant */
+	for {
+		variant, ok := value.(sdlDBusVariant)
+		if !ok {
+			break
+		}
+		value = variant.value
+	}
+	switch value {
+	case uint32(1):
+		return SDL_SYSTEM_THEME_DARK
+	case uint32(2):
+		return SDL_SYSTEM_THEME_LIGHT
+	}
+	return SDL_SYSTEM_THEME_UNKNOWN
+}
+
+func sdlPortalThemeInit() {
+	bus := sdlDBusSession()
+	if bus == nil {
+		return
+	}
+
+	id, ok := bus.AddSignalHandler(sdlPortalPath, "org.freedesktop.portal.Settings", "SettingChanged", func(msg *sdlDBusMessage) {
+		if msg == nil || len(msg.body) != 3 {
+			return
+		}
+		if msg.body[0] == "org.freedesktop.appearance" && msg.body[1] == "color-scheme" {
+			sdlSetSystemTheme(sdlPortalColorScheme(msg.body[2]), true)
+		}
+	})
+	if ok {
+		portalThemeBus, portalThemeHandler = bus, id
+	}
+
+	reply, ok := bus.Call(sdlPortalDestination, sdlPortalPath, "org.freedesktop.portal.Settings", "ReadOne", "ss", "org.freedesktop.appearance", "color-scheme")
+	if !ok {
+		/* Portals before version 2 only have the deprecated Read */
+		reply, ok = bus.Call(sdlPortalDestination, sdlPortalPath, "org.freedesktop.portal.Settings", "Read", "ss", "org.freedesktop.appearance", "color-scheme")
+	}
+	if ok && len(reply) == 1 {
+		sdlSetSystemTheme(sdlPortalColorScheme(reply[0]), false)
+	}
+}
+
+func sdlPortalThemeQuit() {
+	if portalThemeBus != nil {
+		portalThemeBus.RemoveSignalHandler(portalThemeHandler)
+		portalThemeBus, portalThemeHandler = nil, 0
+	}
+}
@@ -0,0 +1,196 @@
+package sdl
+
+import "math"
+import "runtime"
+import "sync"
+
+/*
+ * Procedural surfaces: placeholder art, lookup tables and test patterns
+ * computed from Go functions.
+ *
+ * The pixel function is evaluated for bands of rows on several goroutines,
+ * each writing its own rows, so generating a large surface scales with the
+ * CPU cores.
+ */
+
+/**
+ * A function giving the color of the pixel at x, y of a generated surface.
+ *
+ * It is called from several goroutines at once, in no particular order, so
+ * it must be safe for concurrent use.
+ *
+ * See also SDL_CreateSurfaceFromFunc
+ */
+type SDL_PixelFunc func(x, y int) SDL_Color
+
+/* Rows per band handed to a goroutine, small enough to balance uneven work */
+const sdlProceduralBandRows = 16
+
+/* Fill every pixel of a surface from fn, bands of rows in parallel */
+func sdlFillSurfaceFunc(surface *SDL_Surface, fn SDL_PixelFunc) {
+	bands := (surface.H + sdlProceduralBandRows - 1) / sdlProceduralBandRows
+	workers := min(runtime.GOMAXPROCS(0), bands)
+
+	var next sync.Mutex
+	band := 0
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				next.Lock()
+				y0 := band * sdlProceduralBandRows
+				band++
+				next.Unlock()
+				if y0 >= surface.H {
+					return
+				}
+				for y := y0; y < min(y0+sdlProceduralBandRows, surface.H); y++ {
+					for x := 0; x < surface.W; x++ {
+						surface.putColor(x, y, fn(x, y))
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+/**
+ * Create a surface with the color of each pixel given by a function.
+ *
+ * The function is evaluated in parallel, see SDL_PixelFunc. Indexed formats
+ * get an even spread of 256 colors, as with SDL_ConvertSurface().
+ *
+ * - width the width of the surface.
+ * - height the height of the surface.
+ * - format the SDL_PixelFormat for the new surface's pixel format.
+ * - fn the function giving the color of each pixel.
+ * Returns the new SDL_Surface structure that is created or nil on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * See also SDL_CreateGradientSurface
+ * See also SDL_CreateNoiseSurface
+ * See also SDL_DestroySurface
+ */
+func SDL_CreateSurfaceFromFunc(width int, height int, format SDL_PixelFormat, fn SDL_PixelFunc) *SDL_Surface {
+	if fn == nil {
+		SDL_InvalidParamError("fn")
+		return nil
+	}
+
+	surface := SDL_CreateSurface(width, height, format)
+	if surface == nil {
+		return nil
+	}
+	if surface.palette != nil {
+		sdlDitherPalette(surface.palette)
+	}
+	sdlFillSurfaceFunc(surface, fn)
+	return surface
+}
+
+/* Interpolate between two colors, t in [0, 1] */
+func sdlLerpColor(from, to SDL_Color, t float32) SDL_Color {
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float32(a) + (float32(b)-float32(a))*t + 0.5)
+	}
+	return SDL_Color{lerp(from.R, to.R), lerp(from.G, to.G), lerp(from.B, to.B), lerp(from.A, to.A)}
+}
+
+/**
+ * Create a surface filled with a linear gradient.
+ *
+ * The gradient runs from `from` at the point x0, y0 to `to` at x1, y1, the
+ * colors are constant along lines perpendicular to it and beyond its ends.
+ * A vertical gradient over the whole surface is 0, 0 to 0, height - 1.
+ *
+ * - width the width of the surface.
+ * - height the height of the surface.
+ * - format the SDL_PixelFormat for the new surface's pixel format.
+ * - x0 the x coordinate where the gradient starts.
+ * - y0 the y coordinate where the gradient starts.
+ * - x1 the x coordinate where the gradient ends.
+ * - y1 the y coordinate where the gradient ends.
+ * - from the color at the start of the gradient.
+ * - to the color at the end of the gradient.
+ * Returns the new SDL_Surface structure that is created or nil on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * See also SDL_CreateSurfaceFromFunc
+ */
+func SDL_CreateGradientSurface(width int, height int, format SDL_PixelFormat, x0, y0, x1, y1 float32, from, to SDL_Color) *SDL_Surface {
+	dx, dy := x1-x0, y1-y0
+	length := dx*dx + dy*dy
+	return SDL_CreateSurfaceFromFunc(width, height, format, func(x, y int) SDL_Color {
+		if length == 0 {
+			return from
+		}
+		t := ((float32(x)-x0)*dx + (float32(y)-y0)*dy) / length
+		return sdlLerpColor(from, to, max(min(t, 1), 0))
+	})
+}
+
+/* A hash of a lattice point to [0, 1], the same for a seed on every platform */
+func sdlNoiseLattice(seed uint64, x, y int32) float32 {
+	state := seed ^ uint64(uint32(x))*0x9E3779B97F4A7C15 ^ uint64(uint32(y))*0xC2B2AE3D27D4EB4F
+	SDL_rand_bits_r(&state)
+	return SDL_randf_r(&state)
+}
+
+/* Smoothly interpolated value noise at a point, in [0, 1] */
+func sdlValueNoise(seed uint64, x, y float32) float32 {
+	fx, fy := float32(math.Floor(float64(x))), float32(math.Floor(float64(y)))
+	ix, iy := int32(fx), int32(fy)
+	tx, ty := x-fx, y-fy
+	tx, ty = tx*tx*(3-2*tx), ty*ty*(3-2*ty)
+
+	top := sdlNoiseLattice(seed, ix, iy) + (sdlNoiseLattice(seed, ix+1, iy)-sdlNoiseLattice(seed, ix, iy))*tx
+	bottom := sdlNoiseLattice(seed, ix, iy+1) + (sdlNoiseLattice(seed, ix+1, iy+1)-sdlNoiseLattice(seed, ix, iy+1))*tx
+	return top + (bottom-top)*ty
+}
+
+/**
+ * Create a surface filled with smooth value noise.
+ *
+ * The noise is the sum of `octaves` layers of value noise, each of twice
+ * the frequency and half the amplitude of the previous one, mapped from 0
+ * to 1 onto the colors `from` to `to`. The same seed gives the same pixels
+ * on every platform.
+ *
+ * - width the width of the surface.
+ * - height the height of the surface.
+ * - format the SDL_PixelFormat for the new surface's pixel format.
+ * - seed the noise seed.
+ * - scale the size in pixels of the features of the first octave.
+ * - octaves the number of layers of noise, at least 1.
+ * - from the color for the lowest noise values.
+ * - to the color for the highest noise values.
+ * Returns the new SDL_Surface structure that is created or nil on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * See also SDL_CreateSurfaceFromFunc
+ */
+func SDL_CreateNoiseSurface(width int, height int, format SDL_PixelFormat, seed uint64, scale float32, octaves int, from, to SDL_Color) *SDL_Surface {
+	if scale <= 0 {
+		SDL_InvalidParamError("scale")
+		return nil
+	}
+	if octaves < 1 {
+		SDL_InvalidParamError("octaves")
+		return nil
+	}
+
+	return SDL_CreateSurfaceFromFunc(width, height, format, func(x, y int) SDL_Color {
+		var sum, total float32
+		frequency, amplitude := 1/scale, float32(1)
+		for octave := range octaves {
+			sum += sdlValueNoise(seed+uint64(octave), float32(x)*frequency, float32(y)*frequency) * amplitude
+			total += amplitude
+			frequency *= 2
+			amplitude /= 2
+		}
+		return sdlLerpColor(from, to, sum/total)
+	})
+}
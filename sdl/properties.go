@@ -0,0 +1,842 @@
+package sdl
+
+import "math"
+import "strconv"
+
+/**
+ * SDL properties ID
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_PropertiesID uint32
+
+/**
+ * SDL property type
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_PropertyType int
+
+const (
+	SDL_PROPERTY_TYPE_INVALID SDL_PropertyType = iota
+	SDL_PROPERTY_TYPE_POINTER
+	SDL_PROPERTY_TYPE_STRING
+	SDL_PROPERTY_TYPE_NUMBER
+	SDL_PROPERTY_TYPE_FLOAT
+	SDL_PROPERTY_TYPE_BOOLEAN
+)
+
+type sdlProperty struct {
+	kind          SDL_PropertyType
+	pointer_value any
+	string_value  string
+	number_value  int64
+	float_value   float32
+	boolean_value bool
+	cleanup       SDL_CleanupPropertyCallback
+	userdata      any
+}
+
+/* Must be called with the group lock held. */
+func (property *sdlProperty) free() {
+	if property.kind == SDL_PROPERTY_TYPE_POINTER && property.cleanup != nil {
+		property.cleanup(property.userdata, property.pointer_value)
+	}
+}
+
+type sdlProperties struct {
+	lock  sdlRecursiveMutex
+	props map[string]*sdlProperty
+}
+
+/* The registry of property groups, guarded by propertiesLock */
+var propertiesLock = sdlRWMutex{name: "properties"}
+var propertiesRegistry = map[SDL_PropertiesID]*sdlProperties{}
+var lastPropertyID SDL_PropertiesID
+var globalProperties SDL_PropertiesID
+
+func sdlGetProperties(props SDL_PropertiesID) *sdlProperties {
+	propertiesLock.RLock()
+	defer propertiesLock.RUnlock()
+
+	return propertiesRegistry[props]
+}
+
+/**
+ * Get the global SDL properties.
+ *
+ * Returns a valid property ID on success or 0 on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPointerProperty
+ * See also SDL_SetPointerProperty
+ */
+func SDL_GetGlobalProperties() SDL_PropertiesID {
+	propertiesLock.RLock()
+	props := globalProperties
+	propertiesLock.RUnlock()
+
+	if props == 0 {
+		props = SDL_CreateProperties()
+
+		propertiesLock.Lock()
+		if globalProperties == 0 {
+			globalProperties = props
+		} else {
+			/* Somebody else won the race */
+			propertiesLock.Unlock()
+			SDL_DestroyProperties(props)
+			propertiesLock.Lock()
+			props = globalProperties
+		}
+		propertiesLock.Unlock()
+	}
+	return props
+}
+
+/**
+ * Create a group of properties.
+ *
+ * All properties are automatically destroyed when SDL_Quit() is called.
+ *
+ * Returns an ID for a new group of properties, or 0 on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DestroyProperties
+ */
+func SDL_CreateProperties() SDL_PropertiesID {
+	properties := &sdlProperties{props: map[string]*sdlProperty{}}
+
+	propertiesLock.Lock()
+	defer propertiesLock.Unlock()
+
+	lastPropertyID++
+	if lastPropertyID == 0 {
+		lastPropertyID++
+	}
+	props := lastPropertyID
+	propertiesRegistry[props] = properties
+	return props
+}
+
+/**
+ * Copy a group of properties.
+ *
+ * Copy all the properties from one group of properties to another. Any
+ * property that already exists on `dst` will be overwritten.
+ *
+ * - src the properties to copy.
+ * - dst the destination properties.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_CopyProperties(src SDL_PropertiesID, dst SDL_PropertiesID) bool {
+	if src == 0 {
+		return SDL_InvalidParamError("src")
+	}
+	if dst == 0 {
+		return SDL_InvalidParamError("dst")
+	}
+
+	src_properties := sdlGetProperties(src)
+	if src_properties == nil {
+		return SDL_InvalidParamError("src")
+	}
+	dst_properties := sdlGetProperties(dst)
+	if dst_properties == nil {
+		return SDL_InvalidParamError("dst")
+	}
+	if src_properties == dst_properties {
+		return true
+	}
+
+	src_properties.lock.Lock()
+	copies := make(map[string]sdlProperty, len(src_properties.props))
+	for name, property := range src_properties.props {
+		if property.cleanup != nil {
+			/* Can't copy properties with cleanup functions, we don't know how to duplicate the data */
+			continue
+		}
+		copies[name] = *property
+	}
+	src_properties.lock.Unlock()
+
+	dst_properties.lock.Lock()
+	defer dst_properties.lock.Unlock()
+
+	for name, property := range copies {
+		if old := dst_properties.props[name]; old != nil {
+			old.free()
+		}
+		dst_properties.props[name] = &property
+	}
+	return true
+}
+
+func sdlPrivateSetProperty(props SDL_PropertiesID, name string, property *sdlProperty) bool {
+	if props == 0 {
+		return SDL_InvalidParamError("props")
+	}
+	if name == "" {
+		return SDL_InvalidParamError("name")
+	}
+
+	properties := sdlGetProperties(props)
+	if properties == nil {
+		return SDL_InvalidParamError("props")
+	}
+
+	properties.lock.Lock()
+	defer properties.lock.Unlock()
+
+	if old := properties.props[name]; old != nil {
+		delete(properties.props, name)
+		old.free()
+	}
+	if property != nil {
+		properties.props[name] = property
+	}
+	return true
+}
+
+/**
+ * A callback used to free resources when a property is deleted.
+ *
+ * This should release any resources associated with `value` that are no
+ * longer needed.
+ *
+ * This callback is set per-property. Different properties in the same group
+ * can have different cleanup callbacks.
+ *
+ * This callback will be called _during_ SDL_SetPointerPropertyWithCleanup if
+ * the function fails for any reason.
+ *
+ * - userdata an app-defined pointer passed to the callback.
+ * - value the pointer assigned to the property to clean up.
+ *
+ * This callback may fire from any goroutine that modifies or destroys the
+ * properties, while they are locked.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_SetPointerPropertyWithCleanup
+ */
+type SDL_CleanupPropertyCallback func(userdata any, value any)
+
+/**
+ * Set a pointer property in a group of properties with a cleanup function
+ * that is called when the property is deleted.
+ *
+ * The cleanup function is also called if setting the property fails for any
+ * reason.
+ *
+ * For simply setting basic data types, like numbers, bools, or strings, use
+ * SDL_SetNumberProperty, SDL_SetBooleanProperty, or SDL_SetStringProperty
+ * instead, as those functions will handle cleanup on your behalf. This
+ * function is only for more complex, custom data.
+ *
+ * - props the properties to modify.
+ * - name the name of the property to modify.
+ * - value the new value of the property, or nil to delete the property.
+ * - cleanup the function to call when this property is deleted, or nil
+ *                if no cleanup is necessary.
+ * - userdata a pointer that is passed to the cleanup function.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPointerProperty
+ * See also SDL_SetPointerProperty
+ * See also SDL_CleanupPropertyCallback
+ */
+func SDL_SetPointerPropertyWithCleanup(props SDL_PropertiesID, name string, value any, cleanup SDL_CleanupPropertyCallback, userdata any) bool {
+	if value == nil {
+		if cleanup != nil {
+			cleanup(userdata, value)
+		}
+		return SDL_ClearProperty(props, name)
+	}
+
+	property := &sdlProperty{
+		kind:          SDL_PROPERTY_TYPE_POINTER,
+		pointer_value: value,
+		cleanup:       cleanup,
+		userdata:      userdata,
+	}
+	if !sdlPrivateSetProperty(props, name, property) {
+		if cleanup != nil {
+			cleanup(userdata, value)
+		}
+		return false
+	}
+	return true
+}
+
+/**
+ * Lock a group of properties.
+ *
+ * Obtain a multi-threaded lock for these properties. Other goroutines will
+ * wait while trying to lock these properties until they are unlocked.
+ * Properties must be unlocked before they are destroyed.
+ *
+ * The lock is automatically taken when setting individual properties, this
+ * function is only needed when you want to set several properties atomically
+ * or want to guarantee that properties being queried aren't freed in another
+ * goroutine. The lock is recursive, the goroutine holding it may keep calling
+ * the property functions.
+ *
+ * - props the properties to lock.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_UnlockProperties
+ */
+func SDL_LockProperties(props SDL_PropertiesID) bool {
+	if props == 0 {
+		return SDL_InvalidParamError("props")
+	}
+
+	properties := sdlGetProperties(props)
+	if properties == nil {
+		return SDL_InvalidParamError("props")
+	}
+
+	properties.lock.Lock()
+	return true
+}
+
+/**
+ * Unlock a group of properties.
+ *
+ * - props the properties to unlock.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_LockProperties
+ */
+func SDL_UnlockProperties(props SDL_PropertiesID) {
+	if props == 0 {
+		return
+	}
+
+	properties := sdlGetProperties(props)
+	if properties == nil {
+		return
+	}
+
+	properties.lock.Unlock()
+}
+
+/**
+ * Set a pointer property in a group of properties.
+ *
+ * - props the properties to modify.
+ * - name the name of the property to modify.
+ * - value the new value of the property, or nil to delete the property.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPointerProperty
+ * See also SDL_HasProperty
+ * See also SDL_SetBooleanProperty
+ * See also SDL_SetFloatProperty
+ * See also SDL_SetNumberProperty
+ * See also SDL_SetStringProperty
+ */
+func SDL_SetPointerProperty(props SDL_PropertiesID, name string, value any) bool {
+	if value == nil {
+		return SDL_ClearProperty(props, name)
+	}
+	return sdlPrivateSetProperty(props, name, &sdlProperty{kind: SDL_PROPERTY_TYPE_POINTER, pointer_value: value})
+}
+
+/**
+ * Set a string property in a group of properties.
+ *
+ * This function makes a copy of the string; the caller does not have to
+ * preserve the data after this call completes.
+ *
+ * - props the properties to modify.
+ * - name the name of the property to modify.
+ * - value the new value of the property.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetStringProperty
+ */
+func SDL_SetStringProperty(props SDL_PropertiesID, name string, value string) bool {
+	return sdlPrivateSetProperty(props, name, &sdlProperty{kind: SDL_PROPERTY_TYPE_STRING, string_value: value})
+}
+
+/**
+ * Set an integer property in a group of properties.
+ *
+ * - props the properties to modify.
+ * - name the name of the property to modify.
+ * - value the new value of the property.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetNumberProperty
+ */
+func SDL_SetNumberProperty(props SDL_PropertiesID, name string, value int64) bool {
+	return sdlPrivateSetProperty(props, name, &sdlProperty{kind: SDL_PROPERTY_TYPE_NUMBER, number_value: value})
+}
+
+/**
+ * Set a floating point property in a group of properties.
+ *
+ * - props the properties to modify.
+ * - name the name of the property to modify.
+ * - value the new value of the property.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetFloatProperty
+ */
+func SDL_SetFloatProperty(props SDL_PropertiesID, name string, value float32) bool {
+	return sdlPrivateSetProperty(props, name, &sdlProperty{kind: SDL_PROPERTY_TYPE_FLOAT, float_value: value})
+}
+
+/**
+ * Set a boolean property in a group of properties.
+ *
+ * - props the properties to modify.
+ * - name the name of the property to modify.
+ * - value the new value of the property.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread, although the data
+ * returned is not protected and could potentially be cleaned up if you call
+ * SDL_SetPointerProperty() or SDL_ClearProperty() on these properties from
+ * another goroutine. If you need to avoid this, use SDL_LockProperties() and
+ * SDL_UnlockProperties().
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetBooleanProperty
+ */
+func SDL_SetBooleanProperty(props SDL_PropertiesID, name string, value bool) bool {
+	return sdlPrivateSetProperty(props, name, &sdlProperty{kind: SDL_PROPERTY_TYPE_BOOLEAN, boolean_value: value})
+}
+
+/**
+ * Return whether a property exists in a group of properties.
+ *
+ * - props the properties to query.
+ * - name the name of the property to query.
+ * Returns true if the property exists, or false if it doesn't.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPropertyType
+ */
+func SDL_HasProperty(props SDL_PropertiesID, name string) bool {
+	return SDL_GetPropertyType(props, name) != SDL_PROPERTY_TYPE_INVALID
+}
+
+/*
+ * Look up a property and return a copy of it, so the caller can convert the
+ * value without holding the group lock.
+ */
+func sdlLookupProperty(props SDL_PropertiesID, name string) (sdlProperty, bool) {
+	if props == 0 || name == "" {
+		return sdlProperty{}, false
+	}
+
+	properties := sdlGetProperties(props)
+	if properties == nil {
+		return sdlProperty{}, false
+	}
+
+	properties.lock.Lock()
+	defer properties.lock.Unlock()
+
+	property := properties.props[name]
+	if property == nil {
+		return sdlProperty{}, false
+	}
+	return *property, true
+}
+
+/**
+ * Get the type of a property in a group of properties.
+ *
+ * - props the properties to query.
+ * - name the name of the property to query.
+ * Returns the type of the property, or SDL_PROPERTY_TYPE_INVALID if it is
+ *          not set.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_HasProperty
+ */
+func SDL_GetPropertyType(props SDL_PropertiesID, name string) SDL_PropertyType {
+	property, ok := sdlLookupProperty(props, name)
+	if !ok {
+		return SDL_PROPERTY_TYPE_INVALID
+	}
+	return property.kind
+}
+
+/**
+ * Get a pointer property from a group of properties.
+ *
+ * - props the properties to query.
+ * - name the name of the property to query.
+ * - default_value the default value of the property.
+ * Returns the value of the property, or `default_value` if it is not set or
+ *          not a pointer property.
+ *
+ * It is safe to call this function from any thread, although the data
+ * returned is not protected and could potentially be cleaned up if you call
+ * SDL_SetPointerProperty() or SDL_ClearProperty() on these properties from
+ * another goroutine. If you need to avoid this, use SDL_LockProperties() and
+ * SDL_UnlockProperties().
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetBooleanProperty
+ * See also SDL_GetFloatProperty
+ * See also SDL_GetNumberProperty
+ * See also SDL_GetPropertyType
+ * See also SDL_GetStringProperty
+ * See also SDL_HasProperty
+ * See also SDL_SetPointerProperty
+ */
+func SDL_GetPointerProperty(props SDL_PropertiesID, name string, default_value any) any {
+	property, ok := sdlLookupProperty(props, name)
+	if !ok || property.kind != SDL_PROPERTY_TYPE_POINTER {
+		return default_value
+	}
+	return property.pointer_value
+}
+
+/**
+ * Get a string property from a group of properties.
+ *
+ * Number, float and boolean properties are converted to their string
+ * representation.
+ *
+ * - props the properties to query.
+ * - name the name of the property to query.
+ * - default_value the default value of the property.
+ * Returns the value of the property, or `default_value` if it is not set or
+ *          not a string property.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPropertyType
+ * See also SDL_HasProperty
+ * See also SDL_SetStringProperty
+ */
+func SDL_GetStringProperty(props SDL_PropertiesID, name string, default_value string) string {
+	property, ok := sdlLookupProperty(props, name)
+	if !ok {
+		return default_value
+	}
+
+	switch property.kind {
+	case SDL_PROPERTY_TYPE_STRING:
+		return property.string_value
+	case SDL_PROPERTY_TYPE_NUMBER:
+		return strconv.FormatInt(property.number_value, 10)
+	case SDL_PROPERTY_TYPE_FLOAT:
+		return strconv.FormatFloat(float64(property.float_value), 'f', -1, 32)
+	case SDL_PROPERTY_TYPE_BOOLEAN:
+		return tern(property.boolean_value, "true", "false")
+	}
+	return default_value
+}
+
+/**
+ * Get a number property from a group of properties.
+ *
+ * You can use SDL_GetPropertyType() to query whether the property exists and
+ * is a number property.
+ *
+ * - props the properties to query.
+ * - name the name of the property to query.
+ * - default_value the default value of the property.
+ * Returns the value of the property, or `default_value` if it is not set or
+ *          not a number property.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPropertyType
+ * See also SDL_HasProperty
+ * See also SDL_SetNumberProperty
+ */
+func SDL_GetNumberProperty(props SDL_PropertiesID, name string, default_value int64) int64 {
+	property, ok := sdlLookupProperty(props, name)
+	if !ok {
+		return default_value
+	}
+
+	switch property.kind {
+	case SDL_PROPERTY_TYPE_STRING:
+		if value, err := strconv.ParseInt(property.string_value, 0, 64); err == nil {
+			return value
+		}
+	case SDL_PROPERTY_TYPE_NUMBER:
+		return property.number_value
+	case SDL_PROPERTY_TYPE_FLOAT:
+		return int64(math.Round(float64(property.float_value)))
+	case SDL_PROPERTY_TYPE_BOOLEAN:
+		return tern[int64](property.boolean_value, 1, 0)
+	}
+	return default_value
+}
+
+/**
+ * Get a floating point property from a group of properties.
+ *
+ * You can use SDL_GetPropertyType() to query whether the property exists and
+ * is a floating point property.
+ *
+ * - props the properties to query.
+ * - name the name of the property to query.
+ * - default_value the default value of the property.
+ * Returns the value of the property, or `default_value` if it is not set or
+ *          not a float property.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPropertyType
+ * See also SDL_HasProperty
+ * See also SDL_SetFloatProperty
+ */
+func SDL_GetFloatProperty(props SDL_PropertiesID, name string, default_value float32) float32 {
+	property, ok := sdlLookupProperty(props, name)
+	if !ok {
+		return default_value
+	}
+
+	switch property.kind {
+	case SDL_PROPERTY_TYPE_STRING:
+		if value, err := strconv.ParseFloat(property.string_value, 32); err == nil {
+			return float32(value)
+		}
+	case SDL_PROPERTY_TYPE_NUMBER:
+		return float32(property.number_value)
+	case SDL_PROPERTY_TYPE_FLOAT:
+		return property.float_value
+	case SDL_PROPERTY_TYPE_BOOLEAN:
+		return tern[float32](property.boolean_value, 1, 0)
+	}
+	return default_value
+}
+
+/**
+ * Get a boolean property from a group of properties.
+ *
+ * You can use SDL_GetPropertyType() to query whether the property exists and
+ * is a boolean property.
+ *
+ * - props the properties to query.
+ * - name the name of the property to query.
+ * - default_value the default value of the property.
+ * Returns the value of the property, or `default_value` if it is not set or
+ *          not a boolean property.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetPropertyType
+ * See also SDL_HasProperty
+ * See also SDL_SetBooleanProperty
+ */
+func SDL_GetBooleanProperty(props SDL_PropertiesID, name string, default_value bool) bool {
+	property, ok := sdlLookupProperty(props, name)
+	if !ok {
+		return default_value
+	}
+
+	switch property.kind {
+	case SDL_PROPERTY_TYPE_STRING:
+		return sdlGetStringBoolean(property.string_value, default_value)
+	case SDL_PROPERTY_TYPE_NUMBER:
+		return property.number_value != 0
+	case SDL_PROPERTY_TYPE_FLOAT:
+		return property.float_value != 0
+	case SDL_PROPERTY_TYPE_BOOLEAN:
+		return property.boolean_value
+	}
+	return default_value
+}
+
+/**
+ * Clear a property from a group of properties.
+ *
+ * - props the properties to modify.
+ * - name the name of the property to clear.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_ClearProperty(props SDL_PropertiesID, name string) bool {
+	return sdlPrivateSetProperty(props, name, nil)
+}
+
+/**
+ * A callback used to enumerate all the properties in a group of properties.
+ *
+ * This callback is called from SDL_EnumerateProperties(), and is called once
+ * per property in the set.
+ *
+ * - userdata an app-defined pointer passed to the callback.
+ * - props the SDL_PropertiesID that is being enumerated.
+ * - name the next property name in the enumeration.
+ *
+ * SDL_EnumerateProperties takes a snapshot of the property names, so the
+ * callback may get, set and clear properties of the group.
+ *
+ * This datatype is available since SDL 3.0.0.
+ *
+ * See also SDL_EnumerateProperties
+ */
+type SDL_EnumeratePropertiesCallback func(userdata any, props SDL_PropertiesID, name string)
+
+/**
+ * Enumerate the properties contained in a group of properties.
+ *
+ * The callback function is called for each property in the group of
+ * properties.
+ *
+ * - props the properties to query.
+ * - callback the function to call for each property.
+ * - userdata a pointer that is passed to `callback`.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * It is safe to call this function from any thread.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_EnumerateProperties(props SDL_PropertiesID, callback SDL_EnumeratePropertiesCallback, userdata any) bool {
+	if props == 0 {
+		return SDL_InvalidParamError("props")
+	}
+	if callback == nil {
+		return SDL_InvalidParamError("callback")
+	}
+
+	properties := sdlGetProperties(props)
+	if properties == nil {
+		return SDL_InvalidParamError("props")
+	}
+
+	properties.lock.Lock()
+	names := make([]string, 0, len(properties.props))
+	for name := range properties.props {
+		names = append(names, name)
+	}
+	properties.lock.Unlock()
+
+	for _, name := range names {
+		callback(userdata, props, name)
+	}
+	return true
+}
+
+/**
+ * Destroy a group of properties.
+ *
+ * All properties are deleted and their cleanup functions will be called, if
+ * any.
+ *
+ * - props the properties to destroy.
+ *
+ * This function should not be called while these properties are locked or
+ * other threads might be setting or getting values from these properties.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateProperties
+ */
+func SDL_DestroyProperties(props SDL_PropertiesID) {
+	if props == 0 {
+		return
+	}
+
+	propertiesLock.Lock()
+	properties := propertiesRegistry[props]
+	delete(propertiesRegistry, props)
+	if props == globalProperties {
+		globalProperties = 0
+	}
+	propertiesLock.Unlock()
+
+	if properties != nil {
+		properties.lock.Lock()
+		for name, property := range properties.props {
+			delete(properties.props, name)
+			property.free()
+		}
+		properties.lock.Unlock()
+	}
+}
+
+/* Called by SDL_Quit() to destroy every group of properties. */
+func sdlQuitProperties() {
+	propertiesLock.Lock()
+	ids := make([]SDL_PropertiesID, 0, len(propertiesRegistry))
+	for props := range propertiesRegistry {
+		ids = append(ids, props)
+	}
+	propertiesLock.Unlock()
+
+	for _, props := range ids {
+		SDL_DestroyProperties(props)
+	}
+}
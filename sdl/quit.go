@@ -0,0 +1,104 @@
+package sdl
+
+import "os"
+import "os/signal"
+import "syscall"
+
+/*
+ * Quit requests.
+ *
+ * SDL_EVENT_QUIT is sent when the user asks the application to quit, e.g.
+ * with Ctrl-C in a terminal. While the events subsystem is initialized,
+ * SIGINT and SIGTERM are caught and turned into quit events, so console
+ * hosted games go through their normal shutdown path instead of being
+ * killed. SIGTERM, the OS asking the process to end, also sends
+ * SDL_EVENT_TERMINATING first.
+ */
+
+/**
+ * A variable controlling whether SDL will install signal handlers for
+ * SIGINT and SIGTERM.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "0": SDL will install signal handlers that send SDL_EVENT_QUIT events.
+ *   (default)
+ * - "1": SDL will not install signal handlers, so the default Go behavior
+ *   of exiting the program applies.
+ *
+ * This hint should be set before SDL is initialized.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_NO_SIGNAL_HANDLERS = "SDL_NO_SIGNAL_HANDLERS"
+
+/* The signal relay, guarded by quitSignalsLock */
+var quitSignalsLock = sdlMutex{name: "quit.signals"}
+var quitSignals chan os.Signal
+var quitSignalsDone chan struct{}
+
+func sdlInitQuit() {
+	if SDL_GetHintBoolean(SDL_HINT_NO_SIGNAL_HANDLERS, false) {
+		return
+	}
+
+	quitSignalsLock.Lock()
+	defer quitSignalsLock.Unlock()
+
+	if quitSignals != nil {
+		return
+	}
+
+	/* Leave signals the application chose to ignore alone */
+	var handled []os.Signal
+	for _, sig := range []os.Signal{syscall.SIGINT, syscall.SIGTERM} {
+		if !signal.Ignored(sig) {
+			handled = append(handled, sig)
+		}
+	}
+	if len(handled) == 0 {
+		return
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, handled...)
+	quitSignals, quitSignalsDone = signals, done
+
+	sdlGo(func() {
+		for {
+			select {
+			case sig := <-signals:
+				if sig == syscall.SIGTERM {
+					sdlSendAppEvent(SDL_EVENT_TERMINATING)
+				}
+				sdlSendQuit()
+			case <-done:
+				return
+			}
+		}
+	})
+}
+
+func sdlQuitQuit() {
+	quitSignalsLock.Lock()
+	defer quitSignalsLock.Unlock()
+
+	if quitSignals == nil {
+		return
+	}
+	signal.Stop(quitSignals)
+	close(quitSignalsDone)
+	quitSignals, quitSignalsDone = nil, nil
+}
+
+/* Send an application lifecycle event, returns whether it was queued */
+func sdlSendAppEvent(kind SDL_EventType) bool {
+	event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: kind}}
+	return SDL_PushEvent(&event)
+}
+
+/* Ask the application to quit, returns whether the event was queued */
+func sdlSendQuit() bool {
+	return sdlSendAppEvent(SDL_EVENT_QUIT)
+}
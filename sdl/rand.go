@@ -0,0 +1,284 @@
+package sdl
+
+import "time"
+
+/*
+ * The global generator behind SDL_rand(), SDL_randf() and SDL_rand_bits().
+ * The C version isn't thread safe, here it is guarded so goroutines can
+ * share it; use an SDL_Random per goroutine for reproducible sequences.
+ */
+var randMutex = sdlMutex{name: "rand"}
+var randState uint64
+var randInitialized = false
+
+/**
+ * Seeds the pseudo-random number generator.
+ *
+ * Reusing the seed number will cause SDL_rand_*() to repeat the same stream
+ * of 'random' numbers.
+ *
+ * - seed the value to use as a random number seed, or 0 to use
+ *             the current time.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_rand
+ * See also SDL_rand_bits
+ * See also SDL_randf
+ */
+func SDL_srand(seed uint64) {
+	randMutex.Lock()
+	defer randMutex.Unlock()
+
+	sdlSrandLocked(seed)
+}
+
+func sdlSrandLocked(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	randState = seed
+	randInitialized = true
+}
+
+/**
+ * Generate a pseudo-random number less than n for positive n
+ *
+ * The method used is faster and of better quality than `rand() % n`. Odds are
+ * roughly 99.9% even for n = 1 million. Evenness is better for smaller n, and
+ * much worse as n gets bigger.
+ *
+ * Example: to simulate a d6 use `SDL_rand(6) + 1` The +1 converts 0..5 to
+ * 1..6
+ *
+ * If you want to generate a pseudo-random number in the full range of
+ * int32, you should use: int32(SDL_rand_bits())
+ *
+ * If you want reproducible output, be sure to initialize with SDL_srand()
+ * first.
+ *
+ * There are no guarantees as to the quality of the random sequence produced,
+ * and this should not be used for security (cryptography, passwords) or where
+ * money is on the line (loot-boxes, casinos). There are many random number
+ * libraries available with different characteristics and you should pick one
+ * of those to meet any serious needs.
+ *
+ * - n the number of possible outcomes. n must be positive.
+ * Returns a random value in the range of [0 .. n-1].
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_srand
+ * See also SDL_randf
+ */
+func SDL_rand(n int32) int32 {
+	randMutex.Lock()
+	defer randMutex.Unlock()
+
+	if !randInitialized {
+		sdlSrandLocked(0)
+	}
+	return SDL_rand_r(&randState, n)
+}
+
+/**
+ * Generate a uniform pseudo-random floating point number less than 1.0
+ *
+ * If you want reproducible output, be sure to initialize with SDL_srand()
+ * first.
+ *
+ * There are no guarantees as to the quality of the random sequence produced,
+ * and this should not be used for security (cryptography, passwords) or where
+ * money is on the line (loot-boxes, casinos). There are many random number
+ * libraries available with different characteristics and you should pick one
+ * of those to meet any serious needs.
+ *
+ * Returns a random value in the range of [0.0, 1.0).
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_srand
+ * See also SDL_rand
+ */
+func SDL_randf() float32 {
+	randMutex.Lock()
+	defer randMutex.Unlock()
+
+	if !randInitialized {
+		sdlSrandLocked(0)
+	}
+	return SDL_randf_r(&randState)
+}
+
+/**
+ * Generate 32 pseudo-random bits.
+ *
+ * You likely want to use SDL_rand() to get a psuedo-random number instead.
+ *
+ * There are no guarantees as to the quality of the random sequence produced,
+ * and this should not be used for security (cryptography, passwords) or where
+ * money is on the line (loot-boxes, casinos). There are many random number
+ * libraries available with different characteristics and you should pick one
+ * of those to meet any serious needs.
+ *
+ * Returns a random value in the range of [0-SDL_MAX_UINT32].
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_rand
+ * See also SDL_randf
+ * See also SDL_srand
+ */
+func SDL_rand_bits() uint32 {
+	randMutex.Lock()
+	defer randMutex.Unlock()
+
+	if !randInitialized {
+		sdlSrandLocked(0)
+	}
+	return SDL_rand_bits_r(&randState)
+}
+
+/**
+ * Generate a pseudo-random number less than n for positive n
+ *
+ * The method used is faster and of better quality than `rand() % n`. Odds are
+ * roughly 99.9% even for n = 1 million. Evenness is better for smaller n, and
+ * much worse as n gets bigger.
+ *
+ * Example: to simulate a d6 use `SDL_rand_r(state, 6) + 1` The +1 converts
+ * 0..5 to 1..6
+ *
+ * - state a pointer to the current random number state, this may not be
+ *              nil.
+ * - n the number of possible outcomes. n must be positive.
+ * Returns a random value in the range of [0 .. n-1].
+ *
+ * This function is thread-safe, as long as the state pointer isn't shared
+ * between threads.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_rand
+ * See also SDL_rand_bits_r
+ * See also SDL_randf_r
+ */
+func SDL_rand_r(state *uint64, n int32) int32 {
+	/* Algorithm: get 32 bits from SDL_rand_bits() and treat it as a 0.32 bit
+	 * fixed point number. Multiply by the 31.0 bit n to get a 31.32 bit
+	 * result. Shift right by 32 to get the 31 bit integer that we want.
+	 */
+	if n < 0 {
+		/* The algorithm looks like it works for numbers < 0 but it has an
+		 * infinitesimal chance of returning a value out of range.
+		 * Returning -SDL_rand(abs(n)) blows up at INT_MIN instead.
+		 * It's easier to just say no.
+		 */
+		return 0
+	}
+	val := uint64(SDL_rand_bits_r(state)) * uint64(n)
+	return int32(val >> 32)
+}
+
+/**
+ * Generate a uniform pseudo-random floating point number less than 1.0
+ *
+ * - state a pointer to the current random number state, this may not be
+ *              nil.
+ * Returns a random value in the range of [0.0, 1.0).
+ *
+ * This function is thread-safe, as long as the state pointer isn't shared
+ * between threads.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_rand_bits_r
+ * See also SDL_rand_r
+ * See also SDL_randf
+ */
+func SDL_randf_r(state *uint64) float32 {
+	/* Note: its using 24 bits because float has 23 bits significand + 1 implicit bit */
+	return float32(SDL_rand_bits_r(state)>>(32-24)) * 0x1p-24
+}
+
+/**
+ * Generate 32 pseudo-random bits.
+ *
+ * You likely want to use SDL_rand_r() to get a psuedo-random number instead.
+ *
+ * - state a pointer to the current random number state, this may not be
+ *              nil.
+ * Returns a random value in the range of [0-SDL_MAX_UINT32].
+ *
+ * This function is thread-safe, as long as the state pointer isn't shared
+ * between threads.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_rand_r
+ * See also SDL_randf_r
+ */
+func SDL_rand_bits_r(state *uint64) uint32 {
+	if state == nil {
+		return 0
+	}
+
+	/* The C and A parameters of this LCG have been chosen based on hundreds
+	 * of core-hours of testing with PractRand and TestU01's Crush.
+	 * Using a 32-bit A improves performance on 32-bit architectures.
+	 * C can be any odd number, but < 256 generates smaller code on ARM32
+	 * These values perform as well as a full 64-bit implementation against
+	 * Crush and PractRand up to several terabytes of data.
+	 * Thanks to Chris Long for the suggestions.
+	 */
+	*state = *state*0xff1cd035 + 0x05
+
+	/* Only return top 32 bits because they have a longer period */
+	return uint32(*state >> 32)
+}
+
+/**
+ * An independent stream of pseudo-random numbers.
+ *
+ * The stream produces exactly the same sequence as SDL_rand_r() and friends
+ * fed with the same seed, on every platform and version. It is not safe to
+ * share one SDL_Random between goroutines without locking.
+ */
+type SDL_Random struct {
+	state uint64
+}
+
+/**
+ * Create a pseudo-random number stream.
+ *
+ * - seed the value to use as a random number seed, or 0 to use
+ *             the current time.
+ */
+func SDL_CreateRandom(seed uint64) *SDL_Random {
+	r := &SDL_Random{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed restarts the stream from seed, or from the current time if seed is 0.
+func (r *SDL_Random) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.state = seed
+}
+
+// Rand is SDL_rand_r() on the stream.
+func (r *SDL_Random) Rand(n int32) int32 {
+	return SDL_rand_r(&r.state, n)
+}
+
+// RandF is SDL_randf_r() on the stream.
+func (r *SDL_Random) RandF() float32 {
+	return SDL_randf_r(&r.state)
+}
+
+// RandBits is SDL_rand_bits_r() on the stream.
+func (r *SDL_Random) RandBits() uint32 {
+	return SDL_rand_bits_r(&r.state)
+}
@@ -0,0 +1,71 @@
+package sdl
+
+import "testing"
+
+/* The seed of the golden sequences, the expected values come from the upstream algorithm */
+const testRandSeed = 0x123456789
+
+func TestRandBitsGolden(t *testing.T) {
+	want := []uint32{0x2242ea9d, 0xfdb24de1, 0x1131b555, 0xc2225b08, 0x2884f29f, 0x007d1719}
+	state := uint64(testRandSeed)
+	for i, w := range want {
+		if got := SDL_rand_bits_r(&state); got != w {
+			t.Fatalf("value %d of the sequence is 0x%08x, want 0x%08x", i, got, w)
+		}
+	}
+	if state != 0x007d17191db44bcb {
+		t.Errorf("the state after the sequence is 0x%016x, want 0x007d17191db44bcb", state)
+	}
+}
+
+func TestRandGolden(t *testing.T) {
+	tests := []struct {
+		n, want int32
+	}{
+		{100, 13},
+		{100, 99},
+		{100, 6},
+		{6, 4},
+		{6, 0},
+		{0x7fffffff, 4098956},
+	}
+	state := uint64(testRandSeed)
+	for i, test := range tests {
+		if got := SDL_rand_r(&state, test.n); got != test.want {
+			t.Fatalf("value %d of the sequence is SDL_rand_r(%d) = %d, want %d", i, test.n, got, test.want)
+		}
+	}
+
+	/* SDL_rand() runs the same generator on the state seeded by SDL_srand() */
+	SDL_srand(testRandSeed)
+	for i, test := range tests {
+		if got := SDL_rand(test.n); got != test.want {
+			t.Fatalf("value %d of the sequence is SDL_rand(%d) = %d, want %d", i, test.n, got, test.want)
+		}
+	}
+}
+
+func TestRandfGolden(t *testing.T) {
+	want := []float32{0x1.12175p-3, 0x1.fb649ap-1, 0x1.131b5p-4, 0x1.8444b6p-1}
+	state := uint64(testRandSeed)
+	for i, w := range want {
+		if got := SDL_randf_r(&state); got != w {
+			t.Fatalf("value %d of the sequence is %x, want %x", i, got, w)
+		}
+	}
+}
+
+func TestRandRange(t *testing.T) {
+	state := uint64(testRandSeed)
+	for range 10000 {
+		if got := SDL_rand_r(&state, 7); got < 0 || got >= 7 {
+			t.Fatalf("SDL_rand_r(7) = %d, out of range", got)
+		}
+		if got := SDL_randf_r(&state); got < 0 || got >= 1 {
+			t.Fatalf("SDL_randf_r() = %g, out of range", got)
+		}
+	}
+	if got := SDL_rand_r(&state, -5); got != 0 {
+		t.Errorf("SDL_rand_r(-5) = %d, want 0", got)
+	}
+}
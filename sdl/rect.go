@@ -0,0 +1,271 @@
+package sdl
+
+/**
+ * The structure that defines a point (using integers).
+ *
+ * This struct is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRectEnclosingPoints
+ * See also SDL_PointInRect
+ */
+type SDL_Point struct {
+	X int
+	Y int
+}
+
+/**
+ * The structure that defines a point (using floating point values).
+ *
+ * This struct is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRectEnclosingPointsFloat
+ * See also SDL_PointInRectFloat
+ */
+type SDL_FPoint struct {
+	X float32
+	Y float32
+}
+
+/**
+ * A rectangle, with the origin at the upper left (using integers).
+ *
+ * This struct is available since SDL 3.0.0.
+ *
+ * See also SDL_RectEmpty
+ * See also SDL_RectsEqual
+ * See also SDL_HasRectIntersection
+ * See also SDL_GetRectIntersection
+ * See also SDL_GetRectAndLineIntersection
+ * See also SDL_GetRectUnion
+ * See also SDL_GetRectEnclosingPoints
+ */
+type SDL_Rect struct {
+	X, Y int
+	W, H int
+}
+
+/**
+ * A rectangle, with the origin at the upper left (using floating point
+ * values).
+ *
+ * This struct is available since SDL 3.0.0.
+ *
+ * See also SDL_RectEmptyFloat
+ * See also SDL_RectsEqualFloat
+ * See also SDL_RectsEqualEpsilon
+ * See also SDL_HasRectIntersectionFloat
+ * See also SDL_GetRectIntersectionFloat
+ * See also SDL_GetRectAndLineIntersectionFloat
+ * See also SDL_GetRectUnionFloat
+ * See also SDL_GetRectEnclosingPointsFloat
+ * See also SDL_PointInRectFloat
+ */
+type SDL_FRect struct {
+	X float32
+	Y float32
+	W float32
+	H float32
+}
+
+/**
+ * Convert an SDL_Rect to SDL_FRect
+ *
+ * - rect a pointer to an SDL_Rect.
+ * - frect a pointer filled in with the floating point representation of
+ *              `rect`.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_RectToFRect(rect *SDL_Rect, frect *SDL_FRect) {
+	frect.X = float32(rect.X)
+	frect.Y = float32(rect.Y)
+	frect.W = float32(rect.W)
+	frect.H = float32(rect.H)
+}
+
+/**
+ * Determine whether a point resides inside a rectangle.
+ *
+ * A point is considered part of a rectangle if both `p` and `r` are not nil,
+ * and `p`'s x and y coordinates are >= to the rectangle's top left corner,
+ * and < the rectangle's x+w and y+h. So a 1x1 rectangle considers point (0,0)
+ * as "inside" and (0,1) as not.
+ *
+ * - p the point to test.
+ * - r the rectangle to test.
+ * Returns true if `p` is contained by `r`, false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_PointInRect(p *SDL_Point, r *SDL_Rect) bool {
+	return p != nil && r != nil && (p.X >= r.X) && (p.X < (r.X + r.W)) &&
+		(p.Y >= r.Y) && (p.Y < (r.Y + r.H))
+}
+
+/**
+ * Determine whether a rectangle has no area.
+ *
+ * A rectangle is considered "empty" for this function if `r` is nil, or if
+ * `r`'s width and/or height are <= 0.
+ *
+ * - r the rectangle to test.
+ * Returns true if the rectangle is "empty", false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_RectEmpty(r *SDL_Rect) bool {
+	return r == nil || (r.W <= 0) || (r.H <= 0)
+}
+
+/**
+ * Determine whether two rectangles are equal.
+ *
+ * Rectangles are considered equal if both are not nil and each of their x,
+ * y, width and height match.
+ *
+ * - a the first rectangle to test.
+ * - b the second rectangle to test.
+ * Returns true if the rectangles are equal, false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_RectsEqual(a *SDL_Rect, b *SDL_Rect) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+/**
+ * Determine whether two rectangles intersect.
+ *
+ * If either pointer is nil the function will return false.
+ *
+ * - A an SDL_Rect structure representing the first rectangle.
+ * - B an SDL_Rect structure representing the second rectangle.
+ * Returns true if there is an intersection, false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRectIntersection
+ */
+func SDL_HasRectIntersection(A *SDL_Rect, B *SDL_Rect) bool {
+	var result SDL_Rect
+	return SDL_GetRectIntersection(A, B, &result)
+}
+
+/**
+ * Calculate the intersection of two rectangles.
+ *
+ * If `result` is nil then this function will return false.
+ *
+ * - A an SDL_Rect structure representing the first rectangle.
+ * - B an SDL_Rect structure representing the second rectangle.
+ * - result an SDL_Rect structure filled in with the intersection of
+ *               rectangles `A` and `B`.
+ * Returns true if there is an intersection, false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_HasRectIntersection
+ */
+func SDL_GetRectIntersection(A *SDL_Rect, B *SDL_Rect, result *SDL_Rect) bool {
+	if A == nil || B == nil || result == nil {
+		return false
+	}
+
+	/* Special cases for empty rects */
+	if SDL_RectEmpty(A) || SDL_RectEmpty(B) {
+		*result = SDL_Rect{}
+		return false
+	}
+
+	/* Horizontal intersection */
+	Amin, Amax := A.X, A.X+A.W
+	Bmin, Bmax := B.X, B.X+B.W
+	result.X = max(Amin, Bmin)
+	result.W = min(Amax, Bmax) - result.X
+
+	/* Vertical intersection */
+	Amin, Amax = A.Y, A.Y+A.H
+	Bmin, Bmax = B.Y, B.Y+B.H
+	result.Y = max(Amin, Bmin)
+	result.H = min(Amax, Bmax) - result.Y
+
+	return !SDL_RectEmpty(result)
+}
+
+/**
+ * Calculate the union of two rectangles.
+ *
+ * - A an SDL_Rect structure representing the first rectangle.
+ * - B an SDL_Rect structure representing the second rectangle.
+ * - result an SDL_Rect structure filled in with the union of rectangles
+ *               `A` and `B`.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetRectUnion(A *SDL_Rect, B *SDL_Rect, result *SDL_Rect) bool {
+	if A == nil {
+		return SDL_InvalidParamError("A")
+	} else if B == nil {
+		return SDL_InvalidParamError("B")
+	} else if result == nil {
+		return SDL_InvalidParamError("result")
+	}
+
+	/* Special cases for empty Rects */
+	if SDL_RectEmpty(A) {
+		if SDL_RectEmpty(B) {
+			/* A and B empty */
+			*result = SDL_Rect{}
+		} else {
+			/* A empty, B not empty */
+			*result = *B
+		}
+		return true
+	} else if SDL_RectEmpty(B) {
+		/* A not empty, B empty */
+		*result = *A
+		return true
+	}
+
+	x, y := min(A.X, B.X), min(A.Y, B.Y)
+	result.W = max(A.X+A.W, B.X+B.W) - x
+	result.H = max(A.Y+A.H, B.Y+B.H) - y
+	result.X, result.Y = x, y
+	return true
+}
+
+/**
+ * Determine whether a point resides inside a floating point rectangle.
+ *
+ * A point is considered part of a rectangle if both `p` and `r` are not nil,
+ * and `p`'s x and y coordinates are >= to the rectangle's top left corner,
+ * and <= the rectangle's x+w and y+h. So a 1x1 rectangle considers point
+ * (0,0) and (0,1) as "inside" and (0,2) as not.
+ *
+ * - p the point to test.
+ * - r the rectangle to test.
+ * Returns true if `p` is contained by `r`, false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_PointInRectFloat(p *SDL_FPoint, r *SDL_FRect) bool {
+	return p != nil && r != nil && (p.X >= r.X) && (p.X <= (r.X + r.W)) &&
+		(p.Y >= r.Y) && (p.Y <= (r.Y + r.H))
+}
+
+/**
+ * Determine whether a floating point rectangle can contain any point.
+ *
+ * A rectangle is considered "empty" for this function if `r` is nil, or if
+ * `r`'s width and/or height are < 0.0f.
+ *
+ * - r the rectangle to test.
+ * Returns true if the rectangle is "empty", false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_RectEmptyFloat(r *SDL_FRect) bool {
+	return r == nil || (r.W < 0.0) || (r.H < 0.0)
+}
@@ -0,0 +1,1173 @@
+package sdl
+
+import "strings"
+
+/*
+ * The 2D accelerated rendering API.
+ *
+ * A renderer draws into a window or a surface with one of the render
+ * drivers. Drivers register from init() functions, in the order they are
+ * tried when the application doesn't ask for one, and the software driver
+ * is always available.
+ */
+
+/**
+ * The name of the software renderer.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_SOFTWARE_RENDERER = "software"
+
+/**
+ * A structure representing rendering state
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_Renderer struct {
+	handle sdlHandle /* released by SDL_DestroyRenderer() */
+	name   string
+	window *SDL_Window  /* the window rendered to, or nil */
+	target *SDL_Surface /* the surface rendered to, for software renderers created with SDL_CreateSoftwareRenderer() */
+	props  SDL_PropertiesID
+
+	/* Drawing state */
+	color           SDL_FColor
+	blend_mode      SDL_BlendMode
+	transform       SDL_AffineTransform
+	transform_stack []SDL_AffineTransform /* saved by SDL_PushRenderTransform() */
+	vsync           int
+
+	/* Entry points of the render driver, the ones that are nil are optional */
+	SupportsBlendMode func(renderer *SDL_Renderer, blendMode SDL_BlendMode) bool
+	GetOutputSize     func(renderer *SDL_Renderer, w, h *int) bool
+	RenderClear       func(renderer *SDL_Renderer, color SDL_FColor) bool
+	RenderFillRects   func(renderer *SDL_Renderer, rects []SDL_FRect, color SDL_FColor, blendMode SDL_BlendMode) bool
+	RenderFillQuads   func(renderer *SDL_Renderer, quads [][4]SDL_FPoint, color SDL_FColor, blendMode SDL_BlendMode) bool /* convex quadrilaterals, for rotated and sheared transforms */
+	RenderPresent     func(renderer *SDL_Renderer) bool
+	SetVSync          func(renderer *SDL_Renderer, vsync int) bool
+	FlushRenderer     func(renderer *SDL_Renderer) bool
+	DestroyRenderer   func(renderer *SDL_Renderer)
+
+	driverdata any /* owned by the render driver */
+}
+
+type sdlRenderDriver struct {
+	name string
+
+	/* Set up the renderer for a window, or return false with the error set if the driver can't render to it */
+	create func(renderer *SDL_Renderer, window *SDL_Window, props SDL_PropertiesID) bool
+}
+
+var renderDrivers []sdlRenderDriver
+
+/* Register a render driver, called from init() functions */
+func sdlRegisterRenderDriver(driver sdlRenderDriver) {
+	renderDrivers = append(renderDrivers, driver)
+}
+
+/**
+ * A variable specifying which render driver to use.
+ *
+ * If the application doesn't pick a specific renderer to use, this variable
+ * specifies the name of the preferred renderer. If the preferred renderer
+ * can't be initialized, creating a renderer will fail.
+ *
+ * This variable is case insensitive and can be set to a comma-separated
+ * list of render driver names, which will be tried in the order listed. The
+ * only driver built in everywhere is "software".
+ *
+ * This hint should be set before creating a renderer.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_RENDER_DRIVER = "SDL_RENDER_DRIVER"
+
+/* Check that a renderer is usable, setting an error if it isn't */
+func sdlCheckRenderer(renderer *SDL_Renderer) bool {
+	if renderer == nil || !sdlValidHandle(sdlHandleTypeRenderer, renderer.handle, renderer) {
+		return SDL_SetError("Invalid renderer")
+	}
+	return true
+}
+
+/* Convert a color component to 8 bits, clamping HDR values */
+func sdlColorComponentToByte(value float32) uint8 {
+	return uint8(min(max(value, 0), 1)*255 + 0.5)
+}
+
+/**
+ * Get the number of 2D rendering drivers available for the current display.
+ *
+ * A render driver is a set of code that handles rendering and texture
+ * management on a particular display. Normally there is only one, but some
+ * drivers may have several available with different capabilities.
+ *
+ * There may be none if SDL was compiled without render support.
+ *
+ * Returns the number of built in render drivers.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateRenderer
+ * See also SDL_GetRenderDriver
+ */
+func SDL_GetNumRenderDrivers() int {
+	return len(renderDrivers)
+}
+
+/**
+ * Use this function to get the name of a built in 2D rendering driver.
+ *
+ * The list of rendering drivers is given in the order that they are normally
+ * initialized by default; the drivers that seem more reasonable to choose
+ * first (as far as the SDL developers believe) are earlier in the list.
+ *
+ * The names of drivers are all simple, low-ASCII identifiers, like "opengl",
+ * "direct3d12" or "metal". These never have Unicode characters, and are not
+ * meant to be proper names.
+ *
+ * - index the index of the rendering driver; the value ranges from 0 to
+ *              SDL_GetNumRenderDrivers() - 1.
+ * Returns the name of the rendering driver at the requested index, or ""
+ *          if an invalid index was specified.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetNumRenderDrivers
+ */
+func SDL_GetRenderDriver(index int) string {
+	if index < 0 || index >= len(renderDrivers) {
+		SDL_SetErrorf("index must be in the range of 0 - %d", len(renderDrivers)-1)
+		return ""
+	}
+	return renderDrivers[index].name
+}
+
+/**
+ * Create a 2D rendering context for a window.
+ *
+ * If you want a specific renderer, you can specify its name here. A list of
+ * available renderers can be obtained by calling SDL_GetRenderDriver()
+ * multiple times, with indices from 0 to SDL_GetNumRenderDrivers()-1. If you
+ * don't need a specific renderer, specify "" and SDL will attempt to choose
+ * the best option for you, based on what is available on the user's
+ * system.
+ *
+ * If `name` is a comma-separated list, SDL will try each name, in the order
+ * listed, until one succeeds or all of them fail.
+ *
+ * By default the rendering size matches the window size in pixels.
+ *
+ * - window the window where rendering is displayed.
+ * - name the name of the rendering driver to initialize, or "" to let SDL
+ *             choose one.
+ * Returns a valid rendering context or nil if there was an error; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateRendererWithProperties
+ * See also SDL_CreateSoftwareRenderer
+ * See also SDL_DestroyRenderer
+ * See also SDL_GetNumRenderDrivers
+ * See also SDL_GetRenderDriver
+ * See also SDL_GetRendererName
+ */
+func SDL_CreateRenderer(window *SDL_Window, name string) *SDL_Renderer {
+	props := SDL_CreateProperties()
+	SDL_SetPointerProperty(props, SDL_PROP_RENDERER_CREATE_WINDOW_POINTER, window)
+	SDL_SetStringProperty(props, SDL_PROP_RENDERER_CREATE_NAME_STRING, name)
+	renderer := SDL_CreateRendererWithProperties(props)
+	SDL_DestroyProperties(props)
+	return renderer
+}
+
+// CreateRenderer is SDL_CreateRenderer() returning a Go error instead of nil.
+func CreateRenderer(window *SDL_Window, name string) (*SDL_Renderer, error) {
+	return errorFromObject(SDL_CreateRenderer(window, name))
+}
+
+/**
+ * Create a 2D rendering context for a window, with the specified
+ * properties.
+ *
+ * These are the supported properties:
+ *
+ * - `SDL_PROP_RENDERER_CREATE_NAME_STRING`: the name of the rendering driver
+ *   to use, if a specific one is desired
+ * - `SDL_PROP_RENDERER_CREATE_WINDOW_POINTER`: the window where rendering is
+ *   displayed, required if this isn't a software renderer using a surface
+ * - `SDL_PROP_RENDERER_CREATE_SURFACE_POINTER`: the surface where rendering
+ *   is displayed, if you want a software renderer without a window
+ * - `SDL_PROP_RENDERER_CREATE_PRESENT_VSYNC_NUMBER`: the vertical refresh
+ *   sync interval, see SDL_SetRenderVSync()
+ * - `SDL_PROP_RENDERER_CREATE_SOFTWARE_BUFFERS_NUMBER`: the number of
+ *   buffers of a software renderer for a window, 1 to draw into the window
+ *   surface (the default), 2 for a back buffer or 3 for triple buffering.
+ *   This is an extension to the SDL API.
+ *
+ * - props the properties to use.
+ * Returns a valid rendering context or nil if there was an error; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateProperties
+ * See also SDL_CreateRenderer
+ * See also SDL_CreateSoftwareRenderer
+ * See also SDL_DestroyRenderer
+ * See also SDL_GetRendererName
+ */
+func SDL_CreateRendererWithProperties(props SDL_PropertiesID) *SDL_Renderer {
+	window, _ := SDL_GetPointerProperty(props, SDL_PROP_RENDERER_CREATE_WINDOW_POINTER, nil).(*SDL_Window)
+	surface, _ := SDL_GetPointerProperty(props, SDL_PROP_RENDERER_CREATE_SURFACE_POINTER, nil).(*SDL_Surface)
+
+	renderer := &SDL_Renderer{
+		color:      SDL_FColor{R: 0, G: 0, B: 0, A: 1},
+		blend_mode: SDL_BLENDMODE_NONE,
+		transform:  SDL_IdentityTransform(),
+	}
+
+	if surface != nil {
+		renderer.name = SDL_SOFTWARE_RENDERER
+		renderer.target = surface
+		if !sdlSWCreateRendererForSurface(renderer, surface) {
+			return nil
+		}
+	} else {
+		if window == nil {
+			SDL_InvalidParamError("window")
+			return nil
+		}
+		if !sdlCheckWindow(window) {
+			return nil
+		}
+		if window.renderer != nil {
+			SDL_SetError("Renderer already associated with window")
+			return nil
+		}
+
+		name := SDL_GetStringProperty(props, SDL_PROP_RENDERER_CREATE_NAME_STRING, "")
+		if name == "" {
+			name = SDL_GetHint(SDL_HINT_RENDER_DRIVER)
+		}
+		if !sdlCreateRendererForWindow(renderer, window, name, props) {
+			return nil
+		}
+		renderer.window = window
+		window.renderer = renderer
+	}
+
+	renderer.handle = sdlCreateHandle(sdlHandleTypeRenderer, renderer)
+	sdlTrackObject("renderer", renderer)
+
+	renderer.props = SDL_CreateProperties()
+	SDL_SetStringProperty(renderer.props, SDL_PROP_RENDERER_NAME_STRING, renderer.name)
+	if window != nil {
+		SDL_SetPointerProperty(renderer.props, SDL_PROP_RENDERER_WINDOW_POINTER, window)
+	}
+	if surface != nil {
+		SDL_SetPointerProperty(renderer.props, SDL_PROP_RENDERER_SURFACE_POINTER, surface)
+	}
+
+	if !SDL_SetRenderVSync(renderer, int(SDL_GetNumberProperty(props, SDL_PROP_RENDERER_CREATE_PRESENT_VSYNC_NUMBER, 0))) {
+		SDL_DestroyRenderer(renderer)
+		return nil
+	}
+	return renderer
+}
+
+/* Set up the renderer with the first driver that works, from the ones named in a comma-separated list or all of them */
+func sdlCreateRendererForWindow(renderer *SDL_Renderer, window *SDL_Window, names string, props SDL_PropertiesID) bool {
+	create := func(driver *sdlRenderDriver) bool {
+		renderer.name = driver.name
+		return driver.create(renderer, window, props)
+	}
+
+	if names != "" {
+		for _, name := range strings.Split(names, ",") {
+			name = strings.TrimSpace(name)
+			for i := range renderDrivers {
+				if strings.EqualFold(renderDrivers[i].name, name) && create(&renderDrivers[i]) {
+					return true
+				}
+			}
+		}
+		return SDL_SetError("Couldn't find matching render driver")
+	}
+
+	for i := range renderDrivers {
+		if create(&renderDrivers[i]) {
+			return true
+		}
+	}
+	return SDL_SetError("Couldn't find matching render driver")
+}
+
+const SDL_PROP_RENDERER_CREATE_NAME_STRING = "SDL.renderer.create.name"
+const SDL_PROP_RENDERER_CREATE_WINDOW_POINTER = "SDL.renderer.create.window"
+const SDL_PROP_RENDERER_CREATE_SURFACE_POINTER = "SDL.renderer.create.surface"
+const SDL_PROP_RENDERER_CREATE_PRESENT_VSYNC_NUMBER = "SDL.renderer.create.present_vsync"
+const SDL_PROP_RENDERER_CREATE_SOFTWARE_BUFFERS_NUMBER = "SDL.renderer.create.software.buffers"
+
+/**
+ * Create a 2D software rendering context for a surface.
+ *
+ * SDL_CreateRenderer() can _also_ create a software renderer, but it is
+ * intended to be used with an SDL_Window as the final destination and not an
+ * SDL_Surface.
+ *
+ * - surface the SDL_Surface structure representing the surface where
+ *                rendering is done.
+ * Returns a valid rendering context or nil if there was an error; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DestroyRenderer
+ */
+func SDL_CreateSoftwareRenderer(surface *SDL_Surface) *SDL_Renderer {
+	if surface == nil {
+		SDL_InvalidParamError("surface")
+		return nil
+	}
+	props := SDL_CreateProperties()
+	SDL_SetPointerProperty(props, SDL_PROP_RENDERER_CREATE_SURFACE_POINTER, surface)
+	renderer := SDL_CreateRendererWithProperties(props)
+	SDL_DestroyProperties(props)
+	return renderer
+}
+
+// CreateSoftwareRenderer is SDL_CreateSoftwareRenderer() returning a Go error
+// instead of nil.
+func CreateSoftwareRenderer(surface *SDL_Surface) (*SDL_Renderer, error) {
+	return errorFromObject(SDL_CreateSoftwareRenderer(surface))
+}
+
+/**
+ * Get the renderer associated with a window.
+ *
+ * - window the window to query.
+ * Returns the rendering context on success or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetRenderer(window *SDL_Window) *SDL_Renderer {
+	if !sdlCheckWindow(window) {
+		return nil
+	}
+	return window.renderer
+}
+
+/**
+ * Get the window associated with a renderer.
+ *
+ * - renderer the renderer to query.
+ * Returns the window on success or nil on failure; call SDL_GetError() for
+ *          more information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetRenderWindow(renderer *SDL_Renderer) *SDL_Window {
+	if !sdlCheckRenderer(renderer) {
+		return nil
+	}
+	return renderer.window
+}
+
+/**
+ * Get the name of a renderer.
+ *
+ * - renderer the rendering context.
+ * Returns the name of the selected renderer, or "" on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateRenderer
+ * See also SDL_CreateRendererWithProperties
+ */
+func SDL_GetRendererName(renderer *SDL_Renderer) string {
+	if !sdlCheckRenderer(renderer) {
+		return ""
+	}
+	return renderer.name
+}
+
+/**
+ * Get the properties associated with a renderer.
+ *
+ * The following read-only properties are provided by SDL:
+ *
+ * - `SDL_PROP_RENDERER_NAME_STRING`: the name of the rendering driver
+ * - `SDL_PROP_RENDERER_WINDOW_POINTER`: the window where rendering is
+ *   displayed, if any
+ * - `SDL_PROP_RENDERER_SURFACE_POINTER`: the surface where rendering is
+ *   displayed, if this is a software renderer without a window
+ *
+ * - renderer the rendering context.
+ * Returns a valid property ID on success or 0 on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetRendererProperties(renderer *SDL_Renderer) SDL_PropertiesID {
+	if !sdlCheckRenderer(renderer) {
+		return 0
+	}
+	return renderer.props
+}
+
+const SDL_PROP_RENDERER_NAME_STRING = "SDL.renderer.name"
+const SDL_PROP_RENDERER_WINDOW_POINTER = "SDL.renderer.window"
+const SDL_PROP_RENDERER_SURFACE_POINTER = "SDL.renderer.surface"
+
+/*
+ * The number of output pixels per window coordinate. There is no logical
+ * presentation, viewport or render scale yet, so this is the pixel density
+ * of the window, and 1 for renderers drawing into a surface.
+ */
+func sdlGetRenderScale(renderer *SDL_Renderer) (scaleX, scaleY float32) {
+	window := renderer.window
+	if window == nil {
+		return 1, 1
+	}
+	var w, h, pixelW, pixelH int
+	if !SDL_GetWindowSize(window, &w, &h) || !SDL_GetWindowSizeInPixels(window, &pixelW, &pixelH) || w <= 0 || h <= 0 {
+		return 1, 1
+	}
+	return float32(pixelW) / float32(w), float32(pixelH) / float32(h)
+}
+
+/**
+ * Get a point in render coordinates when given a point in window
+ * coordinates.
+ *
+ * This takes into account the window dimensions, render coordinates are in
+ * pixels while window coordinates may not be on high density displays.
+ *
+ * - renderer the rendering context.
+ * - window_x the x coordinate in window coordinates.
+ * - window_y the y coordinate in window coordinates.
+ * - x a pointer filled with the x coordinate in render coordinates.
+ * - y a pointer filled with the y coordinate in render coordinates.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_RenderCoordinatesToWindow
+ */
+func SDL_RenderCoordinatesFromWindow(renderer *SDL_Renderer, window_x, window_y float32, x, y *float32) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	scaleX, scaleY := sdlGetRenderScale(renderer)
+	if x != nil {
+		*x = window_x * scaleX
+	}
+	if y != nil {
+		*y = window_y * scaleY
+	}
+	return true
+}
+
+/**
+ * Get a point in window coordinates when given a point in render
+ * coordinates.
+ *
+ * This is the inverse of SDL_RenderCoordinatesFromWindow().
+ *
+ * - renderer the rendering context.
+ * - x the x coordinate in render coordinates.
+ * - y the y coordinate in render coordinates.
+ * - window_x a pointer filled with the x coordinate in window coordinates.
+ * - window_y a pointer filled with the y coordinate in window coordinates.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_RenderCoordinatesFromWindow
+ */
+func SDL_RenderCoordinatesToWindow(renderer *SDL_Renderer, x, y float32, window_x, window_y *float32) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	scaleX, scaleY := sdlGetRenderScale(renderer)
+	if window_x != nil {
+		*window_x = x / scaleX
+	}
+	if window_y != nil {
+		*window_y = y / scaleY
+	}
+	return true
+}
+
+/* Convert a window relative point in place if it is in the renderer's window */
+func sdlConvertPointToRenderCoordinates(renderer *SDL_Renderer, windowID SDL_WindowID, x, y *float32) {
+	if renderer.window == nil || renderer.window.id != windowID {
+		return
+	}
+	SDL_RenderCoordinatesFromWindow(renderer, *x, *y, x, y)
+}
+
+/**
+ * Convert the coordinates in an event to render coordinates.
+ *
+ * This takes into account the window dimensions, render coordinates are in
+ * pixels while window coordinates may not be on high density displays.
+ *
+ * Mouse, touch, pen and drop events are converted with this function, other
+ * events are left alone. Only events for the renderer's window are converted.
+ *
+ * Touch coordinates are converted from normalized coordinates in the window
+ * to non-normalized rendering coordinates.
+ *
+ * Relative mouse coordinates (Xrel and Yrel event fields) are _also_
+ * converted. Applications that do not want these fields converted should use
+ * SDL_RenderCoordinatesFromWindow() on the specific event fields instead of
+ * converting the entire event structure.
+ *
+ * Once converted, coordinates may be outside the rendering area.
+ *
+ * - renderer the rendering context.
+ * - event the event to modify.
+ * Returns true if the event is converted or doesn't need conversion, or false
+ *          on failure; call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_RenderCoordinatesFromWindow
+ */
+func SDL_ConvertEventToRenderCoordinates(renderer *SDL_Renderer, event *SDL_Event) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if event == nil {
+		return SDL_InvalidParamError("event")
+	}
+
+	switch event.Type {
+	case SDL_EVENT_MOUSE_MOTION:
+		e := &event.Motion
+		if renderer.window != nil && renderer.window.id == e.WindowID {
+			sdlConvertPointToRenderCoordinates(renderer, e.WindowID, &e.X, &e.Y)
+			scaleX, scaleY := sdlGetRenderScale(renderer)
+			e.Xrel *= scaleX
+			e.Yrel *= scaleY
+		}
+	case SDL_EVENT_MOUSE_BUTTON_DOWN, SDL_EVENT_MOUSE_BUTTON_UP:
+		e := &event.Button
+		sdlConvertPointToRenderCoordinates(renderer, e.WindowID, &e.X, &e.Y)
+	case SDL_EVENT_MOUSE_WHEEL:
+		e := &event.Wheel
+		sdlConvertPointToRenderCoordinates(renderer, e.WindowID, &e.MouseX, &e.MouseY)
+	case SDL_EVENT_FINGER_DOWN, SDL_EVENT_FINGER_UP, SDL_EVENT_FINGER_CANCELED, SDL_EVENT_FINGER_MOTION:
+		// Touch events may not have a window, so they are taken to be relative to the renderer's window
+		if renderer.window != nil {
+			e := &event.TFinger
+			var w, h int
+			if !SDL_GetWindowSize(renderer.window, &w, &h) {
+				return false
+			}
+			scaleX, scaleY := sdlGetRenderScale(renderer)
+			e.X *= float32(w) * scaleX
+			e.Y *= float32(h) * scaleY
+			e.Dx *= float32(w) * scaleX
+			e.Dy *= float32(h) * scaleY
+		}
+	case SDL_EVENT_PEN_MOTION:
+		e := &event.PMotion
+		sdlConvertPointToRenderCoordinates(renderer, e.WindowID, &e.X, &e.Y)
+	case SDL_EVENT_PEN_DOWN, SDL_EVENT_PEN_UP:
+		e := &event.PTouch
+		sdlConvertPointToRenderCoordinates(renderer, e.WindowID, &e.X, &e.Y)
+	case SDL_EVENT_PEN_BUTTON_DOWN, SDL_EVENT_PEN_BUTTON_UP:
+		e := &event.PButton
+		sdlConvertPointToRenderCoordinates(renderer, e.WindowID, &e.X, &e.Y)
+	case SDL_EVENT_PEN_AXIS:
+		e := &event.PAxis
+		sdlConvertPointToRenderCoordinates(renderer, e.WindowID, &e.X, &e.Y)
+	case SDL_EVENT_DROP_POSITION, SDL_EVENT_DROP_FILE, SDL_EVENT_DROP_TEXT, SDL_EVENT_DROP_COMPLETE:
+		e := &event.Drop
+		sdlConvertPointToRenderCoordinates(renderer, e.WindowID, &e.X, &e.Y)
+	}
+	return true
+}
+
+/**
+ * Set the color used for drawing operations.
+ *
+ * Set the color for drawing or filling rectangles, lines, and points, and
+ * for SDL_RenderClear().
+ *
+ * - renderer the rendering context.
+ * - r the red value used to draw on the rendering target.
+ * - g the green value used to draw on the rendering target.
+ * - b the blue value used to draw on the rendering target.
+ * - a the alpha value used to draw on the rendering target; usually
+ *          `SDL_ALPHA_OPAQUE` (255). Use SDL_SetRenderDrawBlendMode to
+ *          specify how the alpha channel is used.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRenderDrawColor
+ * See also SDL_SetRenderDrawColorFloat
+ */
+func SDL_SetRenderDrawColor(renderer *SDL_Renderer, r, g, b, a uint8) bool {
+	return SDL_SetRenderDrawColorFloat(renderer, float32(r)/255, float32(g)/255, float32(b)/255, float32(a)/255)
+}
+
+/**
+ * Set the color used for drawing operations (Rect, Line and Clear).
+ *
+ * Set the color for drawing or filling rectangles, lines, and points, and
+ * for SDL_RenderClear().
+ *
+ * - renderer the rendering context.
+ * - r the red value used to draw on the rendering target.
+ * - g the green value used to draw on the rendering target.
+ * - b the blue value used to draw on the rendering target.
+ * - a the alpha value used to draw on the rendering target. Use
+ *          SDL_SetRenderDrawBlendMode to specify how the alpha channel is
+ *          used.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRenderDrawColorFloat
+ * See also SDL_SetRenderDrawColor
+ */
+func SDL_SetRenderDrawColorFloat(renderer *SDL_Renderer, r, g, b, a float32) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	renderer.color = SDL_FColor{R: r, G: g, B: b, A: a}
+	return true
+}
+
+/**
+ * Get the color used for drawing operations (Rect, Line and Clear).
+ *
+ * - renderer the rendering context.
+ * - r a pointer filled in with the red value used to draw on the
+ *          rendering target.
+ * - g a pointer filled in with the green value used to draw on the
+ *          rendering target.
+ * - b a pointer filled in with the blue value used to draw on the
+ *          rendering target.
+ * - a a pointer filled in with the alpha value used to draw on the
+ *          rendering target; usually `SDL_ALPHA_OPAQUE` (255).
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRenderDrawColorFloat
+ * See also SDL_SetRenderDrawColor
+ */
+func SDL_GetRenderDrawColor(renderer *SDL_Renderer, r, g, b, a *uint8) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if r != nil {
+		*r = sdlColorComponentToByte(renderer.color.R)
+	}
+	if g != nil {
+		*g = sdlColorComponentToByte(renderer.color.G)
+	}
+	if b != nil {
+		*b = sdlColorComponentToByte(renderer.color.B)
+	}
+	if a != nil {
+		*a = sdlColorComponentToByte(renderer.color.A)
+	}
+	return true
+}
+
+/**
+ * Get the color used for drawing operations (Rect, Line and Clear).
+ *
+ * - renderer the rendering context.
+ * - r a pointer filled in with the red value used to draw on the
+ *          rendering target.
+ * - g a pointer filled in with the green value used to draw on the
+ *          rendering target.
+ * - b a pointer filled in with the blue value used to draw on the
+ *          rendering target.
+ * - a a pointer filled in with the alpha value used to draw on the
+ *          rendering target.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetRenderDrawColorFloat
+ * See also SDL_GetRenderDrawColor
+ */
+func SDL_GetRenderDrawColorFloat(renderer *SDL_Renderer, r, g, b, a *float32) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if r != nil {
+		*r = renderer.color.R
+	}
+	if g != nil {
+		*g = renderer.color.G
+	}
+	if b != nil {
+		*b = renderer.color.B
+	}
+	if a != nil {
+		*a = renderer.color.A
+	}
+	return true
+}
+
+/**
+ * Set the blend mode used for drawing operations (Fill and Line).
+ *
+ * Custom blend modes may not be supported by the render driver, this fails
+ * with an error then.
+ *
+ * - renderer the rendering context.
+ * - blendMode the SDL_BlendMode to use for blending.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRenderDrawBlendMode
+ */
+func SDL_SetRenderDrawBlendMode(renderer *SDL_Renderer, blendMode SDL_BlendMode) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if blendMode == SDL_BLENDMODE_INVALID {
+		return SDL_InvalidParamError("blendMode")
+	}
+	if renderer.SupportsBlendMode != nil && !renderer.SupportsBlendMode(renderer, blendMode) {
+		return SDL_Unsupported()
+	}
+	renderer.blend_mode = blendMode
+	return true
+}
+
+/**
+ * Get the blend mode used for drawing operations.
+ *
+ * - renderer the rendering context.
+ * - blendMode a pointer filled in with the current SDL_BlendMode.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetRenderDrawBlendMode
+ */
+func SDL_GetRenderDrawBlendMode(renderer *SDL_Renderer, blendMode *SDL_BlendMode) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if blendMode != nil {
+		*blendMode = renderer.blend_mode
+	}
+	return true
+}
+
+/**
+ * Clear the current rendering target with the drawing color.
+ *
+ * This function clears the entire rendering target, ignoring the viewport
+ * and the clip rectangle. Note, that clearing will also set/fill all pixels
+ * of the rendering target to current renderer draw color, so make sure to
+ * invoke SDL_SetRenderDrawColor() when needed.
+ *
+ * - renderer the rendering context.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetRenderDrawColor
+ */
+func SDL_RenderClear(renderer *SDL_Renderer) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	return renderer.RenderClear(renderer, renderer.color)
+}
+
+// RenderClear is SDL_RenderClear() returning a Go error instead of a boolean.
+func RenderClear(renderer *SDL_Renderer) error {
+	return errorFromResult(SDL_RenderClear(renderer))
+}
+
+/**
+ * Set the transform applied to subsequent drawing operations.
+ *
+ * Points are mapped by the transform from the coordinates passed to the
+ * drawing functions to the rendering target, e.g. to move a camera or to
+ * draw parallax layers without transforming every sprite by hand. It
+ * doesn't apply to SDL_RenderClear() or to the coordinate conversion
+ * functions. Build transforms with SDL_TranslateTransform(),
+ * SDL_RotateTransform() and SDL_ScaleTransform().
+ *
+ * This is an extension to the SDL API.
+ *
+ * - renderer the rendering context.
+ * - transform the new transform.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_GetRenderTransform
+ * See also SDL_PushRenderTransform
+ */
+func SDL_SetRenderTransform(renderer *SDL_Renderer, transform SDL_AffineTransform) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	renderer.transform = transform
+	return true
+}
+
+/**
+ * Get the transform applied to drawing operations.
+ *
+ * This is an extension to the SDL API.
+ *
+ * - renderer the rendering context.
+ * - transform filled in with the current transform.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_SetRenderTransform
+ */
+func SDL_GetRenderTransform(renderer *SDL_Renderer, transform *SDL_AffineTransform) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if transform == nil {
+		return SDL_InvalidParamError("transform")
+	}
+	*transform = renderer.transform
+	return true
+}
+
+/**
+ * Save the current transform, to restore it with SDL_PopRenderTransform().
+ *
+ * Pushes nest, so drawing code can change the transform for its children and
+ * put it back afterwards, e.g.
+ *
+ *	sdl.SDL_PushRenderTransform(renderer)
+ *	var t sdl.SDL_AffineTransform
+ *	sdl.SDL_GetRenderTransform(renderer, &t)
+ *	sdl.SDL_SetRenderTransform(renderer, sdl.SDL_TranslateTransform(t, x, y))
+ *	drawChildren()
+ *	sdl.SDL_PopRenderTransform(renderer)
+ *
+ * This is an extension to the SDL API.
+ *
+ * - renderer the rendering context.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_PopRenderTransform
+ */
+func SDL_PushRenderTransform(renderer *SDL_Renderer) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	renderer.transform_stack = append(renderer.transform_stack, renderer.transform)
+	return true
+}
+
+/**
+ * Restore the transform saved by the matching SDL_PushRenderTransform().
+ *
+ * This is an extension to the SDL API.
+ *
+ * - renderer the rendering context.
+ * Returns true on success or false on failure, e.g. if no transform was
+ *          pushed; call SDL_GetError() for more information.
+ *
+ * See also SDL_PushRenderTransform
+ */
+func SDL_PopRenderTransform(renderer *SDL_Renderer) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	n := len(renderer.transform_stack)
+	if n == 0 {
+		return SDL_SetError("No render transform was pushed")
+	}
+	renderer.transform = renderer.transform_stack[n-1]
+	renderer.transform_stack = renderer.transform_stack[:n-1]
+	return true
+}
+
+/* The corners of a transformed rectangle, in order around it */
+func sdlTransformRectCorners(t SDL_AffineTransform, rect SDL_FRect) [4]SDL_FPoint {
+	return [4]SDL_FPoint{
+		SDL_TransformFPoint(t, SDL_FPoint{X: rect.X, Y: rect.Y}),
+		SDL_TransformFPoint(t, SDL_FPoint{X: rect.X + rect.W, Y: rect.Y}),
+		SDL_TransformFPoint(t, SDL_FPoint{X: rect.X + rect.W, Y: rect.Y + rect.H}),
+		SDL_TransformFPoint(t, SDL_FPoint{X: rect.X, Y: rect.Y + rect.H}),
+	}
+}
+
+/**
+ * Fill a rectangle on the current rendering target with the drawing color.
+ *
+ * The drawing color is blended into the target with the blend mode set by
+ * SDL_SetRenderDrawBlendMode().
+ *
+ * - renderer the renderer which should fill a rectangle.
+ * - rect a pointer to the destination rectangle, or nil for the entire
+ *             rendering target, which ignores the render transform.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_RenderFillRects
+ */
+func SDL_RenderFillRect(renderer *SDL_Renderer, rect *SDL_FRect) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if rect == nil {
+		var w, h int
+		if !renderer.GetOutputSize(renderer, &w, &h) {
+			return false
+		}
+		return renderer.RenderFillRects(renderer, []SDL_FRect{{W: float32(w), H: float32(h)}}, renderer.color, renderer.blend_mode)
+	}
+	return SDL_RenderFillRects(renderer, []SDL_FRect{*rect})
+}
+
+/**
+ * Fill some number of rectangles on the current rendering target with the
+ * drawing color.
+ *
+ * The rectangles are mapped by the render transform. Rotated and sheared
+ * rectangles need a render driver that can fill quadrilaterals, the
+ * software renderer can.
+ *
+ * - renderer the renderer which should fill multiple rectangles.
+ * - rects the rectangles to be filled.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_RenderFillRect
+ * See also SDL_SetRenderTransform
+ */
+func SDL_RenderFillRects(renderer *SDL_Renderer, rects []SDL_FRect) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if len(rects) == 0 {
+		return true
+	}
+
+	t := renderer.transform
+	if t == SDL_IdentityTransform() {
+		return renderer.RenderFillRects(renderer, rects, renderer.color, renderer.blend_mode)
+	}
+	if t.B == 0 && t.C == 0 {
+		/* Translated and scaled, the rectangles stay rectangles */
+		transformed := make([]SDL_FRect, len(rects))
+		for i, rect := range rects {
+			corners := sdlTransformRectCorners(t, rect)
+			transformed[i] = SDL_FRect{
+				X: min(corners[0].X, corners[2].X),
+				Y: min(corners[0].Y, corners[2].Y),
+				W: max(corners[0].X, corners[2].X) - min(corners[0].X, corners[2].X),
+				H: max(corners[0].Y, corners[2].Y) - min(corners[0].Y, corners[2].Y),
+			}
+		}
+		return renderer.RenderFillRects(renderer, transformed, renderer.color, renderer.blend_mode)
+	}
+	if renderer.RenderFillQuads == nil {
+		return SDL_Unsupported()
+	}
+	quads := make([][4]SDL_FPoint, len(rects))
+	for i, rect := range rects {
+		quads[i] = sdlTransformRectCorners(t, rect)
+	}
+	return renderer.RenderFillQuads(renderer, quads, renderer.color, renderer.blend_mode)
+}
+
+/**
+ * Update the screen with any rendering performed since the previous call.
+ *
+ * SDL's rendering functions operate on a backbuffer; that is, calling a
+ * rendering function such as SDL_RenderClear() does not directly put
+ * anything on the screen, but instead writes it to the backbuffer, which
+ * this function makes visible.
+ *
+ * The backbuffer should be considered invalidated after each present; do
+ * not assume that previous contents will exist between frames. You are
+ * strongly encouraged to call SDL_RenderClear() to initialize the backbuffer
+ * before starting each new frame's drawing, even if you plan to overwrite
+ * every pixel.
+ *
+ * A software renderer created for a surface draws into it directly, and
+ * this function does nothing for it.
+ *
+ * - renderer the rendering context.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateRenderer
+ * See also SDL_RenderClear
+ * See also SDL_SetRenderDrawColor
+ */
+func SDL_RenderPresent(renderer *SDL_Renderer) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if renderer.RenderPresent == nil {
+		return true
+	}
+	return renderer.RenderPresent(renderer)
+}
+
+// RenderPresent is SDL_RenderPresent() returning a Go error instead of a
+// boolean.
+func RenderPresent(renderer *SDL_Renderer) error {
+	return errorFromResult(SDL_RenderPresent(renderer))
+}
+
+/**
+ * Force the rendering context to flush any pending commands and state.
+ *
+ * The software renderer with triple buffering holds a presented frame back
+ * until it is due at a vertical blank, this waits for it and shows it. Call
+ * it when no more frames follow for a while, so the last one gets shown.
+ *
+ * - renderer the rendering context.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_FlushRenderer(renderer *SDL_Renderer) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if renderer.FlushRenderer == nil {
+		return true
+	}
+	return renderer.FlushRenderer(renderer)
+}
+
+const SDL_RENDERER_VSYNC_DISABLED = 0
+const SDL_RENDERER_VSYNC_ADAPTIVE = -1
+
+/**
+ * Toggle VSync of the given renderer.
+ *
+ * When a renderer is created, vsync defaults to SDL_RENDERER_VSYNC_DISABLED.
+ *
+ * The `vsync` parameter can be 1 to synchronize present with every vertical
+ * refresh, 2 to synchronize present with every second vertical refresh, etc.,
+ * SDL_RENDERER_VSYNC_ADAPTIVE for late swap tearing (adaptive vsync), or
+ * SDL_RENDERER_VSYNC_DISABLED to disable. Not every value is supported by
+ * every driver, so you should check the return value to see whether the
+ * requested setting is supported.
+ *
+ * The software renderer only estimates the vertical refreshes from the
+ * refresh rate of the display, and can't sync a renderer without a window.
+ *
+ * - renderer the renderer to toggle.
+ * - vsync the vertical refresh sync interval.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRenderVSync
+ */
+func SDL_SetRenderVSync(renderer *SDL_Renderer, vsync int) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if vsync < SDL_RENDERER_VSYNC_ADAPTIVE {
+		return SDL_InvalidParamError("vsync")
+	}
+	if renderer.SetVSync == nil {
+		if vsync != SDL_RENDERER_VSYNC_DISABLED {
+			return SDL_Unsupported()
+		}
+	} else if !renderer.SetVSync(renderer, vsync) {
+		return false
+	}
+	renderer.vsync = vsync
+	return true
+}
+
+/**
+ * Get VSync of the given renderer.
+ *
+ * - renderer the renderer to query.
+ * - vsync an int filled with the current vertical refresh sync interval.
+ *          See SDL_SetRenderVSync() for the meaning of the value.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetRenderVSync
+ */
+func SDL_GetRenderVSync(renderer *SDL_Renderer, vsync *int) bool {
+	if !sdlCheckRenderer(renderer) {
+		return false
+	}
+	if vsync != nil {
+		*vsync = renderer.vsync
+	}
+	return true
+}
+
+/**
+ * Destroy the rendering context for a window.
+ *
+ * This should be called before destroying the associated window, otherwise
+ * SDL_DestroyWindow() destroys it.
+ *
+ * - renderer the rendering context.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateRenderer
+ */
+func SDL_DestroyRenderer(renderer *SDL_Renderer) {
+	if !sdlCheckRenderer(renderer) {
+		return
+	}
+
+	if renderer.DestroyRenderer != nil {
+		renderer.DestroyRenderer(renderer)
+	}
+	if window := renderer.window; window != nil {
+		window.renderer = nil
+		renderer.window = nil
+	}
+	renderer.target = nil
+
+	SDL_DestroyProperties(renderer.props)
+	renderer.props = 0
+	sdlDestroyHandle(sdlHandleTypeRenderer, renderer.handle)
+	sdlUntrackObject(renderer)
+}
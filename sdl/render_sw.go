@@ -0,0 +1,465 @@
+package sdl
+
+import "math"
+
+/*
+ * The software renderer.
+ *
+ * It draws with the surface functions, into the window surface or into the
+ * surface given to SDL_CreateSoftwareRenderer(). It works with every video
+ * driver that supports window surfaces, so it is the last driver tried.
+ *
+ * The areas drawn to since the last present are tracked, and presenting
+ * only copies them to the screen. The whole window is updated after it is
+ * cleared or resized, or when the changes cover most of it anyway.
+ *
+ * By default a window renderer draws straight into the window surface. With
+ * SDL_PROP_RENDERER_CREATE_SOFTWARE_BUFFERS_NUMBER it draws into back
+ * buffers instead, which are copied to the window surface when the frame is
+ * shown. With vsync, frames are shown at the vertical blanks estimated from
+ * the refresh rate of the display; the video drivers can't report the real
+ * ones.
+ *
+ * - 1 buffer: presenting waits for the vertical blank and shows the window
+ *   surface. The least memory and copying, but a video driver that reads the
+ *   window surface while it's drawn into shows half drawn frames.
+ * - 2 buffers: one back buffer. Presenting waits for the vertical blank and
+ *   copies the frame, the window surface only ever has whole frames.
+ * - 3 buffers: two back buffers, drawn into in turn. Presenting doesn't
+ *   wait, the frame is shown at the next present or SDL_FlushRenderer() after
+ *   the vertical blank it was due at, and frames drawn faster than the
+ *   display refreshes are dropped. The least latency without tearing.
+ */
+
+/* Past this many separate changed areas the whole window is updated */
+const sdlSWMaxDirtyRects = 16
+
+/* The refresh rate assumed when the display doesn't report one */
+const sdlSWDefaultRefreshRate = 60
+
+type sdlSWRenderData struct {
+	surface *SDL_Surface /* the window surface */
+	dirty   []SDL_Rect   /* the areas drawn to since the last present, they don't overlap */
+	full    bool         /* the whole frame has to be shown */
+
+	/* Back buffers, drawn into instead of the window surface when there are any */
+	buffer_count int /* the window surface and the back buffers */
+	buffers      []*SDL_Surface
+	current      int        /* the back buffer drawn into */
+	pending      int        /* the back buffer with a frame waiting to be shown, or -1 */
+	unshown      []SDL_Rect /* the areas of the pending frame that aren't on the screen */
+	unshown_full bool
+
+	vblank uint64 /* the estimated vertical blank the last frame was shown at, in nanoseconds */
+}
+
+func init() {
+	sdlRegisterRenderDriver(sdlRenderDriver{
+		name:   SDL_SOFTWARE_RENDERER,
+		create: sdlSWCreateRenderer,
+	})
+}
+
+func sdlSWSetup(renderer *SDL_Renderer) {
+	renderer.SupportsBlendMode = sdlSWSupportsBlendMode
+	renderer.GetOutputSize = sdlSWGetOutputSize
+	renderer.RenderClear = sdlSWRenderClear
+	renderer.RenderFillRects = sdlSWRenderFillRects
+	renderer.RenderFillQuads = sdlSWRenderFillQuads
+	renderer.RenderPresent = sdlSWRenderPresent
+	renderer.SetVSync = sdlSWSetVSync
+	renderer.FlushRenderer = sdlSWFlushRenderer
+	renderer.DestroyRenderer = sdlSWDestroyRenderer
+}
+
+func sdlSWCreateRenderer(renderer *SDL_Renderer, window *SDL_Window, props SDL_PropertiesID) bool {
+	/* Fail now rather than at the first drawing if the video driver has no framebuffer */
+	if SDL_GetWindowSurface(window) == nil {
+		return false
+	}
+	buffer_count := SDL_GetNumberProperty(props, SDL_PROP_RENDERER_CREATE_SOFTWARE_BUFFERS_NUMBER, 1)
+	if buffer_count < 1 || buffer_count > 3 {
+		return SDL_SetError("The software renderer supports 1 to 3 buffers")
+	}
+	sdlSWSetup(renderer)
+	renderer.driverdata = &sdlSWRenderData{full: true, buffer_count: int(buffer_count), pending: -1}
+	return true
+}
+
+func sdlSWCreateRendererForSurface(renderer *SDL_Renderer, surface *SDL_Surface) bool {
+	sdlSWSetup(renderer)
+	return true
+}
+
+/* The surface to draw into, the window surface is created again after the window is resized */
+func sdlSWGetSurface(renderer *SDL_Renderer) *SDL_Surface {
+	window := renderer.window
+	if window == nil {
+		return renderer.target
+	}
+	surface := SDL_GetWindowSurface(window)
+	if surface == nil {
+		return nil
+	}
+	data := renderer.driverdata.(*sdlSWRenderData)
+	if surface != data.surface {
+		/* A new surface, nothing of it is on the screen yet */
+		data.surface = surface
+		data.full = true
+		if !sdlSWCreateBackBuffers(data) {
+			return nil
+		}
+	}
+	if len(data.buffers) > 0 {
+		return data.buffers[data.current]
+	}
+	return surface
+}
+
+/* Create the back buffers matching the window surface, dropping the old ones and their pending frame */
+func sdlSWCreateBackBuffers(data *sdlSWRenderData) bool {
+	sdlSWDestroyBackBuffers(data)
+	for i := 1; i < data.buffer_count; i++ {
+		buffer := SDL_CreateSurface(data.surface.W, data.surface.H, data.surface.Format)
+		if buffer == nil {
+			sdlSWDestroyBackBuffers(data)
+			return false
+		}
+		SDL_SetSurfaceBlendMode(buffer, SDL_BLENDMODE_NONE)
+		data.buffers = append(data.buffers, buffer)
+	}
+	return true
+}
+
+func sdlSWDestroyBackBuffers(data *sdlSWRenderData) {
+	for _, buffer := range data.buffers {
+		SDL_DestroySurface(buffer)
+	}
+	data.buffers = nil
+	data.current = 0
+	data.pending = -1
+	data.unshown = data.unshown[:0]
+	data.unshown_full = false
+}
+
+/*
+ * Add an area to a list of areas that don't overlap, merging it with the
+ * ones it overlaps so every pixel is copied once. Returns false if there
+ * are too many areas and the whole surface should be copied instead.
+ */
+func sdlSWAddRect(rects []SDL_Rect, rect SDL_Rect) ([]SDL_Rect, bool) {
+	for i := 0; i < len(rects); {
+		if SDL_HasRectIntersection(&rect, &rects[i]) {
+			SDL_GetRectUnion(&rect, &rects[i], &rect)
+			rects = append(rects[:i], rects[i+1:]...)
+			i = 0
+		} else {
+			i++
+		}
+	}
+	rects = append(rects, rect)
+	return rects, len(rects) <= sdlSWMaxDirtyRects
+}
+
+/* Record an area of the frame as changed */
+func sdlSWMarkDirty(renderer *SDL_Renderer, surface *SDL_Surface, rect SDL_Rect) {
+	data, ok := renderer.driverdata.(*sdlSWRenderData)
+	if !ok || data.full {
+		return
+	}
+	if !SDL_GetRectIntersection(&rect, &surface.clip_rect, &rect) {
+		return
+	}
+
+	data.dirty, ok = sdlSWAddRect(data.dirty, rect)
+	data.full = !ok
+}
+
+func sdlSWSupportsBlendMode(renderer *SDL_Renderer, blendMode SDL_BlendMode) bool {
+	switch blendMode {
+	case SDL_BLENDMODE_NONE, SDL_BLENDMODE_BLEND, SDL_BLENDMODE_ADD, SDL_BLENDMODE_MOD, SDL_BLENDMODE_MUL:
+		return true
+	}
+	return false
+}
+
+func sdlSWGetOutputSize(renderer *SDL_Renderer, w, h *int) bool {
+	surface := sdlSWGetSurface(renderer)
+	if surface == nil {
+		return false
+	}
+	*w, *h = surface.W, surface.H
+	return true
+}
+
+func sdlSWRenderClear(renderer *SDL_Renderer, color SDL_FColor) bool {
+	surface := sdlSWGetSurface(renderer)
+	if surface == nil {
+		return false
+	}
+
+	if data, ok := renderer.driverdata.(*sdlSWRenderData); ok {
+		data.full = true
+	}
+
+	/* Clearing ignores the clip rectangle */
+	pixel := SDL_MapSurfaceRGBA(surface, sdlColorComponentToByte(color.R), sdlColorComponentToByte(color.G), sdlColorComponentToByte(color.B), sdlColorComponentToByte(color.A))
+	clip := surface.clip_rect
+	surface.clip_rect = SDL_Rect{W: surface.W, H: surface.H}
+	ok := SDL_FillSurfaceRect(surface, nil, pixel)
+	surface.clip_rect = clip
+	return ok
+}
+
+func sdlSWRenderFillRects(renderer *SDL_Renderer, rects []SDL_FRect, color SDL_FColor, blendMode SDL_BlendMode) bool {
+	surface := sdlSWGetSurface(renderer)
+	if surface == nil {
+		return false
+	}
+
+	/* Rectangles are truncated to whole pixels, and are at least one pixel big */
+	pixelRects := make([]SDL_Rect, len(rects))
+	for i, rect := range rects {
+		pixelRects[i] = SDL_Rect{X: int(rect.X), Y: int(rect.Y), W: max(int(rect.W), 1), H: max(int(rect.H), 1)}
+		sdlSWMarkDirty(renderer, surface, pixelRects[i])
+	}
+
+	c := SDL_Color{sdlColorComponentToByte(color.R), sdlColorComponentToByte(color.G), sdlColorComponentToByte(color.B), sdlColorComponentToByte(color.A)}
+	if blendMode == SDL_BLENDMODE_NONE {
+		return SDL_FillSurfaceRects(surface, pixelRects, SDL_MapSurfaceRGBA(surface, c.R, c.G, c.B, c.A))
+	}
+
+	for i := range pixelRects {
+		var clipped SDL_Rect
+		if !SDL_GetRectIntersection(&pixelRects[i], &surface.clip_rect, &clipped) {
+			continue
+		}
+		for y := clipped.Y; y < clipped.Y+clipped.H; y++ {
+			sdlSWFillSpan(surface, y, clipped.X, clipped.X+clipped.W, c, blendMode)
+		}
+	}
+	return true
+}
+
+/* Fill the pixels x0 <= x < x1 of row y, which must be inside the surface */
+func sdlSWFillSpan(surface *SDL_Surface, y, x0, x1 int, c SDL_Color, blendMode SDL_BlendMode) {
+	for x := x0; x < x1; x++ {
+		if blendMode == SDL_BLENDMODE_NONE {
+			surface.putColor(x, y, c)
+		} else {
+			surface.putColor(x, y, sdlBlendColor(c, surface.getColor(x, y), blendMode))
+		}
+	}
+}
+
+/* Fill convex quadrilaterals, a pixel is filled when its center is inside */
+func sdlSWRenderFillQuads(renderer *SDL_Renderer, quads [][4]SDL_FPoint, color SDL_FColor, blendMode SDL_BlendMode) bool {
+	surface := sdlSWGetSurface(renderer)
+	if surface == nil {
+		return false
+	}
+
+	c := SDL_Color{sdlColorComponentToByte(color.R), sdlColorComponentToByte(color.G), sdlColorComponentToByte(color.B), sdlColorComponentToByte(color.A)}
+	clip := surface.clip_rect
+	for _, quad := range quads {
+		top, bottom := quad[0].Y, quad[0].Y
+		for _, p := range quad[1:] {
+			top, bottom = min(top, p.Y), max(bottom, p.Y)
+		}
+		leftmost, rightmost := quad[0].X, quad[0].X
+		for _, p := range quad[1:] {
+			leftmost, rightmost = min(leftmost, p.X), max(rightmost, p.X)
+		}
+		sdlSWMarkDirty(renderer, surface, SDL_Rect{
+			X: int(math.Floor(float64(leftmost))),
+			Y: int(math.Floor(float64(top))),
+			W: int(math.Ceil(float64(rightmost))) - int(math.Floor(float64(leftmost))),
+			H: int(math.Ceil(float64(bottom))) - int(math.Floor(float64(top))),
+		})
+
+		y0 := max(int(math.Ceil(float64(top)-0.5)), clip.Y)
+		y1 := min(int(math.Ceil(float64(bottom)-0.5)), clip.Y+clip.H)
+		for y := y0; y < y1; y++ {
+			/* Where the edges cross the center line of the row */
+			center := float32(y) + 0.5
+			left, right := float32(math.MaxFloat32), float32(-math.MaxFloat32)
+			for i := range quad {
+				p, q := quad[i], quad[(i+1)%len(quad)]
+				if (p.Y <= center) == (q.Y <= center) {
+					continue
+				}
+				x := p.X + (center-p.Y)*(q.X-p.X)/(q.Y-p.Y)
+				left, right = min(left, x), max(right, x)
+			}
+			if left >= right {
+				continue
+			}
+			x0 := max(int(math.Ceil(float64(left)-0.5)), clip.X)
+			x1 := min(int(math.Ceil(float64(right)-0.5)), clip.X+clip.W)
+			sdlSWFillSpan(surface, y, x0, x1, c, blendMode)
+		}
+	}
+	return true
+}
+
+/* Copy areas of one surface to another of the same format, or the whole surface if full */
+func sdlSWCopyRects(src, dst *SDL_Surface, rects []SDL_Rect, full bool) {
+	if full {
+		rects = []SDL_Rect{{X: 0, Y: 0, W: src.W, H: src.H}}
+	}
+	bpp := int(src.details.Bytes_per_pixel)
+	for _, rect := range rects {
+		for y := rect.Y; y < rect.Y+rect.H; y++ {
+			start := y*src.Pitch + rect.X*bpp
+			copy(dst.Pixels[start:start+rect.W*bpp], src.Pixels[start:])
+		}
+	}
+}
+
+/* Show areas of the window surface, all of it if full or when the areas cover most of it */
+func sdlSWUpdateWindow(window *SDL_Window, rects []SDL_Rect, full bool) bool {
+	if !full {
+		/* Updating most of the window piecewise costs more than one full update */
+		area := 0
+		for _, rect := range rects {
+			area += rect.W * rect.H
+		}
+		full = area > window.surface.W*window.surface.H*3/4
+	}
+	if full {
+		return SDL_UpdateWindowSurface(window)
+	}
+	return SDL_UpdateWindowSurfaceRects(window, rects)
+}
+
+/* The time between vertical blanks of the display showing the window, in nanoseconds */
+func sdlSWRefreshInterval(window *SDL_Window) uint64 {
+	refresh_rate := float32(sdlSWDefaultRefreshRate)
+	if mode := SDL_GetCurrentDisplayMode(SDL_GetDisplayForWindow(window)); mode != nil && mode.RefreshRate > 0 {
+		refresh_rate = mode.RefreshRate
+	}
+	return uint64(SDL_NS_PER_SECOND / refresh_rate)
+}
+
+/*
+ * Wait for the estimated vertical blank the next frame is due at, as the
+ * vsync setting asks. Without waiting, return false if it isn't due yet.
+ */
+func sdlSWWaitForVBlank(renderer *SDL_Renderer, data *sdlSWRenderData, wait bool) bool {
+	now := SDL_GetTicksNS()
+	if renderer.vsync == SDL_RENDERER_VSYNC_DISABLED || data.vblank == 0 {
+		data.vblank = now
+		return true
+	}
+
+	interval := sdlSWRefreshInterval(renderer.window)
+	target := data.vblank + uint64(max(renderer.vsync, 1))*interval
+	if now < target {
+		if !wait {
+			return false
+		}
+		SDL_DelayNS(target - now)
+		data.vblank = target
+		return true
+	}
+
+	/* Late, a vertical blank passed without a new frame */
+	last := data.vblank + (now-data.vblank)/interval*interval
+	if wait && renderer.vsync != SDL_RENDERER_VSYNC_ADAPTIVE {
+		SDL_DelayNS(last + interval - now)
+		data.vblank = last + interval
+		return true
+	}
+	/* Show it right away, adaptive vsync tears rather than stutter */
+	data.vblank = last
+	return true
+}
+
+/* Copy the pending frame to the window surface and show it */
+func sdlSWShowPending(renderer *SDL_Renderer, data *sdlSWRenderData) bool {
+	if data.pending < 0 {
+		return true
+	}
+	sdlSWCopyRects(data.buffers[data.pending], data.surface, data.unshown, data.unshown_full)
+	ok := sdlSWUpdateWindow(renderer.window, data.unshown, data.unshown_full)
+	data.pending = -1
+	data.unshown = data.unshown[:0]
+	data.unshown_full = false
+	return ok
+}
+
+func sdlSWRenderPresent(renderer *SDL_Renderer) bool {
+	window := renderer.window
+	if window == nil {
+		return true
+	}
+	if sdlSWGetSurface(renderer) == nil {
+		return false
+	}
+	data := renderer.driverdata.(*sdlSWRenderData)
+
+	if len(data.buffers) == 0 {
+		/* Drawn straight into the window surface */
+		sdlSWWaitForVBlank(renderer, data, true)
+		ok := sdlSWUpdateWindow(window, data.dirty, data.full)
+		data.dirty = data.dirty[:0]
+		data.full = !ok
+		return ok
+	}
+
+	/* The frame is done, what it changed has to be shown along with what's still pending */
+	if data.full {
+		data.unshown_full = true
+	}
+	for _, rect := range data.dirty {
+		if data.unshown_full {
+			break
+		}
+		var ok bool
+		data.unshown, ok = sdlSWAddRect(data.unshown, rect)
+		data.unshown_full = !ok
+	}
+	data.pending = data.current
+	if len(data.buffers) > 1 {
+		/* Continue in the other back buffer, after bringing it up to date */
+		next := 1 - data.current
+		sdlSWCopyRects(data.buffers[data.current], data.buffers[next], data.dirty, data.full)
+		data.current = next
+	}
+	data.dirty = data.dirty[:0]
+	data.full = false
+
+	/* A single back buffer is drawn into next, so its frame can't wait */
+	if !sdlSWWaitForVBlank(renderer, data, len(data.buffers) == 1) {
+		return true
+	}
+	return sdlSWShowPending(renderer, data)
+}
+
+func sdlSWSetVSync(renderer *SDL_Renderer, vsync int) bool {
+	if renderer.window == nil && vsync != SDL_RENDERER_VSYNC_DISABLED {
+		return SDL_Unsupported()
+	}
+	return true
+}
+
+/* Show the frame held back by triple buffering, once it's due */
+func sdlSWFlushRenderer(renderer *SDL_Renderer) bool {
+	data, ok := renderer.driverdata.(*sdlSWRenderData)
+	if !ok || data.pending < 0 {
+		return true
+	}
+	if !sdlSWWaitForVBlank(renderer, data, false) {
+		sdlSWWaitForVBlank(renderer, data, true)
+	}
+	return sdlSWShowPending(renderer, data)
+}
+
+func sdlSWDestroyRenderer(renderer *SDL_Renderer) {
+	if data, ok := renderer.driverdata.(*sdlSWRenderData); ok {
+		sdlSWDestroyBackBuffers(data)
+	}
+	if window := renderer.window; window != nil {
+		SDL_DestroyWindowSurface(window)
+	}
+}
@@ -0,0 +1,303 @@
+package sdl
+
+import "fmt"
+import "math"
+import "reflect"
+import "testing"
+
+/* A software renderer drawing into a cleared surface */
+func testCreateSoftwareRenderer(t *testing.T, w, h int) (*SDL_Renderer, *SDL_Surface) {
+	t.Helper()
+	surface := SDL_CreateSurface(w, h, SDL_PIXELFORMAT_RGBA32)
+	if surface == nil {
+		t.Fatalf("SDL_CreateSurface failed: %s", SDL_GetError())
+	}
+	renderer := SDL_CreateSoftwareRenderer(surface)
+	if renderer == nil {
+		t.Fatalf("SDL_CreateSoftwareRenderer failed: %s", SDL_GetError())
+	}
+	t.Cleanup(func() {
+		SDL_DestroyRenderer(renderer)
+		SDL_DestroySurface(surface)
+	})
+	SDL_SetRenderDrawColor(renderer, 0, 0, 0, 255)
+	SDL_RenderClear(renderer)
+	SDL_SetRenderDrawColor(renderer, 255, 0, 0, 255)
+	return renderer, surface
+}
+
+/* Check that exactly the pixels for which inside() is true are red */
+func testCheckFilled(t *testing.T, surface *SDL_Surface, inside func(x, y int) bool) {
+	t.Helper()
+	for y := 0; y < surface.H; y++ {
+		for x := 0; x < surface.W; x++ {
+			var r, g, b, a uint8
+			if !SDL_ReadSurfacePixel(surface, x, y, &r, &g, &b, &a) {
+				t.Fatalf("SDL_ReadSurfacePixel failed: %s", SDL_GetError())
+			}
+			if filled := r == 255; filled != inside(x, y) {
+				t.Errorf("Pixel %d,%d is %d,%d,%d,%d, expected it %s", x, y, r, g, b, a, tern(inside(x, y), "filled", "empty"))
+			}
+		}
+	}
+}
+
+func TestRenderTransformStack(t *testing.T) {
+	renderer, _ := testCreateSoftwareRenderer(t, 4, 4)
+
+	var transform SDL_AffineTransform
+	if !SDL_GetRenderTransform(renderer, &transform) || transform != SDL_IdentityTransform() {
+		t.Fatalf("Initial transform is %+v, expected the identity", transform)
+	}
+	if SDL_PopRenderTransform(renderer) {
+		t.Fatalf("SDL_PopRenderTransform succeeded with nothing pushed")
+	}
+
+	translated := SDL_TranslateTransform(SDL_IdentityTransform(), 1, 2)
+	scaled := SDL_ScaleTransform(translated, 2, 2)
+	SDL_PushRenderTransform(renderer)
+	SDL_SetRenderTransform(renderer, translated)
+	SDL_PushRenderTransform(renderer)
+	SDL_SetRenderTransform(renderer, scaled)
+
+	for _, expected := range []SDL_AffineTransform{scaled, translated, SDL_IdentityTransform()} {
+		SDL_GetRenderTransform(renderer, &transform)
+		if transform != expected {
+			t.Errorf("Transform is %+v, expected %+v", transform, expected)
+		}
+		SDL_PopRenderTransform(renderer)
+	}
+}
+
+func TestRenderFillRectsScaled(t *testing.T) {
+	renderer, surface := testCreateSoftwareRenderer(t, 16, 16)
+
+	/* Mirrored horizontally around x = 8, then scaled */
+	transform := SDL_ScaleTransform(SDL_TranslateTransform(SDL_ScaleTransform(SDL_IdentityTransform(), -1, 1), 8, 0), 2, 2)
+	SDL_SetRenderTransform(renderer, transform)
+	if !SDL_RenderFillRect(renderer, &SDL_FRect{X: 1, Y: 1, W: 2, H: 3}) {
+		t.Fatalf("SDL_RenderFillRect failed: %s", SDL_GetError())
+	}
+	testCheckFilled(t, surface, func(x, y int) bool {
+		return x >= 10 && x < 14 && y >= 2 && y < 8
+	})
+}
+
+func TestRenderFillRectsRotated(t *testing.T) {
+	renderer, surface := testCreateSoftwareRenderer(t, 16, 16)
+
+	/* A quarter turn maps (x, y) to (-y, x) */
+	transform := SDL_TranslateTransform(SDL_RotateTransform(SDL_IdentityTransform(), 90), 8, 4)
+	SDL_SetRenderTransform(renderer, transform)
+	if !SDL_RenderFillRect(renderer, &SDL_FRect{X: 0, Y: 0, W: 4, H: 2}) {
+		t.Fatalf("SDL_RenderFillRect failed: %s", SDL_GetError())
+	}
+	testCheckFilled(t, surface, func(x, y int) bool {
+		return x >= 6 && x < 8 && y >= 4 && y < 8
+	})
+}
+
+func TestRenderFillRectsRotatedDiamond(t *testing.T) {
+	renderer, surface := testCreateSoftwareRenderer(t, 16, 16)
+
+	/* A square turned by 45 degrees around its center (8, 8) */
+	transform := SDL_TranslateTransform(SDL_RotateTransform(SDL_IdentityTransform(), 45), 8, 8)
+	SDL_SetRenderTransform(renderer, transform)
+	SDL_RenderFillRect(renderer, &SDL_FRect{X: -4, Y: -4, W: 8, H: 8})
+
+	half := float32(4 * math.Sqrt2)
+	testCheckFilled(t, surface, func(x, y int) bool {
+		dx, dy := float32(x)+0.5-8, float32(y)+0.5-8
+		return max(dx, -dx)+max(dy, -dy) < half
+	})
+
+	/* The whole target ignores the transform */
+	SDL_RenderFillRect(renderer, nil)
+	testCheckFilled(t, surface, func(x, y int) bool { return true })
+}
+
+/* A software renderer for an offscreen window, recording the areas each present updates */
+func testCreateWindowRenderer(t *testing.T, w, h int, buffers int, vsync int) (*SDL_Renderer, *[][]SDL_Rect) {
+	t.Helper()
+	SDL_SetHint(SDL_HINT_VIDEO_DRIVER, sdlOffscreenVideoDriverName)
+	if !SDL_InitSubSystem(SDL_INIT_VIDEO) {
+		t.Fatalf("SDL_InitSubSystem failed: %s", SDL_GetError())
+	}
+	window := SDL_CreateWindow("render test", w, h, 0)
+	if window == nil {
+		t.Fatalf("SDL_CreateWindow failed: %s", SDL_GetError())
+	}
+	props := SDL_CreateProperties()
+	SDL_SetPointerProperty(props, SDL_PROP_RENDERER_CREATE_WINDOW_POINTER, window)
+	SDL_SetStringProperty(props, SDL_PROP_RENDERER_CREATE_NAME_STRING, SDL_SOFTWARE_RENDERER)
+	SDL_SetNumberProperty(props, SDL_PROP_RENDERER_CREATE_SOFTWARE_BUFFERS_NUMBER, int64(buffers))
+	SDL_SetNumberProperty(props, SDL_PROP_RENDERER_CREATE_PRESENT_VSYNC_NUMBER, int64(vsync))
+	renderer := SDL_CreateRendererWithProperties(props)
+	SDL_DestroyProperties(props)
+	if renderer == nil {
+		t.Fatalf("SDL_CreateRendererWithProperties failed: %s", SDL_GetError())
+	}
+
+	var updates [][]SDL_Rect
+	device := sdlGetVideoDevice()
+	update := device.UpdateWindowFramebuffer
+	device.UpdateWindowFramebuffer = func(device *sdlVideoDevice, window *SDL_Window, rects []SDL_Rect) bool {
+		updates = append(updates, append([]SDL_Rect(nil), rects...))
+		return update(device, window, rects)
+	}
+	t.Cleanup(func() {
+		device.UpdateWindowFramebuffer = update
+		SDL_DestroyRenderer(renderer)
+		SDL_DestroyWindow(window)
+		SDL_QuitSubSystem(SDL_INIT_VIDEO)
+		SDL_ResetHint(SDL_HINT_VIDEO_DRIVER)
+	})
+	return renderer, &updates
+}
+
+func TestRenderPresentDirtyRects(t *testing.T) {
+	renderer, updates := testCreateWindowRenderer(t, 64, 64, 1, 0)
+	full := []SDL_Rect{{X: 0, Y: 0, W: 64, H: 64}}
+
+	steps := []struct {
+		name     string
+		draw     func()
+		expected []SDL_Rect
+	}{
+		{"first frame", func() {}, full},
+		{"nothing drawn", func() {}, []SDL_Rect{}},
+		{"overlapping rects merge", func() {
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 2, Y: 2, W: 4, H: 4})
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 40, Y: 40, W: 2, H: 2})
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 4, Y: 4, W: 4, H: 4})
+		}, []SDL_Rect{{X: 40, Y: 40, W: 2, H: 2}, {X: 2, Y: 2, W: 6, H: 6}}},
+		{"clipped to the window", func() {
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 60, Y: -2, W: 8, H: 4})
+		}, []SDL_Rect{{X: 60, Y: 0, W: 4, H: 2}}},
+		{"rotated rect bounds", func() {
+			SDL_SetRenderTransform(renderer, SDL_TranslateTransform(SDL_RotateTransform(SDL_IdentityTransform(), 45), 20, 20))
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: -2, Y: -2, W: 4, H: 4})
+			SDL_SetRenderTransform(renderer, SDL_IdentityTransform())
+		}, []SDL_Rect{{X: 17, Y: 17, W: 6, H: 6}}},
+		{"clear", func() {
+			SDL_RenderClear(renderer)
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 2, Y: 2, W: 4, H: 4})
+		}, full},
+		{"most of the window", func() {
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 0, Y: 0, W: 64, H: 56})
+		}, full},
+		{"many small rects", func() {
+			for i := 0; i <= sdlSWMaxDirtyRects; i++ {
+				SDL_RenderFillRect(renderer, &SDL_FRect{X: float32(3 * i), Y: 0, W: 1, H: 1})
+			}
+		}, full},
+	}
+	for _, step := range steps {
+		*updates = nil
+		step.draw()
+		if !SDL_RenderPresent(renderer) {
+			t.Fatalf("%s: SDL_RenderPresent failed: %s", step.name, SDL_GetError())
+		}
+		if len(*updates) != 1 {
+			t.Fatalf("%s: %d updates, expected 1", step.name, len(*updates))
+		}
+		if got := (*updates)[0]; len(got) != len(step.expected) || (len(got) > 0 && !reflect.DeepEqual(got, step.expected)) {
+			t.Errorf("%s: updated %v, expected %v", step.name, got, step.expected)
+		}
+	}
+}
+
+/* Check the color of a pixel */
+func testCheckPixel(t *testing.T, surface *SDL_Surface, x, y int, expected SDL_Color) {
+	t.Helper()
+	var c SDL_Color
+	if !SDL_ReadSurfacePixel(surface, x, y, &c.R, &c.G, &c.B, &c.A) {
+		t.Fatalf("SDL_ReadSurfacePixel failed: %s", SDL_GetError())
+	}
+	if c.R != expected.R || c.G != expected.G || c.B != expected.B {
+		t.Errorf("Pixel %d,%d is %v, expected %v", x, y, c, expected)
+	}
+}
+
+func TestRenderPresentBackBuffers(t *testing.T) {
+	black, red, green := SDL_Color{0, 0, 0, 255}, SDL_Color{255, 0, 0, 255}, SDL_Color{0, 255, 0, 255}
+	for _, buffers := range []int{2, 3} {
+		t.Run(fmt.Sprintf("%d buffers", buffers), func(t *testing.T) {
+			renderer, updates := testCreateWindowRenderer(t, 32, 32, buffers, 0)
+			window := SDL_GetWindowSurface(SDL_GetRenderWindow(renderer))
+
+			SDL_SetRenderDrawColor(renderer, 0, 0, 0, 255)
+			SDL_RenderClear(renderer)
+			SDL_SetRenderDrawColor(renderer, 255, 0, 0, 255)
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 2, Y: 2, W: 4, H: 4})
+			testCheckPixel(t, window, 3, 3, black)
+			SDL_RenderPresent(renderer)
+			testCheckPixel(t, window, 3, 3, red)
+
+			/* The next frame builds on this one in every back buffer */
+			*updates = nil
+			SDL_SetRenderDrawColor(renderer, 0, 255, 0, 255)
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 20, Y: 20, W: 2, H: 2})
+			SDL_RenderPresent(renderer)
+			SDL_RenderFillRect(renderer, &SDL_FRect{X: 10, Y: 10, W: 2, H: 2})
+			SDL_RenderPresent(renderer)
+			testCheckPixel(t, window, 3, 3, red)
+			testCheckPixel(t, window, 20, 20, green)
+			testCheckPixel(t, window, 10, 10, green)
+			expected := [][]SDL_Rect{{{X: 20, Y: 20, W: 2, H: 2}}, {{X: 10, Y: 10, W: 2, H: 2}}}
+			if !reflect.DeepEqual(*updates, expected) {
+				t.Errorf("Updated %v, expected %v", *updates, expected)
+			}
+		})
+	}
+}
+
+func TestRenderTripleBufferingDropsFrames(t *testing.T) {
+	renderer, updates := testCreateWindowRenderer(t, 32, 32, 3, 1)
+	window := SDL_GetWindowSurface(SDL_GetRenderWindow(renderer))
+	data := renderer.driverdata.(*sdlSWRenderData)
+
+	SDL_RenderClear(renderer)
+	SDL_RenderPresent(renderer)
+
+	/* Frames due far in the future are held back, the newest replacing the older ones */
+	*updates = nil
+	data.vblank = SDL_GetTicksNS() + SDL_NS_PER_SECOND
+	SDL_SetRenderDrawColor(renderer, 255, 0, 0, 255)
+	SDL_RenderFillRect(renderer, &SDL_FRect{X: 2, Y: 2, W: 2, H: 2})
+	SDL_RenderPresent(renderer)
+	SDL_SetRenderDrawColor(renderer, 0, 255, 0, 255)
+	SDL_RenderFillRect(renderer, &SDL_FRect{X: 8, Y: 8, W: 2, H: 2})
+	SDL_RenderPresent(renderer)
+	if len(*updates) != 0 {
+		t.Fatalf("Frames were shown before they were due: %v", *updates)
+	}
+	testCheckPixel(t, window, 2, 2, SDL_Color{0, 0, 0, 255})
+
+	/* Once due, the latest frame is shown with the changes of the dropped one */
+	data.vblank = SDL_GetTicksNS() - sdlSWRefreshInterval(SDL_GetRenderWindow(renderer))
+	if !SDL_FlushRenderer(renderer) {
+		t.Fatalf("SDL_FlushRenderer failed: %s", SDL_GetError())
+	}
+	testCheckPixel(t, window, 2, 2, SDL_Color{255, 0, 0, 255})
+	testCheckPixel(t, window, 8, 8, SDL_Color{0, 255, 0, 255})
+	expected := [][]SDL_Rect{{{X: 2, Y: 2, W: 2, H: 2}, {X: 8, Y: 8, W: 2, H: 2}}}
+	if !reflect.DeepEqual(*updates, expected) {
+		t.Errorf("Updated %v, expected %v", *updates, expected)
+	}
+
+	var vsync int
+	if !SDL_GetRenderVSync(renderer, &vsync) || vsync != 1 {
+		t.Errorf("VSync is %d, expected 1", vsync)
+	}
+}
+
+func TestRenderVSyncWithoutWindow(t *testing.T) {
+	renderer, _ := testCreateSoftwareRenderer(t, 4, 4)
+	if SDL_SetRenderVSync(renderer, 1) {
+		t.Errorf("SDL_SetRenderVSync succeeded for a surface")
+	}
+	if !SDL_SetRenderVSync(renderer, SDL_RENDERER_VSYNC_DISABLED) {
+		t.Errorf("SDL_SetRenderVSync failed: %s", SDL_GetError())
+	}
+}
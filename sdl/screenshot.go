@@ -0,0 +1,81 @@
+package sdl
+
+import "image"
+import "image/color"
+
+/*
+ * Screenshots of the desktop.
+ *
+ * This is an extension to the SDL API. Capturing the screen needs the
+ * user's consent on sandboxed and Wayland desktops, so the capture is
+ * asynchronous and the user may be shown a dialog first.
+ *
+ * A platform backend registers its implementation from an init() function;
+ * on platforms without one, the callback gets an SDL_Unsupported() error.
+ */
+
+/**
+ * Callback used by SDL_CaptureScreenshot() with the screenshot.
+ *
+ * The callback may be called from any thread, before or after
+ * SDL_CaptureScreenshot() returns.
+ *
+ * - userdata an app-provided pointer, for the callback's use.
+ * - surface the screenshot, which the callback owns and must free with
+ *                SDL_DestroySurface(), or nil if the capture failed or the
+ *                user canceled it; call SDL_GetError() for more information.
+ *
+ * See also SDL_CaptureScreenshot
+ */
+type SDL_ScreenshotCallback func(userdata any, surface *SDL_Surface)
+
+/* Capture the screen, calling done from any goroutine with the screenshot, or nil and the error message */
+var screenshotBackend func(window *SDL_Window, interactive bool, done func(surface *SDL_Surface, err string))
+
+/* Copy an image into a new SDL_PIXELFORMAT_RGBA32 surface */
+func sdlCreateSurfaceFromImage(img image.Image) *SDL_Surface {
+	bounds := img.Bounds()
+	return SDL_CreateSurfaceFromFunc(bounds.Dx(), bounds.Dy(), SDL_PIXELFORMAT_RGBA32, func(x, y int) SDL_Color {
+		c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+		return SDL_Color{R: c.R, G: c.G, B: c.B, A: c.A}
+	})
+}
+
+/**
+ * Capture the contents of the screen.
+ *
+ * The screenshot is delivered to the callback as an
+ * SDL_PIXELFORMAT_RGBA32 surface. Depending on the platform, the user may
+ * be asked for permission first, and the callback gets nil if it is denied.
+ *
+ * - callback a function pointer to be invoked when the screenshot is ready
+ *                 or the capture failed.
+ * - userdata an optional pointer to pass extra data to the callback when
+ *                 it will be invoked.
+ * - window the window that the permission and selection dialogs should be
+ *               modal for, may be nil.
+ * - interactive true to let the user choose the area or window to capture,
+ *                    false to capture the whole screen.
+ *
+ * See also SDL_ScreenshotCallback
+ */
+func SDL_CaptureScreenshot(callback SDL_ScreenshotCallback, userdata any, window *SDL_Window, interactive bool) {
+	if callback == nil {
+		return
+	}
+	if window != nil && !sdlCheckWindow(window) {
+		callback(userdata, nil)
+		return
+	}
+	if screenshotBackend == nil {
+		SDL_Unsupported()
+		callback(userdata, nil)
+		return
+	}
+	screenshotBackend(window, interactive, func(surface *SDL_Surface, err string) {
+		if surface == nil {
+			SDL_SetError(err)
+		}
+		callback(userdata, surface)
+	})
+}
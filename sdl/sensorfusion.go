@@ -0,0 +1,360 @@
+package sdl
+
+import "math"
+
+/*
+ * Sensor fusion for gamepad and device motion sensors.
+ *
+ * Raw gyroscope readings drift as soon as they are integrated and
+ * accelerometer readings are swamped by the motion of the player, so games
+ * rarely want either directly. SDL_GyroFusion combines both into an
+ * orientation with a Mahony complementary filter: the gyroscope is
+ * integrated for responsiveness, and the direction of gravity measured by
+ * the accelerometer slowly pulls pitch and roll back, while also estimating
+ * the gyroscope bias. Yaw can't be observed from gravity, its drift is
+ * removed by calibrating the bias while the device rests.
+ *
+ * Axes follow the SDL sensor conventions: for a gamepad held in front of
+ * the player, -X is left and +X is right, -Y is down and +Y is up, -Z is
+ * forward and +Z is toward the player. Gyroscope values are in radians per
+ * second, accelerometer values in meters per second squared, including
+ * gravity.
+ */
+
+/**
+ * A constant to represent standard gravity for accelerometer sensors.
+ *
+ * The accelerometer returns the current acceleration in SI meters per second
+ * squared. This measurement includes the force of gravity, so a device at
+ * rest will have an value of SDL_STANDARD_GRAVITY away from the center of
+ * the earth, which is a positive Y value.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_STANDARD_GRAVITY = 9.80665
+
+/**
+ * A rotation, as a unit quaternion.
+ *
+ * The identity is {X: 0, Y: 0, Z: 0, W: 1}.
+ */
+type SDL_Quaternion struct {
+	X, Y, Z, W float32
+}
+
+/* Default strength of the accelerometer correction, in 1/s */
+const sdlGyroFusionDefaultGain = 0.5
+
+/* Default rate of the automatic gyroscope bias estimation, in 1/s² */
+const sdlGyroFusionDefaultBiasGain = 0.01
+
+/* Longest gap between samples that is integrated, longer ones are treated as a restart */
+const sdlGyroFusionMaxStepNS = 100 * SDL_NS_PER_MS
+
+/**
+ * The state of a gyroscope and accelerometer fusion filter.
+ *
+ * See also SDL_CreateGyroFusion
+ */
+type SDL_GyroFusion struct {
+	orientation       SDL_Quaternion /* sensor frame to world frame */
+	bias              [3]float32     /* gyroscope bias, in rad/s */
+	gain              float32
+	bias_gain         float32
+	last_timestamp    uint64
+	initialized       bool
+	calibrating       bool
+	calibration_sum   [3]float64
+	calibration_count int
+}
+
+func sdlQuaternionMultiply(a, b SDL_Quaternion) SDL_Quaternion {
+	return SDL_Quaternion{
+		X: a.W*b.X + a.X*b.W + a.Y*b.Z - a.Z*b.Y,
+		Y: a.W*b.Y - a.X*b.Z + a.Y*b.W + a.Z*b.X,
+		Z: a.W*b.Z + a.X*b.Y - a.Y*b.X + a.Z*b.W,
+		W: a.W*b.W - a.X*b.X - a.Y*b.Y - a.Z*b.Z,
+	}
+}
+
+func sdlQuaternionNormalize(q SDL_Quaternion) SDL_Quaternion {
+	lengthSquared := q.X*q.X + q.Y*q.Y + q.Z*q.Z + q.W*q.W
+	if lengthSquared == 0 {
+		return SDL_Quaternion{W: 1}
+	}
+	inv := 1 / SDL_sqrtf(lengthSquared)
+	return SDL_Quaternion{q.X * inv, q.Y * inv, q.Z * inv, q.W * inv}
+}
+
+/* The rotation taking the measured gravity direction to world up, used as the starting orientation */
+func sdlGyroFusionOrientationFromGravity(ax, ay, az float32) SDL_Quaternion {
+	length := SDL_sqrtf(ax*ax + ay*ay + az*az)
+	if length == 0 {
+		return SDL_Quaternion{W: 1}
+	}
+	ax, ay, az = ax/length, ay/length, az/length
+	if ay < -0.9999 {
+		/* Upside down, any half turn around a horizontal axis will do */
+		return SDL_Quaternion{X: 1}
+	}
+	/* Half-way quaternion between the vector and +Y: (a × Y, 1 + a·Y) */
+	return sdlQuaternionNormalize(SDL_Quaternion{X: -az, Y: 0, Z: ax, W: 1 + ay})
+}
+
+/**
+ * Create a sensor fusion filter.
+ *
+ * The orientation starts out level, and is aligned with gravity on the
+ * first update.
+ *
+ * Returns a new filter, feed it with SDL_UpdateGyroFusion().
+ *
+ * See also SDL_GetGyroFusionOrientation
+ * See also SDL_UpdateGyroFusion
+ */
+func SDL_CreateGyroFusion() *SDL_GyroFusion {
+	return &SDL_GyroFusion{
+		orientation: SDL_Quaternion{W: 1},
+		gain:        sdlGyroFusionDefaultGain,
+		bias_gain:   sdlGyroFusionDefaultBiasGain,
+	}
+}
+
+/**
+ * Reset the orientation of a sensor fusion filter.
+ *
+ * The orientation is realigned with gravity on the next update, the
+ * gyroscope bias and settings are kept.
+ *
+ * - fusion the filter to reset.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ */
+func SDL_ResetGyroFusion(fusion *SDL_GyroFusion) bool {
+	if fusion == nil {
+		return SDL_InvalidParamError("fusion")
+	}
+	fusion.orientation = SDL_Quaternion{W: 1}
+	fusion.initialized = false
+	return true
+}
+
+/**
+ * Set how strongly the accelerometer corrects the orientation.
+ *
+ * Higher values remove drift faster but let shaking and linear motion
+ * disturb the orientation more. 0 integrates the gyroscope alone.
+ *
+ * - fusion the filter to modify.
+ * - gain the correction strength, per second; the default is 0.5.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ */
+func SDL_SetGyroFusionGain(fusion *SDL_GyroFusion, gain float32) bool {
+	if fusion == nil {
+		return SDL_InvalidParamError("fusion")
+	}
+	if gain < 0 || math.IsNaN(float64(gain)) {
+		return SDL_InvalidParamError("gain")
+	}
+	fusion.gain = gain
+	return true
+}
+
+/**
+ * Feed a sensor fusion filter with a new pair of samples.
+ *
+ * The samples would typically come from SDL_EVENT_GAMEPAD_SENSOR_UPDATE or
+ * SDL_EVENT_SENSOR_UPDATE events, with the sensor timestamp of the
+ * gyroscope sample. Samples with an accelerometer reading far from
+ * SDL_STANDARD_GRAVITY, while the device is being shaken, only use the
+ * gyroscope.
+ *
+ * - fusion the filter to update.
+ * - gyro the gyroscope values (X, Y, Z) in radians per second.
+ * - accel the accelerometer values (X, Y, Z) in meters per second squared.
+ * - timestamp_ns the time of the sample, in nanoseconds.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_GetGyroFusionOrientation
+ */
+func SDL_UpdateGyroFusion(fusion *SDL_GyroFusion, gyro []float32, accel []float32, timestamp_ns uint64) bool {
+	if fusion == nil {
+		return SDL_InvalidParamError("fusion")
+	}
+	if len(gyro) < 3 {
+		return SDL_InvalidParamError("gyro")
+	}
+	if len(accel) < 3 {
+		return SDL_InvalidParamError("accel")
+	}
+
+	if fusion.calibrating {
+		for i := range fusion.calibration_sum {
+			fusion.calibration_sum[i] += float64(gyro[i])
+		}
+		fusion.calibration_count++
+	}
+
+	if !fusion.initialized || timestamp_ns <= fusion.last_timestamp || timestamp_ns-fusion.last_timestamp > sdlGyroFusionMaxStepNS {
+		if !fusion.initialized {
+			fusion.orientation = sdlGyroFusionOrientationFromGravity(accel[0], accel[1], accel[2])
+			fusion.initialized = true
+		}
+		fusion.last_timestamp = timestamp_ns
+		return true
+	}
+	dt := float32(timestamp_ns-fusion.last_timestamp) / SDL_NS_PER_SECOND
+	fusion.last_timestamp = timestamp_ns
+
+	wx := gyro[0] - fusion.bias[0]
+	wy := gyro[1] - fusion.bias[1]
+	wz := gyro[2] - fusion.bias[2]
+
+	/* Pull toward the measured gravity when it's trustworthy */
+	ax, ay, az := accel[0], accel[1], accel[2]
+	length := SDL_sqrtf(ax*ax + ay*ay + az*az)
+	if fusion.gain > 0 && length > 0.5*SDL_STANDARD_GRAVITY && length < 1.5*SDL_STANDARD_GRAVITY {
+		ax, ay, az = ax/length, ay/length, az/length
+
+		/* World up, seen from the sensor */
+		q := fusion.orientation
+		vx := 2 * (q.X*q.Y + q.W*q.Z)
+		vy := 1 - 2*(q.X*q.X+q.Z*q.Z)
+		vz := 2 * (q.Y*q.Z - q.W*q.X)
+
+		/* The rotation error is the cross product of measured and estimated up */
+		ex := ay*vz - az*vy
+		ey := az*vx - ax*vz
+		ez := ax*vy - ay*vx
+
+		wx += fusion.gain * ex
+		wy += fusion.gain * ey
+		wz += fusion.gain * ez
+
+		if !fusion.calibrating {
+			fusion.bias[0] -= fusion.bias_gain * ex * dt
+			fusion.bias[1] -= fusion.bias_gain * ey * dt
+			fusion.bias[2] -= fusion.bias_gain * ez * dt
+		}
+	}
+
+	/* Integrate q' = q * (0, w) / 2 */
+	delta := sdlQuaternionMultiply(fusion.orientation, SDL_Quaternion{X: wx, Y: wy, Z: wz})
+	half := 0.5 * dt
+	fusion.orientation = sdlQuaternionNormalize(SDL_Quaternion{
+		X: fusion.orientation.X + delta.X*half,
+		Y: fusion.orientation.Y + delta.Y*half,
+		Z: fusion.orientation.Z + delta.Z*half,
+		W: fusion.orientation.W + delta.W*half,
+	})
+	return true
+}
+
+/**
+ * Get the current orientation of a sensor fusion filter.
+ *
+ * The quaternion rotates vectors from the sensor frame to the world frame,
+ * where +Y is up; the world yaw is relative to the device's heading at the
+ * first update.
+ *
+ * - fusion the filter to query.
+ * Returns the orientation, or the identity if `fusion` is nil.
+ *
+ * See also SDL_UpdateGyroFusion
+ */
+func SDL_GetGyroFusionOrientation(fusion *SDL_GyroFusion) SDL_Quaternion {
+	if fusion == nil {
+		SDL_InvalidParamError("fusion")
+		return SDL_Quaternion{W: 1}
+	}
+	return fusion.orientation
+}
+
+/**
+ * Start measuring the gyroscope bias.
+ *
+ * The device should rest, e.g. lie on a table, until
+ * SDL_StopGyroFusionCalibration() is called; about a second of samples is
+ * enough. The gyroscope readings received in the meantime are averaged to
+ * find the bias.
+ *
+ * - fusion the filter to calibrate.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_StopGyroFusionCalibration
+ */
+func SDL_StartGyroFusionCalibration(fusion *SDL_GyroFusion) bool {
+	if fusion == nil {
+		return SDL_InvalidParamError("fusion")
+	}
+	fusion.calibrating = true
+	fusion.calibration_sum = [3]float64{}
+	fusion.calibration_count = 0
+	return true
+}
+
+/**
+ * Finish measuring the gyroscope bias and start using it.
+ *
+ * - fusion the filter being calibrated.
+ * Returns true on success or false if no calibration was in progress or no
+ *          samples were received; call SDL_GetError() for more information.
+ *
+ * See also SDL_StartGyroFusionCalibration
+ */
+func SDL_StopGyroFusionCalibration(fusion *SDL_GyroFusion) bool {
+	if fusion == nil {
+		return SDL_InvalidParamError("fusion")
+	}
+	if !fusion.calibrating {
+		return SDL_SetError("Gyro calibration not started")
+	}
+	fusion.calibrating = false
+	if fusion.calibration_count == 0 {
+		return SDL_SetError("No gyro samples received during calibration")
+	}
+	for i, sum := range fusion.calibration_sum {
+		fusion.bias[i] = float32(sum / float64(fusion.calibration_count))
+	}
+	return true
+}
+
+/**
+ * Get the estimated gyroscope bias of a sensor fusion filter.
+ *
+ * This can be saved and restored with SDL_SetGyroFusionBias() to skip
+ * calibration the next time the same device is used.
+ *
+ * - fusion the filter to query.
+ * Returns the bias (X, Y, Z) in radians per second.
+ *
+ * See also SDL_SetGyroFusionBias
+ */
+func SDL_GetGyroFusionBias(fusion *SDL_GyroFusion) [3]float32 {
+	if fusion == nil {
+		SDL_InvalidParamError("fusion")
+		return [3]float32{}
+	}
+	return fusion.bias
+}
+
+/**
+ * Set the gyroscope bias of a sensor fusion filter.
+ *
+ * - fusion the filter to modify.
+ * - bias the bias (X, Y, Z) in radians per second.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_GetGyroFusionBias
+ */
+func SDL_SetGyroFusionBias(fusion *SDL_GyroFusion, bias [3]float32) bool {
+	if fusion == nil {
+		return SDL_InvalidParamError("fusion")
+	}
+	fusion.bias = bias
+	return true
+}
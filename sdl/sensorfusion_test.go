@@ -0,0 +1,115 @@
+package sdl
+
+import "math"
+import "testing"
+
+/* Sample rate of the simulated sensors */
+const testGyroFusionRate = 200
+
+/* Feed a filter with constant readings for a while, continuing from the given sample time */
+func testGyroFusionRun(t *testing.T, fusion *SDL_GyroFusion, gyro, accel [3]float32, seconds float64, timestamp uint64) uint64 {
+	t.Helper()
+	step := uint64(SDL_NS_PER_SECOND / testGyroFusionRate)
+	for range int(seconds * testGyroFusionRate) {
+		timestamp += step
+		if !SDL_UpdateGyroFusion(fusion, gyro[:], accel[:], timestamp) {
+			t.Fatalf("SDL_UpdateGyroFusion failed: %s", SDL_GetError())
+		}
+	}
+	return timestamp
+}
+
+/* The angle between two orientations, in radians */
+func testQuaternionAngle(a, b SDL_Quaternion) float64 {
+	dot := math.Abs(float64(a.X*b.X + a.Y*b.Y + a.Z*b.Z + a.W*b.W))
+	return 2 * math.Acos(math.Min(dot, 1))
+}
+
+func testVectorLength(v [3]float32) float64 {
+	return math.Sqrt(float64(v[0]*v[0] + v[1]*v[1] + v[2]*v[2]))
+}
+
+/* A rotation of angle radians around a unit axis */
+func testQuaternionFromAxisAngle(x, y, z, angle float64) SDL_Quaternion {
+	s, c := math.Sincos(angle / 2)
+	return SDL_Quaternion{float32(x * s), float32(y * s), float32(z * s), float32(c)}
+}
+
+/* Start a level filter, then tilt its orientation away from the gravity it measures */
+func testGyroFusionTilted(t *testing.T, tilt float64) (*SDL_GyroFusion, uint64) {
+	t.Helper()
+	fusion := SDL_CreateGyroFusion()
+	level := [3]float32{0, SDL_STANDARD_GRAVITY, 0}
+	timestamp := testGyroFusionRun(t, fusion, [3]float32{}, level, 0.1, 0)
+	if angle := testQuaternionAngle(SDL_GetGyroFusionOrientation(fusion), SDL_Quaternion{W: 1}); angle > 1e-4 {
+		t.Fatalf("a level device starts %g rad away from the identity", angle)
+	}
+	fusion.orientation = testQuaternionFromAxisAngle(0.6, 0, 0.8, tilt)
+	return fusion, timestamp
+}
+
+func TestGyroFusionStationaryConvergesToIdentity(t *testing.T) {
+	const tilt = 30 * math.Pi / 180
+	level := [3]float32{0, SDL_STANDARD_GRAVITY, 0}
+
+	/* While calibrating the bias isn't estimated, the correction alone decays like exp(-gain*t) */
+	fusion, timestamp := testGyroFusionTilted(t, tilt)
+	SDL_StartGyroFusionCalibration(fusion)
+	testGyroFusionRun(t, fusion, [3]float32{}, level, 20, timestamp)
+	if angle := testQuaternionAngle(SDL_GetGyroFusionOrientation(fusion), SDL_Quaternion{W: 1}); angle > tilt*math.Exp(-sdlGyroFusionDefaultGain*20)*2 {
+		t.Errorf("without bias estimation the orientation is %g rad away from the identity after 20s", angle)
+	}
+
+	/*
+	 * The bias estimate takes up part of the initial error and gives it back
+	 * slowly, with a time constant of gain/bias_gain, so the error first
+	 * drops quickly and then creeps to zero.
+	 */
+	fusion, timestamp = testGyroFusionTilted(t, tilt)
+	timestamp = testGyroFusionRun(t, fusion, [3]float32{}, level, 10, timestamp)
+	early := testQuaternionAngle(SDL_GetGyroFusionOrientation(fusion), SDL_Quaternion{W: 1})
+	earlyBias := testVectorLength(SDL_GetGyroFusionBias(fusion))
+	if early > 1*math.Pi/180 {
+		t.Errorf("after 10s the orientation is %g rad away from the identity", early)
+	}
+	testGyroFusionRun(t, fusion, [3]float32{}, level, 120, timestamp)
+	if angle := testQuaternionAngle(SDL_GetGyroFusionOrientation(fusion), SDL_Quaternion{W: 1}); angle > early/4 {
+		t.Errorf("after 130s the orientation is %g rad away from the identity, it was %g after 10s", angle, early)
+	}
+	if bias := testVectorLength(SDL_GetGyroFusionBias(fusion)); bias > earlyBias/4 {
+		t.Errorf("the bias of a gyroscope reading zero is %g rad/s after 130s, it was %g after 10s", bias, earlyBias)
+	}
+}
+
+func TestGyroFusionConstantAngularVelocity(t *testing.T) {
+	const rate = 1.5 /* rad/s */
+	const seconds = 2.0
+	tests := []struct {
+		name    string
+		axis    [3]float32
+		gain    float32
+		gravity [3]float32
+	}{
+		/* Turning around the vertical axis keeps gravity where it is, the correction stays idle */
+		{"yaw with gravity", [3]float32{0, 1, 0}, sdlGyroFusionDefaultGain, [3]float32{0, SDL_STANDARD_GRAVITY, 0}},
+		/* Without the correction any rotation is a plain integration */
+		{"pitch without gravity", [3]float32{1, 0, 0}, 0, [3]float32{0, SDL_STANDARD_GRAVITY, 0}},
+		{"roll without gravity", [3]float32{0, 0, 1}, 0, [3]float32{0, SDL_STANDARD_GRAVITY, 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fusion := SDL_CreateGyroFusion()
+			if !SDL_SetGyroFusionGain(fusion, test.gain) {
+				t.Fatalf("SDL_SetGyroFusionGain failed: %s", SDL_GetError())
+			}
+			timestamp := testGyroFusionRun(t, fusion, [3]float32{}, test.gravity, 0.1, 0)
+			gyro := [3]float32{test.axis[0] * rate, test.axis[1] * rate, test.axis[2] * rate}
+			testGyroFusionRun(t, fusion, gyro, test.gravity, seconds, timestamp)
+
+			want := testQuaternionFromAxisAngle(float64(test.axis[0]), float64(test.axis[1]), float64(test.axis[2]), rate*seconds)
+			if angle := testQuaternionAngle(SDL_GetGyroFusionOrientation(fusion), want); angle > 1e-3 {
+				t.Errorf("after %gs at %g rad/s the orientation is %g rad away from the expected rotation", seconds, rate, angle)
+			}
+		})
+	}
+}
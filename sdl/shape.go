@@ -0,0 +1,110 @@
+package sdl
+
+/*
+ * Shaped windows.
+ *
+ * The shape is an ARGB32 copy of the application surface kept in the window
+ * properties, where drivers find it and the application can read it back.
+ * Drivers with a compositor turn it into an input and output region. The
+ * in-memory drivers have no compositor, they clear the alpha of the window
+ * surface outside the shape when it is presented, so the saved frames show
+ * the shaped window.
+ */
+
+const SDL_PROP_WINDOW_SHAPE_POINTER = "SDL.window.shape"
+
+/**
+ * Set the shape of a transparent window.
+ *
+ * This sets the alpha channel of a transparent window and any fully
+ * transparent areas are also transparent to mouse clicks. If you are using
+ * something besides the SDL render API, then you are responsible for drawing
+ * the alpha channel of the window to match the shape alpha channel to get
+ * consistent cross-platform results.
+ *
+ * The shape is copied inside this function, so you can free it afterwards.
+ * If your shape surface changes, you should call SDL_SetWindowShape() again
+ * to update the window. This is an expensive operation, so should be done
+ * sparingly.
+ *
+ * The window must have been created with the SDL_WINDOW_TRANSPARENT flag.
+ *
+ * - window the window.
+ * - shape the surface representing the shape of the window, or nil to
+ *              remove any current shape.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_SetWindowShape(window *SDL_Window, shape *SDL_Surface) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if window.flags&SDL_WINDOW_TRANSPARENT == 0 {
+		return SDL_SetError("Window must be created with SDL_WINDOW_TRANSPARENT")
+	}
+
+	device := sdlGetVideoDevice()
+	if device.UpdateWindowShape == nil {
+		return SDL_Unsupported()
+	}
+	props := SDL_GetWindowProperties(window)
+	if props == 0 {
+		return false
+	}
+
+	if shape == nil {
+		SDL_ClearProperty(props, SDL_PROP_WINDOW_SHAPE_POINTER)
+		return device.UpdateWindowShape(device, window, nil)
+	}
+	surface := SDL_ConvertSurface(shape, SDL_PIXELFORMAT_ARGB32)
+	if surface == nil {
+		return false
+	}
+	cleanup := func(userdata any, value any) {
+		SDL_DestroySurface(value.(*SDL_Surface))
+	}
+	if !SDL_SetPointerPropertyWithCleanup(props, SDL_PROP_WINDOW_SHAPE_POINTER, surface, cleanup, nil) {
+		return false
+	}
+	return device.UpdateWindowShape(device, window, surface)
+}
+
+/* The current shape of a window, or nil if it has none */
+func sdlGetWindowShape(window *SDL_Window) *SDL_Surface {
+	if window.props == 0 {
+		return nil
+	}
+	shape, _ := SDL_GetPointerProperty(window.props, SDL_PROP_WINDOW_SHAPE_POINTER, nil).(*SDL_Surface)
+	return shape
+}
+
+/* The in-memory drivers apply the shape when presenting, see sdlApplyWindowShape() */
+func sdlUpdateMemoryWindowShape(device *sdlVideoDevice, window *SDL_Window, shape *SDL_Surface) bool {
+	return true
+}
+
+/*
+ * Make the window surface transparent where the shape is, scaling the shape
+ * to the surface size. Only surfaces with an alpha channel are changed.
+ */
+func sdlApplyWindowShape(window *SDL_Window) {
+	shape := sdlGetWindowShape(window)
+	surface := window.surface
+	if shape == nil || surface == nil || surface.details.Amask == 0 || shape.W == 0 || shape.H == 0 {
+		return
+	}
+
+	for y := 0; y < surface.H; y++ {
+		sy := y * shape.H / surface.H
+		for x := 0; x < surface.W; x++ {
+			sx := x * shape.W / surface.W
+			if shape.getColor(sx, sy).A == SDL_ALPHA_TRANSPARENT {
+				c := surface.getColor(x, y)
+				c.A = SDL_ALPHA_TRANSPARENT
+				surface.putColor(x, y, c)
+			}
+		}
+	}
+}
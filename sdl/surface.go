@@ -0,0 +1,1515 @@
+package sdl
+
+import "encoding/binary"
+import "math"
+import "runtime"
+
+/**
+ * The flags on an SDL_Surface.
+ *
+ * These are generally considered read-only.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_SurfaceFlags uint32
+
+const (
+	SDL_SURFACE_PREALLOCATED SDL_SurfaceFlags = 0x00000001 /**< Surface uses preallocated pixel memory */
+	SDL_SURFACE_LOCK_NEEDED  SDL_SurfaceFlags = 0x00000002 /**< Surface needs to be locked to access pixels */
+	SDL_SURFACE_LOCKED       SDL_SurfaceFlags = 0x00000004 /**< Surface is currently locked */
+	SDL_SURFACE_SIMD_ALIGNED SDL_SurfaceFlags = 0x00000008 /**< Surface uses pixel memory allocated with SDL_aligned_alloc() */
+)
+
+/**
+ * Evaluates to true if the surface needs to be locked before access.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_MUSTLOCK(S *SDL_Surface) bool {
+	return S.Flags&SDL_SURFACE_LOCK_NEEDED == SDL_SURFACE_LOCK_NEEDED
+}
+
+/**
+ * The scaling mode.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_ScaleMode int
+
+const (
+	SDL_SCALEMODE_NEAREST SDL_ScaleMode = iota /**< nearest pixel sampling */
+	SDL_SCALEMODE_LINEAR                       /**< linear filtering */
+)
+
+/**
+ * A collection of pixels used in software blitting.
+ *
+ * Pixels are arranged in memory in rows, with the top row first. Each row
+ * occupies an amount of memory given by the pitch (sometimes known as the row
+ * stride in non-SDL APIs).
+ *
+ * Within each row, pixels are arranged from left to right until the width is
+ * reached. Each pixel occupies a number of bits appropriate for its format,
+ * with most formats representing each pixel as one or more whole bytes (in
+ * some indexed formats, instead multiple pixels are packed into each byte),
+ * and a byte order given by the format. After encoding all pixels, any
+ * remaining bytes to reach the pitch are used as padding to reach a desired
+ * alignment, and have undefined contents.
+ *
+ * This structure should be treated as read-only, except for `Pixels`, which,
+ * if not nil, contains the raw pixel data for the surface.
+ *
+ * This struct is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateSurface
+ * See also SDL_DestroySurface
+ */
+type SDL_Surface struct {
+	Flags  SDL_SurfaceFlags /**< The flags of the surface, read-only */
+	Format SDL_PixelFormat  /**< The format of the surface, read-only */
+	W      int              /**< The width of the surface, read-only. */
+	H      int              /**< The height of the surface, read-only. */
+	Pitch  int              /**< The distance in bytes between rows of pixels, read-only */
+	Pixels []byte           /**< A pointer to the pixels of the surface, the pixels are writeable if non-nil */
+
+	Refcount int /**< Application reference count, used when freeing surface */
+
+	details    *SDL_PixelFormatDetails
+	palette    *SDL_Palette
+	clip_rect  SDL_Rect
+	color_key  uint32
+	has_key    bool
+	color_mod  SDL_Color /* R, G, B modulation, A is the alpha modulation */
+	blend_mode SDL_BlendMode
+
+	tracked_bytes int             /* pixel memory counted in SDL_MEMORY_SURFACES */
+	cleanup       runtime.Cleanup /* the safety net if the surface is never destroyed, see leaks.go */
+
+	blit_map sdlBlitMap /* the palette mapped to the last destination, for indexed surfaces */
+}
+
+/*
+ * Calculate the pitch of a surface of the given format and width, from the
+ * bits per pixel rounded up to whole bytes, and 4 byte aligned unless
+ * minimal is set. Like in the C library, the pitch and the size of the
+ * pixels must fit in 32 bits, returns false with an error set otherwise.
+ */
+func sdlCalculateSurfaceSize(format SDL_PixelFormat, width, height int, minimal bool) (pitch int, size int, ok bool) {
+	bits := SDL_BITSPERPIXEL(format)
+	if bits >= 8 {
+		bits = SDL_BYTESPERPIXEL(format) * 8
+	}
+	if bits > 0 && width > (math.MaxInt32-7)/bits {
+		return 0, 0, SDL_SetError("Surface width is too large")
+	}
+	pitch = (width*bits + 7) / 8
+	if !minimal {
+		if pitch > math.MaxInt32-3 {
+			return 0, 0, SDL_SetError("Surface width is too large")
+		}
+		pitch = (pitch + 3) &^ 3
+	}
+	if pitch > 0 && height > math.MaxInt32/pitch {
+		return 0, 0, SDL_SetError("Surface size is too large")
+	}
+	return pitch, pitch * height, true
+}
+
+func sdlInitializeSurface(surface *SDL_Surface) bool {
+	details := SDL_GetPixelFormatDetails(surface.Format)
+	if details == nil {
+		return false
+	}
+	surface.details = details
+	surface.Refcount = 1
+	surface.color_mod = SDL_Color{0xFF, 0xFF, 0xFF, 0xFF}
+	surface.clip_rect = SDL_Rect{0, 0, surface.W, surface.H}
+	surface.blend_mode = tern(SDL_ISPIXELFORMAT_ALPHA(surface.Format), SDL_BLENDMODE_BLEND, SDL_BLENDMODE_NONE)
+
+	if SDL_ISPIXELFORMAT_INDEXED(surface.Format) {
+		palette := SDL_CreatePalette(1 << SDL_BITSPERPIXEL(surface.Format))
+		if palette == nil {
+			return false
+		}
+		if palette.Ncolors == 2 {
+			/* Create a black and white bitmap palette */
+			palette.Colors[0] = SDL_Color{0xFF, 0xFF, 0xFF, 0xFF}
+			palette.Colors[1] = SDL_Color{0x00, 0x00, 0x00, 0xFF}
+		}
+		surface.palette = palette
+	}
+	return true
+}
+
+/**
+ * Allocate a new surface with a specific pixel format.
+ *
+ * The pixels of the new surface are initialized to zero.
+ *
+ * The surface must be freed with SDL_DestroySurface(). A surface the garbage
+ * collector reclaims without that is reported with a warning, with the site
+ * that created it.
+ *
+ * - width the width of the surface.
+ * - height the height of the surface.
+ * - format the SDL_PixelFormat for the new surface's pixel format.
+ * Returns the new SDL_Surface structure that is created or nil on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateSurfaceFrom
+ * See also SDL_DestroySurface
+ */
+func SDL_CreateSurface(width int, height int, format SDL_PixelFormat) *SDL_Surface {
+	if width < 0 {
+		SDL_InvalidParamError("width")
+		return nil
+	}
+	if height < 0 {
+		SDL_InvalidParamError("height")
+		return nil
+	}
+	if format == SDL_PIXELFORMAT_UNKNOWN {
+		SDL_InvalidParamError("format")
+		return nil
+	}
+
+	pitch, size, ok := sdlCalculateSurfaceSize(format, width, height, false)
+	if !ok {
+		return nil
+	}
+
+	surface := &SDL_Surface{
+		Format: format,
+		W:      width,
+		H:      height,
+		Pitch:  pitch,
+	}
+	if !sdlInitializeSurface(surface) {
+		return nil
+	}
+	surface.Pixels = make([]byte, size)
+	surface.tracked_bytes = len(surface.Pixels)
+	sdlTrackAllocation(SDL_MEMORY_SURFACES, surface.tracked_bytes)
+	sdlTrackObject("surface", surface)
+	tracked_bytes := surface.tracked_bytes
+	surface.cleanup = sdlAddObjectCleanup("surface", surface, func() {
+		sdlTrackFree(SDL_MEMORY_SURFACES, tracked_bytes)
+	})
+	return surface
+}
+
+// CreateSurface is SDL_CreateSurface() returning a Go error instead of nil.
+func CreateSurface(width int, height int, format SDL_PixelFormat) (*SDL_Surface, error) {
+	return errorFromObject(SDL_CreateSurface(width, height, format))
+}
+
+/**
+ * Allocate a new surface with a specific pixel format and existing pixel
+ * data.
+ *
+ * No copy is made of the pixel data. Pixel data is not managed automatically;
+ * you must keep the slice alive as long as the surface uses it.
+ *
+ * Pitch is the offset in bytes from one row of pixels to the next, e.g.
+ * `width*4` for `SDL_PIXELFORMAT_RGBA8888`.
+ *
+ * You may pass nil for pixels and 0 for pitch to create a surface that you
+ * will fill in with valid values later.
+ *
+ * - width the width of the surface.
+ * - height the height of the surface.
+ * - format the SDL_PixelFormat for the new surface's pixel format.
+ * - pixels the existing pixel data.
+ * - pitch the number of bytes between each row, including padding.
+ * Returns the new SDL_Surface structure that is created or nil on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateSurface
+ * See also SDL_DestroySurface
+ */
+func SDL_CreateSurfaceFrom(width int, height int, format SDL_PixelFormat, pixels []byte, pitch int) *SDL_Surface {
+	if width < 0 {
+		SDL_InvalidParamError("width")
+		return nil
+	}
+	if height < 0 {
+		SDL_InvalidParamError("height")
+		return nil
+	}
+	if format == SDL_PIXELFORMAT_UNKNOWN {
+		SDL_InvalidParamError("format")
+		return nil
+	}
+
+	if pitch == 0 && pixels == nil {
+		/* The application will fill these in later with valid values */
+	} else {
+		minimalPitch, _, ok := sdlCalculateSurfaceSize(format, width, height, true)
+		if !ok {
+			return nil
+		}
+		if pitch < minimalPitch {
+			SDL_InvalidParamError("pitch")
+			return nil
+		}
+		if height > 0 && pitch > math.MaxInt32/height {
+			SDL_SetError("Surface size is too large")
+			return nil
+		}
+		if height > 0 && len(pixels) < pitch*(height-1)+minimalPitch {
+			SDL_InvalidParamError("pixels")
+			return nil
+		}
+	}
+
+	surface := &SDL_Surface{
+		Flags:  SDL_SURFACE_PREALLOCATED,
+		Format: format,
+		W:      width,
+		H:      height,
+		Pitch:  pitch,
+		Pixels: pixels,
+	}
+	if !sdlInitializeSurface(surface) {
+		return nil
+	}
+	sdlTrackObject("surface", surface)
+	surface.cleanup = sdlAddObjectCleanup("surface", surface, nil)
+	return surface
+}
+
+/**
+ * Free a surface.
+ *
+ * It is safe to pass nil to this function.
+ *
+ * - surface the SDL_Surface to free.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateSurface
+ * See also SDL_CreateSurfaceFrom
+ */
+func SDL_DestroySurface(surface *SDL_Surface) {
+	if surface == nil {
+		return
+	}
+	surface.Refcount--
+	if surface.Refcount > 0 {
+		return
+	}
+
+	sdlUntrackObject(surface)
+	surface.cleanup.Stop()
+	SDL_DestroyPalette(surface.palette)
+	surface.palette = nil
+	surface.Pixels = nil
+	if surface.tracked_bytes > 0 {
+		sdlTrackFree(SDL_MEMORY_SURFACES, surface.tracked_bytes)
+		surface.tracked_bytes = 0
+	}
+}
+
+/**
+ * Set the palette used by a surface.
+ *
+ * A single palette can be shared with many surfaces.
+ *
+ * - surface the SDL_Surface structure to update.
+ * - palette the SDL_Palette structure to use.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreatePalette
+ * See also SDL_GetSurfacePalette
+ */
+func SDL_SetSurfacePalette(surface *SDL_Surface, palette *SDL_Palette) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if palette != nil && palette.Ncolors > (1<<SDL_BITSPERPIXEL(surface.Format)) {
+		return SDL_SetError("SDL_SetSurfacePalette() passed a palette that doesn't match the surface format")
+	}
+	if palette == surface.palette {
+		return true
+	}
+
+	SDL_DestroyPalette(surface.palette)
+	surface.palette = palette
+	if palette != nil {
+		palette.Refcount++
+	}
+	return true
+}
+
+/**
+ * Get the palette used by a surface.
+ *
+ * - surface the SDL_Surface structure to query.
+ * Returns a pointer to the palette used by the surface, or nil if there is
+ *          no palette used.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetSurfacePalette
+ */
+func SDL_GetSurfacePalette(surface *SDL_Surface) *SDL_Palette {
+	if surface == nil {
+		SDL_InvalidParamError("surface")
+		return nil
+	}
+	return surface.palette
+}
+
+/**
+ * Set up a surface for directly accessing the pixels.
+ *
+ * Between calls to SDL_LockSurface() / SDL_UnlockSurface(), you can write to
+ * and read from `surface.Pixels`, using the pixel format stored in
+ * `surface.Format`. Once you are done accessing the surface, you should use
+ * SDL_UnlockSurface() to release it.
+ *
+ * Not all surfaces require locking. If `SDL_MUSTLOCK(surface)` evaluates to
+ * false, then you can read and write to the surface at any time, and the
+ * pixel format of the surface will not change.
+ *
+ * - surface the SDL_Surface structure to be locked.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_MUSTLOCK
+ * See also SDL_UnlockSurface
+ */
+func SDL_LockSurface(surface *SDL_Surface) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	surface.Flags |= SDL_SURFACE_LOCKED
+	return true
+}
+
+/**
+ * Release a surface after directly accessing the pixels.
+ *
+ * - surface the SDL_Surface structure to be unlocked.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_LockSurface
+ */
+func SDL_UnlockSurface(surface *SDL_Surface) {
+	if surface == nil {
+		return
+	}
+	surface.Flags &^= SDL_SURFACE_LOCKED
+}
+
+/**
+ * Set the color key (transparent pixel) in a surface.
+ *
+ * The color key defines a pixel value that will be treated as transparent in
+ * a blit. For example, one can use this to specify that cyan pixels should be
+ * considered transparent, and therefore not rendered.
+ *
+ * It is a pixel of the format used by the surface, as generated by
+ * SDL_MapRGB().
+ *
+ * - surface the SDL_Surface structure to update.
+ * - enabled true to enable color key, false to disable color key.
+ * - key the transparent pixel.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetSurfaceColorKey
+ * See also SDL_SurfaceHasColorKey
+ */
+func SDL_SetSurfaceColorKey(surface *SDL_Surface, enabled bool, key uint32) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if surface.palette != nil && key >= uint32(surface.palette.Ncolors) {
+		return SDL_InvalidParamError("key")
+	}
+	surface.has_key = enabled
+	surface.color_key = key
+	return true
+}
+
+/**
+ * Returns whether the surface has a color key.
+ *
+ * It is safe to pass a nil `surface` here; it will return false.
+ *
+ * - surface the SDL_Surface structure to query.
+ * Returns true if the surface has a color key, false otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetSurfaceColorKey
+ * See also SDL_GetSurfaceColorKey
+ */
+func SDL_SurfaceHasColorKey(surface *SDL_Surface) bool {
+	return surface != nil && surface.has_key
+}
+
+/**
+ * Get the color key (transparent pixel) for a surface.
+ *
+ * The color key is a pixel of the format used by the surface, as generated by
+ * SDL_MapRGB().
+ *
+ * If the surface doesn't have color key enabled this function returns false.
+ *
+ * - surface the SDL_Surface structure to query.
+ * - key a pointer filled in with the transparent pixel.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetSurfaceColorKey
+ * See also SDL_SurfaceHasColorKey
+ */
+func SDL_GetSurfaceColorKey(surface *SDL_Surface, key *uint32) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if !surface.has_key {
+		return SDL_SetError("Surface doesn't have a colorkey")
+	}
+	if key != nil {
+		*key = surface.color_key
+	}
+	return true
+}
+
+/**
+ * Set an additional color value multiplied into blit operations.
+ *
+ * When this surface is blitted, during the blit operation each source color
+ * channel is modulated by the appropriate color value according to the
+ * following formula:
+ *
+ * `srcC = srcC * (color / 255)`
+ *
+ * - surface the SDL_Surface structure to update.
+ * - r the red color value multiplied into blit operations.
+ * - g the green color value multiplied into blit operations.
+ * - b the blue color value multiplied into blit operations.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetSurfaceColorMod
+ * See also SDL_SetSurfaceAlphaMod
+ */
+func SDL_SetSurfaceColorMod(surface *SDL_Surface, r, g, b uint8) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	surface.color_mod.R, surface.color_mod.G, surface.color_mod.B = r, g, b
+	return true
+}
+
+/**
+ * Get the additional color value multiplied into blit operations.
+ *
+ * - surface the SDL_Surface structure to query.
+ * - r a pointer filled in with the current red color value.
+ * - g a pointer filled in with the current green color value.
+ * - b a pointer filled in with the current blue color value.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetSurfaceAlphaMod
+ * See also SDL_SetSurfaceColorMod
+ */
+func SDL_GetSurfaceColorMod(surface *SDL_Surface, r, g, b *uint8) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if r != nil {
+		*r = surface.color_mod.R
+	}
+	if g != nil {
+		*g = surface.color_mod.G
+	}
+	if b != nil {
+		*b = surface.color_mod.B
+	}
+	return true
+}
+
+/**
+ * Set an additional alpha value used in blit operations.
+ *
+ * When this surface is blitted, during the blit operation the source alpha
+ * value is modulated by this alpha value according to the following formula:
+ *
+ * `srcA = srcA * (alpha / 255)`
+ *
+ * - surface the SDL_Surface structure to update.
+ * - alpha the alpha value multiplied into blit operations.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetSurfaceAlphaMod
+ * See also SDL_SetSurfaceColorMod
+ */
+func SDL_SetSurfaceAlphaMod(surface *SDL_Surface, alpha uint8) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	surface.color_mod.A = alpha
+	return true
+}
+
+/**
+ * Get the additional alpha value used in blit operations.
+ *
+ * - surface the SDL_Surface structure to query.
+ * - alpha a pointer filled in with the current alpha value.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetSurfaceColorMod
+ * See also SDL_SetSurfaceAlphaMod
+ */
+func SDL_GetSurfaceAlphaMod(surface *SDL_Surface, alpha *uint8) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if alpha != nil {
+		*alpha = surface.color_mod.A
+	}
+	return true
+}
+
+/**
+ * Set the blend mode used for blit operations.
+ *
+ * To copy a surface to another surface (or texture) without blending with the
+ * existing data, the blendmode of the SOURCE surface should be set to
+ * `SDL_BLENDMODE_NONE`.
+ *
+ * - surface the SDL_Surface structure to update.
+ * - blendMode the SDL_BlendMode to use for blit blending.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetSurfaceBlendMode
+ */
+func SDL_SetSurfaceBlendMode(surface *SDL_Surface, blendMode SDL_BlendMode) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	switch blendMode {
+	case SDL_BLENDMODE_NONE, SDL_BLENDMODE_BLEND, SDL_BLENDMODE_ADD, SDL_BLENDMODE_MOD, SDL_BLENDMODE_MUL:
+	default:
+		return SDL_InvalidParamError("blendMode")
+	}
+	surface.blend_mode = blendMode
+	return true
+}
+
+/**
+ * Get the blend mode used for blit operations.
+ *
+ * - surface the SDL_Surface structure to query.
+ * - blendMode a pointer filled in with the current SDL_BlendMode.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetSurfaceBlendMode
+ */
+func SDL_GetSurfaceBlendMode(surface *SDL_Surface, blendMode *SDL_BlendMode) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if blendMode != nil {
+		*blendMode = surface.blend_mode
+	}
+	return true
+}
+
+/**
+ * Set the clipping rectangle for a surface.
+ *
+ * When `surface` is the destination of a blit, only the area within the clip
+ * rectangle is drawn into.
+ *
+ * Note that blits are automatically clipped to the edges of the source and
+ * destination surfaces.
+ *
+ * - surface the SDL_Surface structure to be clipped.
+ * - rect the SDL_Rect structure representing the clipping rectangle, or
+ *             nil to disable clipping.
+ * Returns true if the rectangle intersects the surface, otherwise false and
+ *          blits will be completely clipped.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetSurfaceClipRect
+ */
+func SDL_SetSurfaceClipRect(surface *SDL_Surface, rect *SDL_Rect) bool {
+	if surface == nil {
+		return false
+	}
+
+	full_rect := SDL_Rect{0, 0, surface.W, surface.H}
+	if rect == nil {
+		surface.clip_rect = full_rect
+		return true
+	}
+	return SDL_GetRectIntersection(rect, &full_rect, &surface.clip_rect)
+}
+
+/**
+ * Get the clipping rectangle for a surface.
+ *
+ * When `surface` is the destination of a blit, only the area within the clip
+ * rectangle is drawn into.
+ *
+ * - surface the SDL_Surface structure representing the surface to be
+ *                clipped.
+ * - rect an SDL_Rect structure filled in with the clipping rectangle for
+ *             the surface.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetSurfaceClipRect
+ */
+func SDL_GetSurfaceClipRect(surface *SDL_Surface, rect *SDL_Rect) bool {
+	if surface == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if rect == nil {
+		return SDL_InvalidParamError("rect")
+	}
+	*rect = surface.clip_rect
+	return true
+}
+
+/* Read the raw pixel value at x, y. The coordinates must be in range. */
+func (surface *SDL_Surface) getPixel(x, y int) uint32 {
+	bpp := int(surface.details.Bytes_per_pixel)
+	p := surface.Pixels[y*surface.Pitch+x*bpp:]
+	switch bpp {
+	case 1:
+		return uint32(p[0])
+	case 2:
+		return uint32(binary.NativeEndian.Uint16(p))
+	default:
+		return binary.NativeEndian.Uint32(p)
+	}
+}
+
+/* Write the raw pixel value at x, y. The coordinates must be in range. */
+func (surface *SDL_Surface) putPixel(x, y int, pixel uint32) {
+	bpp := int(surface.details.Bytes_per_pixel)
+	p := surface.Pixels[y*surface.Pitch+x*bpp:]
+	switch bpp {
+	case 1:
+		p[0] = uint8(pixel)
+	case 2:
+		binary.NativeEndian.PutUint16(p, uint16(pixel))
+	default:
+		binary.NativeEndian.PutUint32(p, pixel)
+	}
+}
+
+/* Read the color at x, y. The coordinates must be in range. */
+func (surface *SDL_Surface) getColor(x, y int) SDL_Color {
+	r, g, b, a := SDL_GetRGBA(surface.getPixel(x, y), surface.details, surface.palette)
+	return SDL_Color{r, g, b, a}
+}
+
+/* Write the color at x, y. The coordinates must be in range. */
+func (surface *SDL_Surface) putColor(x, y int, c SDL_Color) {
+	surface.putPixel(x, y, SDL_MapRGBA(surface.details, surface.palette, c.R, c.G, c.B, c.A))
+}
+
+/**
+ * Map an RGB triple to an opaque pixel value for a surface.
+ *
+ * This function maps the RGB color value to the specified pixel format and
+ * returns the pixel value best approximating the given RGB color value for
+ * the given pixel format.
+ *
+ * If the surface has a palette, the index of the closest matching color in
+ * the palette will be returned.
+ *
+ * If the surface pixel format has an alpha component it will be returned as
+ * all 1 bits (fully opaque).
+ *
+ * - surface the surface to use for the pixel format and palette.
+ * - r the red component of the pixel in the range 0-255.
+ * - g the green component of the pixel in the range 0-255.
+ * - b the blue component of the pixel in the range 0-255.
+ * Returns a pixel value.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_MapSurfaceRGBA
+ */
+func SDL_MapSurfaceRGB(surface *SDL_Surface, r, g, b uint8) uint32 {
+	return SDL_MapSurfaceRGBA(surface, r, g, b, SDL_ALPHA_OPAQUE)
+}
+
+/**
+ * Map an RGBA quadruple to a pixel value for a surface.
+ *
+ * This function maps the RGBA color value to the specified pixel format and
+ * returns the pixel value best approximating the given RGBA color value for
+ * the given pixel format.
+ *
+ * If the surface pixel format has no alpha component the alpha value will be
+ * ignored (as it will be in formats with a palette).
+ *
+ * If the surface has a palette, the index of the closest matching color in
+ * the palette will be returned.
+ *
+ * - surface the surface to use for the pixel format and palette.
+ * - r the red component of the pixel in the range 0-255.
+ * - g the green component of the pixel in the range 0-255.
+ * - b the blue component of the pixel in the range 0-255.
+ * - a the alpha component of the pixel in the range 0-255.
+ * Returns a pixel value.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_MapSurfaceRGB
+ */
+func SDL_MapSurfaceRGBA(surface *SDL_Surface, r, g, b, a uint8) uint32 {
+	if surface == nil {
+		SDL_InvalidParamError("surface")
+		return 0
+	}
+	return SDL_MapRGBA(surface.details, surface.palette, r, g, b, a)
+}
+
+/**
+ * Retrieves a single pixel from a surface.
+ *
+ * This function prioritizes correctness over speed: it is suitable for unit
+ * tests, but is not intended for use in a game engine.
+ *
+ * Like SDL_GetRGBA, this uses the entire 0..255 range when converting color
+ * components from pixel formats with less than 8 bits per RGB component.
+ *
+ * - surface the surface to read.
+ * - x the horizontal coordinate, 0 <= x < width.
+ * - y the vertical coordinate, 0 <= y < height.
+ * - r a pointer filled in with the red channel, 0-255, or nil to ignore
+ *          this channel.
+ * - g a pointer filled in with the green channel, 0-255, or nil to
+ *          ignore this channel.
+ * - b a pointer filled in with the blue channel, 0-255, or nil to
+ *          ignore this channel.
+ * - a a pointer filled in with the alpha channel, 0-255, or nil to ignore
+ *          this channel.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_ReadSurfacePixel(surface *SDL_Surface, x, y int, r, g, b, a *uint8) bool {
+	if surface == nil || surface.Pixels == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if x < 0 || x >= surface.W {
+		return SDL_InvalidParamError("x")
+	}
+	if y < 0 || y >= surface.H {
+		return SDL_InvalidParamError("y")
+	}
+
+	c := surface.getColor(x, y)
+	if r != nil {
+		*r = c.R
+	}
+	if g != nil {
+		*g = c.G
+	}
+	if b != nil {
+		*b = c.B
+	}
+	if a != nil {
+		*a = c.A
+	}
+	return true
+}
+
+/**
+ * Writes a single pixel to a surface.
+ *
+ * This function prioritizes correctness over speed: it is suitable for unit
+ * tests, but is not intended for use in a game engine.
+ *
+ * Like SDL_MapRGBA, this uses the entire 0..255 range when converting color
+ * components from pixel formats with less than 8 bits per RGB component.
+ *
+ * - surface the surface to write.
+ * - x the horizontal coordinate, 0 <= x < width.
+ * - y the vertical coordinate, 0 <= y < height.
+ * - r the red channel value, 0-255.
+ * - g the green channel value, 0-255.
+ * - b the blue channel value, 0-255.
+ * - a the alpha channel value, 0-255.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_WriteSurfacePixel(surface *SDL_Surface, x, y int, r, g, b, a uint8) bool {
+	if surface == nil || surface.Pixels == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if x < 0 || x >= surface.W {
+		return SDL_InvalidParamError("x")
+	}
+	if y < 0 || y >= surface.H {
+		return SDL_InvalidParamError("y")
+	}
+
+	surface.putColor(x, y, SDL_Color{r, g, b, a})
+	return true
+}
+
+/**
+ * Perform a fast fill of a rectangle with a specific color.
+ *
+ * `color` should be a pixel of the format used by the surface, and can be
+ * generated by SDL_MapRGB() or SDL_MapRGBA(). If the color value contains an
+ * alpha component then the destination is simply filled with that alpha
+ * information, no blending takes place.
+ *
+ * If there is a clip rectangle set on the destination (set via
+ * SDL_SetSurfaceClipRect()), then this function will fill based on the
+ * intersection of the clip rectangle and `rect`.
+ *
+ * - dst the SDL_Surface structure that is the drawing target.
+ * - rect the SDL_Rect structure representing the rectangle to fill, or
+ *             nil to fill the entire surface.
+ * - color the color to fill with.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_FillSurfaceRects
+ */
+func SDL_FillSurfaceRect(dst *SDL_Surface, rect *SDL_Rect, color uint32) bool {
+	if dst == nil {
+		return SDL_InvalidParamError("dst")
+	}
+
+	/* If 'rect' == nil, then fill the whole surface */
+	if rect == nil {
+		rect = &dst.clip_rect
+		/* Don't attempt to fill if the surface's clip_rect is empty */
+		if SDL_RectEmpty(rect) {
+			return true
+		}
+	}
+	return SDL_FillSurfaceRects(dst, []SDL_Rect{*rect}, color)
+}
+
+// FillSurfaceRect is SDL_FillSurfaceRect() returning a Go error instead of a
+// boolean.
+func FillSurfaceRect(dst *SDL_Surface, rect *SDL_Rect, color uint32) error {
+	return errorFromResult(SDL_FillSurfaceRect(dst, rect, color))
+}
+
+/**
+ * Perform a fast fill of a set of rectangles with a specific color.
+ *
+ * `color` should be a pixel of the format used by the surface, and can be
+ * generated by SDL_MapRGB() or SDL_MapRGBA(). If the color value contains an
+ * alpha component then the destination is simply filled with that alpha
+ * information, no blending takes place.
+ *
+ * If there is a clip rectangle set on the destination (set via
+ * SDL_SetSurfaceClipRect()), then this function will fill based on the
+ * intersection of the clip rectangle and `rect`.
+ *
+ * - dst the SDL_Surface structure that is the drawing target.
+ * - rects an array of SDL_Rects representing the rectangles to fill.
+ * - color the color to fill with.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_FillSurfaceRect
+ */
+func SDL_FillSurfaceRects(dst *SDL_Surface, rects []SDL_Rect, color uint32) bool {
+	if dst == nil {
+		return SDL_InvalidParamError("dst")
+	}
+	if dst.Pixels == nil {
+		return SDL_SetError("SDL_FillSurfaceRects(): You must lock the surface")
+	}
+
+	for i := range rects {
+		var clipped SDL_Rect
+		if !SDL_GetRectIntersection(&rects[i], &dst.clip_rect, &clipped) {
+			continue
+		}
+		for y := clipped.Y; y < clipped.Y+clipped.H; y++ {
+			for x := clipped.X; x < clipped.X+clipped.W; x++ {
+				dst.putPixel(x, y, color)
+			}
+		}
+	}
+	return true
+}
+
+/* Multiply two 8-bit values as if they were in the range [0, 1] */
+func sdlMul8(a, b uint32) uint32 {
+	return (a*b + 127) / 255
+}
+
+/*
+ * Blend a source color into a destination color, see the descriptions of
+ * the SDL_BlendMode values.
+ */
+func sdlBlendColor(src, dst SDL_Color, mode SDL_BlendMode) SDL_Color {
+	sR, sG, sB, sA := uint32(src.R), uint32(src.G), uint32(src.B), uint32(src.A)
+	dR, dG, dB, dA := uint32(dst.R), uint32(dst.G), uint32(dst.B), uint32(dst.A)
+
+	switch mode {
+	case SDL_BLENDMODE_BLEND:
+		dR = sdlMul8(sR, sA) + sdlMul8(dR, 255-sA)
+		dG = sdlMul8(sG, sA) + sdlMul8(dG, 255-sA)
+		dB = sdlMul8(sB, sA) + sdlMul8(dB, 255-sA)
+		dA = sA + sdlMul8(dA, 255-sA)
+	case SDL_BLENDMODE_ADD:
+		dR = min(sdlMul8(sR, sA)+dR, 255)
+		dG = min(sdlMul8(sG, sA)+dG, 255)
+		dB = min(sdlMul8(sB, sA)+dB, 255)
+	case SDL_BLENDMODE_MOD:
+		dR = sdlMul8(sR, dR)
+		dG = sdlMul8(sG, dG)
+		dB = sdlMul8(sB, dB)
+	case SDL_BLENDMODE_MUL:
+		dR = min(sdlMul8(sR, dR)+sdlMul8(dR, 255-sA), 255)
+		dG = min(sdlMul8(sG, dG)+sdlMul8(dG, 255-sA), 255)
+		dB = min(sdlMul8(sB, dB)+sdlMul8(dB, 255-sA), 255)
+	default:
+		return src
+	}
+	return SDL_Color{uint8(dR), uint8(dG), uint8(dB), uint8(dA)}
+}
+
+/*
+ * Blend the source color (already color keyed and sampled) into the
+ * destination pixel at x, y, applying the modulation and blend mode of src.
+ */
+func (dst *SDL_Surface) blendColor(x, y int, c SDL_Color, src *SDL_Surface) {
+	mod := src.color_mod
+	if mod != (SDL_Color{0xFF, 0xFF, 0xFF, 0xFF}) {
+		c.R = uint8(sdlMul8(uint32(c.R), uint32(mod.R)))
+		c.G = uint8(sdlMul8(uint32(c.G), uint32(mod.G)))
+		c.B = uint8(sdlMul8(uint32(c.B), uint32(mod.B)))
+		c.A = uint8(sdlMul8(uint32(c.A), uint32(mod.A)))
+	}
+	if src.blend_mode != SDL_BLENDMODE_NONE {
+		c = sdlBlendColor(c, dst.getColor(x, y), src.blend_mode)
+	}
+	dst.putColor(x, y, c)
+}
+
+/* Check that both surfaces are usable for a blit */
+func sdlValidateBlit(src, dst *SDL_Surface) bool {
+	if src == nil || src.Pixels == nil {
+		return SDL_InvalidParamError("src")
+	}
+	if dst == nil || dst.Pixels == nil {
+		return SDL_InvalidParamError("dst")
+	}
+	if src.Flags&SDL_SURFACE_LOCKED != 0 || dst.Flags&SDL_SURFACE_LOCKED != 0 {
+		return SDL_SetError("Surfaces must not be locked during blit")
+	}
+	return true
+}
+
+/**
+ * Performs a fast blit from the source surface to the destination surface.
+ *
+ * This assumes that the source and destination rectangles are the same size.
+ * If either `srcrect` or `dstrect` are nil, the entire surface (`src` or
+ * `dst`) is copied. The width and height in `dstrect` are ignored, the final
+ * blit is clipped to the source and the clip rectangle of the destination.
+ *
+ * The blit function should not be called on a locked surface.
+ *
+ * The blit semantics for surfaces with and without blending and colorkey are
+ * defined as follows:
+ *
+ * ```
+ *    RGBA->RGB:
+ *      Source surface blend mode set to SDL_BLENDMODE_BLEND:
+ *       alpha-blend (using the source alpha-channel and per-surface alpha)
+ *       SDL_SRCCOLORKEY ignored.
+ *     Source surface blend mode set to SDL_BLENDMODE_NONE:
+ *       copy RGB.
+ *       if SDL_SRCCOLORKEY set, only copy the pixels that do not match the
+ *       RGB values of the source color key, ignoring alpha in the
+ *       comparison.
+ *
+ *   RGB->RGBA:
+ *     Source surface blend mode set to SDL_BLENDMODE_BLEND:
+ *       alpha-blend (using the source per-surface alpha)
+ *     Source surface blend mode set to SDL_BLENDMODE_NONE:
+ *       copy RGB, set destination alpha to source per-surface alpha value.
+ *     both:
+ *       if SDL_SRCCOLORKEY set, only copy the pixels that do not match the
+ *       source color key.
+ *
+ *   RGBA->RGBA:
+ *     Source surface blend mode set to SDL_BLENDMODE_BLEND:
+ *       alpha-blend (using the source alpha-channel and per-surface alpha)
+ *       SDL_SRCCOLORKEY ignored.
+ *     Source surface blend mode set to SDL_BLENDMODE_NONE:
+ *       copy all of RGBA to the destination.
+ *       if SDL_SRCCOLORKEY set, only copy the pixels that do not match the
+ *       RGB values of the source color key, ignoring alpha in the
+ *       comparison.
+ *
+ *   RGB->RGB:
+ *     Source surface blend mode set to SDL_BLENDMODE_BLEND:
+ *       alpha-blend (using the source per-surface alpha)
+ *     Source surface blend mode set to SDL_BLENDMODE_NONE:
+ *       copy RGB.
+ *     both:
+ *       if SDL_SRCCOLORKEY set, only copy the pixels that do not match the
+ *       source color key.
+ * ```
+ *
+ * - src the SDL_Surface structure to be copied from.
+ * - srcrect the SDL_Rect structure representing the rectangle to be
+ *                copied, or nil to copy the entire surface.
+ * - dst the SDL_Surface structure that is the blit target.
+ * - dstrect the SDL_Rect structure representing the x and y position in
+ *                the destination surface, or nil for (0,0). The width and
+ *                height are ignored.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_BlitSurfaceScaled
+ */
+func SDL_BlitSurface(src *SDL_Surface, srcrect *SDL_Rect, dst *SDL_Surface, dstrect *SDL_Rect) bool {
+	if !sdlValidateBlit(src, dst) {
+		return false
+	}
+
+	/* Clip the source rectangle to the source surface */
+	src_bounds := SDL_Rect{0, 0, src.W, src.H}
+	var r_src SDL_Rect
+	if srcrect == nil {
+		r_src = src_bounds
+	} else if !SDL_GetRectIntersection(srcrect, &src_bounds, &r_src) {
+		return true
+	}
+
+	/* Move the destination along with the source clipping */
+	r_dst := SDL_Rect{X: r_src.X, Y: r_src.Y, W: r_src.W, H: r_src.H}
+	if dstrect != nil {
+		r_dst.X, r_dst.Y = dstrect.X, dstrect.Y
+		if srcrect != nil {
+			r_dst.X += r_src.X - srcrect.X
+			r_dst.Y += r_src.Y - srcrect.Y
+		}
+	} else {
+		r_dst.X, r_dst.Y = 0, 0
+		if srcrect != nil {
+			r_dst.X = r_src.X - srcrect.X
+			r_dst.Y = r_src.Y - srcrect.Y
+		}
+	}
+
+	/* Clip the destination rectangle against the clip rectangle */
+	var clipped SDL_Rect
+	if !SDL_GetRectIntersection(&r_dst, &dst.clip_rect, &clipped) {
+		return true
+	}
+
+	sx0 := r_src.X + (clipped.X - r_dst.X)
+	sy0 := r_src.Y + (clipped.Y - r_dst.Y)
+	if sdlBlitIndexed(src, sx0, sy0, dst, &clipped) {
+		return true
+	}
+	for y := 0; y < clipped.H; y++ {
+		for x := 0; x < clipped.W; x++ {
+			if src.has_key && src.getPixel(sx0+x, sy0+y) == src.color_key {
+				continue
+			}
+			dst.blendColor(clipped.X+x, clipped.Y+y, src.getColor(sx0+x, sy0+y), src)
+		}
+	}
+	return true
+}
+
+// BlitSurface is SDL_BlitSurface() returning a Go error instead of a boolean.
+func BlitSurface(src *SDL_Surface, srcrect *SDL_Rect, dst *SDL_Surface, dstrect *SDL_Rect) error {
+	return errorFromResult(SDL_BlitSurface(src, srcrect, dst, dstrect))
+}
+
+/* Sample the source with bilinear filtering at fractional coordinates */
+func (src *SDL_Surface) sampleLinear(fx, fy float64, bounds *SDL_Rect) (SDL_Color, bool) {
+	fx -= 0.5
+	fy -= 0.5
+	x0 := int(math.Floor(fx))
+	y0 := int(math.Floor(fy))
+	wx := fx - float64(x0)
+	wy := fy - float64(y0)
+
+	clampX := func(x int) int { return max(bounds.X, min(x, bounds.X+bounds.W-1)) }
+	clampY := func(y int) int { return max(bounds.Y, min(y, bounds.Y+bounds.H-1)) }
+
+	var acc [4]float64
+	var weight float64
+	for _, s := range [4]struct {
+		x, y int
+		w    float64
+	}{
+		{x0, y0, (1 - wx) * (1 - wy)},
+		{x0 + 1, y0, wx * (1 - wy)},
+		{x0, y0 + 1, (1 - wx) * wy},
+		{x0 + 1, y0 + 1, wx * wy},
+	} {
+		x, y := clampX(s.x), clampY(s.y)
+		if src.has_key && src.getPixel(x, y) == src.color_key {
+			continue
+		}
+		c := src.getColor(x, y)
+		acc[0] += float64(c.R) * s.w
+		acc[1] += float64(c.G) * s.w
+		acc[2] += float64(c.B) * s.w
+		acc[3] += float64(c.A) * s.w
+		weight += s.w
+	}
+	if weight == 0 {
+		return SDL_Color{}, false
+	}
+	return SDL_Color{
+		uint8(acc[0]/weight + 0.5),
+		uint8(acc[1]/weight + 0.5),
+		uint8(acc[2]/weight + 0.5),
+		uint8(acc[3]/weight + 0.5),
+	}, true
+}
+
+/**
+ * Perform a scaled blit to a destination surface, which may be of a different
+ * format.
+ *
+ * - src the SDL_Surface structure to be copied from.
+ * - srcrect the SDL_Rect structure representing the rectangle to be
+ *                copied, or nil to copy the entire surface.
+ * - dst the SDL_Surface structure that is the blit target.
+ * - dstrect the SDL_Rect structure representing the target rectangle in
+ *                the destination surface, or nil to fill the entire
+ *                destination surface.
+ * - scaleMode the SDL_ScaleMode to be used.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_BlitSurface
+ */
+func SDL_BlitSurfaceScaled(src *SDL_Surface, srcrect *SDL_Rect, dst *SDL_Surface, dstrect *SDL_Rect, scaleMode SDL_ScaleMode) bool {
+	if !sdlValidateBlit(src, dst) {
+		return false
+	}
+	if scaleMode != SDL_SCALEMODE_NEAREST && scaleMode != SDL_SCALEMODE_LINEAR {
+		return SDL_InvalidParamError("scaleMode")
+	}
+
+	full_src := SDL_Rect{0, 0, src.W, src.H}
+	if srcrect == nil {
+		srcrect = &full_src
+	}
+	full_dst := SDL_Rect{0, 0, dst.W, dst.H}
+	if dstrect == nil {
+		dstrect = &full_dst
+	}
+	if SDL_RectEmpty(srcrect) || SDL_RectEmpty(dstrect) {
+		return true
+	}
+
+	/* Source pixels outside of the surface are never sampled */
+	var src_bounds SDL_Rect
+	if !SDL_GetRectIntersection(srcrect, &full_src, &src_bounds) {
+		return true
+	}
+
+	var clipped SDL_Rect
+	if !SDL_GetRectIntersection(dstrect, &dst.clip_rect, &clipped) {
+		return true
+	}
+
+	scaleX := float64(srcrect.W) / float64(dstrect.W)
+	scaleY := float64(srcrect.H) / float64(dstrect.H)
+	for y := clipped.Y; y < clipped.Y+clipped.H; y++ {
+		/* Sample at the center of the destination pixel */
+		fy := float64(srcrect.Y) + (float64(y-dstrect.Y)+0.5)*scaleY
+		for x := clipped.X; x < clipped.X+clipped.W; x++ {
+			fx := float64(srcrect.X) + (float64(x-dstrect.X)+0.5)*scaleX
+
+			if scaleMode == SDL_SCALEMODE_LINEAR {
+				if c, ok := src.sampleLinear(fx, fy, &src_bounds); ok {
+					dst.blendColor(x, y, c, src)
+				}
+				continue
+			}
+
+			sx, sy := int(fx), int(fy)
+			if sx < src_bounds.X || sx >= src_bounds.X+src_bounds.W ||
+				sy < src_bounds.Y || sy >= src_bounds.Y+src_bounds.H {
+				continue
+			}
+			if src.has_key && src.getPixel(sx, sy) == src.color_key {
+				continue
+			}
+			dst.blendColor(x, y, src.getColor(sx, sy), src)
+		}
+	}
+	return true
+}
+
+// BlitSurfaceScaled is SDL_BlitSurfaceScaled() returning a Go error instead
+// of a boolean.
+func BlitSurfaceScaled(src *SDL_Surface, srcrect *SDL_Rect, dst *SDL_Surface, dstrect *SDL_Rect, scaleMode SDL_ScaleMode) error {
+	return errorFromResult(SDL_BlitSurfaceScaled(src, srcrect, dst, dstrect, scaleMode))
+}
+
+/**
+ * Perform a tiled blit to a destination surface, which may be of a different
+ * format.
+ *
+ * The pixels in `srcrect` will be repeated as many times as needed to
+ * completely fill `dstrect`.
+ *
+ * - src the SDL_Surface structure to be copied from.
+ * - srcrect the SDL_Rect structure representing the rectangle to be
+ *                copied, or nil to copy the entire surface.
+ * - dst the SDL_Surface structure that is the blit target.
+ * - dstrect the SDL_Rect structure representing the target rectangle in
+ *                the destination surface, or nil to fill the entire surface.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_BlitSurface
+ */
+func SDL_BlitSurfaceTiled(src *SDL_Surface, srcrect *SDL_Rect, dst *SDL_Surface, dstrect *SDL_Rect) bool {
+	return SDL_BlitSurfaceTiledWithScale(src, srcrect, 1.0, SDL_SCALEMODE_NEAREST, dst, dstrect)
+}
+
+/**
+ * Perform a scaled and tiled blit to a destination surface, which may be of a
+ * different format.
+ *
+ * The pixels in `srcrect` will be scaled and repeated as many times as needed
+ * to completely fill `dstrect`.
+ *
+ * - src the SDL_Surface structure to be copied from.
+ * - srcrect the SDL_Rect structure representing the rectangle to be
+ *                copied, or nil to copy the entire surface.
+ * - scale the scale used to transform srcrect into the destination
+ *              rectangle, e.g. a 32x32 texture with a scale of 2 would fill
+ *              64x64 tiles.
+ * - scaleMode scale algorithm to be used.
+ * - dst the SDL_Surface structure that is the blit target.
+ * - dstrect the SDL_Rect structure representing the target rectangle in
+ *                the destination surface, or nil to fill the entire surface.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_BlitSurface
+ */
+func SDL_BlitSurfaceTiledWithScale(src *SDL_Surface, srcrect *SDL_Rect, scale float32, scaleMode SDL_ScaleMode, dst *SDL_Surface, dstrect *SDL_Rect) bool {
+	if !sdlValidateBlit(src, dst) {
+		return false
+	}
+	if scale <= 0.0 {
+		return SDL_InvalidParamError("scale")
+	}
+
+	full_src := SDL_Rect{0, 0, src.W, src.H}
+	if srcrect == nil {
+		srcrect = &full_src
+	}
+	full_dst := SDL_Rect{0, 0, dst.W, dst.H}
+	if dstrect == nil {
+		dstrect = &full_dst
+	}
+	if SDL_RectEmpty(srcrect) || SDL_RectEmpty(dstrect) {
+		return true
+	}
+
+	/* Tiles are clipped to the target rectangle, on top of the clip rect */
+	saved_clip := dst.clip_rect
+	defer func() { dst.clip_rect = saved_clip }()
+	if !SDL_GetRectIntersection(dstrect, &saved_clip, &dst.clip_rect) {
+		return true
+	}
+
+	tile_w := int(math.Round(float64(float32(srcrect.W) * scale)))
+	tile_h := int(math.Round(float64(float32(srcrect.H) * scale)))
+	if tile_w <= 0 || tile_h <= 0 {
+		return true
+	}
+
+	for y := dstrect.Y; y < dstrect.Y+dstrect.H; y += tile_h {
+		for x := dstrect.X; x < dstrect.X+dstrect.W; x += tile_w {
+			tile := SDL_Rect{x, y, tile_w, tile_h}
+			var ok bool
+			if tile_w == srcrect.W && tile_h == srcrect.H {
+				ok = SDL_BlitSurface(src, srcrect, dst, &tile)
+			} else {
+				ok = SDL_BlitSurfaceScaled(src, srcrect, dst, &tile, scaleMode)
+			}
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/**
+ * Perform a scaled blit using the 9-grid algorithm to a destination surface,
+ * which may be of a different format.
+ *
+ * The pixels in the source surface are split into a 3x3 grid, using the
+ * different corner sizes for each corner, and the sides and center making up
+ * the remaining pixels. The corners are then scaled using `scale` and fit
+ * into the corners of the destination rectangle. The sides and center are
+ * then stretched into place to cover the remaining destination rectangle.
+ *
+ * The corners can't be negative, and the left and right corners together
+ * can't be wider than `srcrect`, nor the top and bottom ones taller.
+ *
+ * - src the SDL_Surface structure to be copied from.
+ * - srcrect the SDL_Rect structure representing the rectangle to be used
+ *                for the 9-grid, or nil to use the entire surface.
+ * - left_width the width, in pixels, of the left corners in `srcrect`.
+ * - right_width the width, in pixels, of the right corners in `srcrect`.
+ * - top_height the height, in pixels, of the top corners in `srcrect`.
+ * - bottom_height the height, in pixels, of the bottom corners in
+ *                      `srcrect`.
+ * - scale the scale used to transform the corner of `srcrect` into the
+ *              corner of `dstrect`, or 0.0f for an unscaled blit.
+ * - scaleMode scale algorithm to be used.
+ * - dst the SDL_Surface structure that is the blit target.
+ * - dstrect the SDL_Rect structure representing the target rectangle in
+ *                the destination surface, or nil to fill the entire surface.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_BlitSurface
+ */
+func SDL_BlitSurface9Grid(src *SDL_Surface, srcrect *SDL_Rect, left_width, right_width, top_height, bottom_height int, scale float32, scaleMode SDL_ScaleMode, dst *SDL_Surface, dstrect *SDL_Rect) bool {
+	if !sdlValidateBlit(src, dst) {
+		return false
+	}
+
+	full_src := SDL_Rect{0, 0, src.W, src.H}
+	if srcrect == nil {
+		srcrect = &full_src
+	}
+	full_dst := SDL_Rect{0, 0, dst.W, dst.H}
+	if dstrect == nil {
+		dstrect = &full_dst
+	}
+	if left_width < 0 {
+		return SDL_InvalidParamError("left_width")
+	}
+	if right_width < 0 || left_width+right_width > srcrect.W {
+		return SDL_InvalidParamError("right_width")
+	}
+	if top_height < 0 {
+		return SDL_InvalidParamError("top_height")
+	}
+	if bottom_height < 0 || top_height+bottom_height > srcrect.H {
+		return SDL_InvalidParamError("bottom_height")
+	}
+
+	var dst_left_width, dst_right_width, dst_top_height, dst_bottom_height int
+	if scale <= 0.0 || scale == 1.0 {
+		dst_left_width = left_width
+		dst_right_width = right_width
+		dst_top_height = top_height
+		dst_bottom_height = bottom_height
+	} else {
+		dst_left_width = int(math.Round(float64(float32(left_width) * scale)))
+		dst_right_width = int(math.Round(float64(float32(right_width) * scale)))
+		dst_top_height = int(math.Round(float64(float32(top_height) * scale)))
+		dst_bottom_height = int(math.Round(float64(float32(bottom_height) * scale)))
+	}
+
+	src_center_w := srcrect.W - left_width - right_width
+	src_center_h := srcrect.H - top_height - bottom_height
+	dst_center_w := dstrect.W - dst_left_width - dst_right_width
+	dst_center_h := dstrect.H - dst_top_height - dst_bottom_height
+
+	/* Columns and rows of the grid: source offset and size, destination offset and size */
+	columns := [3][4]int{
+		{0, left_width, 0, dst_left_width},
+		{left_width, src_center_w, dst_left_width, dst_center_w},
+		{srcrect.W - right_width, right_width, dstrect.W - dst_right_width, dst_right_width},
+	}
+	rows := [3][4]int{
+		{0, top_height, 0, dst_top_height},
+		{top_height, src_center_h, dst_top_height, dst_center_h},
+		{srcrect.H - bottom_height, bottom_height, dstrect.H - dst_bottom_height, dst_bottom_height},
+	}
+
+	for _, row := range rows {
+		for _, column := range columns {
+			curr_src := SDL_Rect{srcrect.X + column[0], srcrect.Y + row[0], column[1], row[1]}
+			curr_dst := SDL_Rect{dstrect.X + column[2], dstrect.Y + row[2], column[3], row[3]}
+			if SDL_RectEmpty(&curr_src) || SDL_RectEmpty(&curr_dst) {
+				continue
+			}
+			if !SDL_BlitSurfaceScaled(src, &curr_src, dst, &curr_dst, scaleMode) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,218 @@
+package sdl
+
+import "testing"
+
+/* Compare a surface with the image drawn by expected, and fail with the differences */
+func testCheckSurface(t *testing.T, surface *SDL_Surface, expected SDL_PixelFunc) {
+	t.Helper()
+	reference := SDL_CreateSurfaceFromFunc(surface.W, surface.H, SDL_PIXELFORMAT_RGBA32, expected)
+	if reference == nil {
+		t.Fatalf("SDL_CreateSurfaceFromFunc failed: %s", SDL_GetError())
+	}
+	defer SDL_DestroySurface(reference)
+	if diff := SDLTest_DiffSurfaces(surface, reference, 0, 8); diff != "" {
+		t.Errorf("Unexpected pixels:\n%s", diff)
+	}
+}
+
+/* Create a cleared blit target */
+func testCreateTarget(t *testing.T, w, h int, format SDL_PixelFormat) *SDL_Surface {
+	t.Helper()
+	dst := SDL_CreateSurface(w, h, format)
+	if dst == nil {
+		t.Fatalf("SDL_CreateSurface failed: %s", SDL_GetError())
+	}
+	t.Cleanup(func() { SDL_DestroySurface(dst) })
+	return dst
+}
+
+/* Create color bars with bars of the given width */
+func testCreateColorBars(t *testing.T, bar_w, h int) *SDL_Surface {
+	t.Helper()
+	src := SDLTest_CreateColorBars(bar_w*len(sdlTestColorBars), h)
+	if src == nil {
+		t.Fatalf("SDLTest_CreateColorBars failed: %s", SDL_GetError())
+	}
+	t.Cleanup(func() { SDL_DestroySurface(src) })
+	return src
+}
+
+func TestBlitSurface(t *testing.T) {
+	src := testCreateColorBars(t, 2, 4)
+	dst := testCreateTarget(t, 24, 8, SDL_PIXELFORMAT_XRGB8888)
+	black := SDL_Color{0, 0, 0, 255}
+
+	/* Part of the source, placed inside the target */
+	srcrect := SDL_Rect{4, 1, 6, 2}
+	dstrect := SDL_Rect{10, 3, 0, 0}
+	if !SDL_BlitSurface(src, &srcrect, dst, &dstrect) {
+		t.Fatalf("SDL_BlitSurface failed: %s", SDL_GetError())
+	}
+	testCheckSurface(t, dst, func(x, y int) SDL_Color {
+		if x >= 10 && x < 16 && y >= 3 && y < 5 {
+			return sdlTestColorBars[(x-10+4)/2]
+		}
+		return black
+	})
+
+	/* Clipped by the target on the left, the source is clipped along */
+	SDL_FillSurfaceRect(dst, nil, SDL_MapSurfaceRGB(dst, 0, 0, 0))
+	dstrect = SDL_Rect{-4, 2, 0, 0}
+	if !SDL_BlitSurface(src, nil, dst, &dstrect) {
+		t.Fatalf("SDL_BlitSurface failed: %s", SDL_GetError())
+	}
+	testCheckSurface(t, dst, func(x, y int) SDL_Color {
+		if x < 12 && y >= 2 && y < 6 {
+			return sdlTestColorBars[(x+4)/2]
+		}
+		return black
+	})
+}
+
+func TestBlitSurfaceScaled(t *testing.T) {
+	src := testCreateColorBars(t, 1, 1)
+	dst := testCreateTarget(t, 40, 6, SDL_PIXELFORMAT_RGBA32)
+
+	/* Each source pixel becomes a 4x3 block */
+	dstrect := SDL_Rect{4, 2, 32, 3}
+	if !SDL_BlitSurfaceScaled(src, nil, dst, &dstrect, SDL_SCALEMODE_NEAREST) {
+		t.Fatalf("SDL_BlitSurfaceScaled failed: %s", SDL_GetError())
+	}
+	testCheckSurface(t, dst, func(x, y int) SDL_Color {
+		if x >= 4 && x < 36 && y >= 2 && y < 5 {
+			return sdlTestColorBars[(x-4)/4]
+		}
+		return SDL_Color{}
+	})
+
+	/* Linear filtering keeps the middle of wide bars */
+	src = testCreateColorBars(t, 8, 1)
+	dst = testCreateTarget(t, 128, 2, SDL_PIXELFORMAT_RGBA32)
+	if !SDL_BlitSurfaceScaled(src, nil, dst, nil, SDL_SCALEMODE_LINEAR) {
+		t.Fatalf("SDL_BlitSurfaceScaled failed: %s", SDL_GetError())
+	}
+	for i, color := range sdlTestColorBars {
+		if got := dst.getColor(i*16+8, 1); got != color {
+			t.Errorf("Middle of bar %d is %v, expected %v", i, got, color)
+		}
+	}
+}
+
+func TestBlitSurfaceTiled(t *testing.T) {
+	src := testCreateColorBars(t, 1, 2)
+	dst := testCreateTarget(t, 24, 8, SDL_PIXELFORMAT_RGBA32)
+
+	/* A part of the source repeated over the target rectangle, the last tiles cut off */
+	srcrect := SDL_Rect{2, 0, 3, 2}
+	dstrect := SDL_Rect{1, 1, 20, 5}
+	if !SDL_BlitSurfaceTiled(src, &srcrect, dst, &dstrect) {
+		t.Fatalf("SDL_BlitSurfaceTiled failed: %s", SDL_GetError())
+	}
+	testCheckSurface(t, dst, func(x, y int) SDL_Color {
+		if x >= 1 && x < 21 && y >= 1 && y < 6 {
+			return sdlTestColorBars[2+(x-1)%3]
+		}
+		return SDL_Color{}
+	})
+}
+
+func TestBlitSurfaceTiledWithScale(t *testing.T) {
+	src := testCreateColorBars(t, 1, 1)
+	dst := testCreateTarget(t, 40, 4, SDL_PIXELFORMAT_RGBA32)
+
+	/* Tiles twice the size of the source */
+	if !SDL_BlitSurfaceTiledWithScale(src, nil, 2.0, SDL_SCALEMODE_NEAREST, dst, nil) {
+		t.Fatalf("SDL_BlitSurfaceTiledWithScale failed: %s", SDL_GetError())
+	}
+	testCheckSurface(t, dst, func(x, y int) SDL_Color {
+		return sdlTestColorBars[(x%16)/2]
+	})
+
+	if SDL_BlitSurfaceTiledWithScale(src, nil, 0.0, SDL_SCALEMODE_NEAREST, dst, nil) {
+		t.Errorf("SDL_BlitSurfaceTiledWithScale succeeded with a scale of 0")
+	}
+}
+
+/* The colors of the 9-grid test source, one per cell */
+var testGridColors = [3][3]SDL_Color{
+	{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}},
+	{{255, 255, 0, 255}, {0, 255, 255, 255}, {255, 0, 255, 255}},
+	{{255, 255, 255, 255}, {128, 128, 128, 255}, {0, 0, 0, 255}},
+}
+
+/* The cell of a coordinate, along a side of the grid */
+func testGridCell(v, first, last, size int) int {
+	switch {
+	case v < first:
+		return 0
+	case v >= size-last:
+		return 2
+	}
+	return 1
+}
+
+func TestBlitSurface9Grid(t *testing.T) {
+	const left, right, top, bottom = 1, 2, 2, 1
+	src := SDL_CreateSurfaceFromFunc(7, 6, SDL_PIXELFORMAT_RGBA32, func(x, y int) SDL_Color {
+		return testGridColors[testGridCell(y, top, bottom, 6)][testGridCell(x, left, right, 7)]
+	})
+	if src == nil {
+		t.Fatalf("SDL_CreateSurfaceFromFunc failed: %s", SDL_GetError())
+	}
+	defer SDL_DestroySurface(src)
+
+	tests := []struct {
+		name  string
+		scale float32
+		w, h  int
+	}{
+		{"unscaled corners", 0.0, 20, 15},
+		{"scaled corners", 2.0, 20, 15},
+		{"shrunk center", 1.0, 4, 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := testCreateTarget(t, test.w, test.h, SDL_PIXELFORMAT_RGBA32)
+			if !SDL_BlitSurface9Grid(src, nil, left, right, top, bottom, test.scale, SDL_SCALEMODE_NEAREST, dst, nil) {
+				t.Fatalf("SDL_BlitSurface9Grid failed: %s", SDL_GetError())
+			}
+			scale := 1
+			if test.scale > 0.0 {
+				scale = int(test.scale)
+			}
+			testCheckSurface(t, dst, func(x, y int) SDL_Color {
+				row := testGridCell(y, top*scale, bottom*scale, test.h)
+				column := testGridCell(x, left*scale, right*scale, test.w)
+				return testGridColors[row][column]
+			})
+		})
+	}
+}
+
+func TestBlitSurface9GridRejectsCorners(t *testing.T) {
+	src := testCreateColorBars(t, 1, 6)
+	dst := testCreateTarget(t, 16, 16, SDL_PIXELFORMAT_RGBA32)
+	srcrect := SDL_Rect{1, 1, 6, 4}
+
+	tests := []struct {
+		name                     string
+		left, right, top, bottom int
+		ok                       bool
+	}{
+		{"corners fill the source", 3, 3, 2, 2, true},
+		{"too wide", 4, 3, 1, 1, false},
+		{"too tall", 1, 1, 3, 2, false},
+		{"negative left", -1, 1, 1, 1, false},
+		{"negative right", 1, -1, 1, 1, false},
+		{"negative top", 1, 1, -1, 1, false},
+		{"negative bottom", 1, 1, 1, -1, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok := SDL_BlitSurface9Grid(src, &srcrect, test.left, test.right, test.top, test.bottom, 0.0, SDL_SCALEMODE_NEAREST, dst, nil)
+			if ok != test.ok {
+				t.Errorf("SDL_BlitSurface9Grid returned %v, expected %v: %s", ok, test.ok, SDL_GetError())
+			}
+		})
+	}
+}
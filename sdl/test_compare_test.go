@@ -0,0 +1,181 @@
+package sdl
+
+import "fmt"
+import "strings"
+
+/*
+ * Comparison helpers for golden-image tests: a pixel-by-pixel comparison of
+ * two surfaces that dumps both of them as BMP files when they differ, and a
+ * textual diff suitable for test output and bug reports. They are test
+ * helpers and only built with the tests.
+ */
+
+/* Counter for _CompareSurface calls; used for filename creation when comparisons fail */
+var compareSurfaceCount int
+
+/* Maximum number of mismatching pixels described in the log */
+const compareSurfacesMaxLoggedPixels = 10
+
+/* Squared distance between two colors, summed over the four channels */
+func sdlTestColorDistance(a, b SDL_Color) int {
+	dR := int(a.R) - int(b.R)
+	dG := int(a.G) - int(b.G)
+	dB := int(a.B) - int(b.B)
+	dA := int(a.A) - int(b.A)
+	return dR*dR + dG*dG + dB*dB + dA*dA
+}
+
+/* Check that two surfaces can be compared pixel by pixel */
+func sdlTestValidateComparison(surface, referenceSurface *SDL_Surface) bool {
+	if surface == nil || surface.Pixels == nil {
+		return SDL_InvalidParamError("surface")
+	}
+	if referenceSurface == nil || referenceSurface.Pixels == nil {
+		return SDL_InvalidParamError("referenceSurface")
+	}
+	if surface.W != referenceSurface.W || surface.H != referenceSurface.H {
+		return SDL_SetErrorf("Expected %dx%d surface, got %dx%d",
+			referenceSurface.W, referenceSurface.H, surface.W, surface.H)
+	}
+	return true
+}
+
+/**
+ * Compares a surface and with reference image data for equality
+ *
+ * The surfaces may be of different pixel formats, pixels are compared after
+ * conversion to RGBA. When the comparison fails, the first differing pixels
+ * are logged in the SDL_LOG_CATEGORY_TEST category and both surfaces are
+ * saved as CompareSurfacesNNNN_TestOutput.bmp and
+ * CompareSurfacesNNNN_Reference.bmp in the working directory.
+ *
+ * - surface surface used in comparison
+ * - referenceSurface test surface used in comparison
+ * - allowable_error allowable difference (=sum of squared difference for
+ *                   each RGBA component) in blending accuracy.
+ * Returns 0 if comparison succeeded, >0 (=number of pixels for which the
+ *          comparison failed) if comparison failed, -1 if any of the
+ *          surfaces were nil or of different sizes; call SDL_GetError() for
+ *          more information.
+ *
+ * See also SDLTest_DiffSurfaces
+ */
+func SDLTest_CompareSurfaces(surface *SDL_Surface, referenceSurface *SDL_Surface, allowable_error int) int {
+	if !sdlTestValidateComparison(surface, referenceSurface) {
+		return -1
+	}
+
+	ret := 0
+	var sampleX, sampleY int
+	var sampleActual, sampleReference SDL_Color
+	for j := 0; j < surface.H; j++ {
+		for i := 0; i < surface.W; i++ {
+			actual := surface.getColor(i, j)
+			reference := referenceSurface.getColor(i, j)
+			if sdlTestColorDistance(actual, reference) <= allowable_error {
+				continue
+			}
+			ret++
+			if ret == 1 {
+				sampleX, sampleY = i, j
+				sampleActual, sampleReference = actual, reference
+			}
+		}
+	}
+
+	/* Save test image and reference for analysis on failures */
+	compareSurfaceCount++
+	if ret != 0 {
+		SDL_LogError(SDL_LOG_CATEGORY_TEST, "Comparison of pixels with allowable error of %d failed %d times.", allowable_error, ret)
+		SDL_LogError(SDL_LOG_CATEGORY_TEST, "Reference surface format: %s", SDL_GetPixelFormatName(referenceSurface.Format))
+		SDL_LogError(SDL_LOG_CATEGORY_TEST, "Actual surface format: %s", SDL_GetPixelFormatName(surface.Format))
+		SDL_LogError(SDL_LOG_CATEGORY_TEST, "First detected occurrence at position %d,%d", sampleX, sampleY)
+		SDL_LogError(SDL_LOG_CATEGORY_TEST, "Reference pixel: R=%d G=%d B=%d A=%d", sampleReference.R, sampleReference.G, sampleReference.B, sampleReference.A)
+		SDL_LogError(SDL_LOG_CATEGORY_TEST, "Actual pixel   : R=%d G=%d B=%d A=%d", sampleActual.R, sampleActual.G, sampleActual.B, sampleActual.A)
+
+		diff := SDLTest_DiffSurfaces(surface, referenceSurface, allowable_error, compareSurfacesMaxLoggedPixels)
+		SDL_LogError(SDL_LOG_CATEGORY_TEST, "%s", diff)
+
+		imageFilename := fmt.Sprintf("CompareSurfaces%04d_TestOutput.bmp", compareSurfaceCount)
+		SDL_SaveBMP(surface, imageFilename)
+		referenceFilename := fmt.Sprintf("CompareSurfaces%04d_Reference.bmp", compareSurfaceCount)
+		SDL_SaveBMP(referenceSurface, referenceFilename)
+		SDL_LogError(SDL_LOG_CATEGORY_TEST, "Surfaces from failed comparison saved as '%s' and '%s'", imageFilename, referenceFilename)
+	}
+	return ret
+}
+
+/**
+ * Describe the differences between a surface and a reference as text.
+ *
+ * The result lists every pixel whose difference exceeds `allowable_error`,
+ * one per line, followed by a map of the surface where differing pixels are
+ * marked with `X` and matching ones with `.`, e.g.
+ *
+ * ```
+ * (1,0): got RGBA(255,0,0,255), expected RGBA(0,0,255,255), error 130050
+ * .X..
+ * ....
+ * ```
+ *
+ * The map is left out for surfaces wider or taller than 64 pixels.
+ *
+ * - surface surface used in comparison.
+ * - referenceSurface test surface used in comparison.
+ * - allowable_error allowable difference, see SDLTest_CompareSurfaces().
+ * - max_pixels the maximum number of differing pixels to list, or 0 for no
+ *                   limit.
+ * Returns the description, "" if the surfaces match, or a description of
+ *          the error if they can't be compared.
+ *
+ * See also SDLTest_CompareSurfaces
+ */
+func SDLTest_DiffSurfaces(surface *SDL_Surface, referenceSurface *SDL_Surface, allowable_error int, max_pixels int) string {
+	if !sdlTestValidateComparison(surface, referenceSurface) {
+		return SDL_GetError()
+	}
+
+	const maxMapSize = 64
+	var lines strings.Builder
+	var pixelMap strings.Builder
+	drawMap := surface.W <= maxMapSize && surface.H <= maxMapSize
+
+	failed := 0
+	for j := 0; j < surface.H; j++ {
+		for i := 0; i < surface.W; i++ {
+			actual := surface.getColor(i, j)
+			reference := referenceSurface.getColor(i, j)
+			distance := sdlTestColorDistance(actual, reference)
+			if distance <= allowable_error {
+				if drawMap {
+					pixelMap.WriteByte('.')
+				}
+				continue
+			}
+
+			failed++
+			if drawMap {
+				pixelMap.WriteByte('X')
+			}
+			if max_pixels <= 0 || failed <= max_pixels {
+				fmt.Fprintf(&lines, "(%d,%d): got RGBA(%d,%d,%d,%d), expected RGBA(%d,%d,%d,%d), error %d\n",
+					i, j, actual.R, actual.G, actual.B, actual.A,
+					reference.R, reference.G, reference.B, reference.A, distance)
+			}
+		}
+		if drawMap {
+			pixelMap.WriteByte('\n')
+		}
+	}
+
+	if failed == 0 {
+		return ""
+	}
+	if max_pixels > 0 && failed > max_pixels {
+		fmt.Fprintf(&lines, "... and %d more\n", failed-max_pixels)
+	}
+	if drawMap {
+		lines.WriteString(pixelMap.String())
+	}
+	return strings.TrimRight(lines.String(), "\n")
+}
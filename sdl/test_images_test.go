@@ -0,0 +1,138 @@
+package sdl
+
+/*
+ * Generated reference images for blit and blend tests.
+ *
+ * The pixels are computed, not stored, so every image is the same on every
+ * platform and any size can be asked for. These are not the pixel arrays of
+ * the upstream SDLTest images: the upstream test sources aren't available
+ * to this tree, so results compared against these images only hold for this
+ * package, not for upstream SDL. They are test helpers and only built with
+ * the tests.
+ */
+
+/* The eight bars of SDLTest_CreateColorBars(), from left to right */
+var sdlTestColorBars = [...]SDL_Color{
+	{255, 255, 255, 255}, /* white */
+	{255, 255, 0, 255},   /* yellow */
+	{0, 255, 255, 255},   /* cyan */
+	{0, 255, 0, 255},     /* green */
+	{255, 0, 255, 255},   /* magenta */
+	{255, 0, 0, 255},     /* red */
+	{0, 0, 255, 255},     /* blue */
+	{0, 0, 0, 255},       /* black */
+}
+
+/**
+ * Create a checkerboard test image.
+ *
+ * The top left cell has the first color.
+ *
+ * - width the width of the image.
+ * - height the height of the image.
+ * - cell the size of the squares in pixels.
+ * - a the color of the first squares.
+ * - b the color of the second squares.
+ * Returns the new SDL_PIXELFORMAT_RGBA32 surface or nil on failure; call
+ *          SDL_GetError() for more information.
+ */
+func SDLTest_CreateCheckerboard(width int, height int, cell int, a SDL_Color, b SDL_Color) *SDL_Surface {
+	if cell <= 0 {
+		SDL_InvalidParamError("cell")
+		return nil
+	}
+	return SDL_CreateSurfaceFromFunc(width, height, SDL_PIXELFORMAT_RGBA32, func(x, y int) SDL_Color {
+		if (x/cell+y/cell)%2 == 0 {
+			return a
+		}
+		return b
+	})
+}
+
+/**
+ * Create a color bars test image.
+ *
+ * Eight opaque vertical bars of the same width: white, yellow, cyan, green,
+ * magenta, red, blue and black. Every channel is either 0 or 255, so the
+ * image goes through any pixel format conversion unchanged.
+ *
+ * - width the width of the image.
+ * - height the height of the image.
+ * Returns the new SDL_PIXELFORMAT_RGBA32 surface or nil on failure; call
+ *          SDL_GetError() for more information.
+ */
+func SDLTest_CreateColorBars(width int, height int) *SDL_Surface {
+	return SDL_CreateSurfaceFromFunc(width, height, SDL_PIXELFORMAT_RGBA32, func(x, y int) SDL_Color {
+		return sdlTestColorBars[x*len(sdlTestColorBars)/width]
+	})
+}
+
+/**
+ * Create a blend test image.
+ *
+ * The color goes around the hue circle from left to right, and the alpha
+ * from opaque at the top to transparent at the bottom, so blitting it with a
+ * blend mode covers the whole range of source colors and alphas.
+ *
+ * - width the width of the image.
+ * - height the height of the image.
+ * Returns the new SDL_PIXELFORMAT_RGBA32 surface or nil on failure; call
+ *          SDL_GetError() for more information.
+ */
+func SDLTest_CreateBlendRamp(width int, height int) *SDL_Surface {
+	return SDL_CreateSurfaceFromFunc(width, height, SDL_PIXELFORMAT_RGBA32, func(x, y int) SDL_Color {
+		/* Six segments of the hue circle, each ramping one channel */
+		hue := x * 6 * 255 / max(width-1, 1)
+		segment := min(hue/255, 5)
+		ramp := uint8(hue - segment*255)
+		var c SDL_Color
+		switch segment {
+		case 0:
+			c = SDL_Color{255, ramp, 0, 0}
+		case 1:
+			c = SDL_Color{255 - ramp, 255, 0, 0}
+		case 2:
+			c = SDL_Color{0, 255, ramp, 0}
+		case 3:
+			c = SDL_Color{0, 255 - ramp, 255, 0}
+		case 4:
+			c = SDL_Color{ramp, 0, 255, 0}
+		case 5:
+			c = SDL_Color{255, 0, 255 - ramp, 0}
+		}
+		c.A = uint8(255 - y*255/max(height-1, 1))
+		return c
+	})
+}
+
+/**
+ * Create a small face test image, a blit source with a transparent border.
+ *
+ * A yellow disk with a black outline, eyes and smile on a fully transparent
+ * background, like the face used by the upstream blit tests but drawn from
+ * circles, so its pixels differ.
+ *
+ * Returns the new 32x32 SDL_PIXELFORMAT_RGBA32 surface or nil on failure;
+ *          call SDL_GetError() for more information.
+ */
+func SDLTest_CreateFace() *SDL_Surface {
+	const size = 32
+	inside := func(x, y int, cx, cy, r float32) bool {
+		dx, dy := float32(x)+0.5-cx, float32(y)+0.5-cy
+		return dx*dx+dy*dy <= r*r
+	}
+	return SDL_CreateSurfaceFromFunc(size, size, SDL_PIXELFORMAT_RGBA32, func(x, y int) SDL_Color {
+		black := SDL_Color{0, 0, 0, 255}
+		switch {
+		case !inside(x, y, 16, 16, 15):
+			return SDL_Color{0, 0, 0, 0}
+		case !inside(x, y, 16, 16, 13.5):
+			return black
+		case inside(x, y, 11, 12, 2.5), inside(x, y, 21, 12, 2.5):
+			return black
+		case y >= 18 && inside(x, y, 16, 16, 9) && !inside(x, y, 16, 16, 7.5):
+			return black
+		}
+		return SDL_Color{255, 220, 0, 255}
+	})
+}
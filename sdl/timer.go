@@ -0,0 +1,125 @@
+package sdl
+
+import "time"
+
+/**
+ * Number of milliseconds in a second.
+ *
+ * This is always 1000.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_MS_PER_SECOND = 1000
+
+/**
+ * Number of microseconds in a second.
+ *
+ * This is always 1000000.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_US_PER_SECOND = 1000000
+
+/**
+ * Number of nanoseconds in a second.
+ *
+ * This is always 1000000000.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_NS_PER_SECOND = 1000000000
+
+/**
+ * Number of nanoseconds in a millisecond.
+ *
+ * This is always 1000000.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_NS_PER_MS = 1000000
+
+/**
+ * Number of nanoseconds in a microsecond.
+ *
+ * This is always 1000.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_NS_PER_US = 1000
+
+/**
+ * Convert milliseconds to nanoseconds.
+ *
+ * - MS the number of milliseconds to convert.
+ * Returns `MS` expressed in nanoseconds.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_MS_TO_NS(MS uint64) uint64 { return MS * SDL_NS_PER_MS }
+
+/**
+ * Convert nanoseconds to milliseconds.
+ *
+ * - NS the number of nanoseconds to convert.
+ * Returns `NS` expressed in milliseconds.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_NS_TO_MS(NS uint64) uint64 { return NS / SDL_NS_PER_MS }
+
+/* The reference point of the tick counters, the monotonic clock reading is used */
+var tickStart = time.Now()
+
+/**
+ * Get the number of milliseconds since SDL library initialization.
+ *
+ * Returns an unsigned 64-bit value representing the number of milliseconds
+ * since the SDL library initialized.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetTicks() uint64 {
+	return uint64(time.Since(tickStart) / time.Millisecond)
+}
+
+/**
+ * Get the number of nanoseconds since SDL library initialization.
+ *
+ * Returns an unsigned 64-bit value representing the number of nanoseconds
+ * since the SDL library initialized.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetTicksNS() uint64 {
+	return uint64(time.Since(tickStart))
+}
+
+/**
+ * Wait a specified number of milliseconds before returning.
+ *
+ * This function waits a specified number of milliseconds before returning.
+ * It waits at least the specified time, but possibly longer due to OS
+ * scheduling.
+ *
+ * - ms the number of milliseconds to delay.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_Delay(ms uint32) {
+	time.Sleep(time.Duration(ms) * time.Millisecond)
+}
+
+/**
+ * Wait a specified number of nanoseconds before returning.
+ *
+ * This function waits a specified number of nanoseconds before returning.
+ * It waits at least the specified time, but possibly longer due to OS
+ * scheduling.
+ *
+ * - ns the number of nanoseconds to delay.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_DelayNS(ns uint64) {
+	time.Sleep(time.Duration(ns))
+}
@@ -0,0 +1,41 @@
+package sdl
+
+/**
+ * A unique ID for a touch device.
+ *
+ * This ID is valid for the time the device is connected to the system, and
+ * is never reused for the lifetime of the application.
+ *
+ * The value 0 is an invalid ID.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_TouchID uint64
+
+/**
+ * A unique ID for a single finger on a touch device.
+ *
+ * This ID is valid for the time the finger (stylus, etc) is touching and will
+ * be unique for all fingers currently in contact, so this ID tracks the
+ * lifetime of a single continuous touch. This value may represent an index, a
+ * pointer, or some other unique ID, depending on the platform.
+ *
+ * The value 0 is an invalid ID.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_FingerID uint64
+
+/**
+ * The SDL_TouchID for touch events simulated with mouse input.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_MOUSE_TOUCHID SDL_TouchID = 0xFFFFFFFFFFFFFFFF
+
+/**
+ * The SDL_TouchID for touch events simulated with pen input.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_PEN_TOUCHID SDL_TouchID = 0xFFFFFFFFFFFFFFFE
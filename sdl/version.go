@@ -0,0 +1,170 @@
+package sdl
+
+import "runtime/debug"
+
+/**
+ * The version of the SDL3 API this port tracks.
+ *
+ * Functions documented as "available since SDL x.y.z" are implemented as of
+ * this API level.
+ */
+const (
+	SDL_MAJOR_VERSION = 3
+	SDL_MINOR_VERSION = 0
+	SDL_MICRO_VERSION = 0
+)
+
+/**
+ * The version of the Go port itself. This is bumped independently from the
+ * SDL3 API level above.
+ */
+const (
+	SDL_PORT_MAJOR_VERSION = 0
+	SDL_PORT_MINOR_VERSION = 1
+	SDL_PORT_MICRO_VERSION = 0
+)
+
+/**
+ * This macro turns the version numbers into a numeric value.
+ *
+ * (1,2,3) becomes 1002003.
+ *
+ * - major the major version number.
+ * - minor the minorversion number.
+ * - patch the patch version number.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_VERSIONNUM(major, minor, patch int) int {
+	return major*1000000 + minor*1000 + patch
+}
+
+/**
+ * This macro extracts the major version from a version number
+ *
+ * 1002003 becomes 1.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_VERSIONNUM_MAJOR(version int) int {
+	return version / 1000000
+}
+
+/**
+ * This macro extracts the minor version from a version number
+ *
+ * 1002003 becomes 2.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_VERSIONNUM_MINOR(version int) int {
+	return (version / 1000) % 1000
+}
+
+/**
+ * This macro extracts the micro version from a version number
+ *
+ * 1002003 becomes 3.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_VERSIONNUM_MICRO(version int) int {
+	return version % 1000
+}
+
+/**
+ * This is the version number macro for the current SDL API level.
+ */
+const SDL_VERSION = SDL_MAJOR_VERSION*1000000 + SDL_MINOR_VERSION*1000 + SDL_MICRO_VERSION
+
+/**
+ * This macro will evaluate to true if compiled with SDL at least X.Y.Z.
+ */
+func SDL_VERSION_ATLEAST(x, y, z int) bool {
+	return SDL_VERSION >= SDL_VERSIONNUM(x, y, z)
+}
+
+/**
+ * Information about the version of the port and the SDL API it tracks.
+ *
+ * Major, Minor and Patch describe the Go port, API is the SDL_VERSIONNUM()
+ * of the SDL3 API level.
+ */
+type SDL_Version struct {
+	Major int
+	Minor int
+	Patch int
+	API   int
+}
+
+/**
+ * Get the version of SDL that is linked against your program.
+ *
+ * In the Go port the library is always compiled into the program, so this
+ * matches the SDL_PORT_* and SDL_*_VERSION constants.
+ *
+ * Returns the version of the port and the SDL3 API level it tracks.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetRevision
+ */
+func SDL_GetVersion() SDL_Version {
+	return SDL_Version{
+		Major: SDL_PORT_MAJOR_VERSION,
+		Minor: SDL_PORT_MINOR_VERSION,
+		Patch: SDL_PORT_MICRO_VERSION,
+		API:   SDL_VERSION,
+	}
+}
+
+// SDL_REVISION can be set at link time using -ldflags "-X <module>/sdl.SDL_REVISION=<rev>"
+var SDL_REVISION = ""
+
+/**
+ * Get the code revision of SDL that is linked against your program.
+ *
+ * This value is the revision of the code you are linked with and may be
+ * different from the code you are compiling with. It is taken from
+ * SDL_REVISION if set at link time, otherwise from the VCS information the
+ * Go toolchain embeds into the binary.
+ *
+ * The revision is arbitrary string (a hash value) uniquely identifying the
+ * exact revision of the SDL library in use, and is only useful in comparing
+ * against other revisions. It is NOT an incrementing number.
+ *
+ * If SDL wasn't built from a git repository with the appropriate tools, this
+ * will return an empty string.
+ *
+ * You shouldn't use this function for anything but logging it for debugging
+ * purposes. The string is not intended to be reliable in any way.
+ *
+ * Returns an arbitrary string, uniquely identifying the exact revision of
+ *          the SDL library in use.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetVersion
+ */
+func SDL_GetRevision() string {
+	if SDL_REVISION != "" {
+		return SDL_REVISION
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		revision, modified := "", false
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				revision = setting.Value
+			case "vcs.modified":
+				modified = setting.Value == "true"
+			}
+		}
+		if revision != "" && modified {
+			revision += "-dirty"
+		}
+		return revision
+	}
+	return ""
+}
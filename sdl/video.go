@@ -0,0 +1,3696 @@
+package sdl
+
+import "math"
+import "slices"
+import "strings"
+
+/**
+ * This is a unique ID for a window.
+ *
+ * The value 0 is an invalid ID.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_WindowID uint32
+
+/**
+ * This is a unique ID for a display for the time it is connected to the
+ * system, and is never reused for the lifetime of the application.
+ *
+ * If the display is disconnected and reconnected, it will get a new ID.
+ *
+ * The value 0 is an invalid ID.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_DisplayID uint32
+
+/**
+ * Display orientation values; the way a display is rotated.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_DisplayOrientation int
+
+const (
+	SDL_ORIENTATION_UNKNOWN           SDL_DisplayOrientation = iota /**< The display orientation can't be determined */
+	SDL_ORIENTATION_LANDSCAPE                                       /**< The display is in landscape mode, with the right side up, relative to portrait mode */
+	SDL_ORIENTATION_LANDSCAPE_FLIPPED                               /**< The display is in landscape mode, with the left side up, relative to portrait mode */
+	SDL_ORIENTATION_PORTRAIT                                        /**< The display is in portrait mode */
+	SDL_ORIENTATION_PORTRAIT_FLIPPED                                /**< The display is in portrait mode, upside down */
+)
+
+/* A display known to the video backend */
+type sdlVideoDisplay struct {
+	id                  SDL_DisplayID
+	name                string
+	bounds              SDL_Rect /* in screen coordinates */
+	natural_orientation SDL_DisplayOrientation
+	current_orientation SDL_DisplayOrientation
+	content_scale       float32
+
+	desktop_mode      SDL_DisplayMode
+	current_mode      SDL_DisplayMode
+	fullscreen_modes  []SDL_DisplayMode /* sorted with sdlCompareDisplayModes() */
+	fullscreen_window *SDL_Window       /* the window covering the display, if any */
+
+	hdr   sdlHDROutputProperties
+	props SDL_PropertiesID /* created on demand, see SDL_GetDisplayProperties() */
+}
+
+/* The HDR capabilities of a display, as reported by the video backend */
+type sdlHDROutputProperties struct {
+	SDR_white_level float32 /* the value of SDR white in the linear colorspace, 1.0 unless HDR is enabled */
+	HDR_headroom    float32 /* the maximum value of HDR content in terms of SDR white, 1.0 without HDR */
+}
+
+var sdlDefaultHDRProperties = sdlHDROutputProperties{SDR_white_level: 1.0, HDR_headroom: 1.0}
+
+/* The connected displays, the first one is the primary display. Guarded by displaysLock. */
+var displaysLock = sdlRWMutex{name: "video.displays"}
+var displays []*sdlVideoDisplay
+var lastDisplayID SDL_DisplayID
+
+/* Must be called with displaysLock held. */
+func sdlFindDisplayLocked(displayID SDL_DisplayID) *sdlVideoDisplay {
+	for _, display := range displays {
+		if display.id == displayID {
+			return display
+		}
+	}
+	return nil
+}
+
+/* Queue a display event, the display state must already be updated */
+func sdlSendDisplayEvent(displayID SDL_DisplayID, kind SDL_EventType, data1, data2 int) bool {
+	SDL_assert(kind >= SDL_EVENT_DISPLAY_FIRST && kind <= SDL_EVENT_DISPLAY_LAST)
+
+	event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: kind}}
+	event.Display = SDL_DisplayEvent{DisplayID: displayID, Data1: int32(data1), Data2: int32(data2)}
+	return SDL_PushEvent(&event)
+}
+
+/*
+ * Called by video backends when a display is connected, e.g. a laptop is
+ * docked. The display gets a new ID, which is returned. The first display
+ * added is the primary one.
+ */
+func sdlAddVideoDisplay(name string, bounds SDL_Rect, orientation SDL_DisplayOrientation, content_scale float32, send_event bool) SDL_DisplayID {
+	if content_scale <= 0 {
+		content_scale = 1
+	}
+
+	displaysLock.Lock()
+	lastDisplayID++
+	display := &sdlVideoDisplay{
+		id:                  lastDisplayID,
+		name:                name,
+		bounds:              bounds,
+		natural_orientation: orientation,
+		current_orientation: orientation,
+		content_scale:       content_scale,
+		hdr:                 sdlDefaultHDRProperties,
+	}
+	display.desktop_mode = SDL_DisplayMode{Format: SDL_PIXELFORMAT_XRGB8888, W: bounds.W, H: bounds.H}
+	sdlFinalizeDisplayMode(display, &display.desktop_mode)
+	display.current_mode = display.desktop_mode
+	displays = append(displays, display)
+	displaysLock.Unlock()
+
+	if send_event {
+		sdlSendDisplayEvent(display.id, SDL_EVENT_DISPLAY_ADDED, 0, 0)
+	}
+	sdlOnDisplaysChanged(0, SDL_Rect{})
+	return display.id
+}
+
+/* Called by video backends when a display is disconnected */
+func sdlDelVideoDisplay(displayID SDL_DisplayID, send_event bool) {
+	displaysLock.Lock()
+	found := false
+	var props SDL_PropertiesID
+	var bounds SDL_Rect
+	for i, display := range displays {
+		if display.id == displayID {
+			displays = append(displays[:i], displays[i+1:]...)
+			found = true
+			props = display.props
+			bounds = display.bounds
+			break
+		}
+	}
+	displaysLock.Unlock()
+
+	if props != 0 {
+		SDL_DestroyProperties(props)
+	}
+	if found && send_event {
+		sdlSendDisplayEvent(displayID, SDL_EVENT_DISPLAY_REMOVED, 0, 0)
+	}
+	if found {
+		sdlOnDisplaysChanged(displayID, bounds)
+	}
+}
+
+/* Called by video backends when a display is moved in the desktop layout or resized */
+func sdlSetDisplayBounds(displayID SDL_DisplayID, bounds SDL_Rect) {
+	displaysLock.Lock()
+	display := sdlFindDisplayLocked(displayID)
+	changed := display != nil && display.bounds != bounds
+	moved := display != nil && (display.bounds.X != bounds.X || display.bounds.Y != bounds.Y)
+	if display != nil {
+		display.bounds = bounds
+	}
+	displaysLock.Unlock()
+
+	if moved {
+		sdlSendDisplayEvent(displayID, SDL_EVENT_DISPLAY_MOVED, 0, 0)
+	}
+	if changed {
+		sdlOnDisplaysChanged(0, SDL_Rect{})
+	}
+}
+
+/* Called by video backends when a display is rotated */
+func sdlSetDisplayOrientation(displayID SDL_DisplayID, orientation SDL_DisplayOrientation) {
+	displaysLock.Lock()
+	display := sdlFindDisplayLocked(displayID)
+	changed := display != nil && display.current_orientation != orientation
+	if changed {
+		display.current_orientation = orientation
+	}
+	displaysLock.Unlock()
+
+	if changed {
+		sdlSendDisplayEvent(displayID, SDL_EVENT_DISPLAY_ORIENTATION, int(orientation), 0)
+	}
+}
+
+/* Called by video backends when the user changes the scaling of a display */
+func sdlSetDisplayContentScale(displayID SDL_DisplayID, scale float32) {
+	displaysLock.Lock()
+	display := sdlFindDisplayLocked(displayID)
+	changed := display != nil && scale > 0 && display.content_scale != scale
+	if changed {
+		display.content_scale = scale
+	}
+	displaysLock.Unlock()
+
+	if !changed {
+		return
+	}
+	sdlSendDisplayEvent(displayID, SDL_EVENT_DISPLAY_CONTENT_SCALE_CHANGED, 0, 0)
+	for _, window := range SDL_GetWindows() {
+		if SDL_GetDisplayForWindow(window) == displayID {
+			sdlUpdateWindowDisplayScale(window, true)
+		}
+	}
+}
+
+/*
+ * Called by video backends when the HDR state of a display changes, e.g. the
+ * user turns HDR on in the system settings. The windows on the display get
+ * SDL_EVENT_WINDOW_HDR_STATE_CHANGED.
+ */
+func sdlSetDisplayHDRProperties(displayID SDL_DisplayID, hdr sdlHDROutputProperties) {
+	displaysLock.Lock()
+	display := sdlFindDisplayLocked(displayID)
+	changed := display != nil && display.hdr != hdr
+	var props SDL_PropertiesID
+	if changed {
+		display.hdr = hdr
+		props = display.props
+	}
+	displaysLock.Unlock()
+
+	if !changed {
+		return
+	}
+	if props != 0 {
+		SDL_SetBooleanProperty(props, SDL_PROP_DISPLAY_HDR_ENABLED_BOOLEAN, hdr.HDR_headroom > 1.0)
+	}
+	for _, window := range SDL_GetWindows() {
+		if SDL_GetDisplayForWindow(window) == displayID {
+			sdlUpdateWindowHDRProperties(window, true)
+		}
+	}
+}
+
+/* The HDR state of a display, or the SDR defaults if it isn't known */
+func sdlGetDisplayHDRProperties(displayID SDL_DisplayID) sdlHDROutputProperties {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	if display := sdlFindDisplayLocked(displayID); display != nil {
+		return display.hdr
+	}
+	return sdlDefaultHDRProperties
+}
+
+/* Forget every display, called when the video subsystem shuts down */
+func sdlQuitDisplays() {
+	displaysLock.Lock()
+	var props []SDL_PropertiesID
+	for _, display := range displays {
+		if display.props != 0 {
+			props = append(props, display.props)
+		}
+	}
+	displays = nil
+	displaysLock.Unlock()
+
+	for _, p := range props {
+		SDL_DestroyProperties(p)
+	}
+}
+
+/**
+ * Get a list of currently connected displays.
+ *
+ * Returns the display IDs, or nil on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetDisplays() []SDL_DisplayID {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	if len(displays) == 0 {
+		SDL_SetError("No displays available")
+		return nil
+	}
+	ids := make([]SDL_DisplayID, len(displays))
+	for i, display := range displays {
+		ids[i] = display.id
+	}
+	return ids
+}
+
+/**
+ * Return the primary display.
+ *
+ * Returns the instance ID of the primary display on success or 0 on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplays
+ */
+func SDL_GetPrimaryDisplay() SDL_DisplayID {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	if len(displays) == 0 {
+		SDL_SetError("No displays available")
+		return 0
+	}
+	return displays[0].id
+}
+
+/**
+ * Get the name of a display in UTF-8 encoding.
+ *
+ * - displayID the instance ID of the display to query.
+ * Returns the name of a display or "" on failure; call SDL_GetError() for
+ *          more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplays
+ */
+func SDL_GetDisplayName(displayID SDL_DisplayID) string {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		SDL_SetErrorf("Invalid display %d", displayID)
+		return ""
+	}
+	return display.name
+}
+
+/**
+ * Get the desktop area represented by a display.
+ *
+ * The primary display is often located at (0,0), but may be placed at a
+ * different location depending on monitor layout.
+ *
+ * - displayID the instance ID of the display to query.
+ * - rect the SDL_Rect structure filled in with the display bounds.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplays
+ */
+func SDL_GetDisplayBounds(displayID SDL_DisplayID, rect *SDL_Rect) bool {
+	if rect == nil {
+		return SDL_InvalidParamError("rect")
+	}
+
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		return SDL_SetErrorf("Invalid display %d", displayID)
+	}
+	*rect = display.bounds
+	return true
+}
+
+/**
+ * Get the orientation of a display when it is unrotated.
+ *
+ * - displayID the instance ID of the display to query.
+ * Returns the SDL_DisplayOrientation enum value of the display, or
+ *          `SDL_ORIENTATION_UNKNOWN` if it isn't available.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplays
+ */
+func SDL_GetNaturalDisplayOrientation(displayID SDL_DisplayID) SDL_DisplayOrientation {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		return SDL_ORIENTATION_UNKNOWN
+	}
+	return display.natural_orientation
+}
+
+/**
+ * Get the orientation of a display.
+ *
+ * - displayID the instance ID of the display to query.
+ * Returns the SDL_DisplayOrientation enum value of the display, or
+ *          `SDL_ORIENTATION_UNKNOWN` if it isn't available.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplays
+ */
+func SDL_GetCurrentDisplayOrientation(displayID SDL_DisplayID) SDL_DisplayOrientation {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		return SDL_ORIENTATION_UNKNOWN
+	}
+	return display.current_orientation
+}
+
+/**
+ * Get the content scale of a display.
+ *
+ * The content scale is the expected scale for content based on the DPI
+ * settings of the display. For example, a 4K display might have a 2.0 (200%)
+ * display scale, which means that the user expects UI elements to be twice
+ * as big on this display, to aid in readability.
+ *
+ * - displayID the instance ID of the display to query.
+ * Returns the content scale of the display, or 0.0f on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplays
+ */
+func SDL_GetDisplayContentScale(displayID SDL_DisplayID) float32 {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		SDL_SetErrorf("Invalid display %d", displayID)
+		return 0
+	}
+	return display.content_scale
+}
+
+/**
+ * Get the properties associated with a display.
+ *
+ * The following read-only properties are provided by SDL:
+ *
+ * - `SDL_PROP_DISPLAY_HDR_ENABLED_BOOLEAN`: true if the display has HDR
+ *   headroom above the SDR white point. This is for informational and
+ *   diagnostic purposes only, as not all platforms provide this information
+ *   at the display level.
+ *
+ * - displayID the instance ID of the display to query.
+ * Returns a valid property ID on success or 0 on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetDisplayProperties(displayID SDL_DisplayID) SDL_PropertiesID {
+	displaysLock.Lock()
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		displaysLock.Unlock()
+		SDL_SetErrorf("Invalid display %d", displayID)
+		return 0
+	}
+	props, created := display.props, false
+	if props == 0 {
+		props = SDL_CreateProperties()
+		display.props, created = props, true
+	}
+	hdr := display.hdr
+	displaysLock.Unlock()
+
+	if created {
+		SDL_SetBooleanProperty(props, SDL_PROP_DISPLAY_HDR_ENABLED_BOOLEAN, hdr.HDR_headroom > 1.0)
+	}
+	return props
+}
+
+const SDL_PROP_DISPLAY_HDR_ENABLED_BOOLEAN = "SDL.display.HDR_enabled"
+
+/**
+ * The structure that defines a display mode.
+ *
+ * This struct is available since SDL 3.0.0.
+ *
+ * See also SDL_GetFullscreenDisplayModes
+ * See also SDL_GetDesktopDisplayMode
+ * See also SDL_GetCurrentDisplayMode
+ * See also SDL_SetWindowFullscreenMode
+ * See also SDL_GetWindowFullscreenMode
+ */
+type SDL_DisplayMode struct {
+	DisplayID              SDL_DisplayID   /**< the display this mode is associated with */
+	Format                 SDL_PixelFormat /**< pixel format */
+	W                      int             /**< width */
+	H                      int             /**< height */
+	PixelDensity           float32         /**< scale converting size to pixels (e.g. a 1920x1080 mode with 2.0 scale would have 3840x2160 pixels) */
+	RefreshRate            float32         /**< refresh rate (or 0.0f for unspecified) */
+	RefreshRateNumerator   int             /**< precise refresh rate numerator (or 0 for unspecified) */
+	RefreshRateDenominator int             /**< precise refresh rate denominator */
+
+	internal any /* driver data for the mode */
+}
+
+/* Fill in the derived fields of a display mode reported by a backend */
+func sdlFinalizeDisplayMode(display *sdlVideoDisplay, mode *SDL_DisplayMode) {
+	mode.DisplayID = display.id
+	if mode.PixelDensity <= 0 {
+		mode.PixelDensity = 1
+	}
+	if mode.RefreshRateNumerator > 0 && mode.RefreshRateDenominator > 0 {
+		mode.RefreshRate = float32(mode.RefreshRateNumerator) / float32(mode.RefreshRateDenominator)
+	} else {
+		mode.RefreshRateNumerator, mode.RefreshRateDenominator = 0, 0
+	}
+}
+
+/* Order modes from the largest to the smallest, then by density and refresh rate, highest first */
+func sdlCompareDisplayModes(a, b SDL_DisplayMode) int {
+	switch {
+	case a.W != b.W:
+		return b.W - a.W
+	case a.H != b.H:
+		return b.H - a.H
+	case a.PixelDensity != b.PixelDensity:
+		return tern(a.PixelDensity > b.PixelDensity, -1, 1)
+	case a.RefreshRate != b.RefreshRate:
+		return tern(a.RefreshRate > b.RefreshRate, -1, 1)
+	}
+	return 0
+}
+
+/* Whether two modes describe the same video mode, regardless of driver data */
+func sdlDisplayModesEqual(a, b *SDL_DisplayMode) bool {
+	return a.Format == b.Format && a.W == b.W && a.H == b.H &&
+		a.PixelDensity == b.PixelDensity && a.RefreshRate == b.RefreshRate
+}
+
+/* Called by video backends to list a mode usable for exclusive fullscreen. Duplicates are ignored. */
+func sdlAddFullscreenDisplayMode(displayID SDL_DisplayID, mode *SDL_DisplayMode) bool {
+	displaysLock.Lock()
+	defer displaysLock.Unlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		return false
+	}
+	added := *mode
+	sdlFinalizeDisplayMode(display, &added)
+	for i := range display.fullscreen_modes {
+		if sdlDisplayModesEqual(&display.fullscreen_modes[i], &added) {
+			return false
+		}
+	}
+	display.fullscreen_modes = append(display.fullscreen_modes, added)
+	slices.SortStableFunc(display.fullscreen_modes, sdlCompareDisplayModes)
+	return true
+}
+
+/* Called by video backends when the desktop mode of a display changes */
+func sdlSetDesktopDisplayMode(displayID SDL_DisplayID, mode *SDL_DisplayMode) {
+	displaysLock.Lock()
+	display := sdlFindDisplayLocked(displayID)
+	changed := false
+	if display != nil {
+		desktop := *mode
+		sdlFinalizeDisplayMode(display, &desktop)
+		changed = !sdlDisplayModesEqual(&display.desktop_mode, &desktop)
+		if sdlDisplayModesEqual(&display.current_mode, &display.desktop_mode) {
+			display.current_mode = desktop
+		}
+		display.desktop_mode = desktop
+	}
+	displaysLock.Unlock()
+
+	if changed {
+		sdlSendDisplayEvent(displayID, SDL_EVENT_DISPLAY_DESKTOP_MODE_CHANGED, 0, 0)
+	}
+}
+
+/* Switch a display to a mode, or back to its desktop mode if mode is nil */
+func sdlSetDisplayModeForDisplay(displayID SDL_DisplayID, mode *SDL_DisplayMode) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+
+	displaysLock.RLock()
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		displaysLock.RUnlock()
+		return SDL_SetErrorf("Invalid display %d", displayID)
+	}
+	target := display.desktop_mode
+	if mode != nil {
+		target = *mode
+	}
+	unchanged := sdlDisplayModesEqual(&display.current_mode, &target)
+	displaysLock.RUnlock()
+
+	if unchanged {
+		return true
+	}
+	if device.SetDisplayMode == nil {
+		return SDL_SetError("Video driver doesn't support changing display mode")
+	}
+	if !device.SetDisplayMode(device, displayID, &target) {
+		return false
+	}
+
+	displaysLock.Lock()
+	if display = sdlFindDisplayLocked(displayID); display != nil {
+		display.current_mode = target
+	}
+	displaysLock.Unlock()
+
+	sdlSendDisplayEvent(displayID, SDL_EVENT_DISPLAY_CURRENT_MODE_CHANGED, 0, 0)
+	return true
+}
+
+/**
+ * Get a list of fullscreen display modes available on a display.
+ *
+ * The display modes are sorted in this priority:
+ *
+ * - w -> largest to smallest
+ * - h -> largest to smallest
+ * - bits per pixel -> more colors to fewer colors
+ * - packed pixel layout -> largest to smallest
+ * - refresh rate -> highest to lowest
+ * - pixel density -> lowest to highest
+ *
+ * - displayID the instance ID of the display to query.
+ * Returns the display modes on success or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplays
+ */
+func SDL_GetFullscreenDisplayModes(displayID SDL_DisplayID) []SDL_DisplayMode {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		SDL_SetErrorf("Invalid display %d", displayID)
+		return nil
+	}
+	if len(display.fullscreen_modes) == 0 {
+		/* The desktop mode can always be used */
+		return []SDL_DisplayMode{display.desktop_mode}
+	}
+	return slices.Clone(display.fullscreen_modes)
+}
+
+/**
+ * Get the closest match to the requested display mode.
+ *
+ * The available display modes are scanned and `closest` is filled in with the
+ * closest mode matching the requested mode and returned. The mode format and
+ * refresh rate default to the desktop mode if they are set to 0. The modes
+ * are scanned with size being first priority, format being second priority,
+ * and finally checking the refresh rate. If all the available modes are too
+ * small, then false is returned.
+ *
+ * - displayID the instance ID of the display to query.
+ * - w the width in pixels of the desired display mode.
+ * - h the height in pixels of the desired display mode.
+ * - refresh_rate the refresh rate of the desired display mode, or 0.0f
+ *                     for the desktop refresh rate.
+ * - include_high_density_modes boolean to include high density modes in
+ *                                   the search.
+ * - closest a pointer filled in with the closest display mode equal to
+ *                or larger than the desired mode.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplays
+ * See also SDL_GetFullscreenDisplayModes
+ */
+func SDL_GetClosestFullscreenDisplayMode(displayID SDL_DisplayID, w int, h int, refresh_rate float32, include_high_density_modes bool, closest *SDL_DisplayMode) bool {
+	if closest == nil {
+		return SDL_InvalidParamError("closest")
+	}
+	if w <= 0 || h <= 0 {
+		return SDL_InvalidParamError(tern(w <= 0, "w", "h"))
+	}
+
+	modes := SDL_GetFullscreenDisplayModes(displayID)
+	if modes == nil {
+		return false
+	}
+	if refresh_rate == 0 {
+		refresh_rate = SDL_GetDesktopDisplayMode(displayID).RefreshRate
+	}
+
+	aspect_ratio := float32(w) / float32(h)
+	var found *SDL_DisplayMode
+	for i := range modes {
+		mode := &modes[i]
+		if w > mode.W {
+			/* Out of sorted modes large enough here */
+			break
+		}
+		if h > mode.H {
+			/* Wider, but not tall enough, due to a different aspect ratio */
+			continue
+		}
+		if !include_high_density_modes && mode.PixelDensity > 1 {
+			continue
+		}
+		if found != nil {
+			found_aspect_ratio := float32(found.W) / float32(found.H)
+			mode_aspect_ratio := float32(mode.W) / float32(mode.H)
+			if math.Abs(float64(aspect_ratio-found_aspect_ratio)) < math.Abs(float64(aspect_ratio-mode_aspect_ratio)) {
+				/* The mode we already found has a better aspect ratio match */
+				continue
+			}
+			if mode.W == found.W && mode.H == found.H &&
+				math.Abs(float64(found.RefreshRate-refresh_rate)) < math.Abs(float64(mode.RefreshRate-refresh_rate)) {
+				/* We already found a mode and the new mode is further from our
+				 * refresh rate target */
+				continue
+			}
+		}
+		found = mode
+	}
+	if found == nil {
+		return SDL_SetError("Couldn't find any matching video modes")
+	}
+	*closest = *found
+	return true
+}
+
+/**
+ * Get information about the desktop's display mode.
+ *
+ * There's a difference between this function and SDL_GetCurrentDisplayMode()
+ * when SDL runs fullscreen and has changed the resolution. In that case this
+ * function will return the previous native display mode, and not the current
+ * display mode.
+ *
+ * - displayID the instance ID of the display to query.
+ * Returns a copy of the desktop display mode or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetCurrentDisplayMode
+ * See also SDL_GetDisplays
+ */
+func SDL_GetDesktopDisplayMode(displayID SDL_DisplayID) *SDL_DisplayMode {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		SDL_SetErrorf("Invalid display %d", displayID)
+		return nil
+	}
+	mode := display.desktop_mode
+	return &mode
+}
+
+/**
+ * Get information about the current display mode.
+ *
+ * There's a difference between this function and SDL_GetDesktopDisplayMode()
+ * when SDL runs fullscreen and has changed the resolution. In that case this
+ * function will return the current display mode, and not the previous native
+ * display mode.
+ *
+ * - displayID the instance ID of the display to query.
+ * Returns a copy of the current display mode or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDesktopDisplayMode
+ * See also SDL_GetDisplays
+ */
+func SDL_GetCurrentDisplayMode(displayID SDL_DisplayID) *SDL_DisplayMode {
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	display := sdlFindDisplayLocked(displayID)
+	if display == nil {
+		SDL_SetErrorf("Invalid display %d", displayID)
+		return nil
+	}
+	mode := display.current_mode
+	return &mode
+}
+
+/**
+ * The flags on a window.
+ *
+ * These cover a lot of true/false, or on/off, window state. Some of it is
+ * immutable after being set through SDL_CreateWindow(), some of it can be
+ * changed on existing windows by the app, and some of it might be altered by
+ * the user or system outside of the app's control.
+ *
+ * This datatype is available since SDL 3.0.0.
+ */
+type SDL_WindowFlags uint64
+
+const (
+	SDL_WINDOW_FULLSCREEN          SDL_WindowFlags = 0x0000000000000001 /**< window is in fullscreen mode */
+	SDL_WINDOW_OPENGL              SDL_WindowFlags = 0x0000000000000002 /**< window usable with OpenGL context */
+	SDL_WINDOW_OCCLUDED            SDL_WindowFlags = 0x0000000000000004 /**< window is occluded */
+	SDL_WINDOW_HIDDEN              SDL_WindowFlags = 0x0000000000000008 /**< window is neither mapped onto the desktop nor shown in the taskbar/dock/window list; SDL_ShowWindow() is required for it to become visible */
+	SDL_WINDOW_BORDERLESS          SDL_WindowFlags = 0x0000000000000010 /**< no window decoration */
+	SDL_WINDOW_RESIZABLE           SDL_WindowFlags = 0x0000000000000020 /**< window can be resized */
+	SDL_WINDOW_MINIMIZED           SDL_WindowFlags = 0x0000000000000040 /**< window is minimized */
+	SDL_WINDOW_MAXIMIZED           SDL_WindowFlags = 0x0000000000000080 /**< window is maximized */
+	SDL_WINDOW_MOUSE_GRABBED       SDL_WindowFlags = 0x0000000000000100 /**< window has grabbed mouse input */
+	SDL_WINDOW_INPUT_FOCUS         SDL_WindowFlags = 0x0000000000000200 /**< window has input focus */
+	SDL_WINDOW_MOUSE_FOCUS         SDL_WindowFlags = 0x0000000000000400 /**< window has mouse focus */
+	SDL_WINDOW_EXTERNAL            SDL_WindowFlags = 0x0000000000000800 /**< window not created by SDL */
+	SDL_WINDOW_MODAL               SDL_WindowFlags = 0x0000000000001000 /**< window is modal */
+	SDL_WINDOW_HIGH_PIXEL_DENSITY  SDL_WindowFlags = 0x0000000000002000 /**< window uses high pixel density back buffer if possible */
+	SDL_WINDOW_MOUSE_CAPTURE       SDL_WindowFlags = 0x0000000000004000 /**< window has mouse captured (unrelated to MOUSE_GRABBED) */
+	SDL_WINDOW_MOUSE_RELATIVE_MODE SDL_WindowFlags = 0x0000000000008000 /**< window has relative mode enabled */
+	SDL_WINDOW_ALWAYS_ON_TOP       SDL_WindowFlags = 0x0000000000010000 /**< window should always be above others */
+	SDL_WINDOW_UTILITY             SDL_WindowFlags = 0x0000000000020000 /**< window should be treated as a utility window, not showing in the task bar and window list */
+	SDL_WINDOW_TOOLTIP             SDL_WindowFlags = 0x0000000000040000 /**< window should be treated as a tooltip and does not get mouse or keyboard focus, requires a parent window */
+	SDL_WINDOW_POPUP_MENU          SDL_WindowFlags = 0x0000000000080000 /**< window should be treated as a popup menu, requires a parent window */
+	SDL_WINDOW_KEYBOARD_GRABBED    SDL_WindowFlags = 0x0000000000100000 /**< window has grabbed keyboard input */
+	SDL_WINDOW_VULKAN              SDL_WindowFlags = 0x0000000010000000 /**< window usable for Vulkan surface */
+	SDL_WINDOW_METAL               SDL_WindowFlags = 0x0000000020000000 /**< window usable for Metal view */
+	SDL_WINDOW_TRANSPARENT         SDL_WindowFlags = 0x0000000040000000 /**< window with transparent buffer */
+	SDL_WINDOW_NOT_FOCUSABLE       SDL_WindowFlags = 0x0000000080000000 /**< window should not be focusable */
+)
+
+/**
+ * A magic value used with SDL_WINDOWPOS_UNDEFINED.
+ *
+ * Generally this macro isn't used directly, but rather through
+ * SDL_WINDOWPOS_UNDEFINED or SDL_WINDOWPOS_UNDEFINED_DISPLAY.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_WINDOWPOS_UNDEFINED_MASK = 0x1FFF0000
+
+/**
+ * Used to indicate that you don't care what the window position is on a
+ * specific display, SDL places the window there.
+ *
+ * - X the SDL_DisplayID of the display to use.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_WINDOWPOS_UNDEFINED_DISPLAY(X SDL_DisplayID) int {
+	return SDL_WINDOWPOS_UNDEFINED_MASK | int(X)
+}
+
+/**
+ * Used to indicate that you don't care what the window position/display is.
+ *
+ * This always uses the primary display.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_WINDOWPOS_UNDEFINED = SDL_WINDOWPOS_UNDEFINED_MASK | 0
+
+/**
+ * A macro to test if the window position is marked as "undefined."
+ *
+ * - X the window position value.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_WINDOWPOS_ISUNDEFINED(X int) bool {
+	return X&0xFFFF0000 == SDL_WINDOWPOS_UNDEFINED_MASK
+}
+
+/**
+ * A magic value used with SDL_WINDOWPOS_CENTERED.
+ *
+ * Generally this macro isn't used directly, but rather through
+ * SDL_WINDOWPOS_CENTERED or SDL_WINDOWPOS_CENTERED_DISPLAY.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_WINDOWPOS_CENTERED_MASK = 0x2FFF0000
+
+/**
+ * Used to indicate that the window position should be centered on a
+ * specific display.
+ *
+ * - X the SDL_DisplayID of the display to use.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_WINDOWPOS_CENTERED_DISPLAY(X SDL_DisplayID) int {
+	return SDL_WINDOWPOS_CENTERED_MASK | int(X)
+}
+
+/**
+ * Used to indicate that the window position should be centered.
+ *
+ * This always uses the primary display.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+const SDL_WINDOWPOS_CENTERED = SDL_WINDOWPOS_CENTERED_MASK | 0
+
+/**
+ * A macro to test if the window position is marked as "centered."
+ *
+ * - X the window position value.
+ *
+ * This macro is available since SDL 3.0.0.
+ */
+func SDL_WINDOWPOS_ISCENTERED(X int) bool {
+	return X&0xFFFF0000 == SDL_WINDOWPOS_CENTERED_MASK
+}
+
+/*
+ * Resolve SDL_WINDOWPOS_UNDEFINED and SDL_WINDOWPOS_CENTERED coordinates of
+ * a w x h window to a position centered on the display they name, or on the
+ * primary display if that isn't a valid display. Other coordinates are
+ * returned as they are.
+ */
+func sdlResolveWindowPosition(x, y, w, h int) (int, int) {
+	special := func(pos int) bool {
+		return SDL_WINDOWPOS_ISUNDEFINED(pos) || SDL_WINDOWPOS_ISCENTERED(pos)
+	}
+	if !special(x) && !special(y) {
+		return x, y
+	}
+
+	var displayID SDL_DisplayID
+	if special(x) {
+		displayID = SDL_DisplayID(x & 0xFFFF)
+	} else {
+		displayID = SDL_DisplayID(y & 0xFFFF)
+	}
+	displaysLock.RLock()
+	valid := sdlFindDisplayLocked(displayID) != nil
+	displaysLock.RUnlock()
+	if !valid {
+		displayID = SDL_GetPrimaryDisplay()
+	}
+
+	var bounds SDL_Rect
+	if !SDL_GetDisplayBounds(displayID, &bounds) {
+		return 0, 0
+	}
+	if special(x) {
+		x = bounds.X + (bounds.W-w)/2
+	}
+	if special(y) {
+		y = bounds.Y + (bounds.H-h)/2
+	}
+	return x, y
+}
+
+/**
+ * The struct used as an opaque handle to a window.
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_Window struct {
+	id     SDL_WindowID
+	handle sdlHandle /* released by SDL_DestroyWindow() */
+	title  string
+	flags  SDL_WindowFlags
+	x, y   int /* position in screen coordinates */
+	w, h   int /* client area size in screen coordinates */
+
+	last_pixel_w, last_pixel_h int /* the size in pixels last reported with SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED */
+
+	last_displayID SDL_DisplayID          /* the display last reported with SDL_EVENT_WINDOW_DISPLAY_CHANGED */
+	display_scale  float32                /* the scale last reported with SDL_EVENT_WINDOW_DISPLAY_SCALE_CHANGED */
+	hdr            sdlHDROutputProperties /* the HDR state of that display, published in the window properties */
+	props          SDL_PropertiesID
+
+	/* Size limits in screen coordinates, 0 for no limit */
+	min_w, min_h int
+	max_w, max_h int
+
+	/* Insets of the area not covered by notches or system overlays, in screen coordinates */
+	safe_inset_left, safe_inset_right, safe_inset_top, safe_inset_bottom int
+
+	opacity float32 /* 1.0 for opaque, see SDL_SetWindowOpacity() */
+
+	external_graphics_context bool /* the application manages the graphics context of the external window */
+
+	/* Custom decorations, see SDL_SetWindowHitTest() */
+	hit_test      SDL_HitTest
+	hit_test_data any
+	hit_test_drag sdlHitTestDrag /* the move or resize in progress, for drivers without a window manager */
+
+	/* Events delivered while the application's event loop can't run, see SDL_SetWindowEventHook() */
+	event_hook          SDL_WindowEventHook
+	event_hook_userdata any
+	live_resize         bool /* the user is resizing the window, see sdlBeginWindowLiveResize() */
+
+	/* Window hierarchy, a popup's position is relative to its parent */
+	parent          *SDL_Window
+	children        []*SDL_Window
+	restore_on_show bool /* hidden along with its parent, show it again with it */
+	constrain_popup bool /* keep the popup within the display of its parent */
+
+	/* Fullscreen state, see SDL_SetWindowFullscreen() */
+	requested_fullscreen_mode SDL_DisplayMode /* W is 0 for desktop fullscreen */
+	fullscreen_exclusive      bool            /* the display mode was changed for the window */
+	fullscreen_display        SDL_DisplayID
+	windowed                  SDL_Rect /* the geometry to restore when leaving fullscreen */
+	pending_fullscreen        bool     /* fullscreen was left by hiding the window, enter it again when shown */
+
+	placements map[string]SDL_Rect /* the floating geometry used with each display configuration, see placement.go */
+	kiosk      *sdlKioskState      /* the settings to restore, while in kiosk mode */
+
+	/* The framebuffer surface, see SDL_GetWindowSurface() */
+	surface       *SDL_Surface
+	surface_valid bool /* cleared when the window is resized */
+
+	renderer *SDL_Renderer /* see SDL_CreateRenderer() */
+
+	driverdata any /* owned by the video driver */
+
+	/* Text input state, see SDL_StartTextInputWithProperties() */
+	text_input_active         bool
+	text_input_type           SDL_TextInputType
+	text_input_capitalization SDL_Capitalization
+	text_input_autocorrect    bool
+	text_input_multiline      bool
+
+	/* Taskbar progress, see SDL_SetWindowProgressState() */
+	progress_state SDL_ProgressState
+	progress_value float32
+}
+
+/*
+ * Video drivers.
+ *
+ * A driver registers a bootstrap from an init() function; the first one
+ * that can be created is used when the video subsystem is initialized. The
+ * device holds the driver entry points, optional ones may be nil.
+ */
+type sdlVideoDevice struct {
+	name string
+
+	/* Initialize the driver and add its displays with sdlAddVideoDisplay() */
+	VideoInit func(device *sdlVideoDevice) bool
+	VideoQuit func(device *sdlVideoDevice)
+
+	/* Switch a display to a mode from its fullscreen or desktop mode */
+	SetDisplayMode func(device *sdlVideoDevice, displayID SDL_DisplayID, mode *SDL_DisplayMode) bool
+
+	/* Window functions, called with the window state already updated */
+	CreateSDLWindow       func(device *sdlVideoDevice, window *SDL_Window, props SDL_PropertiesID) bool
+	ShowWindow            func(device *sdlVideoDevice, window *SDL_Window)
+	HideWindow            func(device *sdlVideoDevice, window *SDL_Window)
+	RaiseWindow           func(device *sdlVideoDevice, window *SDL_Window)
+	MinimizeWindow        func(device *sdlVideoDevice, window *SDL_Window)
+	MaximizeWindow        func(device *sdlVideoDevice, window *SDL_Window)
+	RestoreWindow         func(device *sdlVideoDevice, window *SDL_Window)
+	SetWindowTitle        func(device *sdlVideoDevice, window *SDL_Window)
+	SetWindowIcon         func(device *sdlVideoDevice, window *SDL_Window, icon *SDL_Surface) bool
+	SetWindowPosition     func(device *sdlVideoDevice, window *SDL_Window, x, y int) bool
+	SetWindowSize         func(device *sdlVideoDevice, window *SDL_Window, w, h int)
+	GetWindowSizeInPixels func(device *sdlVideoDevice, window *SDL_Window) (w, h int)
+	GetWindowContentScale func(device *sdlVideoDevice, window *SDL_Window) float32
+	SetWindowMinimumSize  func(device *sdlVideoDevice, window *SDL_Window)
+	SetWindowMaximumSize  func(device *sdlVideoDevice, window *SDL_Window)
+	SetWindowBordered     func(device *sdlVideoDevice, window *SDL_Window, bordered bool)
+	SetWindowResizable    func(device *sdlVideoDevice, window *SDL_Window, resizable bool)
+	SetWindowAlwaysOnTop  func(device *sdlVideoDevice, window *SDL_Window, on_top bool)
+	SetWindowOpacity      func(device *sdlVideoDevice, window *SDL_Window, opacity float32) bool
+	SetWindowHitTest      func(device *sdlVideoDevice, window *SDL_Window, enabled bool) bool
+	UpdateWindowShape     func(device *sdlVideoDevice, window *SDL_Window, shape *SDL_Surface) bool
+	SetWindowMouseGrab    func(device *sdlVideoDevice, window *SDL_Window, grabbed bool) bool
+	SetWindowKeyboardGrab func(device *sdlVideoDevice, window *SDL_Window, grabbed bool) bool
+
+	/* The window framebuffer, see SDL_GetWindowSurface() */
+	CreateWindowFramebuffer  func(device *sdlVideoDevice, window *SDL_Window) *SDL_Surface
+	UpdateWindowFramebuffer  func(device *sdlVideoDevice, window *SDL_Window, rects []SDL_Rect) bool
+	DestroyWindowFramebuffer func(device *sdlVideoDevice, window *SDL_Window)
+	SetWindowFullscreen      func(device *sdlVideoDevice, window *SDL_Window, displayID SDL_DisplayID, fullscreen bool) bool
+	DestroyWindow            func(device *sdlVideoDevice, window *SDL_Window)
+
+	/* Show the window progress state and value in the OS shell, if supported */
+	ApplyWindowProgress func(device *sdlVideoDevice, window *SDL_Window) bool
+
+	/* Request the attention of the user, see SDL_FlashWindow() */
+	FlashWindow func(device *sdlVideoDevice, window *SDL_Window, operation SDL_FlashOperation) bool
+
+	/* Apply suspend_screensaver to the system */
+	SuspendScreenSaver func(device *sdlVideoDevice) bool
+
+	/* OpenGL support, see gl.go */
+	GL_LoadLibrary     func(device *sdlVideoDevice, path string) bool
+	GL_UnloadLibrary   func(device *sdlVideoDevice)
+	GL_GetProcAddress  func(device *sdlVideoDevice, proc string) SDL_FunctionPointer
+	GL_CreateContext   func(device *sdlVideoDevice, window *SDL_Window) SDL_GLContext
+	GL_MakeCurrent     func(device *sdlVideoDevice, window *SDL_Window, context SDL_GLContext) bool
+	GL_GetAttribute    func(device *sdlVideoDevice, context SDL_GLContext, attr SDL_GLAttr) (int, bool)
+	GL_SetSwapInterval func(device *sdlVideoDevice, interval int) bool
+	GL_GetSwapInterval func(device *sdlVideoDevice) (int, bool)
+	GL_SwapWindow      func(device *sdlVideoDevice, window *SDL_Window) bool
+	GL_DestroyContext  func(device *sdlVideoDevice, context SDL_GLContext) bool
+	GL_GetEGLSurface   func(device *sdlVideoDevice, window *SDL_Window) SDL_EGLSurface
+
+	suspend_screensaver bool
+	grabbed_window      *SDL_Window /* the window holding the input grab, see grab.go */
+	system_theme        SDL_SystemTheme
+
+	gl_config           sdlGLConfig
+	gl_library_loaded   int    /* SDL_GL_LoadLibrary() calls and OpenGL windows using the library */
+	gl_library_path     string /* "" for the default library */
+	gl_allow_no_surface bool   /* contexts can be made current without a window */
+
+	egl_data                     *sdlEGLData /* set by drivers while the EGL library is loaded, see egl.go */
+	egl_platformattrib_callback  SDL_EGLAttribArrayCallback
+	egl_surfaceattrib_callback   SDL_EGLIntArrayCallback
+	egl_contextattrib_callback   SDL_EGLIntArrayCallback
+	egl_attrib_callback_userdata any
+}
+
+type sdlVideoBootStrap struct {
+	name        string
+	desc        string
+	create      func() *sdlVideoDevice /* returns nil if the driver can't run here */
+	demand_only bool                   /* only used when requested with SDL_HINT_VIDEO_DRIVER */
+	fallback    bool                   /* only used when no other driver can run */
+}
+
+var videoBootstraps []sdlVideoBootStrap
+
+/* Register a video driver, called from init() functions */
+func sdlRegisterVideoDriver(bootstrap sdlVideoBootStrap) {
+	/* Keep the fallback drivers at the end, they are tried last */
+	i := len(videoBootstraps)
+	if !bootstrap.fallback {
+		for i > 0 && videoBootstraps[i-1].fallback {
+			i--
+		}
+	}
+	videoBootstraps = slices.Insert(videoBootstraps, i, bootstrap)
+}
+
+/**
+ * A variable that decides what video backend to use.
+ *
+ * By default, SDL will try all available video backends in a reasonable
+ * order until it finds one that can work, but this hint allows the app or
+ * user to force a specific target, such as "dummy" for headless testing or
+ * "offscreen" for rendering into memory without a display server.
+ *
+ * This hint accepts a comma-separated list of driver names, and each will be
+ * tried in the order listed during init, until one succeeds or all of them
+ * fail.
+ *
+ * This hint should be set before SDL is initialized.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_VIDEO_DRIVER = "SDL_VIDEO_DRIVER"
+
+/**
+ * A variable controlling whether the screensaver is enabled.
+ *
+ * The variable can be set to the following values:
+ *
+ * - "0": Disable screensaver. (default)
+ * - "1": Enable screensaver.
+ *
+ * This hint should be set before SDL is initialized.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_VIDEO_ALLOW_SCREENSAVER = "SDL_VIDEO_ALLOW_SCREENSAVER"
+
+/* Create the first device that works, from the drivers named in SDL_HINT_VIDEO_DRIVER or all of them */
+func sdlCreateVideoDevice() *sdlVideoDevice {
+	create := func(bootstrap *sdlVideoBootStrap) *sdlVideoDevice {
+		device := bootstrap.create()
+		if device != nil {
+			device.name = bootstrap.name
+			device.gl_config = sdlDefaultGLConfig
+		}
+		return device
+	}
+
+	if hint := SDL_GetHint(SDL_HINT_VIDEO_DRIVER); hint != "" {
+		for _, name := range strings.Split(hint, ",") {
+			name = strings.TrimSpace(name)
+			for i := range videoBootstraps {
+				if strings.EqualFold(videoBootstraps[i].name, name) {
+					if device := create(&videoBootstraps[i]); device != nil {
+						return device
+					}
+				}
+			}
+		}
+		SDL_SetErrorf("%s not available", hint)
+		return nil
+	}
+
+	for i := range videoBootstraps {
+		if videoBootstraps[i].demand_only {
+			continue
+		}
+		if device := create(&videoBootstraps[i]); device != nil {
+			return device
+		}
+	}
+	SDL_SetError("No available video device")
+	return nil
+}
+
+/* The current video driver and its windows, guarded by videoLock */
+var videoLock = sdlRWMutex{name: "video.windows"}
+var video *sdlVideoDevice
+var videoWindows []*SDL_Window
+var videoWindowsByID = map[SDL_WindowID]*SDL_Window{}
+var lastWindowID SDL_WindowID
+
+func init() {
+	sdlRegisterSubsystem(SDL_INIT_VIDEO, sdlVideoInit, sdlVideoQuit)
+}
+
+func sdlVideoInit() bool {
+	device := sdlCreateVideoDevice()
+	if device == nil {
+		return false
+	}
+
+	if device.VideoInit != nil && !device.VideoInit(device) {
+		sdlQuitDisplays()
+		return false
+	}
+	if len(SDL_GetDisplays()) == 0 {
+		if device.VideoQuit != nil {
+			device.VideoQuit(device)
+		}
+		return SDL_SetError("The video driver did not add any displays")
+	}
+
+	videoLock.Lock()
+	video = device
+	videoLock.Unlock()
+
+	if systemThemeBackend != nil {
+		systemThemeBackend.Init()
+	}
+	if !SDL_GetHintBoolean(SDL_HINT_VIDEO_ALLOW_SCREENSAVER, false) {
+		SDL_DisableScreenSaver()
+	}
+	return true
+}
+
+func sdlVideoQuit() {
+	if systemThemeBackend != nil {
+		systemThemeBackend.Quit()
+	}
+	for _, window := range SDL_GetWindows() {
+		SDL_DestroyWindow(window)
+	}
+	glCurrentLock.Lock()
+	clear(glCurrent) /* contexts left current on windowless surfaces */
+	glCurrentLock.Unlock()
+
+	videoLock.Lock()
+	device := video
+	video = nil
+	videoLock.Unlock()
+
+	if device != nil {
+		/* Give the screen saver back to the system */
+		if device.suspend_screensaver && device.SuspendScreenSaver != nil {
+			device.suspend_screensaver = false
+			device.SuspendScreenSaver(device)
+		}
+
+		/* Leave no display in a fullscreen mode */
+		for _, displayID := range SDL_GetDisplays() {
+			if mode := SDL_GetDesktopDisplayMode(displayID); mode != nil && device.SetDisplayMode != nil {
+				if current := SDL_GetCurrentDisplayMode(displayID); !sdlDisplayModesEqual(current, mode) {
+					device.SetDisplayMode(device, displayID, mode)
+				}
+			}
+		}
+		if device.VideoQuit != nil {
+			device.VideoQuit(device)
+		}
+	}
+	sdlQuitDisplays()
+}
+
+/* The current video device, or nil with an error set if video isn't initialized */
+func sdlGetVideoDevice() *sdlVideoDevice {
+	videoLock.RLock()
+	defer videoLock.RUnlock()
+
+	if video == nil {
+		SDL_SetError("Video subsystem has not been initialized")
+	}
+	return video
+}
+
+/* Check that a window is usable, setting an error if it isn't */
+func sdlCheckWindow(window *SDL_Window) bool {
+	if sdlGetVideoDevice() == nil {
+		return false
+	}
+	if window == nil || !sdlValidHandle(sdlHandleTypeWindow, window.handle, window) {
+		return SDL_SetError("Invalid window")
+	}
+	return true
+}
+
+/**
+ * Get the number of video drivers compiled into SDL.
+ *
+ * Returns the number of built in video drivers.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetVideoDriver
+ */
+func SDL_GetNumVideoDrivers() int {
+	return len(videoBootstraps)
+}
+
+/**
+ * Get the name of a built in video driver.
+ *
+ * The video drivers are presented in the order in which they are normally
+ * checked during initialization.
+ *
+ * The names of drivers are all simple, low-ASCII identifiers, like "cocoa",
+ * "x11" or "windows". These never have Unicode characters, and are not meant
+ * to be proper names.
+ *
+ * - index the index of a video driver.
+ * Returns the name of the video driver with the given **index**, or "" if
+ *          the index is out of range.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetNumVideoDrivers
+ */
+func SDL_GetVideoDriver(index int) string {
+	if index < 0 || index >= len(videoBootstraps) {
+		return ""
+	}
+	return videoBootstraps[index].name
+}
+
+/**
+ * Get the name of the currently initialized video driver.
+ *
+ * Returns the name of the current video driver or "" if no driver has been
+ *          initialized.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetNumVideoDrivers
+ * See also SDL_GetVideoDriver
+ */
+func SDL_GetCurrentVideoDriver() string {
+	videoLock.RLock()
+	defer videoLock.RUnlock()
+
+	if video == nil {
+		return ""
+	}
+	return video.name
+}
+
+/* The flags that can be requested when creating a window, the others describe state */
+const sdlCreateWindowFlagsMask = SDL_WINDOW_FULLSCREEN | SDL_WINDOW_OPENGL | SDL_WINDOW_HIDDEN |
+	SDL_WINDOW_BORDERLESS | SDL_WINDOW_RESIZABLE | SDL_WINDOW_MINIMIZED | SDL_WINDOW_MAXIMIZED |
+	SDL_WINDOW_MOUSE_GRABBED | SDL_WINDOW_EXTERNAL | SDL_WINDOW_MODAL | SDL_WINDOW_HIGH_PIXEL_DENSITY |
+	SDL_WINDOW_ALWAYS_ON_TOP | SDL_WINDOW_UTILITY | SDL_WINDOW_TOOLTIP | SDL_WINDOW_POPUP_MENU |
+	SDL_WINDOW_KEYBOARD_GRABBED | SDL_WINDOW_VULKAN | SDL_WINDOW_METAL | SDL_WINDOW_TRANSPARENT |
+	SDL_WINDOW_NOT_FOCUSABLE
+
+/* The largest window size accepted, in screen coordinates */
+const sdlMaxWindowSize = 16384
+
+const SDL_PROP_WINDOW_CREATE_ALWAYS_ON_TOP_BOOLEAN = "SDL.window.create.always_on_top"
+const SDL_PROP_WINDOW_CREATE_BORDERLESS_BOOLEAN = "SDL.window.create.borderless"
+const SDL_PROP_WINDOW_CREATE_CONSTRAIN_POPUP_BOOLEAN = "SDL.window.create.constrain_popup"
+const SDL_PROP_WINDOW_CREATE_FOCUSABLE_BOOLEAN = "SDL.window.create.focusable"
+const SDL_PROP_WINDOW_CREATE_EXTERNAL_GRAPHICS_CONTEXT_BOOLEAN = "SDL.window.create.external_graphics_context"
+const SDL_PROP_WINDOW_CREATE_FLAGS_NUMBER = "SDL.window.create.flags"
+const SDL_PROP_WINDOW_CREATE_FULLSCREEN_BOOLEAN = "SDL.window.create.fullscreen"
+const SDL_PROP_WINDOW_CREATE_HEIGHT_NUMBER = "SDL.window.create.height"
+const SDL_PROP_WINDOW_CREATE_HIDDEN_BOOLEAN = "SDL.window.create.hidden"
+const SDL_PROP_WINDOW_CREATE_HIGH_PIXEL_DENSITY_BOOLEAN = "SDL.window.create.high_pixel_density"
+const SDL_PROP_WINDOW_CREATE_MAXIMIZED_BOOLEAN = "SDL.window.create.maximized"
+const SDL_PROP_WINDOW_CREATE_MENU_BOOLEAN = "SDL.window.create.menu"
+const SDL_PROP_WINDOW_CREATE_METAL_BOOLEAN = "SDL.window.create.metal"
+const SDL_PROP_WINDOW_CREATE_MINIMIZED_BOOLEAN = "SDL.window.create.minimized"
+const SDL_PROP_WINDOW_CREATE_MODAL_BOOLEAN = "SDL.window.create.modal"
+const SDL_PROP_WINDOW_CREATE_MOUSE_GRABBED_BOOLEAN = "SDL.window.create.mouse_grabbed"
+const SDL_PROP_WINDOW_CREATE_OPENGL_BOOLEAN = "SDL.window.create.opengl"
+const SDL_PROP_WINDOW_CREATE_PARENT_POINTER = "SDL.window.create.parent"
+const SDL_PROP_WINDOW_CREATE_RESIZABLE_BOOLEAN = "SDL.window.create.resizable"
+const SDL_PROP_WINDOW_CREATE_TITLE_STRING = "SDL.window.create.title"
+const SDL_PROP_WINDOW_CREATE_TOOLTIP_BOOLEAN = "SDL.window.create.tooltip"
+const SDL_PROP_WINDOW_CREATE_TRANSPARENT_BOOLEAN = "SDL.window.create.transparent"
+const SDL_PROP_WINDOW_CREATE_UTILITY_BOOLEAN = "SDL.window.create.utility"
+const SDL_PROP_WINDOW_CREATE_VULKAN_BOOLEAN = "SDL.window.create.vulkan"
+const SDL_PROP_WINDOW_CREATE_WIDTH_NUMBER = "SDL.window.create.width"
+const SDL_PROP_WINDOW_CREATE_X_NUMBER = "SDL.window.create.x"
+const SDL_PROP_WINDOW_CREATE_Y_NUMBER = "SDL.window.create.y"
+const SDL_PROP_WINDOW_CREATE_COCOA_WINDOW_POINTER = "SDL.window.create.cocoa.window"
+const SDL_PROP_WINDOW_CREATE_COCOA_VIEW_POINTER = "SDL.window.create.cocoa.view"
+const SDL_PROP_WINDOW_CREATE_WAYLAND_SURFACE_ROLE_CUSTOM_BOOLEAN = "SDL.window.create.wayland.surface_role_custom"
+const SDL_PROP_WINDOW_CREATE_WAYLAND_CREATE_EGL_WINDOW_BOOLEAN = "SDL.window.create.wayland.create_egl_window"
+const SDL_PROP_WINDOW_CREATE_WAYLAND_WL_SURFACE_POINTER = "SDL.window.create.wayland.wl_surface"
+const SDL_PROP_WINDOW_CREATE_WIN32_HWND_POINTER = "SDL.window.create.win32.hwnd"
+const SDL_PROP_WINDOW_CREATE_WIN32_PIXEL_FORMAT_HWND_POINTER = "SDL.window.create.win32.pixel_format_hwnd"
+const SDL_PROP_WINDOW_CREATE_X11_WINDOW_NUMBER = "SDL.window.create.x11.window"
+const SDL_PROP_WINDOW_CREATE_JS_CANVAS_ID_STRING = "SDL.window.create.js.canvas_id"
+
+/* Whether the properties wrap a native window created by the application */
+func sdlHasExternalWindowProperties(props SDL_PropertiesID) bool {
+	return SDL_GetPointerProperty(props, SDL_PROP_WINDOW_CREATE_COCOA_WINDOW_POINTER, nil) != nil ||
+		SDL_GetPointerProperty(props, SDL_PROP_WINDOW_CREATE_COCOA_VIEW_POINTER, nil) != nil ||
+		SDL_GetPointerProperty(props, SDL_PROP_WINDOW_CREATE_WAYLAND_WL_SURFACE_POINTER, nil) != nil ||
+		SDL_GetPointerProperty(props, SDL_PROP_WINDOW_CREATE_WIN32_HWND_POINTER, nil) != nil ||
+		SDL_GetNumberProperty(props, SDL_PROP_WINDOW_CREATE_X11_WINDOW_NUMBER, 0) != 0
+}
+
+/* The window flags requested by boolean creation properties, on top of SDL_PROP_WINDOW_CREATE_FLAGS_NUMBER */
+func sdlGetWindowCreateFlags(props SDL_PropertiesID) SDL_WindowFlags {
+	flags := SDL_WindowFlags(SDL_GetNumberProperty(props, SDL_PROP_WINDOW_CREATE_FLAGS_NUMBER, 0))
+	booleans := []struct {
+		name string
+		flag SDL_WindowFlags
+	}{
+		{SDL_PROP_WINDOW_CREATE_ALWAYS_ON_TOP_BOOLEAN, SDL_WINDOW_ALWAYS_ON_TOP},
+		{SDL_PROP_WINDOW_CREATE_BORDERLESS_BOOLEAN, SDL_WINDOW_BORDERLESS},
+		{SDL_PROP_WINDOW_CREATE_FULLSCREEN_BOOLEAN, SDL_WINDOW_FULLSCREEN},
+		{SDL_PROP_WINDOW_CREATE_HIDDEN_BOOLEAN, SDL_WINDOW_HIDDEN},
+		{SDL_PROP_WINDOW_CREATE_HIGH_PIXEL_DENSITY_BOOLEAN, SDL_WINDOW_HIGH_PIXEL_DENSITY},
+		{SDL_PROP_WINDOW_CREATE_MAXIMIZED_BOOLEAN, SDL_WINDOW_MAXIMIZED},
+		{SDL_PROP_WINDOW_CREATE_MENU_BOOLEAN, SDL_WINDOW_POPUP_MENU},
+		{SDL_PROP_WINDOW_CREATE_METAL_BOOLEAN, SDL_WINDOW_METAL},
+		{SDL_PROP_WINDOW_CREATE_MINIMIZED_BOOLEAN, SDL_WINDOW_MINIMIZED},
+		{SDL_PROP_WINDOW_CREATE_MODAL_BOOLEAN, SDL_WINDOW_MODAL},
+		{SDL_PROP_WINDOW_CREATE_MOUSE_GRABBED_BOOLEAN, SDL_WINDOW_MOUSE_GRABBED},
+		{SDL_PROP_WINDOW_CREATE_OPENGL_BOOLEAN, SDL_WINDOW_OPENGL},
+		{SDL_PROP_WINDOW_CREATE_RESIZABLE_BOOLEAN, SDL_WINDOW_RESIZABLE},
+		{SDL_PROP_WINDOW_CREATE_TOOLTIP_BOOLEAN, SDL_WINDOW_TOOLTIP},
+		{SDL_PROP_WINDOW_CREATE_TRANSPARENT_BOOLEAN, SDL_WINDOW_TRANSPARENT},
+		{SDL_PROP_WINDOW_CREATE_UTILITY_BOOLEAN, SDL_WINDOW_UTILITY},
+		{SDL_PROP_WINDOW_CREATE_VULKAN_BOOLEAN, SDL_WINDOW_VULKAN},
+	}
+	for _, b := range booleans {
+		if SDL_GetBooleanProperty(props, b.name, false) {
+			flags |= b.flag
+		}
+	}
+	if !SDL_GetBooleanProperty(props, SDL_PROP_WINDOW_CREATE_FOCUSABLE_BOOLEAN, true) {
+		flags |= SDL_WINDOW_NOT_FOCUSABLE
+	}
+	if sdlHasExternalWindowProperties(props) {
+		flags |= SDL_WINDOW_EXTERNAL
+	}
+	return flags
+}
+
+/**
+ * Create a window with the specified dimensions and flags.
+ *
+ * `flags` may be any of the following OR'd together:
+ *
+ * - `SDL_WINDOW_FULLSCREEN`: fullscreen window at desktop resolution
+ * - `SDL_WINDOW_OPENGL`: window usable with an OpenGL context
+ * - `SDL_WINDOW_HIDDEN`: window is not visible
+ * - `SDL_WINDOW_BORDERLESS`: no window decoration
+ * - `SDL_WINDOW_RESIZABLE`: window can be resized
+ * - `SDL_WINDOW_MINIMIZED`: window is minimized
+ * - `SDL_WINDOW_MAXIMIZED`: window is maximized
+ * - `SDL_WINDOW_MOUSE_GRABBED`: window has grabbed mouse focus
+ * - `SDL_WINDOW_HIGH_PIXEL_DENSITY`: window uses high pixel density back
+ *   buffer if possible
+ * - `SDL_WINDOW_ALWAYS_ON_TOP`: window should always be above others
+ * - `SDL_WINDOW_UTILITY`: window should be treated as a utility window, not
+ *   showing in the task bar and window list
+ * - `SDL_WINDOW_VULKAN`: window usable with a Vulkan instance
+ * - `SDL_WINDOW_METAL`: window usable with a Metal instance
+ * - `SDL_WINDOW_TRANSPARENT`: window with transparent buffer
+ * - `SDL_WINDOW_NOT_FOCUSABLE`: window should not be focusable
+ *
+ * The SDL_Window is implicitly shown if SDL_WINDOW_HIDDEN is not set.
+ *
+ * - title the title of the window, in UTF-8 encoding.
+ * - w the width of the window.
+ * - h the height of the window.
+ * - flags 0, or one or more SDL_WindowFlags OR'd together.
+ * Returns the window that was created or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateWindowWithProperties
+ * See also SDL_DestroyWindow
+ */
+func SDL_CreateWindow(title string, w int, h int, flags SDL_WindowFlags) *SDL_Window {
+	props := SDL_CreateProperties()
+	defer SDL_DestroyProperties(props)
+
+	if title != "" {
+		SDL_SetStringProperty(props, SDL_PROP_WINDOW_CREATE_TITLE_STRING, title)
+	}
+	SDL_SetNumberProperty(props, SDL_PROP_WINDOW_CREATE_WIDTH_NUMBER, int64(w))
+	SDL_SetNumberProperty(props, SDL_PROP_WINDOW_CREATE_HEIGHT_NUMBER, int64(h))
+	SDL_SetNumberProperty(props, SDL_PROP_WINDOW_CREATE_FLAGS_NUMBER, int64(flags))
+	return SDL_CreateWindowWithProperties(props)
+}
+
+// CreateWindow is SDL_CreateWindow() returning a Go error instead of nil.
+func CreateWindow(title string, w int, h int, flags SDL_WindowFlags) (*SDL_Window, error) {
+	return errorFromObject(SDL_CreateWindow(title, w, h, flags))
+}
+
+/**
+ * Create a window with the specified properties.
+ *
+ * These are the supported properties:
+ *
+ * - `SDL_PROP_WINDOW_CREATE_ALWAYS_ON_TOP_BOOLEAN`: true if the window should
+ *   be always on top
+ * - `SDL_PROP_WINDOW_CREATE_BORDERLESS_BOOLEAN`: true if the window has no
+ *   window decoration
+ * - `SDL_PROP_WINDOW_CREATE_CONSTRAIN_POPUP_BOOLEAN`: true if the popup
+ *   window position should be constrained to the display bounds (defaults
+ *   true)
+ * - `SDL_PROP_WINDOW_CREATE_EXTERNAL_GRAPHICS_CONTEXT_BOOLEAN`: true if the
+ *   application wants to use the window with its own graphics context,
+ *   wrapping an external window, SDL won't create or destroy one for it
+ * - `SDL_PROP_WINDOW_CREATE_FOCUSABLE_BOOLEAN`: true if the window should
+ *   accept keyboard input (defaults true)
+ * - `SDL_PROP_WINDOW_CREATE_FLAGS_NUMBER`: the window flags, combined with
+ *   the boolean properties
+ * - `SDL_PROP_WINDOW_CREATE_FULLSCREEN_BOOLEAN`: true if the window should
+ *   start in fullscreen mode at desktop resolution
+ * - `SDL_PROP_WINDOW_CREATE_HEIGHT_NUMBER`: the height of the window
+ * - `SDL_PROP_WINDOW_CREATE_HIDDEN_BOOLEAN`: true if the window should start
+ *   hidden
+ * - `SDL_PROP_WINDOW_CREATE_HIGH_PIXEL_DENSITY_BOOLEAN`: true if the window
+ *   uses a high pixel density buffer if possible
+ * - `SDL_PROP_WINDOW_CREATE_MAXIMIZED_BOOLEAN`: true if the window should
+ *   start maximized
+ * - `SDL_PROP_WINDOW_CREATE_MENU_BOOLEAN`: true if the window is a popup menu
+ * - `SDL_PROP_WINDOW_CREATE_METAL_BOOLEAN`: true if the window will be used
+ *   with Metal rendering
+ * - `SDL_PROP_WINDOW_CREATE_MINIMIZED_BOOLEAN`: true if the window should
+ *   start minimized
+ * - `SDL_PROP_WINDOW_CREATE_MODAL_BOOLEAN`: true if the window is modal to
+ *   its parent
+ * - `SDL_PROP_WINDOW_CREATE_MOUSE_GRABBED_BOOLEAN`: true if the window starts
+ *   with grabbed mouse focus
+ * - `SDL_PROP_WINDOW_CREATE_OPENGL_BOOLEAN`: true if the window will be used
+ *   with OpenGL rendering
+ * - `SDL_PROP_WINDOW_CREATE_PARENT_POINTER`: an SDL_Window that will be the
+ *   parent of this window, required for windows with the "tooltip", "menu"
+ *   and "modal" properties
+ * - `SDL_PROP_WINDOW_CREATE_RESIZABLE_BOOLEAN`: true if the window should be
+ *   resizable
+ * - `SDL_PROP_WINDOW_CREATE_TITLE_STRING`: the title of the window, in UTF-8
+ *   encoding
+ * - `SDL_PROP_WINDOW_CREATE_TOOLTIP_BOOLEAN`: true if the window is a tooltip
+ * - `SDL_PROP_WINDOW_CREATE_TRANSPARENT_BOOLEAN`: true if the window show
+ *   transparent in the areas with alpha of 0
+ * - `SDL_PROP_WINDOW_CREATE_UTILITY_BOOLEAN`: true if the window is a utility
+ *   window, not showing in the task bar and window list
+ * - `SDL_PROP_WINDOW_CREATE_VULKAN_BOOLEAN`: true if the window will be used
+ *   with Vulkan rendering
+ * - `SDL_PROP_WINDOW_CREATE_WIDTH_NUMBER`: the width of the window
+ * - `SDL_PROP_WINDOW_CREATE_X_NUMBER`: the x position of the window, or
+ *   `SDL_WINDOWPOS_CENTERED`, defaults to `SDL_WINDOWPOS_UNDEFINED`. This is
+ *   relative to the parent for popup windows.
+ * - `SDL_PROP_WINDOW_CREATE_Y_NUMBER`: the y position of the window, or
+ *   `SDL_WINDOWPOS_CENTERED`, defaults to `SDL_WINDOWPOS_UNDEFINED`. This is
+ *   relative to the parent for popup windows.
+ *
+ * These are additional supported properties on macOS:
+ *
+ * - `SDL_PROP_WINDOW_CREATE_COCOA_WINDOW_POINTER`: the
+ *   `(__unsafe_unretained)` NSWindow associated with the window, if you want
+ *   to wrap an existing window.
+ * - `SDL_PROP_WINDOW_CREATE_COCOA_VIEW_POINTER`: the `(__unsafe_unretained)`
+ *   NSView associated with the window, defaults to `[window contentView]`
+ *
+ * These are additional supported properties on Wayland:
+ *
+ * - `SDL_PROP_WINDOW_CREATE_WAYLAND_SURFACE_ROLE_CUSTOM_BOOLEAN` - true if
+ *   the application wants to use the Wayland surface for a custom role and
+ *   does not want it attached to an XDG toplevel window.
+ * - `SDL_PROP_WINDOW_CREATE_WAYLAND_CREATE_EGL_WINDOW_BOOLEAN` - true if the
+ *   application wants an associated `wl_egl_window` object to be created,
+ *   even if the window does not have the OpenGL property or flag set.
+ * - `SDL_PROP_WINDOW_CREATE_WAYLAND_WL_SURFACE_POINTER` - the wl_surface
+ *   associated with the window, if you want to wrap an existing window.
+ *
+ * These are additional supported properties on Windows:
+ *
+ * - `SDL_PROP_WINDOW_CREATE_WIN32_HWND_POINTER`: the HWND associated with the
+ *   window, if you want to wrap an existing window.
+ * - `SDL_PROP_WINDOW_CREATE_WIN32_PIXEL_FORMAT_HWND_POINTER`: optional,
+ *   another window to share pixel format with, useful for OpenGL windows
+ *
+ * These are additional supported properties with X11:
+ *
+ * - `SDL_PROP_WINDOW_CREATE_X11_WINDOW_NUMBER`: the X11 Window associated
+ *   with the window, if you want to wrap an existing window.
+ *
+ * These are additional supported properties in the browser:
+ *
+ * - `SDL_PROP_WINDOW_CREATE_JS_CANVAS_ID_STRING`: the id of the canvas
+ *   element to draw the window in. By default the element with the id
+ *   "canvas" is used by the first window, and a new canvas is added to the
+ *   page for the others.
+ *
+ * Wrapping an existing window sets `SDL_WINDOW_EXTERNAL`, the window is
+ * adopted by the video driver of its platform and is not destroyed with the
+ * SDL_Window. Drivers without a windowing system, like "dummy", keep only
+ * the SDL state of such a window.
+ *
+ * Widths and heights below 1 are raised to 1.
+ *
+ * - props the properties to use.
+ * Returns the window that was created or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateProperties
+ * See also SDL_CreateWindow
+ * See also SDL_DestroyWindow
+ */
+func SDL_CreateWindowWithProperties(props SDL_PropertiesID) *SDL_Window {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return nil
+	}
+
+	w := int(SDL_GetNumberProperty(props, SDL_PROP_WINDOW_CREATE_WIDTH_NUMBER, 0))
+	h := int(SDL_GetNumberProperty(props, SDL_PROP_WINDOW_CREATE_HEIGHT_NUMBER, 0))
+	w, h = max(w, 1), max(h, 1)
+	if w > sdlMaxWindowSize || h > sdlMaxWindowSize {
+		SDL_SetErrorf("Window is too large (%dx%d, the limit is %d)", w, h, sdlMaxWindowSize)
+		return nil
+	}
+
+	flags := sdlGetWindowCreateFlags(props) & sdlCreateWindowFlagsMask
+	parent, _ := SDL_GetPointerProperty(props, SDL_PROP_WINDOW_CREATE_PARENT_POINTER, nil).(*SDL_Window)
+	if parent != nil && !sdlCheckWindow(parent) {
+		return nil
+	}
+	if flags&(SDL_WINDOW_TOOLTIP|SDL_WINDOW_POPUP_MENU) != 0 {
+		if !sdlCheckPopupFlags(flags) {
+			return nil
+		}
+		if flags&SDL_WINDOW_UTILITY != 0 {
+			SDL_SetErrorf("Conflicting window type flags specified: 0x%.8x", uint64(flags))
+			return nil
+		}
+		if parent == nil {
+			SDL_SetError("Popup windows must have a parent")
+			return nil
+		}
+
+		/* Popups follow the state of their parent and are never decorated, tooltips never take the focus */
+		flags &^= SDL_WINDOW_FULLSCREEN | SDL_WINDOW_MINIMIZED | SDL_WINDOW_MAXIMIZED
+		flags |= SDL_WINDOW_BORDERLESS
+		if flags&SDL_WINDOW_TOOLTIP != 0 {
+			flags |= SDL_WINDOW_NOT_FOCUSABLE
+		}
+	}
+	if flags&SDL_WINDOW_MODAL != 0 && parent == nil {
+		SDL_SetError("Modal windows must have a parent")
+		return nil
+	}
+	if flags&SDL_WINDOW_MINIMIZED != 0 && flags&SDL_WINDOW_MAXIMIZED != 0 {
+		flags &^= SDL_WINDOW_MAXIMIZED
+	}
+
+	/* OpenGL windows hold a reference to the library, released by SDL_DestroyWindow() */
+	if flags&SDL_WINDOW_OPENGL != 0 {
+		if device.GL_CreateContext == nil {
+			SDL_SetErrorf("OpenGL support is either not configured in SDL or not available in current SDL video driver (%s) or platform", device.name)
+			return nil
+		}
+		if !SDL_GL_LoadLibrary("") {
+			return nil
+		}
+	}
+
+	/* Popups are placed relative to their parent, other windows centered on a display by default */
+	defaultPosition := int64(SDL_WINDOWPOS_UNDEFINED)
+	if flags&(SDL_WINDOW_TOOLTIP|SDL_WINDOW_POPUP_MENU) != 0 {
+		defaultPosition = 0
+	}
+	x := int(SDL_GetNumberProperty(props, SDL_PROP_WINDOW_CREATE_X_NUMBER, defaultPosition))
+	y := int(SDL_GetNumberProperty(props, SDL_PROP_WINDOW_CREATE_Y_NUMBER, defaultPosition))
+	if defaultPosition != 0 {
+		x, y = sdlResolveWindowPosition(x, y, w, h)
+	}
+
+	/* Windows are created hidden and shown once the driver is done with them */
+	window := &SDL_Window{
+		title:   SDL_GetStringProperty(props, SDL_PROP_WINDOW_CREATE_TITLE_STRING, ""),
+		flags:   flags | SDL_WINDOW_HIDDEN,
+		x:       x,
+		y:       y,
+		w:       w,
+		h:       h,
+		opacity: 1.0,
+		parent:  parent,
+
+		constrain_popup:           SDL_GetBooleanProperty(props, SDL_PROP_WINDOW_CREATE_CONSTRAIN_POPUP_BOOLEAN, true),
+		external_graphics_context: SDL_GetBooleanProperty(props, SDL_PROP_WINDOW_CREATE_EXTERNAL_GRAPHICS_CONTEXT_BOOLEAN, false),
+	}
+	window.x, window.y = sdlConstrainPopup(window, window.x, window.y)
+
+	window.handle = sdlCreateHandle(sdlHandleTypeWindow, window)
+
+	videoLock.Lock()
+	lastWindowID++
+	window.id = lastWindowID
+	videoWindows = append(videoWindows, window)
+	videoWindowsByID[window.id] = window
+	videoLock.Unlock()
+	sdlTrackObject("window", window)
+
+	if parent != nil {
+		parent.children = append(parent.children, window)
+	}
+
+	if device.CreateSDLWindow != nil && !device.CreateSDLWindow(device, window, props) {
+		SDL_DestroyWindow(window)
+		return nil
+	}
+	window.last_pixel_w, window.last_pixel_h = sdlGetWindowSizeInPixels(window)
+	window.last_displayID = SDL_GetDisplayForWindow(window)
+	sdlUpdateWindowHDRProperties(window, false)
+	sdlUpdateWindowDisplayScale(window, false)
+
+	if flags&SDL_WINDOW_FULLSCREEN != 0 {
+		window.flags &^= SDL_WINDOW_FULLSCREEN
+		sdlUpdateFullscreenMode(window, true)
+	}
+	if flags&SDL_WINDOW_HIDDEN == 0 {
+		sdlShowWindow(device, window)
+	}
+	return window
+}
+
+/* Make a window visible, if it isn't already */
+func sdlShowWindow(device *sdlVideoDevice, window *SDL_Window) {
+	if window.flags&SDL_WINDOW_HIDDEN == 0 {
+		return
+	}
+	if window.parent != nil && window.parent.flags&SDL_WINDOW_HIDDEN != 0 {
+		window.restore_on_show = true
+		return
+	}
+	if device.ShowWindow != nil {
+		device.ShowWindow(device, window)
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_SHOWN, 0, 0)
+
+	if window.pending_fullscreen {
+		window.pending_fullscreen = false
+		sdlUpdateFullscreenMode(window, true)
+	}
+	sdlOnPopupShown(window)
+
+	for _, child := range window.children {
+		if child.restore_on_show {
+			child.restore_on_show = false
+			sdlShowWindow(device, child)
+		}
+	}
+}
+
+/* Hide a window and the children shown with it, if it isn't already hidden */
+func sdlHideWindow(device *sdlVideoDevice, window *SDL_Window) {
+	if window.flags&SDL_WINDOW_HIDDEN != 0 {
+		return
+	}
+
+	if window.flags&SDL_WINDOW_FULLSCREEN != 0 {
+		sdlUpdateFullscreenMode(window, false)
+		window.pending_fullscreen = true
+	}
+	if device.HideWindow != nil {
+		device.HideWindow(device, window)
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_HIDDEN, 0, 0)
+	sdlOnPopupHidden(window)
+
+	for _, child := range window.children {
+		if child.flags&SDL_WINDOW_HIDDEN == 0 {
+			sdlHideWindow(device, child)
+			child.restore_on_show = true
+		}
+	}
+}
+
+/**
+ * Destroy a window.
+ *
+ * Any child windows owned by the window will be recursively destroyed as
+ * well.
+ *
+ * An SDL_EVENT_WINDOW_DESTROYED event is sent for the window, and its
+ * properties are destroyed.
+ *
+ * - window the window to destroy.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateWindow
+ * See also SDL_CreateWindowWithProperties
+ */
+func SDL_DestroyWindow(window *SDL_Window) {
+	if !sdlCheckWindow(window) {
+		return
+	}
+	device := sdlGetVideoDevice()
+
+	for len(window.children) > 0 {
+		SDL_DestroyWindow(window.children[len(window.children)-1])
+	}
+	sdlOnPopupHidden(window)
+
+	if window.kiosk != nil {
+		SDL_LeaveKioskMode(window)
+	}
+	SDL_StopTextInput(window)
+	sdlClearEditingTextCandidates(window.id)
+	sdlUpdateFullscreenMode(window, false)
+	if window.renderer != nil {
+		SDL_DestroyRenderer(window.renderer)
+	}
+	SDL_DestroyWindowSurface(window)
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_DESTROYED, 0, 0)
+	if device.grabbed_window == window {
+		device.grabbed_window = nil
+	}
+
+	sdlForgetGLCurrent(window, nil)
+
+	if device.DestroyWindow != nil {
+		device.DestroyWindow(device, window)
+	}
+	if window.flags&SDL_WINDOW_OPENGL != 0 {
+		SDL_GL_UnloadLibrary()
+	}
+
+	videoLock.Lock()
+	if i := slices.Index(videoWindows, window); i >= 0 {
+		videoWindows = slices.Delete(videoWindows, i, i+1)
+	}
+	delete(videoWindowsByID, window.id)
+	videoLock.Unlock()
+
+	if parent := window.parent; parent != nil {
+		if i := slices.Index(parent.children, window); i >= 0 {
+			parent.children = slices.Delete(parent.children, i, i+1)
+		}
+		window.parent = nil
+	}
+
+	if window.props != 0 {
+		SDL_DestroyProperties(window.props)
+		window.props = 0
+	}
+	sdlDestroyHandle(sdlHandleTypeWindow, window.handle)
+	sdlUntrackObject(window)
+}
+
+/**
+ * Get a list of valid windows.
+ *
+ * Returns the windows, in creation order, or nil if there are none.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetWindows() []*SDL_Window {
+	videoLock.RLock()
+	defer videoLock.RUnlock()
+
+	if len(videoWindows) == 0 {
+		return nil
+	}
+	return slices.Clone(videoWindows)
+}
+
+/**
+ * Get the numeric ID of a window.
+ *
+ * The numeric ID is what SDL_WindowEvent references, and is necessary to map
+ * these events to specific SDL_Window objects. IDs are never reused while the
+ * program runs, so events still queued for a destroyed window can't be
+ * mistaken for a newer one.
+ *
+ * - window the window to query.
+ * Returns the ID of the window on success or 0 on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowFromID
+ */
+func SDL_GetWindowID(window *SDL_Window) SDL_WindowID {
+	if !sdlCheckWindow(window) {
+		return 0
+	}
+	return window.id
+}
+
+/**
+ * Get a window from a stored ID.
+ *
+ * The numeric ID is what SDL_WindowEvent references, and is necessary to map
+ * these events to specific SDL_Window objects.
+ *
+ * - id the ID of the window.
+ * Returns the window associated with `id` or nil if it doesn't exist; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowID
+ */
+func SDL_GetWindowFromID(id SDL_WindowID) *SDL_Window {
+	if sdlGetVideoDevice() == nil {
+		return nil
+	}
+
+	videoLock.RLock()
+	window := videoWindowsByID[id]
+	videoLock.RUnlock()
+
+	if window == nil {
+		SDL_SetError("Invalid window")
+		return nil
+	}
+	return window
+}
+
+/**
+ * Get the window flags.
+ *
+ * - window the window to query.
+ * Returns a mask of the SDL_WindowFlags associated with `window`.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateWindow
+ */
+func SDL_GetWindowFlags(window *SDL_Window) SDL_WindowFlags {
+	if !sdlCheckWindow(window) {
+		return 0
+	}
+	return window.flags
+}
+
+/**
+ * Get the properties associated with a window.
+ *
+ * Video drivers publish their native handles here, e.g. the X11 window or
+ * the NSWindow of the window, so applications can interoperate with other
+ * libraries. The properties are destroyed with the window.
+ *
+ * The following read-only properties are provided by SDL:
+ *
+ * - `SDL_PROP_WINDOW_HDR_ENABLED_BOOLEAN`: true if the window has HDR
+ *   headroom above the SDR white point. This property can change
+ *   dynamically when SDL_EVENT_WINDOW_HDR_STATE_CHANGED is sent.
+ * - `SDL_PROP_WINDOW_SDR_WHITE_LEVEL_FLOAT`: the value of SDR white in the
+ *   linear sRGB colorspace. On Windows this corresponds to the SDR white
+ *   level in scRGB colorspace, and on Apple platforms this is always 1.0 for
+ *   EDR content. This property can change dynamically when
+ *   SDL_EVENT_WINDOW_HDR_STATE_CHANGED is sent.
+ * - `SDL_PROP_WINDOW_HDR_HEADROOM_FLOAT`: the additional high dynamic range
+ *   that can be displayed, in terms of the SDR white point. When HDR is not
+ *   enabled, this will be 1.0. This property can change dynamically when
+ *   SDL_EVENT_WINDOW_HDR_STATE_CHANGED is sent.
+ *
+ * On X11:
+ *
+ * - `SDL_PROP_WINDOW_X11_WINDOW_NUMBER`: the X11 Window associated with the
+ *   window, published by the X11 video driver.
+ *
+ * In the browser:
+ *
+ * - `SDL_PROP_WINDOW_JS_CANVAS_ID_STRING`: the id of the canvas element
+ *   of the window.
+ * - `SDL_PROP_WINDOW_JS_CANVAS_POINTER`: the canvas element of the window,
+ *   a syscall/js Value.
+ * - `SDL_PROP_WINDOW_JS_WEBGL_CONTEXT_POINTER`: the WebGL rendering context
+ *   of an OpenGL window, a syscall/js Value, once SDL_GL_CreateContext()
+ *   created it. Go WebGL bindings take it instead of function pointers.
+ *
+ * - window the window to query.
+ * Returns a valid property ID on success or 0 on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetWindowProperties(window *SDL_Window) SDL_PropertiesID {
+	if !sdlCheckWindow(window) {
+		return 0
+	}
+	if window.props == 0 {
+		window.props = SDL_CreateProperties()
+		sdlPublishWindowHDRProperties(window)
+	}
+	return window.props
+}
+
+const SDL_PROP_WINDOW_HDR_ENABLED_BOOLEAN = "SDL.window.HDR_enabled"
+const SDL_PROP_WINDOW_SDR_WHITE_LEVEL_FLOAT = "SDL.window.SDR_white_level"
+const SDL_PROP_WINDOW_HDR_HEADROOM_FLOAT = "SDL.window.HDR_headroom"
+const SDL_PROP_WINDOW_X11_WINDOW_NUMBER = "SDL.window.x11.window"
+const SDL_PROP_WINDOW_JS_CANVAS_ID_STRING = "SDL.window.js.canvas_id"
+const SDL_PROP_WINDOW_JS_CANVAS_POINTER = "SDL.window.js.canvas"
+const SDL_PROP_WINDOW_JS_WEBGL_CONTEXT_POINTER = "SDL.window.js.webgl_context"
+
+/* Copy the HDR state of a window into its properties, if they exist */
+func sdlPublishWindowHDRProperties(window *SDL_Window) {
+	if window.props == 0 {
+		return
+	}
+	SDL_SetBooleanProperty(window.props, SDL_PROP_WINDOW_HDR_ENABLED_BOOLEAN, window.hdr.HDR_headroom > 1.0)
+	SDL_SetFloatProperty(window.props, SDL_PROP_WINDOW_SDR_WHITE_LEVEL_FLOAT, window.hdr.SDR_white_level)
+	SDL_SetFloatProperty(window.props, SDL_PROP_WINDOW_HDR_HEADROOM_FLOAT, window.hdr.HDR_headroom)
+}
+
+/* Take the HDR state of the display the window is on, and tell the application when it changed */
+func sdlUpdateWindowHDRProperties(window *SDL_Window, send_event bool) {
+	hdr := sdlGetDisplayHDRProperties(window.last_displayID)
+	if hdr == window.hdr {
+		return
+	}
+	window.hdr = hdr
+	sdlPublishWindowHDRProperties(window)
+	if send_event {
+		sdlSendWindowEvent(window, SDL_EVENT_WINDOW_HDR_STATE_CHANGED, tern(hdr.HDR_headroom > 1.0, 1, 0), 0)
+	}
+}
+
+/* Send SDL_EVENT_WINDOW_DISPLAY_CHANGED when a window moved to another display */
+func sdlCheckWindowDisplayChanged(window *SDL_Window) {
+	displayID := SDL_GetDisplayForWindow(window)
+	if displayID == 0 || displayID == window.last_displayID {
+		return
+	}
+	window.last_displayID = displayID
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_DISPLAY_CHANGED, int(displayID), 0)
+	sdlUpdateWindowHDRProperties(window, true)
+	sdlUpdateWindowDisplayScale(window, true)
+}
+
+/**
+ * Get the pixel format associated with the window.
+ *
+ * - window the window to query.
+ * Returns the pixel format of the window on success or
+ *          SDL_PIXELFORMAT_UNKNOWN on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetWindowPixelFormat(window *SDL_Window) SDL_PixelFormat {
+	if !sdlCheckWindow(window) {
+		return SDL_PIXELFORMAT_UNKNOWN
+	}
+	mode := SDL_GetCurrentDisplayMode(SDL_GetDisplayForWindow(window))
+	if mode == nil {
+		return SDL_PIXELFORMAT_UNKNOWN
+	}
+	return mode.Format
+}
+
+/**
+ * An enumeration of progress states that can be shown for a window in the
+ * taskbar, dock or launcher.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_ProgressState int32
+
+const (
+	SDL_PROGRESS_STATE_INVALID       SDL_ProgressState = iota - 1 /**< An invalid progress state indicating an error; check SDL_GetError() */
+	SDL_PROGRESS_STATE_NONE                                       /**< No progress bar is shown */
+	SDL_PROGRESS_STATE_INDETERMINATE                              /**< The progress bar is shown in a indeterminate state */
+	SDL_PROGRESS_STATE_NORMAL                                     /**< The progress bar is shown in a normal state */
+	SDL_PROGRESS_STATE_PAUSED                                     /**< The progress bar is shown in a paused state */
+	SDL_PROGRESS_STATE_ERROR                                      /**< The progress bar is shown in a state indicating the application had an error */
+)
+
+/* Pass the window progress on to the driver, it's only remembered if the driver can't show it */
+func sdlApplyWindowProgress(window *SDL_Window) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+	if device.ApplyWindowProgress != nil {
+		return device.ApplyWindowProgress(device, window)
+	}
+	return true
+}
+
+/**
+ * Sets the state of the progress bar for the given window's taskbar icon.
+ *
+ * On Windows this is the taskbar button progress, on Linux the Unity
+ * LauncherEntry progress and on macOS the dock tile.
+ *
+ * - window the window whose progress state is to be modified.
+ * - state the progress state. SDL_PROGRESS_STATE_NONE stops displaying
+ *              the progress bar.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowProgressState
+ * See also SDL_SetWindowProgressValue
+ */
+func SDL_SetWindowProgressState(window *SDL_Window, state SDL_ProgressState) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if state < SDL_PROGRESS_STATE_NONE || state > SDL_PROGRESS_STATE_ERROR {
+		return SDL_InvalidParamError("state")
+	}
+
+	window.progress_state = state
+	return sdlApplyWindowProgress(window)
+}
+
+/**
+ * Get the state of the progress bar for the given window's taskbar icon.
+ *
+ * - window the window to get the current progress state from.
+ * Returns the progress state, or SDL_PROGRESS_STATE_INVALID on failure;
+ *          call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowProgressState
+ */
+func SDL_GetWindowProgressState(window *SDL_Window) SDL_ProgressState {
+	if !sdlCheckWindow(window) {
+		return SDL_PROGRESS_STATE_INVALID
+	}
+	return window.progress_state
+}
+
+/**
+ * Sets the value of the progress bar for the given window's taskbar icon.
+ *
+ * The value is only shown while the progress state is
+ * SDL_PROGRESS_STATE_NORMAL, SDL_PROGRESS_STATE_PAUSED or
+ * SDL_PROGRESS_STATE_ERROR.
+ *
+ * - window the window whose progress value is to be modified.
+ * - value the progress value in the range of [0.0f - 1.0f]. If the value
+ *              is outside the valid range, it gets clamped.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowProgressValue
+ * See also SDL_SetWindowProgressState
+ */
+func SDL_SetWindowProgressValue(window *SDL_Window, value float32) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+
+	window.progress_value = min(max(value, 0), 1)
+	return sdlApplyWindowProgress(window)
+}
+
+/**
+ * Get the value of the progress bar for the given window's taskbar icon.
+ *
+ * - window the window to get the current progress value from.
+ * Returns the progress value in the range of [0.0f - 1.0f], or -1.0f on
+ *          failure; call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowProgressValue
+ */
+func SDL_GetWindowProgressValue(window *SDL_Window) float32 {
+	if !sdlCheckWindow(window) {
+		return -1
+	}
+	return window.progress_value
+}
+
+/* The display containing the center of a rectangle, or the primary display */
+func sdlGetDisplayForRect(rect SDL_Rect) SDL_DisplayID {
+	cx, cy := rect.X+rect.W/2, rect.Y+rect.H/2
+
+	displaysLock.RLock()
+	defer displaysLock.RUnlock()
+
+	for _, display := range displays {
+		b := display.bounds
+		if cx >= b.X && cx < b.X+b.W && cy >= b.Y && cy < b.Y+b.H {
+			return display.id
+		}
+	}
+	if len(displays) == 0 {
+		SDL_SetError("No displays available")
+		return 0
+	}
+	return displays[0].id
+}
+
+/**
+ * Get the display associated with a window.
+ *
+ * - window the window to query.
+ * Returns the instance ID of the display containing the center of the window
+ *          on success or 0 on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetDisplayBounds
+ * See also SDL_GetDisplays
+ */
+func SDL_GetDisplayForWindow(window *SDL_Window) SDL_DisplayID {
+	if !sdlCheckWindow(window) {
+		return 0
+	}
+	if window.flags&SDL_WINDOW_FULLSCREEN != 0 {
+		return window.fullscreen_display
+	}
+	x, y := sdlRelativeToGlobalForWindow(window, window.x, window.y)
+	return sdlGetDisplayForRect(SDL_Rect{X: x, Y: y, W: window.w, H: window.h})
+}
+
+/*
+ * Enter or leave fullscreen. Exclusive fullscreen switches the display to the
+ * requested mode, desktop fullscreen covers the display at its desktop mode.
+ * A display is covered by one window at a time, the previous one leaves
+ * fullscreen.
+ */
+func sdlUpdateFullscreenMode(window *SDL_Window, fullscreen bool) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+
+	if !fullscreen {
+		if window.flags&SDL_WINDOW_FULLSCREEN == 0 {
+			return true
+		}
+		displayID := window.fullscreen_display
+		if window.fullscreen_exclusive {
+			sdlSetDisplayModeForDisplay(displayID, nil)
+		}
+		if device.SetWindowFullscreen != nil {
+			device.SetWindowFullscreen(device, window, displayID, false)
+		}
+
+		displaysLock.Lock()
+		if display := sdlFindDisplayLocked(displayID); display != nil && display.fullscreen_window == window {
+			display.fullscreen_window = nil
+		}
+		displaysLock.Unlock()
+
+		window.fullscreen_exclusive = false
+		window.fullscreen_display = 0
+		sdlSendWindowEvent(window, SDL_EVENT_WINDOW_LEAVE_FULLSCREEN, 0, 0)
+		sdlSendWindowEvent(window, SDL_EVENT_WINDOW_MOVED, window.windowed.X, window.windowed.Y)
+		sdlSendWindowEvent(window, SDL_EVENT_WINDOW_RESIZED, window.windowed.W, window.windowed.H)
+		return true
+	}
+
+	var mode *SDL_DisplayMode
+	var displayID SDL_DisplayID
+	if window.requested_fullscreen_mode.W != 0 {
+		mode = &window.requested_fullscreen_mode
+		displayID = mode.DisplayID
+	} else {
+		displayID = SDL_GetDisplayForWindow(window)
+	}
+	if window.flags&SDL_WINDOW_FULLSCREEN != 0 && window.fullscreen_display != displayID {
+		/* Moving to another display, release the one we're on */
+		sdlUpdateFullscreenMode(window, false)
+	}
+
+	displaysLock.RLock()
+	var other *SDL_Window
+	var bounds SDL_Rect
+	display := sdlFindDisplayLocked(displayID)
+	if display != nil {
+		other, bounds = display.fullscreen_window, display.bounds
+	}
+	displaysLock.RUnlock()
+	if display == nil {
+		return SDL_SetErrorf("Invalid display %d", displayID)
+	}
+	if other != nil && other != window {
+		sdlUpdateFullscreenMode(other, false)
+	}
+
+	if !sdlSetDisplayModeForDisplay(displayID, mode) {
+		return false
+	}
+	if device.SetWindowFullscreen != nil && !device.SetWindowFullscreen(device, window, displayID, true) {
+		if mode != nil {
+			sdlSetDisplayModeForDisplay(displayID, nil)
+		}
+		return false
+	}
+
+	displaysLock.Lock()
+	if display = sdlFindDisplayLocked(displayID); display != nil {
+		display.fullscreen_window = window
+	}
+	displaysLock.Unlock()
+
+	if window.flags&SDL_WINDOW_FULLSCREEN == 0 {
+		window.windowed = SDL_Rect{X: window.x, Y: window.y, W: window.w, H: window.h}
+	}
+	window.fullscreen_exclusive = mode != nil
+	window.fullscreen_display = displayID
+
+	w, h := bounds.W, bounds.H
+	if mode != nil {
+		w, h = int(float32(mode.W)/mode.PixelDensity), int(float32(mode.H)/mode.PixelDensity)
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_ENTER_FULLSCREEN, 0, 0)
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_MOVED, bounds.X, bounds.Y)
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_RESIZED, w, h)
+	return true
+}
+
+/*
+ * Called when a window is minimized or restored. A minimized exclusive
+ * fullscreen window gives the desktop mode back to the user, and takes its
+ * mode again once restored.
+ */
+func sdlOnWindowMinimizedChanged(window *SDL_Window, minimized bool) {
+	if !window.fullscreen_exclusive {
+		return
+	}
+	if minimized {
+		sdlSetDisplayModeForDisplay(window.fullscreen_display, nil)
+	} else {
+		sdlSetDisplayModeForDisplay(window.fullscreen_display, &window.requested_fullscreen_mode)
+	}
+}
+
+/* Minimize a window, the driver reports the change if it can't do it synchronously */
+func sdlMinimizeWindow(window *SDL_Window) {
+	device := sdlGetVideoDevice()
+	if device == nil || window.flags&SDL_WINDOW_MINIMIZED != 0 {
+		return
+	}
+	if device.MinimizeWindow != nil {
+		device.MinimizeWindow(device, window)
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_MINIMIZED, 0, 0)
+}
+
+/**
+ * Set the display mode to use when a window is visible and fullscreen.
+ *
+ * This only affects the display mode used when the window is fullscreen. To
+ * change the window size when the window is not fullscreen, use
+ * SDL_SetWindowSize().
+ *
+ * If the window is currently in the fullscreen state, this request is
+ * applied immediately.
+ *
+ * - window the window to affect.
+ * - mode a pointer to the display mode to use, which can be nil for
+ *             borderless fullscreen desktop mode, or one of the fullscreen
+ *             modes returned by SDL_GetFullscreenDisplayModes() to change
+ *             the display mode (exclusive fullscreen).
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowFullscreenMode
+ * See also SDL_SetWindowFullscreen
+ */
+func SDL_SetWindowFullscreenMode(window *SDL_Window, mode *SDL_DisplayMode) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+
+	if mode != nil {
+		var match *SDL_DisplayMode
+		modes := SDL_GetFullscreenDisplayModes(mode.DisplayID)
+		for i := range modes {
+			if sdlDisplayModesEqual(&modes[i], mode) {
+				match = &modes[i]
+				break
+			}
+		}
+		if match == nil {
+			return SDL_SetError("Invalid fullscreen display mode")
+		}
+		window.requested_fullscreen_mode = *match
+	} else {
+		window.requested_fullscreen_mode = SDL_DisplayMode{}
+	}
+
+	if window.flags&SDL_WINDOW_FULLSCREEN != 0 {
+		return sdlUpdateFullscreenMode(window, true)
+	}
+	return true
+}
+
+/**
+ * Query the display mode to use when a window is visible at fullscreen.
+ *
+ * - window the window to query.
+ * Returns a copy of the exclusive fullscreen mode to use or nil for
+ *          borderless fullscreen desktop mode.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowFullscreenMode
+ * See also SDL_SetWindowFullscreen
+ */
+func SDL_GetWindowFullscreenMode(window *SDL_Window) *SDL_DisplayMode {
+	if !sdlCheckWindow(window) || window.requested_fullscreen_mode.W == 0 {
+		return nil
+	}
+	mode := window.requested_fullscreen_mode
+	return &mode
+}
+
+/**
+ * Request that the window's fullscreen state be changed.
+ *
+ * By default a window in fullscreen state uses borderless fullscreen desktop
+ * mode, but a specific exclusive display mode can be set using
+ * SDL_SetWindowFullscreenMode().
+ *
+ * SDL_EVENT_WINDOW_ENTER_FULLSCREEN or SDL_EVENT_WINDOW_LEAVE_FULLSCREEN is
+ * sent when the state changes, followed by the new window position and size.
+ * Leaving fullscreen restores the window geometry from before, and the
+ * desktop mode of the display if it was changed.
+ *
+ * - window the window to change.
+ * - fullscreen true for fullscreen mode, false for windowed mode.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowFullscreenMode
+ * See also SDL_SetWindowFullscreenMode
+ */
+func SDL_SetWindowFullscreen(window *SDL_Window, fullscreen bool) bool {
+	if !sdlCheckWindowNotPopup(window) {
+		return false
+	}
+	if (window.flags&SDL_WINDOW_FULLSCREEN != 0) == fullscreen {
+		return true
+	}
+	return sdlUpdateFullscreenMode(window, fullscreen)
+}
+
+/**
+ * Set the title of a window.
+ *
+ * This string is expected to be in UTF-8 encoding.
+ *
+ * - window the window to change.
+ * - title the desired window title in UTF-8 format.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowTitle
+ */
+func SDL_SetWindowTitle(window *SDL_Window, title string) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if title == window.title {
+		return true
+	}
+
+	window.title = title
+	if device := sdlGetVideoDevice(); device.SetWindowTitle != nil {
+		device.SetWindowTitle(device, window)
+	}
+	return true
+}
+
+/**
+ * Get the title of a window.
+ *
+ * - window the window to query.
+ * Returns the title of the window in UTF-8 format or "" if there is no
+ *          title.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowTitle
+ */
+func SDL_GetWindowTitle(window *SDL_Window) string {
+	if !sdlCheckWindow(window) {
+		return ""
+	}
+	return window.title
+}
+
+/**
+ * Set the icon for a window.
+ *
+ * The window doesn't keep a reference to the surface, the video driver
+ * copies the pixels it needs.
+ *
+ * - window the window to change.
+ * - icon an SDL_Surface structure containing the icon for the window.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_SetWindowIcon(window *SDL_Window, icon *SDL_Surface) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if icon == nil {
+		return SDL_InvalidParamError("icon")
+	}
+
+	device := sdlGetVideoDevice()
+	if device.SetWindowIcon == nil {
+		return SDL_Unsupported()
+	}
+	return device.SetWindowIcon(device, window, icon)
+}
+
+/**
+ * Request that the window's position be set.
+ *
+ * If the window is in a fullscreen state, this request only affects the
+ * position used when the window leaves fullscreen.
+ *
+ * The position is in screen coordinates, the window manager may still
+ * adjust it; SDL_EVENT_WINDOW_MOVED is sent with the final position.
+ *
+ * - window the window to reposition.
+ * - x the x coordinate of the window, or `SDL_WINDOWPOS_CENTERED` or
+ *          `SDL_WINDOWPOS_UNDEFINED`.
+ * - y the y coordinate of the window, or `SDL_WINDOWPOS_CENTERED` or
+ *          `SDL_WINDOWPOS_UNDEFINED`.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowPosition
+ */
+func SDL_SetWindowPosition(window *SDL_Window, x int, y int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if !sdlIsPopup(window) {
+		x, y = sdlResolveWindowPosition(x, y, window.w, window.h)
+	}
+	if window.flags&SDL_WINDOW_FULLSCREEN != 0 {
+		window.windowed.X, window.windowed.Y = x, y
+		return true
+	}
+
+	x, y = sdlConstrainPopup(window, x, y)
+
+	device := sdlGetVideoDevice()
+	if device.SetWindowPosition != nil && !device.SetWindowPosition(device, window, x, y) {
+		return false
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_MOVED, x, y)
+	return true
+}
+
+/**
+ * Get the position of a window.
+ *
+ * This is the current position of the window as last reported by the
+ * windowing system. The position of a popup window is relative to its
+ * parent.
+ *
+ * - window the window to query.
+ * - x a pointer filled in with the x position of the window, may be nil.
+ * - y a pointer filled in with the y position of the window, may be nil.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowPosition
+ */
+func SDL_GetWindowPosition(window *SDL_Window, x *int, y *int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if x != nil {
+		*x = window.x
+	}
+	if y != nil {
+		*y = window.y
+	}
+	return true
+}
+
+/* Clamp a size to the window size limits */
+func (window *SDL_Window) clampSize(w, h int) (int, int) {
+	if window.min_w > 0 {
+		w = max(w, window.min_w)
+	}
+	if window.min_h > 0 {
+		h = max(h, window.min_h)
+	}
+	if window.max_w > 0 {
+		w = min(w, window.max_w)
+	}
+	if window.max_h > 0 {
+		h = min(h, window.max_h)
+	}
+	return w, h
+}
+
+/**
+ * Request that the size of a window's client area be set.
+ *
+ * If the window is in a fullscreen state, this request only affects the size
+ * used when the window leaves fullscreen. To change the exclusive fullscreen
+ * mode of a window, use SDL_SetWindowFullscreenMode().
+ *
+ * The size is clamped to the minimum and maximum size of the window.
+ *
+ * - window the window to change.
+ * - w the width of the window, must be > 0.
+ * - h the height of the window, must be > 0.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowSize
+ * See also SDL_SetWindowFullscreenMode
+ */
+func SDL_SetWindowSize(window *SDL_Window, w int, h int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if w <= 0 {
+		return SDL_InvalidParamError("w")
+	}
+	if h <= 0 {
+		return SDL_InvalidParamError("h")
+	}
+
+	w, h = window.clampSize(w, h)
+	if window.flags&SDL_WINDOW_FULLSCREEN != 0 {
+		window.windowed.W, window.windowed.H = w, h
+		return true
+	}
+
+	if device := sdlGetVideoDevice(); device.SetWindowSize != nil {
+		device.SetWindowSize(device, window, w, h)
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_RESIZED, w, h)
+	return true
+}
+
+/**
+ * Get the size of a window's client area.
+ *
+ * The window pixel size may differ from its window coordinate size if the
+ * window is on a high pixel density display.
+ *
+ * - window the window to query the width and height from.
+ * - w a pointer filled in with the width of the window, may be nil.
+ * - h a pointer filled in with the height of the window, may be nil.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowSize
+ */
+func SDL_GetWindowSize(window *SDL_Window, w *int, h *int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if w != nil {
+		*w = window.w
+	}
+	if h != nil {
+		*h = window.h
+	}
+	return true
+}
+
+/*
+ * The size of the window client area in pixels. Drivers that know better
+ * provide it, otherwise it's the size scaled by the pixel density of the
+ * display mode the window is shown with.
+ */
+func sdlGetWindowSizeInPixels(window *SDL_Window) (int, int) {
+	device := sdlGetVideoDevice()
+	if device.GetWindowSizeInPixels != nil {
+		return device.GetWindowSizeInPixels(device, window)
+	}
+
+	/* Without SDL_WINDOW_HIGH_PIXEL_DENSITY a window gets a pixel per screen coordinate, except in an exclusive mode */
+	w, h := window.w, window.h
+	var mode *SDL_DisplayMode
+	if displayID := SDL_GetDisplayForWindow(window); window.flags&SDL_WINDOW_FULLSCREEN != 0 && window.fullscreen_exclusive {
+		mode = SDL_GetCurrentDisplayMode(displayID)
+	} else if window.flags&SDL_WINDOW_HIGH_PIXEL_DENSITY != 0 {
+		mode = SDL_GetDesktopDisplayMode(displayID)
+	}
+	if mode != nil && mode.PixelDensity > 0 {
+		w = int(math.Ceil(float64(float32(w) * mode.PixelDensity)))
+		h = int(math.Ceil(float64(float32(h) * mode.PixelDensity)))
+	}
+	return w, h
+}
+
+/* Send SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED if the size in pixels differs from the one last reported */
+func sdlCheckWindowPixelSizeChanged(window *SDL_Window) {
+	w, h := sdlGetWindowSizeInPixels(window)
+	if w != window.last_pixel_w || h != window.last_pixel_h {
+		window.last_pixel_w, window.last_pixel_h = w, h
+		sdlSendWindowEvent(window, SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED, w, h)
+		sdlUpdateWindowDisplayScale(window, true)
+	}
+}
+
+/**
+ * Get the size of a window's client area, in pixels.
+ *
+ * This may differ from SDL_GetWindowSize() on high pixel density displays,
+ * where a window of a given size in screen coordinates is backed by more
+ * pixels. Use this to size framebuffers and render targets.
+ *
+ * - window the window from which the drawable size should be queried.
+ * - w a pointer to variable for storing the width in pixels, may be nil.
+ * - h a pointer to variable for storing the height in pixels, may be nil.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_CreateWindow
+ * See also SDL_GetWindowSize
+ */
+func SDL_GetWindowSizeInPixels(window *SDL_Window, w *int, h *int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	pw, ph := sdlGetWindowSizeInPixels(window)
+	if w != nil {
+		*w = pw
+	}
+	if h != nil {
+		*h = ph
+	}
+	return true
+}
+
+/* The pixels per screen coordinate of a window */
+func sdlGetWindowPixelDensity(window *SDL_Window) float32 {
+	pw, _ := sdlGetWindowSizeInPixels(window)
+	if window.w <= 0 || pw <= 0 {
+		return 1.0
+	}
+	return float32(pw) / float32(window.w)
+}
+
+/* Recompute the display scale of a window, sending SDL_EVENT_WINDOW_DISPLAY_SCALE_CHANGED if asked and it changed */
+func sdlUpdateWindowDisplayScale(window *SDL_Window, send_event bool) {
+	var display_scale float32
+	if device := sdlGetVideoDevice(); device.GetWindowContentScale != nil {
+		display_scale = device.GetWindowContentScale(device, window)
+	} else {
+		display_scale = sdlGetWindowPixelDensity(window) * max(SDL_GetDisplayContentScale(SDL_GetDisplayForWindow(window)), 1.0)
+	}
+	if display_scale == window.display_scale {
+		return
+	}
+	window.display_scale = display_scale
+	if send_event {
+		sdlSendWindowEvent(window, SDL_EVENT_WINDOW_DISPLAY_SCALE_CHANGED, 0, 0)
+	}
+}
+
+/**
+ * Get the pixel density of a window.
+ *
+ * This is a ratio of pixel size to window size. For example, if the window
+ * is 1920x1080 and it has a high density back buffer of 3840x2160 pixels, it
+ * would have a pixel density of 2.0.
+ *
+ * Windows only get a high density back buffer when created with
+ * `SDL_WINDOW_HIGH_PIXEL_DENSITY`, otherwise the pixel density is 1.0 except
+ * in exclusive fullscreen modes.
+ *
+ * - window the window to query.
+ * Returns the pixel density or 0.0f on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowDisplayScale
+ */
+func SDL_GetWindowPixelDensity(window *SDL_Window) float32 {
+	if !sdlCheckWindow(window) {
+		return 0.0
+	}
+	return sdlGetWindowPixelDensity(window)
+}
+
+/**
+ * Get the content display scale relative to a window's pixel size.
+ *
+ * This is a combination of the window pixel density and the display content
+ * scale, and is the expected scale for displaying content in this window. For
+ * example, if a 3840x2160 window had a display scale of 2.0, the user expects
+ * the content to take twice as many pixels and be the same physical size as
+ * if it were being displayed in a 1920x1080 window with a display scale of
+ * 1.0.
+ *
+ * Conceptually this value corresponds to the scale display setting, and is
+ * updated when that setting is changed, or the window moves to a display
+ * with a different scale setting.
+ *
+ * - window the window to query.
+ * Returns the display scale, or 0.0f on failure; call SDL_GetError() for
+ *          more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowPixelDensity
+ * See also SDL_GetDisplayContentScale
+ */
+func SDL_GetWindowDisplayScale(window *SDL_Window) float32 {
+	if !sdlCheckWindow(window) {
+		return 0.0
+	}
+	return window.display_scale
+}
+
+/*
+ * Called by video backends when the parts of a window covered by notches,
+ * rounded corners or system overlays change. Desktop windows keep the
+ * default of no insets.
+ */
+func sdlSetWindowSafeAreaInsets(window *SDL_Window, left, right, top, bottom int) {
+	if window.safe_inset_left == left && window.safe_inset_right == right &&
+		window.safe_inset_top == top && window.safe_inset_bottom == bottom {
+		return
+	}
+	window.safe_inset_left = left
+	window.safe_inset_right = right
+	window.safe_inset_top = top
+	window.safe_inset_bottom = bottom
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_SAFE_AREA_CHANGED, 0, 0)
+}
+
+/**
+ * Get the safe area for this window.
+ *
+ * Some devices have portions of the screen which are partially obscured or
+ * not interactive, possibly due to on-screen controls, curved edges, camera
+ * notches, TV overscan, etc. This function provides the area of the window
+ * which is safe to have interactable content. You should continue rendering
+ * into the rest of the window, but it should not contain visually important
+ * or interactible content.
+ *
+ * - window the window to query.
+ * - rect a pointer filled in with the client area that is safe for
+ *             interactive content.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetWindowSafeArea(window *SDL_Window, rect *SDL_Rect) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if rect == nil {
+		return SDL_InvalidParamError("rect")
+	}
+
+	rect.X = window.safe_inset_left
+	rect.Y = window.safe_inset_top
+	rect.W = max(window.w-window.safe_inset_left-window.safe_inset_right, 0)
+	rect.H = max(window.h-window.safe_inset_top-window.safe_inset_bottom, 0)
+	return true
+}
+
+/**
+ * Set the minimum size of a window's client area.
+ *
+ * - window the window to change.
+ * - min_w the minimum width of the window, or 0 for no limit.
+ * - min_h the minimum height of the window, or 0 for no limit.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowMinimumSize
+ * See also SDL_SetWindowMaximumSize
+ */
+func SDL_SetWindowMinimumSize(window *SDL_Window, min_w int, min_h int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if min_w < 0 {
+		return SDL_InvalidParamError("min_w")
+	}
+	if min_h < 0 {
+		return SDL_InvalidParamError("min_h")
+	}
+	if (window.max_w > 0 && min_w > window.max_w) || (window.max_h > 0 && min_h > window.max_h) {
+		return SDL_SetError("SDL_SetWindowMinimumSize(): Tried to set minimum size larger than maximum size")
+	}
+
+	window.min_w, window.min_h = min_w, min_h
+	if device := sdlGetVideoDevice(); device.SetWindowMinimumSize != nil {
+		device.SetWindowMinimumSize(device, window)
+	}
+
+	/* Ensure that window is not smaller than minimal size */
+	w, h := window.clampSize(window.w, window.h)
+	return SDL_SetWindowSize(window, w, h)
+}
+
+/**
+ * Get the minimum size of a window's client area.
+ *
+ * - window the window to query.
+ * - w a pointer filled in with the minimum width of the window, may be
+ *          nil.
+ * - h a pointer filled in with the minimum height of the window, may be
+ *          nil.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowMaximumSize
+ * See also SDL_SetWindowMinimumSize
+ */
+func SDL_GetWindowMinimumSize(window *SDL_Window, w *int, h *int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if w != nil {
+		*w = window.min_w
+	}
+	if h != nil {
+		*h = window.min_h
+	}
+	return true
+}
+
+/**
+ * Set the maximum size of a window's client area.
+ *
+ * - window the window to change.
+ * - max_w the maximum width of the window, or 0 for no limit.
+ * - max_h the maximum height of the window, or 0 for no limit.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowMaximumSize
+ * See also SDL_SetWindowMinimumSize
+ */
+func SDL_SetWindowMaximumSize(window *SDL_Window, max_w int, max_h int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if max_w < 0 {
+		return SDL_InvalidParamError("max_w")
+	}
+	if max_h < 0 {
+		return SDL_InvalidParamError("max_h")
+	}
+	if (max_w > 0 && max_w < window.min_w) || (max_h > 0 && max_h < window.min_h) {
+		return SDL_SetError("SDL_SetWindowMaximumSize(): Tried to set maximum size smaller than minimum size")
+	}
+
+	window.max_w, window.max_h = max_w, max_h
+	if device := sdlGetVideoDevice(); device.SetWindowMaximumSize != nil {
+		device.SetWindowMaximumSize(device, window)
+	}
+
+	/* Ensure that window is not larger than maximal size */
+	w, h := window.clampSize(window.w, window.h)
+	return SDL_SetWindowSize(window, w, h)
+}
+
+/**
+ * Get the maximum size of a window's client area.
+ *
+ * - window the window to query.
+ * - w a pointer filled in with the maximum width of the window, may be
+ *          nil.
+ * - h a pointer filled in with the maximum height of the window, may be
+ *          nil.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowMinimumSize
+ * See also SDL_SetWindowMaximumSize
+ */
+func SDL_GetWindowMaximumSize(window *SDL_Window, w *int, h *int) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if w != nil {
+		*w = window.max_w
+	}
+	if h != nil {
+		*h = window.max_h
+	}
+	return true
+}
+
+/* Change a window flag the application can toggle at runtime, then let the driver apply it */
+func sdlSetWindowFlag(window *SDL_Window, flag SDL_WindowFlags, on bool, apply func(device *sdlVideoDevice, window *SDL_Window, on bool)) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if (window.flags&flag != 0) == on {
+		return true
+	}
+
+	if on {
+		window.flags |= flag
+	} else {
+		window.flags &^= flag
+	}
+	if apply != nil {
+		apply(sdlGetVideoDevice(), window, on)
+	}
+	return true
+}
+
+/**
+ * Set the border state of a window.
+ *
+ * This will add or remove the window's `SDL_WINDOW_BORDERLESS` flag and add
+ * or remove the border from the actual window. This is a no-op if the
+ * window's border already matches the requested state.
+ *
+ * - window the window of which to change the border state.
+ * - bordered false to remove border, true to add border.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowFlags
+ */
+func SDL_SetWindowBordered(window *SDL_Window, bordered bool) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	var apply func(device *sdlVideoDevice, window *SDL_Window, borderless bool)
+	if setBordered := sdlGetVideoDevice().SetWindowBordered; setBordered != nil {
+		apply = func(device *sdlVideoDevice, window *SDL_Window, borderless bool) {
+			setBordered(device, window, !borderless)
+		}
+	}
+	return sdlSetWindowFlag(window, SDL_WINDOW_BORDERLESS, !bordered, apply)
+}
+
+/**
+ * Set the user-resizable state of a window.
+ *
+ * This will add or remove the window's `SDL_WINDOW_RESIZABLE` flag and
+ * allow/disallow user resizing of the window. This is a no-op if the
+ * window's resizable state already matches the requested state.
+ *
+ * - window the window of which to change the resizable state.
+ * - resizable true to allow resizing, false to disallow.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowFlags
+ */
+func SDL_SetWindowResizable(window *SDL_Window, resizable bool) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	return sdlSetWindowFlag(window, SDL_WINDOW_RESIZABLE, resizable, sdlGetVideoDevice().SetWindowResizable)
+}
+
+/**
+ * Set the window to always be above the others.
+ *
+ * This will add or remove the window's `SDL_WINDOW_ALWAYS_ON_TOP` flag. This
+ * will bring the window to the front and keep the window above the rest.
+ *
+ * - window the window of which to change the always on top state.
+ * - on_top true to set the window always on top, false to disable.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowFlags
+ */
+func SDL_SetWindowAlwaysOnTop(window *SDL_Window, on_top bool) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	return sdlSetWindowFlag(window, SDL_WINDOW_ALWAYS_ON_TOP, on_top, sdlGetVideoDevice().SetWindowAlwaysOnTop)
+}
+
+/**
+ * Set the opacity for a window.
+ *
+ * The parameter `opacity` will be clamped internally between 0.0f
+ * (transparent) and 1.0f (opaque).
+ *
+ * This function also returns false if setting the opacity isn't supported.
+ *
+ * - window the window which will be made transparent or opaque.
+ * - opacity the opacity value (0.0f - transparent, 1.0f - opaque).
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowOpacity
+ */
+func SDL_SetWindowOpacity(window *SDL_Window, opacity float32) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	device := sdlGetVideoDevice()
+	if device.SetWindowOpacity == nil {
+		return SDL_Unsupported()
+	}
+
+	opacity = min(max(opacity, 0.0), 1.0)
+	if !device.SetWindowOpacity(device, window, opacity) {
+		return false
+	}
+	window.opacity = opacity
+	return true
+}
+
+/**
+ * Get the opacity of a window.
+ *
+ * If transparency isn't supported on this platform, opacity will be returned
+ * as 1.0f without error.
+ *
+ * - window the window to get the current opacity value from.
+ * Returns the opacity, (0.0f - transparent, 1.0f - opaque), or -1.0f on
+ *          failure; call SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_SetWindowOpacity
+ */
+func SDL_GetWindowOpacity(window *SDL_Window) float32 {
+	if !sdlCheckWindow(window) {
+		return -1.0
+	}
+	return window.opacity
+}
+
+/**
+ * Show a window.
+ *
+ * If the window was hidden while fullscreen, it enters fullscreen again.
+ *
+ * - window the window to show.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_HideWindow
+ * See also SDL_RaiseWindow
+ */
+func SDL_ShowWindow(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	sdlShowWindow(sdlGetVideoDevice(), window)
+	return true
+}
+
+/**
+ * Hide a window.
+ *
+ * A fullscreen window leaves fullscreen while it is hidden, giving the
+ * display back to the desktop.
+ *
+ * - window the window to hide.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_ShowWindow
+ */
+func SDL_HideWindow(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	window.restore_on_show = false
+	sdlHideWindow(sdlGetVideoDevice(), window)
+	return true
+}
+
+/**
+ * Request that a window be raised above other windows and gain the input
+ * focus.
+ *
+ * The result of this request is subject to desktop window manager policy,
+ * particularly if raising the requested window would result in stealing
+ * focus from another application. Hidden windows are not raised.
+ *
+ * - window the window to raise.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_RaiseWindow(window *SDL_Window) bool {
+	if !sdlCheckWindowNotPopup(window) {
+		return false
+	}
+	if window.flags&SDL_WINDOW_HIDDEN != 0 {
+		return true
+	}
+
+	if device := sdlGetVideoDevice(); device.RaiseWindow != nil {
+		device.RaiseWindow(device, window)
+	}
+	return true
+}
+
+/**
+ * Window flash operation.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_FlashOperation int
+
+const (
+	SDL_FLASH_CANCEL        SDL_FlashOperation = iota /**< Cancel any window flash state */
+	SDL_FLASH_BRIEFLY                                 /**< Flash the window briefly to get attention */
+	SDL_FLASH_UNTIL_FOCUSED                           /**< Flash the window until it gets focus */
+)
+
+/**
+ * Request a window to demand attention from the user.
+ *
+ * - window the window to be flashed.
+ * - operation the operation to perform.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_FlashWindow(window *SDL_Window, operation SDL_FlashOperation) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if operation < SDL_FLASH_CANCEL || operation > SDL_FLASH_UNTIL_FOCUSED {
+		return SDL_InvalidParamError("operation")
+	}
+
+	if device := sdlGetVideoDevice(); device.FlashWindow != nil {
+		return device.FlashWindow(device, window, operation)
+	}
+	return SDL_Unsupported()
+}
+
+/**
+ * System theme.
+ *
+ * This enum is available since SDL 3.0.0.
+ */
+type SDL_SystemTheme int
+
+const (
+	SDL_SYSTEM_THEME_UNKNOWN SDL_SystemTheme = iota /**< Unknown system theme */
+	SDL_SYSTEM_THEME_LIGHT                          /**< Light colored system theme */
+	SDL_SYSTEM_THEME_DARK                           /**< Dark colored system theme */
+)
+
+/*
+ * The platform source of the system theme, for platforms where it isn't
+ * known to the video driver. Init reports the current theme with
+ * sdlSetSystemTheme() and watches for changes until Quit.
+ */
+type sdlSystemThemeBackend struct {
+	Init func()
+	Quit func()
+}
+
+var systemThemeBackend *sdlSystemThemeBackend
+
+/*
+ * Called by video drivers and the system theme backend with the current
+ * theme, from any goroutine. SDL_EVENT_SYSTEM_THEME_CHANGED is sent when it
+ * changes and send_event is true.
+ */
+func sdlSetSystemTheme(theme SDL_SystemTheme, send_event bool) {
+	videoLock.Lock()
+	changed := video != nil && video.system_theme != theme
+	if changed {
+		video.system_theme = theme
+	}
+	videoLock.Unlock()
+
+	if changed && send_event && SDL_EventEnabled(SDL_EVENT_SYSTEM_THEME_CHANGED) {
+		event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: SDL_EVENT_SYSTEM_THEME_CHANGED}}
+		SDL_PushEvent(&event)
+	}
+}
+
+/**
+ * Get the current system theme.
+ *
+ * Returns the current system theme, light, dark, or unknown.
+ *
+ * This function is available since SDL 3.0.0.
+ */
+func SDL_GetSystemTheme() SDL_SystemTheme {
+	videoLock.RLock()
+	defer videoLock.RUnlock()
+
+	if video == nil {
+		return SDL_SYSTEM_THEME_UNKNOWN
+	}
+	return video.system_theme
+}
+
+/**
+ * Check whether the screensaver is currently enabled.
+ *
+ * The screensaver is disabled by default.
+ *
+ * The default can also be changed using `SDL_HINT_VIDEO_ALLOW_SCREENSAVER`.
+ *
+ * Returns true if the screensaver is enabled, false if it is disabled.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DisableScreenSaver
+ * See also SDL_EnableScreenSaver
+ */
+func SDL_ScreenSaverEnabled() bool {
+	videoLock.RLock()
+	defer videoLock.RUnlock()
+
+	if video == nil {
+		return false
+	}
+	return !video.suspend_screensaver
+}
+
+/* Set whether the screen saver is suspended, telling the driver if that changed */
+func sdlSuspendScreenSaver(suspend bool) bool {
+	device := sdlGetVideoDevice()
+	if device == nil {
+		return false
+	}
+
+	videoLock.Lock()
+	changed := device.suspend_screensaver != suspend
+	device.suspend_screensaver = suspend
+	videoLock.Unlock()
+
+	if !changed {
+		return true
+	}
+	if device.SuspendScreenSaver != nil {
+		return device.SuspendScreenSaver(device)
+	}
+	return true
+}
+
+/**
+ * Allow the screen to be blanked by a screen saver.
+ *
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DisableScreenSaver
+ * See also SDL_ScreenSaverEnabled
+ */
+func SDL_EnableScreenSaver() bool {
+	return sdlSuspendScreenSaver(false)
+}
+
+/**
+ * Prevent the screen from being blanked by a screen saver.
+ *
+ * If you disable the screensaver, it is automatically re-enabled when SDL
+ * quits.
+ *
+ * The screensaver is disabled by default, but this may by changed by
+ * SDL_HINT_VIDEO_ALLOW_SCREENSAVER.
+ *
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_EnableScreenSaver
+ * See also SDL_ScreenSaverEnabled
+ */
+func SDL_DisableScreenSaver() bool {
+	return sdlSuspendScreenSaver(true)
+}
+
+/**
+ * Request that the window be made as large as possible.
+ *
+ * Non-resizable windows can't be maximized, the request is ignored for
+ * them.
+ *
+ * - window the window to maximize.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_MinimizeWindow
+ * See also SDL_RestoreWindow
+ */
+func SDL_MaximizeWindow(window *SDL_Window) bool {
+	if !sdlCheckWindowNotPopup(window) {
+		return false
+	}
+	if window.flags&SDL_WINDOW_RESIZABLE == 0 || window.flags&SDL_WINDOW_MAXIMIZED != 0 {
+		return true
+	}
+
+	if device := sdlGetVideoDevice(); device.MaximizeWindow != nil {
+		device.MaximizeWindow(device, window)
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_MAXIMIZED, 0, 0)
+	return true
+}
+
+/**
+ * Request that the window be minimized to an iconic representation.
+ *
+ * An exclusive fullscreen window gives the display back to the desktop mode
+ * while it is minimized.
+ *
+ * - window the window to minimize.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_MaximizeWindow
+ * See also SDL_RestoreWindow
+ */
+func SDL_MinimizeWindow(window *SDL_Window) bool {
+	if !sdlCheckWindowNotPopup(window) {
+		return false
+	}
+	sdlMinimizeWindow(window)
+	return true
+}
+
+/**
+ * Request that the size and position of a minimized or maximized window be
+ * restored.
+ *
+ * - window the window to restore.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_MaximizeWindow
+ * See also SDL_MinimizeWindow
+ */
+func SDL_RestoreWindow(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if window.flags&(SDL_WINDOW_MINIMIZED|SDL_WINDOW_MAXIMIZED) == 0 {
+		return true
+	}
+
+	if device := sdlGetVideoDevice(); device.RestoreWindow != nil {
+		device.RestoreWindow(device, window)
+	}
+	sdlSendWindowEvent(window, SDL_EVENT_WINDOW_RESTORED, 0, 0)
+	return true
+}
+
+/**
+ * Return whether the window has a surface associated with it.
+ *
+ * - window the window to query.
+ * Returns true if there is a surface associated with the window, or false
+ *          otherwise.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowSurface
+ */
+func SDL_WindowHasSurface(window *SDL_Window) bool {
+	return sdlCheckWindow(window) && window.surface != nil
+}
+
+/**
+ * Get the SDL surface associated with the window.
+ *
+ * A new surface will be created with the optimal format for the window, if
+ * necessary. This surface will be freed when the window is destroyed. Do not
+ * free this surface.
+ *
+ * This surface will be invalidated if the window is resized. After resizing
+ * a window this function must be called again to return a valid surface.
+ *
+ * You may not combine this with 3D or the rendering API on this window.
+ *
+ * - window the window to query.
+ * Returns the surface associated with the window, or nil on failure; call
+ *          SDL_GetError() for more information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_DestroyWindowSurface
+ * See also SDL_WindowHasSurface
+ * See also SDL_UpdateWindowSurface
+ * See also SDL_UpdateWindowSurfaceRects
+ */
+func SDL_GetWindowSurface(window *SDL_Window) *SDL_Surface {
+	if !sdlCheckWindow(window) {
+		return nil
+	}
+	if window.surface != nil && window.surface_valid {
+		return window.surface
+	}
+
+	device := sdlGetVideoDevice()
+	if device.CreateWindowFramebuffer == nil {
+		SDL_SetError("The video driver doesn't support window surfaces")
+		return nil
+	}
+	SDL_DestroyWindowSurface(window)
+
+	surface := device.CreateWindowFramebuffer(device, window)
+	if surface == nil {
+		return nil
+	}
+	sdlUntrackObject(surface) /* owned by the window */
+	window.surface = surface
+	window.surface_valid = true
+	return surface
+}
+
+// GetWindowSurface is SDL_GetWindowSurface() returning a Go error instead of
+// nil.
+func GetWindowSurface(window *SDL_Window) (*SDL_Surface, error) {
+	return errorFromObject(SDL_GetWindowSurface(window))
+}
+
+/**
+ * Copy the window surface to the screen.
+ *
+ * This is the function you use to reflect any changes to the surface on the
+ * screen.
+ *
+ * - window the window to update.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowSurface
+ * See also SDL_UpdateWindowSurfaceRects
+ */
+func SDL_UpdateWindowSurface(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if window.surface == nil || !window.surface_valid {
+		return SDL_SetError("Window surface is invalid, please call SDL_GetWindowSurface() to get a new surface")
+	}
+	return SDL_UpdateWindowSurfaceRects(window, []SDL_Rect{{X: 0, Y: 0, W: window.surface.W, H: window.surface.H}})
+}
+
+// UpdateWindowSurface is SDL_UpdateWindowSurface() returning a Go error
+// instead of a boolean.
+func UpdateWindowSurface(window *SDL_Window) error {
+	return errorFromResult(SDL_UpdateWindowSurface(window))
+}
+
+/**
+ * Copy areas of the window surface to the screen.
+ *
+ * This is the function you use to reflect changes to portions of the
+ * surface on the screen.
+ *
+ * - window the window to update.
+ * - rects the SDL_Rect structures representing areas of the surface to
+ *              copy, in pixels.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowSurface
+ * See also SDL_UpdateWindowSurface
+ */
+func SDL_UpdateWindowSurfaceRects(window *SDL_Window, rects []SDL_Rect) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if window.surface == nil || !window.surface_valid {
+		return SDL_SetError("Window surface is invalid, please call SDL_GetWindowSurface() to get a new surface")
+	}
+
+	device := sdlGetVideoDevice()
+	if device.UpdateWindowFramebuffer == nil {
+		return true
+	}
+	return device.UpdateWindowFramebuffer(device, window, rects)
+}
+
+/**
+ * Destroy the surface associated with the window.
+ *
+ * - window the window to update.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * This function is available since SDL 3.0.0.
+ *
+ * See also SDL_GetWindowSurface
+ * See also SDL_WindowHasSurface
+ */
+func SDL_DestroyWindowSurface(window *SDL_Window) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+	if window.surface == nil {
+		return true
+	}
+
+	if device := sdlGetVideoDevice(); device.DestroyWindowFramebuffer != nil {
+		device.DestroyWindowFramebuffer(device, window)
+	}
+	SDL_DestroySurface(window.surface)
+	window.surface = nil
+	window.surface_valid = false
+	return true
+}
@@ -0,0 +1,83 @@
+package sdl
+
+import "fmt"
+
+/*
+ * The dummy video driver.
+ *
+ * It has a single 1024x768 display and keeps windows purely as SDL
+ * bookkeeping, nothing is ever shown. Window surfaces live in memory, and
+ * can be saved as BMP files with SDL_HINT_VIDEO_DUMMY_SAVE_FRAMES. It is
+ * always available, so it is the fallback when no other driver can run.
+ */
+
+const sdlDummyVideoDriverName = "dummy"
+
+/**
+ * A variable controlling whether the dummy video driver saves output frames.
+ *
+ * - "0": Video frames are not saved to disk. (default)
+ * - "1": Video frames are saved to files in the format "SDL_windowX-Y.bmp",
+ *   where X is the window ID, and Y is the frame number.
+ *
+ * This hint can be set anytime.
+ *
+ * This hint is available since SDL 3.0.0.
+ */
+const SDL_HINT_VIDEO_DUMMY_SAVE_FRAMES = "SDL_VIDEO_DUMMY_SAVE_FRAMES"
+
+func init() {
+	sdlRegisterVideoDriver(sdlVideoBootStrap{
+		name:     sdlDummyVideoDriverName,
+		desc:     "SDL dummy video driver",
+		create:   sdlDummyCreateDevice,
+		fallback: true,
+	})
+}
+
+func sdlDummyCreateDevice() *sdlVideoDevice {
+	return &sdlVideoDevice{
+		VideoInit:               sdlDummyVideoInit,
+		CreateSDLWindow:         sdlCreateMemoryWindow,
+		CreateWindowFramebuffer: sdlCreateMemoryFramebuffer,
+		UpdateWindowFramebuffer: sdlDummyUpdateWindowFramebuffer,
+		UpdateWindowShape:       sdlUpdateMemoryWindowShape,
+	}
+}
+
+func sdlDummyVideoInit(device *sdlVideoDevice) bool {
+	bounds := SDL_Rect{X: 0, Y: 0, W: 1024, H: 768}
+	return sdlAddVideoDisplay("Dummy Display", bounds, SDL_ORIENTATION_LANDSCAPE, 1, false) != 0
+}
+
+/* Per window state of the in-memory drivers */
+type sdlMemoryWindowData struct {
+	frame_number int /* the number of frames presented */
+}
+
+func sdlCreateMemoryWindow(device *sdlVideoDevice, window *SDL_Window, props SDL_PropertiesID) bool {
+	window.driverdata = &sdlMemoryWindowData{}
+	return true
+}
+
+/* A window surface in memory, shared by the drivers without a display server */
+func sdlCreateMemoryFramebuffer(device *sdlVideoDevice, window *SDL_Window) *SDL_Surface {
+	w, h := sdlGetWindowSizeInPixels(window)
+	if window.flags&SDL_WINDOW_TRANSPARENT != 0 {
+		return SDL_CreateSurface(w, h, SDL_PIXELFORMAT_ARGB8888)
+	}
+	return SDL_CreateSurface(w, h, SDL_PIXELFORMAT_XRGB8888)
+}
+
+func sdlDummyUpdateWindowFramebuffer(device *sdlVideoDevice, window *SDL_Window, rects []SDL_Rect) bool {
+	data := window.driverdata.(*sdlMemoryWindowData)
+	data.frame_number++
+	sdlApplyWindowShape(window)
+
+	/* Send the data to the display */
+	if SDL_GetHintBoolean(SDL_HINT_VIDEO_DUMMY_SAVE_FRAMES, false) {
+		file := fmt.Sprintf("SDL_window%d-%8.8d.bmp", window.id, data.frame_number)
+		return SDL_SaveBMP(window.surface, file)
+	}
+	return true
+}
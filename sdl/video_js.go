@@ -0,0 +1,702 @@
+//go:build js && wasm
+
+package sdl
+
+import "fmt"
+import "math"
+import "syscall/js"
+
+/*
+ * The browser video driver, for GOOS=js GOARCH=wasm.
+ *
+ * The page is the only display, sized like the browser viewport, and each
+ * window draws in a canvas element. Window surfaces are copied into the
+ * canvas through a 2D context, OpenGL windows get a WebGL context instead.
+ *
+ * DOM event listeners run on their own goroutines, so they only record the
+ * events; the event pump turns them into SDL events on the main thread,
+ * where the window state may be changed. Keyboard events wait for the
+ * keyboard event types.
+ *
+ * The browser only runs its event loop and draws the page while the Go
+ * program waits. Presenting a frame, with SDL_UpdateWindowSurface() or with
+ * SDL_GL_SwapWindow() and a swap interval, waits for the next animation
+ * frame, which paces the main loop to the display like vsync does. This
+ * also stops the main loop while the page is in a background tab.
+ * Applications presenting without vsync must wait in their main loop, e.g.
+ * with SDL_WaitEvent() or SDL_Delay().
+ */
+
+const sdlJSVideoDriverName = "browser"
+
+/* The device IDs of the mouse and the touch screen, the browser merges all devices */
+const sdlJSMouseID SDL_MouseID = 1
+const sdlJSTouchID SDL_TouchID = 1
+
+func init() {
+	sdlRegisterVideoDriver(sdlVideoBootStrap{
+		name:   sdlJSVideoDriverName,
+		desc:   "SDL browser video driver",
+		create: sdlJSCreateDevice,
+	})
+}
+
+/* A DOM event listener, removed with sdlJSUnlisten() */
+type sdlJSListener struct {
+	target js.Value
+	kind   string
+	fn     js.Func
+}
+
+/* Add a listener, passive ones can't cancel the default action of the event */
+func sdlJSListen(listeners *[]sdlJSListener, target js.Value, kind string, passive bool, handler func(event js.Value)) {
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		handler(args[0])
+		return nil
+	})
+	target.Call("addEventListener", kind, fn, map[string]any{"passive": passive})
+	*listeners = append(*listeners, sdlJSListener{target: target, kind: kind, fn: fn})
+}
+
+func sdlJSUnlisten(listeners []sdlJSListener) {
+	for _, listener := range listeners {
+		listener.target.Call("removeEventListener", listener.kind, listener.fn)
+		listener.fn.Release()
+	}
+}
+
+/* The DOM events recorded by the listeners, run by the event pump */
+var jsEventsLock = sdlMutex{name: "js.events"}
+var jsEvents []func()
+
+/* Record an event for the main thread, and have it pump */
+func sdlJSQueueEvent(handler func()) {
+	jsEventsLock.Lock()
+	jsEvents = append(jsEvents, handler)
+	jsEventsLock.Unlock()
+	sdlPumpEventsProxied()
+}
+
+func sdlJSPumpEvents() {
+	jsEventsLock.Lock()
+	events := jsEvents
+	jsEvents = nil
+	jsEventsLock.Unlock()
+
+	for _, handler := range events {
+		handler()
+	}
+}
+
+/* The driver state, while the video subsystem is initialized with this driver */
+type sdlJSVideoData struct {
+	displayID     SDL_DisplayID
+	listeners     []sdlJSListener
+	swap_interval int
+}
+
+var jsVideo *sdlJSVideoData
+
+type sdlJSWindowData struct {
+	canvas    js.Value
+	created   bool   /* the canvas was added by SDL, and is removed with the window */
+	display   string /* the CSS display of the canvas when shown */
+	listeners []sdlJSListener
+
+	/* The framebuffer, see sdlJSCreateWindowFramebuffer() */
+	context2d js.Value
+	image     js.Value
+	scratch   []byte /* the pixels with the alpha forced opaque */
+
+	fingers map[SDL_FingerID][2]float32 /* the last position of each finger, for the motion deltas */
+}
+
+func sdlJSCreateDevice() *sdlVideoDevice {
+	/* Web workers and Node.js have no page to draw on */
+	if js.Global().Get("document").IsUndefined() {
+		return nil
+	}
+	return &sdlVideoDevice{
+		VideoInit:                sdlJSVideoInit,
+		VideoQuit:                sdlJSVideoQuit,
+		CreateSDLWindow:          sdlJSCreateWindow,
+		ShowWindow:               sdlJSShowWindow,
+		HideWindow:               sdlJSHideWindow,
+		SetWindowTitle:           sdlJSSetWindowTitle,
+		SetWindowSize:            sdlJSSetWindowSize,
+		GetWindowSizeInPixels:    sdlJSGetWindowSizeInPixels,
+		SetWindowFullscreen:      sdlJSSetWindowFullscreen,
+		CreateWindowFramebuffer:  sdlJSCreateWindowFramebuffer,
+		UpdateWindowFramebuffer:  sdlJSUpdateWindowFramebuffer,
+		DestroyWindowFramebuffer: sdlJSDestroyWindowFramebuffer,
+		DestroyWindow:            sdlJSDestroyWindow,
+		GL_LoadLibrary:           sdlJSGLLoadLibrary,
+		GL_CreateContext:         sdlJSGLCreateContext,
+		GL_MakeCurrent:           sdlJSGLMakeCurrent,
+		GL_SetSwapInterval:       sdlJSGLSetSwapInterval,
+		GL_GetSwapInterval:       sdlJSGLGetSwapInterval,
+		GL_SwapWindow:            sdlJSGLSwapWindow,
+		GL_DestroyContext:        sdlJSGLDestroyContext,
+	}
+}
+
+/* The size of the viewport and its pixel density */
+func sdlJSGetViewport() (SDL_Rect, float32) {
+	global := js.Global()
+	bounds := SDL_Rect{W: global.Get("innerWidth").Int(), H: global.Get("innerHeight").Int()}
+	scale := float32(global.Get("devicePixelRatio").Float())
+	if scale <= 0 {
+		scale = 1
+	}
+	return bounds, scale
+}
+
+func sdlJSGetOrientation(bounds SDL_Rect) SDL_DisplayOrientation {
+	if bounds.H > bounds.W {
+		return SDL_ORIENTATION_PORTRAIT
+	}
+	return SDL_ORIENTATION_LANDSCAPE
+}
+
+func sdlJSVideoInit(device *sdlVideoDevice) bool {
+	bounds, scale := sdlJSGetViewport()
+	data := &sdlJSVideoData{swap_interval: 1}
+	data.displayID = sdlAddVideoDisplay("Browser", bounds, sdlJSGetOrientation(bounds), scale, false)
+	if data.displayID == 0 {
+		return false
+	}
+	jsVideo = data
+	sdlAddEventPump(sdlJSPumpEvents)
+
+	global, document := js.Global(), js.Global().Get("document")
+
+	/* Resizing the browser or zooming the page changes the display */
+	sdlJSListen(&data.listeners, global, "resize", true, func(event js.Value) {
+		sdlJSQueueEvent(func() {
+			bounds, scale := sdlJSGetViewport()
+			sdlSetDisplayBounds(data.displayID, bounds)
+			sdlSetDesktopDisplayMode(data.displayID, &SDL_DisplayMode{Format: SDL_PIXELFORMAT_XRGB8888, W: bounds.W, H: bounds.H})
+			sdlSetDisplayOrientation(data.displayID, sdlJSGetOrientation(bounds))
+			sdlSetDisplayContentScale(data.displayID, scale)
+		})
+	})
+
+	/* A background tab is like a mobile application in the background */
+	sdlJSListen(&data.listeners, document, "visibilitychange", true, func(event js.Value) {
+		hidden := document.Get("hidden").Bool()
+		sdlJSQueueEvent(func() {
+			if hidden {
+				sdlSendAppEvent(SDL_EVENT_WILL_ENTER_BACKGROUND)
+				sdlSendAppEvent(SDL_EVENT_DID_ENTER_BACKGROUND)
+			} else {
+				sdlSendAppEvent(SDL_EVENT_WILL_ENTER_FOREGROUND)
+				sdlSendAppEvent(SDL_EVENT_DID_ENTER_FOREGROUND)
+			}
+			for _, window := range SDL_GetWindows() {
+				if hidden {
+					sdlSendWindowEvent(window, SDL_EVENT_WINDOW_OCCLUDED, 0, 0)
+				} else {
+					sdlSendWindowEvent(window, SDL_EVENT_WINDOW_EXPOSED, 0, 0)
+				}
+			}
+		})
+	})
+
+	/* The user can leave fullscreen with Escape at any time */
+	sdlJSListen(&data.listeners, document, "fullscreenchange", true, func(event js.Value) {
+		element := document.Get("fullscreenElement")
+		sdlJSQueueEvent(func() {
+			for _, window := range SDL_GetWindows() {
+				if wd, ok := window.driverdata.(*sdlJSWindowData); ok && window.flags&SDL_WINDOW_FULLSCREEN != 0 && !wd.canvas.Equal(element) {
+					SDL_SetWindowFullscreen(window, false)
+				}
+			}
+		})
+	})
+
+	/* The pointer buttons are released outside of the canvas too */
+	sdlJSListen(&data.listeners, document, "mouseup", true, func(event js.Value) {
+		button := event.Get("button").Int()
+		x, y := float32(event.Get("clientX").Float()), float32(event.Get("clientY").Float())
+		sdlJSQueueEvent(func() {
+			for _, window := range SDL_GetWindows() {
+				if wd, ok := window.driverdata.(*sdlJSWindowData); ok && window.flags&SDL_WINDOW_MOUSE_FOCUS != 0 {
+					rect := wd.canvas.Call("getBoundingClientRect")
+					x -= float32(rect.Get("left").Float())
+					y -= float32(rect.Get("top").Float())
+					sdlJSSendMouseButton(window, button, false, 1, x, y)
+					return
+				}
+			}
+		})
+	})
+	return true
+}
+
+func sdlJSVideoQuit(device *sdlVideoDevice) {
+	sdlRemoveEventPump(sdlJSPumpEvents)
+	if jsVideo != nil {
+		sdlJSUnlisten(jsVideo.listeners)
+		jsVideo = nil
+	}
+	jsEventsLock.Lock()
+	jsEvents = nil
+	jsEventsLock.Unlock()
+}
+
+/* The SDL mouse button for a DOM MouseEvent.button: left, middle, right, back and forward */
+func sdlJSMouseButton(button int) (uint8, bool) {
+	if button < 0 || button > 4 {
+		return 0, false
+	}
+	return uint8(button + 1), true
+}
+
+/* The SDL button state for a DOM MouseEvent.buttons mask: left, right, middle, back and forward */
+func sdlJSMouseButtons(buttons int) SDL_MouseButtonFlags {
+	var flags SDL_MouseButtonFlags
+	if buttons&1 != 0 {
+		flags |= SDL_BUTTON_LMASK
+	}
+	if buttons&2 != 0 {
+		flags |= SDL_BUTTON_RMASK
+	}
+	if buttons&4 != 0 {
+		flags |= SDL_BUTTON_MMASK
+	}
+	if buttons&8 != 0 {
+		flags |= SDL_BUTTON_X1MASK
+	}
+	if buttons&16 != 0 {
+		flags |= SDL_BUTTON_X2MASK
+	}
+	return flags
+}
+
+func sdlJSSendMouseButton(window *SDL_Window, button int, down bool, clicks int, x, y float32) {
+	sdlButton, ok := sdlJSMouseButton(button)
+	if !ok {
+		return
+	}
+	event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: tern(down, SDL_EVENT_MOUSE_BUTTON_DOWN, SDL_EVENT_MOUSE_BUTTON_UP)}}
+	event.Button = SDL_MouseButtonEvent{
+		WindowID: window.id,
+		Which:    sdlJSMouseID,
+		Button:   sdlButton,
+		Down:     down,
+		Clicks:   uint8(max(min(clicks, 255), 1)),
+		X:        x,
+		Y:        y,
+	}
+	SDL_PushEvent(&event)
+}
+
+/* Record an event for a window, dropped if the window is gone by the time it is pumped */
+func sdlJSQueueWindowEvent(windowID SDL_WindowID, handler func(window *SDL_Window, data *sdlJSWindowData)) {
+	sdlJSQueueEvent(func() {
+		for _, window := range SDL_GetWindows() {
+			if window.id == windowID {
+				if data, ok := window.driverdata.(*sdlJSWindowData); ok {
+					handler(window, data)
+				}
+				return
+			}
+		}
+	})
+}
+
+/* Record the touches changed by a DOM TouchEvent */
+func sdlJSQueueTouches(windowID SDL_WindowID, canvas js.Value, event js.Value, kind SDL_EventType) {
+	/* Don't let the browser scroll or synthesize mouse events */
+	event.Call("preventDefault")
+
+	type touch struct {
+		finger   SDL_FingerID
+		x, y     float32
+		pressure float32
+	}
+	rect := canvas.Call("getBoundingClientRect")
+	left, top := rect.Get("left").Float(), rect.Get("top").Float()
+	width, height := math.Max(rect.Get("width").Float(), 1), math.Max(rect.Get("height").Float(), 1)
+
+	changed := event.Get("changedTouches")
+	touches := make([]touch, 0, changed.Length())
+	for i := 0; i < changed.Length(); i++ {
+		t := changed.Index(i)
+		pressure := float32(t.Get("force").Float())
+		if pressure == 0 {
+			pressure = 1 /* force isn't supported */
+		}
+		touches = append(touches, touch{
+			finger:   SDL_FingerID(t.Get("identifier").Int() + 1), /* 0 is an invalid ID */
+			x:        float32((t.Get("clientX").Float() - left) / width),
+			y:        float32((t.Get("clientY").Float() - top) / height),
+			pressure: pressure,
+		})
+	}
+
+	sdlJSQueueWindowEvent(windowID, func(window *SDL_Window, data *sdlJSWindowData) {
+		for _, t := range touches {
+			last, known := data.fingers[t.finger]
+			if kind == SDL_EVENT_FINGER_MOTION && !known {
+				continue
+			}
+			event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: kind}}
+			event.TFinger = SDL_TouchFingerEvent{
+				TouchID:  sdlJSTouchID,
+				FingerID: t.finger,
+				X:        t.x,
+				Y:        t.y,
+				Pressure: t.pressure,
+				WindowID: window.id,
+			}
+			if known {
+				event.TFinger.Dx, event.TFinger.Dy = t.x-last[0], t.y-last[1]
+			}
+			if kind == SDL_EVENT_FINGER_DOWN || kind == SDL_EVENT_FINGER_MOTION {
+				data.fingers[t.finger] = [2]float32{t.x, t.y}
+			} else {
+				delete(data.fingers, t.finger)
+			}
+			SDL_PushEvent(&event)
+		}
+	})
+}
+
+/* Whether another window draws in a canvas */
+func sdlJSCanvasInUse(canvas js.Value) bool {
+	for _, window := range SDL_GetWindows() {
+		if data, ok := window.driverdata.(*sdlJSWindowData); ok && data.canvas.Equal(canvas) {
+			return true
+		}
+	}
+	return false
+}
+
+func sdlJSCreateWindow(device *sdlVideoDevice, window *SDL_Window, props SDL_PropertiesID) bool {
+	document := js.Global().Get("document")
+	data := &sdlJSWindowData{fingers: make(map[SDL_FingerID][2]float32)}
+
+	id := SDL_GetStringProperty(props, SDL_PROP_WINDOW_CREATE_JS_CANVAS_ID_STRING, "")
+	if id != "" {
+		data.canvas = document.Call("getElementById", id)
+		if data.canvas.IsNull() || data.canvas.Get("tagName").String() != "CANVAS" {
+			return SDL_SetErrorf("No canvas element with id '%s'", id)
+		}
+		if sdlJSCanvasInUse(data.canvas) {
+			return SDL_SetErrorf("Canvas '%s' is already used by another window", id)
+		}
+	} else if canvas := document.Call("getElementById", "canvas"); !canvas.IsNull() && canvas.Get("tagName").String() == "CANVAS" && !sdlJSCanvasInUse(canvas) {
+		data.canvas, id = canvas, "canvas"
+	} else {
+		id = fmt.Sprintf("SDL_window%d", window.id)
+		data.canvas = document.Call("createElement", "canvas")
+		data.canvas.Set("id", id)
+		document.Get("body").Call("appendChild", data.canvas)
+		data.created = true
+	}
+
+	canvas := data.canvas
+	canvas.Set("tabIndex", 0) /* to get the keyboard focus */
+	style := canvas.Get("style")
+	data.display = style.Get("display").String()
+	style.Set("display", "none") /* windows are created hidden */
+	window.driverdata = data
+	sdlJSResizeCanvas(window, data)
+
+	windowID := window.id
+	sdlJSListen(&data.listeners, canvas, "mousemove", true, func(event js.Value) {
+		x, y := float32(event.Get("offsetX").Float()), float32(event.Get("offsetY").Float())
+		xrel, yrel := float32(event.Get("movementX").Float()), float32(event.Get("movementY").Float())
+		state := sdlJSMouseButtons(event.Get("buttons").Int())
+		sdlJSQueueWindowEvent(windowID, func(window *SDL_Window, data *sdlJSWindowData) {
+			event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: SDL_EVENT_MOUSE_MOTION}}
+			event.Motion = SDL_MouseMotionEvent{WindowID: window.id, Which: sdlJSMouseID, State: state, X: x, Y: y, Xrel: xrel, Yrel: yrel}
+			SDL_PushEvent(&event)
+		})
+	})
+	sdlJSListen(&data.listeners, canvas, "mousedown", true, func(event js.Value) {
+		button, clicks := event.Get("button").Int(), event.Get("detail").Int()
+		x, y := float32(event.Get("offsetX").Float()), float32(event.Get("offsetY").Float())
+		sdlJSQueueWindowEvent(windowID, func(window *SDL_Window, data *sdlJSWindowData) {
+			sdlJSSendMouseButton(window, button, true, clicks, x, y)
+		})
+	})
+	sdlJSListen(&data.listeners, canvas, "wheel", false, func(event js.Value) {
+		event.Call("preventDefault")
+		x, y := float32(event.Get("deltaX").Float()), float32(event.Get("deltaY").Float())
+		switch event.Get("deltaMode").Int() {
+		case 0: /* DOM_DELTA_PIXEL */
+			x, y = x/100, y/100
+		case 1: /* DOM_DELTA_LINE */
+			x, y = x/3, y/3
+		case 2: /* DOM_DELTA_PAGE */
+			x, y = x*80, y*80
+		}
+		mouseX, mouseY := float32(event.Get("offsetX").Float()), float32(event.Get("offsetY").Float())
+		sdlJSQueueWindowEvent(windowID, func(window *SDL_Window, data *sdlJSWindowData) {
+			event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: SDL_EVENT_MOUSE_WHEEL}}
+			event.Wheel = SDL_MouseWheelEvent{WindowID: window.id, Which: sdlJSMouseID, X: x, Y: -y, Direction: SDL_MOUSEWHEEL_NORMAL, MouseX: mouseX, MouseY: mouseY}
+			SDL_PushEvent(&event)
+		})
+	})
+	sdlJSListen(&data.listeners, canvas, "contextmenu", false, func(event js.Value) {
+		/* The right button belongs to the application */
+		event.Call("preventDefault")
+	})
+
+	for kind, sdlKind := range map[string]SDL_EventType{
+		"mouseenter": SDL_EVENT_WINDOW_MOUSE_ENTER,
+		"mouseleave": SDL_EVENT_WINDOW_MOUSE_LEAVE,
+		"focus":      SDL_EVENT_WINDOW_FOCUS_GAINED,
+		"blur":       SDL_EVENT_WINDOW_FOCUS_LOST,
+	} {
+		sdlJSListen(&data.listeners, canvas, kind, true, func(event js.Value) {
+			sdlJSQueueWindowEvent(windowID, func(window *SDL_Window, data *sdlJSWindowData) {
+				sdlSendWindowEvent(window, sdlKind, 0, 0)
+			})
+		})
+	}
+
+	for kind, sdlKind := range map[string]SDL_EventType{
+		"touchstart":  SDL_EVENT_FINGER_DOWN,
+		"touchmove":   SDL_EVENT_FINGER_MOTION,
+		"touchend":    SDL_EVENT_FINGER_UP,
+		"touchcancel": SDL_EVENT_FINGER_CANCELED,
+	} {
+		sdlJSListen(&data.listeners, canvas, kind, false, func(event js.Value) {
+			sdlJSQueueTouches(windowID, canvas, event, sdlKind)
+		})
+	}
+
+	props = SDL_GetWindowProperties(window)
+	SDL_SetStringProperty(props, SDL_PROP_WINDOW_JS_CANVAS_ID_STRING, id)
+	SDL_SetPointerProperty(props, SDL_PROP_WINDOW_JS_CANVAS_POINTER, canvas)
+	return true
+}
+
+/* Size the canvas buffer in pixels and its element in CSS pixels */
+func sdlJSResizeCanvas(window *SDL_Window, data *sdlJSWindowData) {
+	w, h := sdlJSGetWindowSizeInPixels(nil, window)
+	data.canvas.Set("width", w)
+	data.canvas.Set("height", h)
+	style := data.canvas.Get("style")
+	style.Set("width", fmt.Sprintf("%dpx", window.w))
+	style.Set("height", fmt.Sprintf("%dpx", window.h))
+}
+
+func sdlJSShowWindow(device *sdlVideoDevice, window *SDL_Window) {
+	data := window.driverdata.(*sdlJSWindowData)
+	data.canvas.Get("style").Set("display", data.display)
+}
+
+func sdlJSHideWindow(device *sdlVideoDevice, window *SDL_Window) {
+	data := window.driverdata.(*sdlJSWindowData)
+	data.canvas.Get("style").Set("display", "none")
+}
+
+func sdlJSSetWindowTitle(device *sdlVideoDevice, window *SDL_Window) {
+	js.Global().Get("document").Set("title", window.title)
+}
+
+func sdlJSSetWindowSize(device *sdlVideoDevice, window *SDL_Window, w, h int) {
+	sdlJSResizeCanvas(window, window.driverdata.(*sdlJSWindowData))
+}
+
+func sdlJSGetWindowSizeInPixels(device *sdlVideoDevice, window *SDL_Window) (int, int) {
+	if window.flags&SDL_WINDOW_HIGH_PIXEL_DENSITY == 0 {
+		return window.w, window.h
+	}
+	_, scale := sdlJSGetViewport()
+	return int(math.Ceil(float64(float32(window.w) * scale))), int(math.Ceil(float64(float32(window.h) * scale)))
+}
+
+func sdlJSSetWindowFullscreen(device *sdlVideoDevice, window *SDL_Window, displayID SDL_DisplayID, fullscreen bool) bool {
+	data := window.driverdata.(*sdlJSWindowData)
+	document := js.Global().Get("document")
+
+	if !fullscreen {
+		if data.canvas.Equal(document.Get("fullscreenElement")) {
+			document.Call("exitFullscreen")
+		}
+		return true
+	}
+
+	/* Browsers only allow fullscreen from an input event handler, a refusal takes the window out of fullscreen */
+	windowID := window.id
+	var refused js.Func
+	refused = js.FuncOf(func(this js.Value, args []js.Value) any {
+		refused.Release()
+		sdlJSQueueWindowEvent(windowID, func(window *SDL_Window, data *sdlJSWindowData) {
+			SDL_SetWindowFullscreen(window, false)
+		})
+		return nil
+	})
+	data.canvas.Call("requestFullscreen").Call("catch", refused)
+	return true
+}
+
+func sdlJSCreateWindowFramebuffer(device *sdlVideoDevice, window *SDL_Window) *SDL_Surface {
+	data := window.driverdata.(*sdlJSWindowData)
+	if data.context2d.IsUndefined() {
+		context := data.canvas.Call("getContext", "2d", map[string]any{"alpha": window.flags&SDL_WINDOW_TRANSPARENT != 0})
+		if context.IsNull() {
+			SDL_SetError("Couldn't get a 2D context for the canvas, it is used with WebGL")
+			return nil
+		}
+		data.context2d = context
+	}
+
+	w, h := sdlGetWindowSizeInPixels(window)
+	surface := SDL_CreateSurface(w, h, SDL_PIXELFORMAT_RGBA32)
+	if surface == nil {
+		return nil
+	}
+	/* ImageData has the SDL_PIXELFORMAT_RGBA32 layout, without padding */
+	data.image = data.context2d.Call("createImageData", w, h)
+	data.scratch = make([]byte, w*h*4)
+	return surface
+}
+
+func sdlJSUpdateWindowFramebuffer(device *sdlVideoDevice, window *SDL_Window, rects []SDL_Rect) bool {
+	data := window.driverdata.(*sdlJSWindowData)
+	surface := window.surface
+	if surface == nil || data.image.IsUndefined() {
+		return SDL_SetError("Window surface is invalid, please call SDL_GetWindowSurface() to get a new surface")
+	}
+
+	/* An opaque canvas would premultiply by whatever is in the alpha channel */
+	row := surface.W * 4
+	opaque := window.flags&SDL_WINDOW_TRANSPARENT == 0
+	bounds := SDL_Rect{X: 0, Y: 0, W: surface.W, H: surface.H}
+	pixels := data.image.Get("data")
+	for _, rect := range rects {
+		if !SDL_GetRectIntersection(&rect, &bounds, &rect) {
+			continue
+		}
+		for y := rect.Y; y < rect.Y+rect.H; y++ {
+			dst := data.scratch[y*row+rect.X*4 : y*row+(rect.X+rect.W)*4]
+			copy(dst, surface.Pixels[y*surface.Pitch+rect.X*4:])
+			if opaque {
+				for i := 3; i < len(dst); i += 4 {
+					dst[i] = 0xFF
+				}
+			}
+		}
+		/* Only the rows of the area are copied over, and only the area is drawn */
+		start, end := rect.Y*row, (rect.Y+rect.H)*row
+		js.CopyBytesToJS(pixels.Call("subarray", start, end), data.scratch[start:end])
+		data.context2d.Call("putImageData", data.image, 0, 0, rect.X, rect.Y, rect.W, rect.H)
+	}
+
+	sdlJSWaitAnimationFrame()
+	return true
+}
+
+func sdlJSDestroyWindowFramebuffer(device *sdlVideoDevice, window *SDL_Window) {
+	data := window.driverdata.(*sdlJSWindowData)
+	data.image = js.Undefined()
+	data.scratch = nil
+}
+
+func sdlJSDestroyWindow(device *sdlVideoDevice, window *SDL_Window) {
+	data, ok := window.driverdata.(*sdlJSWindowData)
+	if !ok {
+		return
+	}
+	sdlJSUnlisten(data.listeners)
+	if data.created {
+		data.canvas.Call("remove")
+	} else {
+		data.canvas.Get("style").Set("display", data.display)
+	}
+	window.driverdata = nil
+}
+
+/* Let the browser run until it draws the next frame */
+func sdlJSWaitAnimationFrame() {
+	done := make(chan struct{})
+	var fn js.Func
+	fn = js.FuncOf(func(this js.Value, args []js.Value) any {
+		fn.Release()
+		close(done)
+		return nil
+	})
+	js.Global().Call("requestAnimationFrame", fn)
+	<-done
+}
+
+/* A WebGL context and the window it was created for */
+type sdlJSGLContext struct {
+	webgl    js.Value
+	windowID SDL_WindowID
+}
+
+func sdlJSGLLoadLibrary(device *sdlVideoDevice, path string) bool {
+	/* WebGL is built in, there's nothing to load */
+	if js.Global().Get("WebGLRenderingContext").IsUndefined() {
+		return SDL_SetError("WebGL isn't supported by this browser")
+	}
+	return true
+}
+
+func sdlJSGLCreateContext(device *sdlVideoDevice, window *SDL_Window) SDL_GLContext {
+	data := window.driverdata.(*sdlJSWindowData)
+	config := &device.gl_config
+	attributes := map[string]any{
+		"alpha":     config[SDL_GL_ALPHA_SIZE] > 0,
+		"depth":     config[SDL_GL_DEPTH_SIZE] > 0,
+		"stencil":   config[SDL_GL_STENCIL_SIZE] > 0,
+		"antialias": config[SDL_GL_MULTISAMPLESAMPLES] > 0,
+	}
+
+	/* WebGL 2 is OpenGL ES 3.0 and WebGL 1 is OpenGL ES 2.0, desktop profiles take what they can get */
+	kinds := []string{"webgl2", "webgl"}
+	if SDL_GLProfile(config[SDL_GL_CONTEXT_PROFILE_MASK]) == SDL_GL_CONTEXT_PROFILE_ES {
+		if config[SDL_GL_CONTEXT_MAJOR_VERSION] >= 3 {
+			kinds = []string{"webgl2"}
+		} else {
+			kinds = []string{"webgl"}
+		}
+	}
+	for _, kind := range kinds {
+		webgl := data.canvas.Call("getContext", kind, attributes)
+		if !webgl.IsNull() {
+			SDL_SetPointerProperty(SDL_GetWindowProperties(window), SDL_PROP_WINDOW_JS_WEBGL_CONTEXT_POINTER, webgl)
+			return &SDL_GLContextState{driverdata: &sdlJSGLContext{webgl: webgl, windowID: window.id}}
+		}
+	}
+	SDL_SetError("Couldn't create a WebGL context, the canvas may be used with a 2D context")
+	return nil
+}
+
+func sdlJSGLMakeCurrent(device *sdlVideoDevice, window *SDL_Window, context SDL_GLContext) bool {
+	/* WebGL calls go through the context object, nothing is current */
+	return true
+}
+
+func sdlJSGLSetSwapInterval(device *sdlVideoDevice, interval int) bool {
+	/* Animation frames are the only sync there is, adaptive vsync is plain vsync */
+	jsVideo.swap_interval = tern(interval != 0, 1, 0)
+	return true
+}
+
+func sdlJSGLGetSwapInterval(device *sdlVideoDevice) (int, bool) {
+	return jsVideo.swap_interval, true
+}
+
+func sdlJSGLSwapWindow(device *sdlVideoDevice, window *SDL_Window) bool {
+	/* The browser presents the drawing buffer by itself once we yield to it */
+	if jsVideo.swap_interval != 0 {
+		sdlJSWaitAnimationFrame()
+	}
+	return true
+}
+
+func sdlJSGLDestroyContext(device *sdlVideoDevice, context SDL_GLContext) bool {
+	data := context.driverdata.(*sdlJSGLContext)
+	if window := SDL_GetWindowFromID(data.windowID); window != nil {
+		SDL_ClearProperty(SDL_GetWindowProperties(window), SDL_PROP_WINDOW_JS_WEBGL_CONTEXT_POINTER)
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package sdl
+
+/*
+ * The offscreen video driver.
+ *
+ * Like the dummy driver it needs no display server and keeps window surfaces
+ * in memory, but it is only used when requested with SDL_HINT_VIDEO_DRIVER,
+ * e.g. SDL_VIDEO_DRIVER=offscreen in CI. It is the place for headless
+ * graphics contexts, so its display matches a common desktop.
+ */
+
+const sdlOffscreenVideoDriverName = "offscreen"
+
+func init() {
+	sdlRegisterVideoDriver(sdlVideoBootStrap{
+		name:        sdlOffscreenVideoDriverName,
+		desc:        "SDL offscreen video driver",
+		create:      sdlOffscreenCreateDevice,
+		demand_only: true,
+	})
+}
+
+func sdlOffscreenCreateDevice() *sdlVideoDevice {
+	return &sdlVideoDevice{
+		VideoInit:               sdlOffscreenVideoInit,
+		CreateSDLWindow:         sdlCreateMemoryWindow,
+		CreateWindowFramebuffer: sdlCreateMemoryFramebuffer,
+		UpdateWindowFramebuffer: sdlOffscreenUpdateWindowFramebuffer,
+		UpdateWindowShape:       sdlUpdateMemoryWindowShape,
+	}
+}
+
+func sdlOffscreenVideoInit(device *sdlVideoDevice) bool {
+	bounds := SDL_Rect{X: 0, Y: 0, W: 1920, H: 1080}
+	return sdlAddVideoDisplay("Offscreen Display", bounds, SDL_ORIENTATION_LANDSCAPE, 1, false) != 0
+}
+
+func sdlOffscreenUpdateWindowFramebuffer(device *sdlVideoDevice, window *SDL_Window, rects []SDL_Rect) bool {
+	/* Nothing to show it on, the pixels stay in the window surface */
+	window.driverdata.(*sdlMemoryWindowData).frame_number++
+	sdlApplyWindowShape(window)
+	return true
+}
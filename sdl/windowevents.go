@@ -0,0 +1,258 @@
+package sdl
+
+/*
+ * Window and display events, sent by the video backends as the state of
+ * their windows and of the connected displays changes. Display events come
+ * from the display registry in video.go.
+ *
+ * The window state is updated as events are sent, so redundant
+ * notifications from the platform (a move to the current position, a
+ * second focus gain, ...) don't reach the application.
+ *
+ * Events that describe the latest state of a window replace the ones of the
+ * same kind still queued for it, and a queued SDL_EVENT_WINDOW_EXPOSED is
+ * moved behind new size changes, so applications always see a window's new
+ * size before being asked to redraw it.
+ *
+ * While the user resizes a window, Win32 and Cocoa run a modal loop of
+ * their own and the application's event loop doesn't get to run until the
+ * mouse button is released. Backends bracket these loops with
+ * sdlBeginWindowLiveResize() and sdlEndWindowLiveResize(): every size change
+ * in between is followed by an SDL_EVENT_WINDOW_EXPOSED with data1 set to 1,
+ * and the window event hook gets both right away, so the application can
+ * redraw at the new size from inside the loop. The resize drag of
+ * hittest.go, for drivers without a window manager, is bracketed the same
+ * way.
+ */
+
+/**
+ * A function called with the events of a window as they are sent.
+ *
+ * The hook runs on the thread sending the event, the main thread for
+ * platform events, before the event is queued. During an interactive resize
+ * it is how the application sees SDL_EVENT_WINDOW_RESIZED events and redraws
+ * on SDL_EVENT_WINDOW_EXPOSED events with `event.Window.Data1` set to 1,
+ * while its event loop is blocked. The event is delivered to the event queue
+ * afterwards as usual, and is passed to the hook even if its type is
+ * disabled with SDL_SetEventEnabled().
+ *
+ * This is an extension to the SDL API.
+ *
+ * - userdata what was passed as `userdata` to SDL_SetWindowEventHook().
+ * - event the window event being sent.
+ *
+ * See also SDL_SetWindowEventHook
+ */
+type SDL_WindowEventHook func(userdata any, event *SDL_Event)
+
+/**
+ * Set a hook called with the events of a window as they are sent.
+ *
+ * This is an extension to the SDL API.
+ *
+ * - window the window to hook.
+ * - hook the function to call, or nil to remove the hook.
+ * - userdata a pointer that is passed to `hook`.
+ * Returns true on success or false on failure; call SDL_GetError() for more
+ *          information.
+ *
+ * See also SDL_WindowEventHook
+ */
+func SDL_SetWindowEventHook(window *SDL_Window, hook SDL_WindowEventHook, userdata any) bool {
+	if !sdlCheckWindow(window) {
+		return false
+	}
+
+	window.event_hook = hook
+	window.event_hook_userdata = userdata
+	return true
+}
+
+/* Called by video backends when the user starts resizing a window, e.g. on WM_ENTERSIZEMOVE */
+func sdlBeginWindowLiveResize(window *SDL_Window) {
+	window.live_resize = true
+}
+
+/* Called by video backends when the interactive resize is over */
+func sdlEndWindowLiveResize(window *SDL_Window) {
+	window.live_resize = false
+}
+
+/**
+ * Window state change event data (event.Window.*)
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_WindowEvent struct {
+	WindowID SDL_WindowID /**< The associated window */
+	Data1    int32        /**< event dependent data */
+	Data2    int32        /**< event dependent data */
+}
+
+/**
+ * Display state change event data (event.Display.*)
+ *
+ * This struct is available since SDL 3.0.0.
+ */
+type SDL_DisplayEvent struct {
+	DisplayID SDL_DisplayID /**< The associated display */
+	Data1     int32         /**< event dependent data */
+	Data2     int32         /**< event dependent data */
+}
+
+/* Events carrying the latest state of a window, where older ones are of no use */
+func sdlIsSupersededWindowEvent(kind SDL_EventType) bool {
+	switch kind {
+	case SDL_EVENT_WINDOW_EXPOSED,
+		SDL_EVENT_WINDOW_MOVED,
+		SDL_EVENT_WINDOW_RESIZED,
+		SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED,
+		SDL_EVENT_WINDOW_OCCLUDED:
+		return true
+	}
+	return false
+}
+
+/*
+ * Update the state of the window for an event, returns false if the event
+ * doesn't change anything and shouldn't be sent.
+ */
+func (window *SDL_Window) applyWindowEvent(kind SDL_EventType, data1, data2 int) bool {
+	setFlag := func(flag SDL_WindowFlags, on bool) bool {
+		if (window.flags&flag != 0) == on {
+			return false
+		}
+		window.flags ^= flag
+		return true
+	}
+
+	switch kind {
+	case SDL_EVENT_WINDOW_SHOWN:
+		return setFlag(SDL_WINDOW_HIDDEN, false)
+	case SDL_EVENT_WINDOW_HIDDEN:
+		return setFlag(SDL_WINDOW_HIDDEN, true)
+	case SDL_EVENT_WINDOW_EXPOSED:
+		window.flags &^= SDL_WINDOW_OCCLUDED
+	case SDL_EVENT_WINDOW_OCCLUDED:
+		return setFlag(SDL_WINDOW_OCCLUDED, true)
+	case SDL_EVENT_WINDOW_MOVED:
+		if window.x == data1 && window.y == data2 {
+			return false
+		}
+		window.x, window.y = data1, data2
+	case SDL_EVENT_WINDOW_RESIZED:
+		if window.w == data1 && window.h == data2 {
+			return false
+		}
+		window.w, window.h = data1, data2
+	case SDL_EVENT_WINDOW_MINIMIZED:
+		window.flags &^= SDL_WINDOW_MAXIMIZED
+		return setFlag(SDL_WINDOW_MINIMIZED, true)
+	case SDL_EVENT_WINDOW_MAXIMIZED:
+		window.flags &^= SDL_WINDOW_MINIMIZED
+		return setFlag(SDL_WINDOW_MAXIMIZED, true)
+	case SDL_EVENT_WINDOW_RESTORED:
+		if window.flags&(SDL_WINDOW_MINIMIZED|SDL_WINDOW_MAXIMIZED) == 0 {
+			return false
+		}
+		window.flags &^= SDL_WINDOW_MINIMIZED | SDL_WINDOW_MAXIMIZED
+	case SDL_EVENT_WINDOW_MOUSE_ENTER:
+		return setFlag(SDL_WINDOW_MOUSE_FOCUS, true)
+	case SDL_EVENT_WINDOW_MOUSE_LEAVE:
+		return setFlag(SDL_WINDOW_MOUSE_FOCUS, false)
+	case SDL_EVENT_WINDOW_FOCUS_GAINED:
+		return setFlag(SDL_WINDOW_INPUT_FOCUS, true)
+	case SDL_EVENT_WINDOW_FOCUS_LOST:
+		return setFlag(SDL_WINDOW_INPUT_FOCUS, false)
+	case SDL_EVENT_WINDOW_ENTER_FULLSCREEN:
+		return setFlag(SDL_WINDOW_FULLSCREEN, true)
+	case SDL_EVENT_WINDOW_LEAVE_FULLSCREEN:
+		return setFlag(SDL_WINDOW_FULLSCREEN, false)
+	}
+	return true
+}
+
+/*
+ * Called by video backends when the state of a window changes. Returns
+ * whether the event was queued; the window state is updated even when the
+ * event type is disabled.
+ *
+ * SDL_EVENT_WINDOW_CLOSE_REQUESTED only asks the application to close the
+ * window: nothing is destroyed until it calls SDL_DestroyWindow(), so
+ * ignoring the event keeps the window open.
+ */
+func sdlSendWindowEvent(window *SDL_Window, kind SDL_EventType, data1, data2 int) bool {
+	if window == nil {
+		return false
+	}
+	SDL_assert(kind >= SDL_EVENT_WINDOW_FIRST && kind <= SDL_EVENT_WINDOW_LAST)
+
+	if !window.applyWindowEvent(kind, data1, data2) {
+		return false
+	}
+	switch kind {
+	case SDL_EVENT_WINDOW_MOVED:
+		sdlRememberWindowPlacement(window)
+		defer sdlCheckWindowDisplayChanged(window)
+	case SDL_EVENT_WINDOW_RESIZED:
+		if window.live_resize {
+			/* Ask for a redraw once the size change is complete */
+			defer sdlSendWindowEvent(window, SDL_EVENT_WINDOW_EXPOSED, 1, 0)
+		}
+		window.surface_valid = false
+		sdlRememberWindowPlacement(window)
+		defer sdlCheckWindowDisplayChanged(window)
+		defer sdlCheckWindowPixelSizeChanged(window) /* reported after the new size */
+	case SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED:
+		window.surface_valid = false
+	case SDL_EVENT_WINDOW_MINIMIZED:
+		sdlOnWindowMinimizedChanged(window, true)
+	case SDL_EVENT_WINDOW_RESTORED, SDL_EVENT_WINDOW_MAXIMIZED:
+		sdlOnWindowMinimizedChanged(window, false)
+	case SDL_EVENT_WINDOW_FOCUS_GAINED, SDL_EVENT_WINDOW_FOCUS_LOST:
+		sdlUpdateWindowGrab(window)
+	}
+
+	event := SDL_Event{SDL_CommonEvent: SDL_CommonEvent{Type: kind, Timestamp: SDL_GetTicksNS()}}
+	event.Window = SDL_WindowEvent{WindowID: window.id, Data1: int32(data1), Data2: int32(data2)}
+	if window.event_hook != nil {
+		hooked := event
+		window.event_hook(window.event_hook_userdata, &hooked)
+	}
+	if !SDL_EventEnabled(kind) {
+		return false
+	}
+	sdlLogEvent(&event)
+
+	eventQ.lock.Lock()
+	defer eventQ.lock.Unlock()
+
+	if !eventQ.active {
+		return false
+	}
+
+	/* Drop the queued events this one supersedes, and hold back a pending redraw of a resized window */
+	var exposed *SDL_Event
+	sizeChanged := kind == SDL_EVENT_WINDOW_RESIZED || kind == SDL_EVENT_WINDOW_PIXEL_SIZE_CHANGED
+	for i := len(eventQ.events) - 1; i >= 0; i-- {
+		queued := &eventQ.events[i]
+		if queued.Window.WindowID != window.id || queued.Type < SDL_EVENT_WINDOW_FIRST || queued.Type > SDL_EVENT_WINDOW_LAST {
+			continue
+		}
+		if queued.Type == kind && sdlIsSupersededWindowEvent(kind) {
+			eventQ.removeLocked(i)
+		} else if sizeChanged && queued.Type == SDL_EVENT_WINDOW_EXPOSED {
+			held := *queued
+			exposed = &held
+			eventQ.removeLocked(i)
+		}
+	}
+
+	if !eventQ.addLocked(&event) {
+		return false
+	}
+	if exposed != nil {
+		eventQ.addLocked(exposed)
+	}
+	return true
+}
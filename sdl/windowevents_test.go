@@ -0,0 +1,112 @@
+package sdl
+
+import "testing"
+
+/* Create a window on the offscreen driver, destroyed with the test */
+func testCreateWindow(t *testing.T, w, h int, flags SDL_WindowFlags) *SDL_Window {
+	t.Helper()
+	SDL_SetHint(SDL_HINT_VIDEO_DRIVER, sdlOffscreenVideoDriverName)
+	if !SDL_InitSubSystem(SDL_INIT_VIDEO) {
+		t.Fatalf("SDL_InitSubSystem failed: %s", SDL_GetError())
+	}
+	window := SDL_CreateWindow("window events test", w, h, flags)
+	if window == nil {
+		t.Fatalf("SDL_CreateWindow failed: %s", SDL_GetError())
+	}
+	t.Cleanup(func() {
+		SDL_DestroyWindow(window)
+		SDL_QuitSubSystem(SDL_INIT_VIDEO)
+		SDL_ResetHint(SDL_HINT_VIDEO_DRIVER)
+	})
+	return window
+}
+
+func TestWindowEventHookLiveResize(t *testing.T) {
+	window := testCreateWindow(t, 100, 80, SDL_WINDOW_RESIZABLE|SDL_WINDOW_BORDERLESS)
+	SDL_SetWindowPosition(window, 10, 10)
+	SDL_SetWindowHitTest(window, func(win *SDL_Window, area *SDL_Point, data any) SDL_HitTestResult {
+		return SDL_HITTEST_RESIZE_BOTTOMRIGHT
+	}, nil)
+
+	type hooked struct {
+		kind         SDL_EventType
+		data1, data2 int32
+		w, h         int /* the window size when the hook ran */
+	}
+	var events []hooked
+	hook := func(userdata any, event *SDL_Event) {
+		var w, h int
+		SDL_GetWindowSize(window, &w, &h)
+		events = append(events, hooked{event.Type, event.Window.Data1, event.Window.Data2, w, h})
+	}
+	if !SDL_SetWindowEventHook(window, hook, nil) {
+		t.Fatalf("SDL_SetWindowEventHook failed: %s", SDL_GetError())
+	}
+
+	if !sdlHitTestButtonDown(window, 99, 79) {
+		t.Fatalf("The press didn't start a resize")
+	}
+	sdlHitTestMotion(window, 119, 89)
+	sdlHitTestMotion(window, 129, 99)
+	sdlHitTestButtonUp(window)
+
+	/* Each size change is followed by a live redraw at the new size */
+	var resizes []hooked
+	for i, event := range events {
+		if event.kind != SDL_EVENT_WINDOW_RESIZED {
+			continue
+		}
+		resizes = append(resizes, event)
+		var exposed *hooked
+		for _, later := range events[i+1:] {
+			if later.kind == SDL_EVENT_WINDOW_EXPOSED {
+				exposed = &later
+				break
+			}
+		}
+		if exposed == nil || exposed.data1 != 1 || exposed.w != int(event.data1) || exposed.h != int(event.data2) {
+			t.Errorf("Resize to %dx%d wasn't followed by a live expose: %+v", event.data1, event.data2, events)
+		}
+	}
+	if len(resizes) != 2 || resizes[0].data1 != 120 || resizes[0].data2 != 90 || resizes[1].data1 != 130 || resizes[1].data2 != 100 {
+		t.Fatalf("Hooked resizes %+v, expected 120x90 and 130x100", resizes)
+	}
+
+	/* Outside the drag, resizes aren't followed by exposes */
+	events = nil
+	SDL_SetWindowSize(window, 50, 50)
+	for _, event := range events {
+		if event.kind == SDL_EVENT_WINDOW_EXPOSED {
+			t.Errorf("Programmatic resize sent a live expose: %+v", events)
+		}
+	}
+	if len(events) == 0 {
+		t.Errorf("The hook didn't see the programmatic resize")
+	}
+}
+
+func TestWindowEventHookDisabledEvents(t *testing.T) {
+	window := testCreateWindow(t, 100, 80, SDL_WINDOW_RESIZABLE)
+	SDL_SetEventEnabled(SDL_EVENT_WINDOW_RESIZED, false)
+	defer SDL_SetEventEnabled(SDL_EVENT_WINDOW_RESIZED, true)
+
+	hooked := 0
+	SDL_SetWindowEventHook(window, func(userdata any, event *SDL_Event) {
+		if event.Type == SDL_EVENT_WINDOW_RESIZED {
+			hooked++
+		}
+	}, nil)
+	SDL_SetWindowSize(window, 60, 40)
+	if hooked != 1 {
+		t.Errorf("The hook saw %d resizes, expected 1", hooked)
+	}
+	if SDL_HasEvent(SDL_EVENT_WINDOW_RESIZED) {
+		t.Errorf("A disabled resize event was queued")
+	}
+
+	SDL_SetWindowEventHook(window, nil, nil)
+	SDL_SetWindowSize(window, 70, 40)
+	if hooked != 1 {
+		t.Errorf("The removed hook was called")
+	}
+}
@@ -0,0 +1,274 @@
+package sdl
+
+import "bufio"
+import "bytes"
+import "encoding/binary"
+import "io"
+import "net"
+import "os"
+import "path/filepath"
+import "strconv"
+import "strings"
+import "time"
+
+/*
+ * A minimal X11 protocol client: connection setup with MIT-MAGIC-COOKIE-1
+ * authorization, core requests and their replies, in the client's byte
+ * order. Extensions, events and the setup data (screens, formats, resource
+ * ids) aren't handled; this is enough for the keyboard control requests and
+ * is what an X11 video driver would grow from.
+ */
+
+/* How long to wait for the X server to answer */
+const sdlX11Timeout = 5 * time.Second
+
+/* Longer replies are taken as a broken connection, none of the requests sent here get close */
+const sdlX11MaxReplySize = 16 << 20
+
+/* Xauthority address families */
+const (
+	sdlX11FamilyInternet  = 0
+	sdlX11FamilyInternet6 = 6
+	sdlX11FamilyLocal     = 256
+	sdlX11FamilyWild      = 65535
+)
+
+/* Core request opcodes */
+const (
+	sdlX11GetKeyboardControl = 103
+)
+
+type sdlX11Connection struct {
+	conn     net.Conn
+	reader   *bufio.Reader
+	sequence uint16 /* the sequence number of the last request sent */
+}
+
+/*
+ * Split a display name like ":0", "unix:1.0" or "host:10" into the network
+ * and address to connect to and the display number, false if it isn't valid.
+ */
+func sdlX11ParseDisplay(display string) (network string, address string, number string, ok bool) {
+	i := strings.LastIndexByte(display, ':')
+	if i < 0 {
+		return "", "", "", false
+	}
+	host, number := display[:i], display[i+1:]
+	number, _, _ = strings.Cut(number, ".")
+	if n, err := strconv.Atoi(number); err != nil || n < 0 {
+		return "", "", "", false
+	}
+	switch {
+	case host == "" || host == "unix":
+		return "unix", "/tmp/.X11-unix/X" + number, number, true
+	case strings.HasPrefix(host, "/"):
+		/* A socket path, as launchd sets it */
+		return "unix", host + ":" + number, number, true
+	}
+	port, _ := strconv.Atoi(number)
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return "tcp", net.JoinHostPort(host, strconv.Itoa(6000+port)), number, true
+}
+
+/* Read a counted string of an Xauthority entry */
+func sdlX11ReadAuthorityField(r io.Reader) ([]byte, bool) {
+	var n uint16
+	if binary.Read(r, binary.BigEndian, &n) != nil {
+		return nil, false
+	}
+	field := make([]byte, n)
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, false
+	}
+	return field, true
+}
+
+/*
+ * Find the MIT-MAGIC-COOKIE-1 cookie of a display in an Xauthority file.
+ * Local connections match the entries of this host, TCP connections the
+ * entries of the server address, nil if there is none.
+ */
+func sdlX11AuthorityCookie(data []byte, family uint16, address []byte, number string) []byte {
+	r := bytes.NewReader(data)
+	for {
+		var entry_family uint16
+		if binary.Read(r, binary.BigEndian, &entry_family) != nil {
+			return nil
+		}
+		var fields [4][]byte
+		for i := range fields {
+			var ok bool
+			if fields[i], ok = sdlX11ReadAuthorityField(r); !ok {
+				return nil
+			}
+		}
+		entry_address, entry_number, name, cookie := fields[0], fields[1], fields[2], fields[3]
+		if entry_family != sdlX11FamilyWild && (entry_family != family || !bytes.Equal(entry_address, address)) {
+			continue
+		}
+		if len(entry_number) > 0 && string(entry_number) != number {
+			continue
+		}
+		if string(name) == "MIT-MAGIC-COOKIE-1" {
+			return cookie
+		}
+	}
+}
+
+/* The cookie for a connection to a display, from $XAUTHORITY or ~/.Xauthority */
+func sdlX11ConnectionCookie(conn net.Conn, number string) []byte {
+	path := os.Getenv("XAUTHORITY")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(home, ".Xauthority")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var family uint16 = sdlX11FamilyLocal
+	var address []byte
+	if tcp, ok := conn.RemoteAddr().(*net.TCPAddr); ok && !tcp.IP.IsLoopback() {
+		if ip := tcp.IP.To4(); ip != nil {
+			family, address = sdlX11FamilyInternet, ip
+		} else {
+			family, address = sdlX11FamilyInternet6, tcp.IP
+		}
+	} else {
+		hostname, _ := os.Hostname()
+		address = []byte(hostname)
+	}
+	return sdlX11AuthorityCookie(data, family, address, number)
+}
+
+/* Pad a length to a multiple of 4 bytes */
+func sdlX11Pad(n int) int {
+	return (n + 3) &^ 3
+}
+
+/* Exchange the connection setup, or return nil with an error set */
+func sdlX11Setup(conn net.Conn, cookie []byte) *sdlX11Connection {
+	name := ""
+	if cookie != nil {
+		name = "MIT-MAGIC-COOKIE-1"
+	}
+	setup := []byte{'l', 0}
+	setup = binary.LittleEndian.AppendUint16(setup, 11)
+	setup = binary.LittleEndian.AppendUint16(setup, 0)
+	setup = binary.LittleEndian.AppendUint16(setup, uint16(len(name)))
+	setup = binary.LittleEndian.AppendUint16(setup, uint16(len(cookie)))
+	setup = append(setup, 0, 0)
+	setup = append(append(setup, name...), make([]byte, sdlX11Pad(len(name))-len(name))...)
+	setup = append(append(setup, cookie...), make([]byte, sdlX11Pad(len(cookie))-len(cookie))...)
+
+	conn.SetDeadline(time.Now().Add(sdlX11Timeout))
+	defer conn.SetDeadline(time.Time{})
+	if _, err := conn.Write(setup); err != nil {
+		SDL_SetErrorf("Couldn't connect to the X server: %v", err)
+		return nil
+	}
+	reader := bufio.NewReader(conn)
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		SDL_SetErrorf("Couldn't connect to the X server: %v", err)
+		return nil
+	}
+	data := make([]byte, 4*int(binary.LittleEndian.Uint16(header[6:])))
+	if _, err := io.ReadFull(reader, data); err != nil {
+		SDL_SetErrorf("Couldn't connect to the X server: %v", err)
+		return nil
+	}
+	switch header[0] {
+	case 0:
+		reason := data[:min(int(header[1]), len(data))]
+		SDL_SetErrorf("X server refused the connection: %s", strings.TrimSpace(string(reason)))
+		return nil
+	case 1:
+		return &sdlX11Connection{conn: conn, reader: reader}
+	}
+	SDL_SetError("X server requires an unsupported authentication")
+	return nil
+}
+
+/* Connect to the display named by $DISPLAY, or return nil with an error set */
+func sdlX11Connect() *sdlX11Connection {
+	display := os.Getenv("DISPLAY")
+	network, address, number, ok := sdlX11ParseDisplay(display)
+	if !ok {
+		SDL_SetErrorf("Invalid X display '%s'", display)
+		return nil
+	}
+	conn, err := net.DialTimeout(network, address, sdlX11Timeout)
+	if err != nil && network == "unix" && strings.HasPrefix(address, "/tmp/.X11-unix/") {
+		/* The server may only listen on the abstract socket, e.g. from inside a sandbox */
+		conn, err = net.DialTimeout(network, "@"+address, sdlX11Timeout)
+	}
+	if err != nil {
+		SDL_SetErrorf("Couldn't connect to X display '%s': %v", display, err)
+		return nil
+	}
+	x := sdlX11Setup(conn, sdlX11ConnectionCookie(conn, number))
+	if x == nil {
+		conn.Close()
+	}
+	return x
+}
+
+func (x *sdlX11Connection) Close() {
+	x.conn.Close()
+}
+
+/*
+ * Send a request and wait for its reply, or return nil with an error set.
+ * The request is an opcode, a byte of data and a body of 32-bit values.
+ */
+func (x *sdlX11Connection) Call(opcode byte, data byte, body ...uint32) []byte {
+	request := []byte{opcode, data}
+	request = binary.LittleEndian.AppendUint16(request, uint16(1+len(body)))
+	for _, value := range body {
+		request = binary.LittleEndian.AppendUint32(request, value)
+	}
+
+	x.conn.SetDeadline(time.Now().Add(sdlX11Timeout))
+	defer x.conn.SetDeadline(time.Time{})
+	if _, err := x.conn.Write(request); err != nil {
+		SDL_SetErrorf("Couldn't send X request %d: %v", opcode, err)
+		return nil
+	}
+	x.sequence++
+
+	/* Replies, errors and events all start with 32 bytes, replies can be longer */
+	for {
+		packet := make([]byte, 32)
+		if _, err := io.ReadFull(x.reader, packet); err != nil {
+			SDL_SetErrorf("Couldn't read X reply %d: %v", opcode, err)
+			return nil
+		}
+		sequence := binary.LittleEndian.Uint16(packet[2:])
+		switch packet[0] {
+		case 0:
+			if sequence == x.sequence {
+				SDL_SetErrorf("X request %d failed with error %d", opcode, packet[1])
+				return nil
+			}
+		case 1:
+			extra := binary.LittleEndian.Uint32(packet[4:])
+			if extra > sdlX11MaxReplySize/4 {
+				SDL_SetErrorf("Invalid X reply %d", opcode)
+				return nil
+			}
+			packet = append(packet, make([]byte, 4*int(extra))...)
+			if _, err := io.ReadFull(x.reader, packet[32:]); err != nil {
+				SDL_SetErrorf("Couldn't read X reply %d: %v", opcode, err)
+				return nil
+			}
+			if sequence == x.sequence {
+				return packet
+			}
+		}
+	}
+}
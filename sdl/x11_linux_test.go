@@ -0,0 +1,165 @@
+package sdl
+
+import "bytes"
+import "encoding/binary"
+import "io"
+import "net"
+import "testing"
+
+func TestX11ParseDisplay(t *testing.T) {
+	tests := []struct {
+		display string
+		network string
+		address string
+		number  string
+		ok      bool
+	}{
+		{":0", "unix", "/tmp/.X11-unix/X0", "0", true},
+		{"unix:1.0", "unix", "/tmp/.X11-unix/X1", "1", true},
+		{"localhost:10.0", "tcp", "localhost:6010", "10", true},
+		{"[::1]:2", "tcp", "[::1]:6002", "2", true},
+		{"::1:3", "tcp", "[::1]:6003", "3", true},
+		{"/private/tmp/launch-x/org.xquartz:0", "unix", "/private/tmp/launch-x/org.xquartz:0", "0", true},
+		{"", "", "", "", false},
+		{"host", "", "", "", false},
+		{":x", "", "", "", false},
+	}
+	for _, test := range tests {
+		network, address, number, ok := sdlX11ParseDisplay(test.display)
+		if ok != test.ok || network != test.network || address != test.address || number != test.number {
+			t.Errorf("sdlX11ParseDisplay(%q) = %q, %q, %q, %v, expected %q, %q, %q, %v",
+				test.display, network, address, number, ok, test.network, test.address, test.number, test.ok)
+		}
+	}
+}
+
+/* Append an Xauthority entry */
+func testX11AuthorityEntry(data []byte, family uint16, fields ...string) []byte {
+	data = binary.BigEndian.AppendUint16(data, family)
+	for _, field := range fields {
+		data = binary.BigEndian.AppendUint16(data, uint16(len(field)))
+		data = append(data, field...)
+	}
+	return data
+}
+
+func TestX11AuthorityCookie(t *testing.T) {
+	var data []byte
+	data = testX11AuthorityEntry(data, sdlX11FamilyLocal, "otherhost", "0", "MIT-MAGIC-COOKIE-1", "other")
+	data = testX11AuthorityEntry(data, sdlX11FamilyLocal, "myhost", "1", "MIT-MAGIC-COOKIE-1", "display1")
+	data = testX11AuthorityEntry(data, sdlX11FamilyLocal, "myhost", "0", "XDM-AUTHORIZATION-1", "xdm")
+	data = testX11AuthorityEntry(data, sdlX11FamilyLocal, "myhost", "0", "MIT-MAGIC-COOKIE-1", "display0")
+	data = testX11AuthorityEntry(data, sdlX11FamilyInternet, "\x0a\x00\x00\x01", "", "MIT-MAGIC-COOKIE-1", "remote")
+
+	tests := []struct {
+		family   uint16
+		address  string
+		number   string
+		expected string
+	}{
+		{sdlX11FamilyLocal, "myhost", "0", "display0"},
+		{sdlX11FamilyLocal, "myhost", "1", "display1"},
+		{sdlX11FamilyLocal, "myhost", "2", ""},
+		{sdlX11FamilyInternet, "\x0a\x00\x00\x01", "5", "remote"},
+		{sdlX11FamilyInternet, "\x0a\x00\x00\x02", "0", ""},
+	}
+	for _, test := range tests {
+		cookie := sdlX11AuthorityCookie(data, test.family, []byte(test.address), test.number)
+		if string(cookie) != test.expected {
+			t.Errorf("Cookie for %d %q :%s = %q, expected %q", test.family, test.address, test.number, cookie, test.expected)
+		}
+	}
+
+	/* A truncated file finds nothing past the damage */
+	if cookie := sdlX11AuthorityCookie(data[:len(data)-3], sdlX11FamilyInternet, []byte("\x0a\x00\x00\x01"), "0"); cookie != nil {
+		t.Errorf("Truncated entry returned %q", cookie)
+	}
+}
+
+/*
+ * Run a fake X server on one end of a pipe: it checks the connection setup,
+ * then answers GetKeyboardControl with the given led-mask, preceded by an
+ * event the client has to skip.
+ */
+func testX11Server(t *testing.T, server net.Conn, cookie string, ledMask uint32) {
+	defer server.Close()
+
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(server, header); err != nil {
+		t.Errorf("Reading setup failed: %v", err)
+		return
+	}
+	if header[0] != 'l' || binary.LittleEndian.Uint16(header[2:]) != 11 {
+		t.Errorf("Unexpected setup header %v", header)
+		return
+	}
+	name := make([]byte, sdlX11Pad(int(binary.LittleEndian.Uint16(header[6:]))))
+	data := make([]byte, sdlX11Pad(int(binary.LittleEndian.Uint16(header[8:]))))
+	io.ReadFull(server, name)
+	io.ReadFull(server, data)
+	if !bytes.HasPrefix(data, []byte(cookie)) {
+		reason := "No protocol specified"
+		reply := []byte{0, byte(len(reason)), 11, 0, 0, 0}
+		reply = binary.LittleEndian.AppendUint16(reply, uint16(sdlX11Pad(len(reason))/4))
+		reply = append(reply, reason...)
+		server.Write(append(reply, make([]byte, sdlX11Pad(len(reason))-len(reason))...))
+		return
+	}
+	server.Write([]byte{1, 0, 11, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(server, request); err != nil {
+		t.Errorf("Reading request failed: %v", err)
+		return
+	}
+	if request[0] != sdlX11GetKeyboardControl || binary.LittleEndian.Uint16(request[2:]) != 1 {
+		t.Errorf("Unexpected request %v", request)
+		return
+	}
+	event := make([]byte, 32)
+	event[0] = 12 /* Expose */
+	server.Write(event)
+
+	reply := make([]byte, 52)
+	reply[0] = 1
+	binary.LittleEndian.PutUint16(reply[2:], 1)
+	binary.LittleEndian.PutUint32(reply[4:], 5)
+	binary.LittleEndian.PutUint32(reply[8:], ledMask)
+	server.Write(reply)
+}
+
+func TestX11GetKeyboardControl(t *testing.T) {
+	client, server := net.Pipe()
+	go testX11Server(t, server, "secret", 0x5)
+
+	x := sdlX11Setup(client, []byte("secret"))
+	if x == nil {
+		t.Fatalf("sdlX11Setup failed: %s", SDL_GetError())
+	}
+	defer x.Close()
+	reply := x.Call(sdlX11GetKeyboardControl, 0)
+	if reply == nil {
+		t.Fatalf("GetKeyboardControl failed: %s", SDL_GetError())
+	}
+	if len(reply) != 52 {
+		t.Fatalf("Reply is %d bytes, expected 52", len(reply))
+	}
+	leds := sdlX11KeyboardLEDsFromMask(binary.LittleEndian.Uint32(reply[8:]))
+	if leds != SDL_KEYBOARD_LED_CAPS_LOCK|SDL_KEYBOARD_LED_SCROLL_LOCK {
+		t.Errorf("LEDs are 0x%x, expected caps and scroll lock", leds)
+	}
+}
+
+func TestX11SetupRefused(t *testing.T) {
+	client, server := net.Pipe()
+	go testX11Server(t, server, "secret", 0)
+
+	if x := sdlX11Setup(client, []byte("wrong!")); x != nil {
+		x.Close()
+		t.Fatalf("sdlX11Setup succeeded with the wrong cookie")
+	}
+	client.Close()
+	if err := SDL_GetError(); err != "X server refused the connection: No protocol specified" {
+		t.Errorf("Unexpected error '%s'", err)
+	}
+}